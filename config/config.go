@@ -134,6 +134,9 @@ type RDF struct {
 	SparqlPath       string `json:"sparqlPath"`       // the relative path of the endpoint. This can should contain the database name that is injected when the sparql endpoint is build
 	SparqlUpdatePath string `json:"sparqlUpdatePath"` // the relative path of the update endpoint. This can should contain the database name that is injected when the sparql endpoint is build
 	GraphStorePath   string `json:"dataPath"`         // the relative GraphStore path of the endpoint. This can should contain the database name that is injected when the sparql endpoint is build
+	TripleStore      string `json:"tripleStore"`      // the vendor of the triple store: fuseki, blazegraph or anzograph
+	SparqlUsername   string `json:"sparqlUsername"`   // the basic auth username of the triple store
+	SparqlPassword   string `json:"sparqlPassword"`   // the basic auth password of the triple store
 	BaseURL          string `json:"baseUrl"`          // the RDF baseUrl used for minting new URIs (should not include scheme)
 	BaseScheme       string `json:"baseScheme"`       // the scheme (http or https) used in the baseURL
 	RDFStoreEnabled  bool   `json:"rdfStoreEnabled"`  // Store to Triple Store while saving RDF
@@ -278,6 +281,7 @@ func setDefaults() {
 	viper.SetDefault("RDF.SparqlPath", "/%s/sparql")
 	viper.SetDefault("RDF.SparqlUpdatePath", "/%s/update")
 	viper.SetDefault("RDF.GraphStorePath", "/%s/data")
+	viper.SetDefault("RDF.TripleStore", "fuseki")
 	viper.SetDefault("RDF.BaseUrl", "http://data.hub3.org")
 	viper.SetDefault("RDF.BaseScheme", "http")
 	viper.SetDefault("RDF.RoutedEntryPoints", []string{"http://localhost:3000", "http://localhost:3001"})
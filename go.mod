@@ -14,7 +14,7 @@ require (
 	github.com/cenkalti/backoff/v4 v4.0.2
 	github.com/deiu/gon3 v0.0.0-20170627184619-f84eb1e0bd62
 	github.com/die-net/lrucache v0.0.0-20190707192454-883874fe3947
-	github.com/disintegration/imaging v1.6.2 // indirect
+	github.com/disintegration/imaging v1.6.2
 	github.com/docker/go-connections v0.4.0
 	github.com/elastic/go-elasticsearch/v8 v8.0.0-20200521065016-b05f73fe0dcf
 	github.com/elastic/go-sysinfo v1.3.0 // indirect
@@ -36,6 +36,7 @@ require (
 	github.com/jcmturner/gokrb5/v8 v8.3.0 // indirect
 	github.com/jinzhu/gorm v1.9.12
 	github.com/jinzhu/now v1.1.1 // indirect
+	github.com/jung-kurt/gofpdf v1.16.2
 	github.com/justinas/alice v1.2.0
 	github.com/kiivihal/goharvest v0.0.0-20190502201718-d93ace331ed0
 	github.com/kiivihal/rdf2go v0.1.2
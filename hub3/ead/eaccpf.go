@@ -0,0 +1,389 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ead
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/delving/hub3/config"
+	"github.com/delving/hub3/hub3/fragments"
+	r "github.com/kiivihal/rdf2go"
+)
+
+// ErrNoRecordID is returned when an EAC-CPF record has no recordId.
+var ErrNoRecordID = errors.New("EAC-CPF record has no recordId")
+
+// AuthoritySpec is the dataset the EAC-CPF authority records are indexed in.
+const AuthoritySpec = "authorities"
+
+// EACCPF is an EAC-CPF authority record for a person, family or corporate body.
+type EACCPF struct {
+	XMLName        xml.Name          `xml:"eac-cpf"`
+	Control        EACControl        `xml:"control"`
+	CPFDescription EACCPFDescription `xml:"cpfDescription"`
+}
+
+// EACControl holds the control section of an EAC-CPF record.
+type EACControl struct {
+	RecordID   string `xml:"recordId"`
+	AgencyCode string `xml:"maintenanceAgency>agencyCode"`
+	AgencyName string `xml:"maintenanceAgency>agencyName"`
+}
+
+// EACCPFDescription holds the description of the entity.
+type EACCPFDescription struct {
+	EntityType  string            `xml:"identity>entityType"`
+	EntityID    []string          `xml:"identity>entityId"`
+	NameEntry   []*EACNameEntry   `xml:"identity>nameEntry"`
+	ExistDates  *EACExistDates    `xml:"description>existDates"`
+	Places      []string          `xml:"description>places>place>placeEntry"`
+	Occupations []string          `xml:"description>occupations>occupation>term"`
+	BiogHist    *EACBiogHist      `xml:"description>biogHist"`
+	Relations   []*EACCPFRelation `xml:"relations>cpfRelation"`
+}
+
+// EACNameEntry is a name of the entity that consists of one or more parts.
+type EACNameEntry struct {
+	Parts []struct {
+		LocalType string `xml:"localType,attr"`
+		Text      string `xml:",chardata"`
+	} `xml:"part"`
+}
+
+// Name returns the parts of the name joined with a space.
+func (ne *EACNameEntry) Name() string {
+	parts := []string{}
+
+	for _, p := range ne.Parts {
+		if text := strings.TrimSpace(p.Text); text != "" {
+			parts = append(parts, text)
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// EACExistDates holds the dates of existence of the entity.
+type EACExistDates struct {
+	Date      *eacDate      `xml:"date"`
+	DateRange *eacDateRange `xml:"dateRange"`
+}
+
+type eacDateRange struct {
+	FromDate *eacDate `xml:"fromDate"`
+	ToDate   *eacDate `xml:"toDate"`
+}
+
+type eacDate struct {
+	StandardDate string `xml:"standardDate,attr"`
+	Text         string `xml:",chardata"`
+}
+
+// Normal returns the ISO 8601 date or interval of the dates of existence.
+func (ed *EACExistDates) Normal() string {
+	if ed.DateRange != nil {
+		dr := &ead3DateRange{}
+		if ed.DateRange.FromDate != nil {
+			dr.FromDate = &ead3Date{StandardDate: ed.DateRange.FromDate.StandardDate}
+		}
+
+		if ed.DateRange.ToDate != nil {
+			dr.ToDate = &ead3Date{StandardDate: ed.DateRange.ToDate.StandardDate}
+		}
+
+		return dr.normal()
+	}
+
+	if ed.Date != nil {
+		return ed.Date.StandardDate
+	}
+
+	return ""
+}
+
+// EACBiogHist holds the biographical or historical note.
+type EACBiogHist struct {
+	Raw []byte `xml:",innerxml"`
+}
+
+// EACCPFRelation is a relation to another EAC-CPF record.
+type EACCPFRelation struct {
+	Href         string `xml:"href,attr"`
+	RelationType string `xml:"cpfRelationType,attr"`
+	Entry        string `xml:"relationEntry"`
+}
+
+// Authority is the EAC-CPF record in the form that is linked from the archive trees.
+type Authority struct {
+	ID          string   `json:"id"`
+	EntityType  string   `json:"entityType,omitempty"`
+	Names       []string `json:"names,omitempty"`
+	ExistDates  string   `json:"existDates,omitempty"`
+	Places      []string `json:"places,omitempty"`
+	Occupations []string `json:"occupations,omitempty"`
+	BiogHist    string   `json:"biogHist,omitempty"`
+	Related     []string `json:"related,omitempty"`
+}
+
+// ParseEACCPF parses an EAC-CPF record.
+func ParseEACCPF(rdr io.Reader) (*EACCPF, error) {
+	eac := new(EACCPF)
+
+	if err := xml.NewDecoder(rdr).Decode(eac); err != nil {
+		return nil, fmt.Errorf("unable to parse EAC-CPF; %w", err)
+	}
+
+	if strings.TrimSpace(eac.Control.RecordID) == "" {
+		return nil, ErrNoRecordID
+	}
+
+	return eac, nil
+}
+
+// ReadEACCPF reads an EAC-CPF record from a path.
+func ReadEACCPF(fpath string) (*EACCPF, error) {
+	f, err := os.Open(fpath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ParseEACCPF(f)
+}
+
+// Authority returns the Authority for the EAC-CPF record.
+func (eac *EACCPF) Authority() *Authority {
+	desc := eac.CPFDescription
+
+	a := &Authority{
+		ID:         strings.TrimSpace(eac.Control.RecordID),
+		EntityType: strings.TrimSpace(desc.EntityType),
+	}
+
+	for _, ne := range desc.NameEntry {
+		if name := ne.Name(); name != "" {
+			a.Names = append(a.Names, name)
+		}
+	}
+
+	if desc.ExistDates != nil {
+		a.ExistDates = desc.ExistDates.Normal()
+	}
+
+	for _, place := range desc.Places {
+		if place = strings.TrimSpace(place); place != "" {
+			a.Places = append(a.Places, place)
+		}
+	}
+
+	for _, occupation := range desc.Occupations {
+		if occupation = strings.TrimSpace(occupation); occupation != "" {
+			a.Occupations = append(a.Occupations, occupation)
+		}
+	}
+
+	if desc.BiogHist != nil {
+		a.BiogHist = string(space.ReplaceAll(sanitizeXML(desc.BiogHist.Raw), []byte(" ")))
+	}
+
+	for _, rel := range desc.Relations {
+		if rel.Href != "" {
+			a.Related = append(a.Related, rel.Href)
+		}
+	}
+
+	return a
+}
+
+// NewAuthoritySubject returns the subject URI of an authority record of the organization.
+func NewAuthoritySubject(orgID, id string) string {
+	return fmt.Sprintf(
+		"%s/%s/authority/%s",
+		config.Config.RDF.BaseURL,
+		orgID,
+		id,
+	)
+}
+
+// Triples returns the RDF triples of the Authority of the organization.
+func (a *Authority) Triples(orgID string) []*r.Triple {
+	s := r.NewResource(NewAuthoritySubject(orgID, a.ID))
+
+	triples := []*r.Triple{
+		r.NewTriple(s, r.NewResource(fragments.RDFType), NewResource("Authority")),
+	}
+
+	t := func(p, o string, oType convert) {
+		if t := addNonEmptyTriple(s, p, o, oType); t != nil {
+			triples = append(triples, t)
+		}
+	}
+
+	t("recordId", a.ID, r.NewLiteral)
+	t("entityType", a.EntityType, r.NewLiteral)
+	t("existDates", a.ExistDates, r.NewLiteral)
+	t("bioghist", a.BiogHist, r.NewLiteral)
+
+	for _, name := range a.Names {
+		t("name", name, r.NewLiteral)
+	}
+
+	for _, place := range a.Places {
+		t("place", place, r.NewLiteral)
+	}
+
+	for _, occupation := range a.Occupations {
+		t("occupation", occupation, r.NewLiteral)
+	}
+
+	for _, related := range a.Related {
+		t("relatedAuthority", related, r.NewResource)
+	}
+
+	return triples
+}
+
+// FragmentGraph returns the FragmentGraph to index the triples of the Authority
+// in the AuthoritySpec dataset of the organization.
+func (a *Authority) FragmentGraph(orgID string) (*fragments.FragmentGraph, error) {
+	rm := fragments.NewEmptyResourceMap()
+
+	for idx, t := range a.Triples(orgID) {
+		if err := rm.AppendOrderedTriple(t, false, idx); err != nil {
+			return nil, err
+		}
+	}
+
+	subject := NewAuthoritySubject(orgID, a.ID)
+
+	fg := fragments.NewFragmentGraph()
+	fg.Meta = &fragments.Header{
+		OrgID:         orgID,
+		Spec:          AuthoritySpec,
+		HubID:         fmt.Sprintf("%s_%s_%s", orgID, AuthoritySpec, a.ID),
+		DocType:       fragments.FragmentGraphDocType,
+		EntryURI:      subject,
+		NamedGraphURI: fmt.Sprintf("%s/graph", subject),
+		Modified:      fragments.NowInMillis(),
+		Tags:          []string{"eac-cpf"},
+	}
+	fg.SetResources(rm)
+
+	return fg, nil
+}
+
+// LoadAuthorities reads all the EAC-CPF records in a directory and adds them to the NodeConfig.
+//
+// A missing directory is not an error.
+func (cfg *NodeConfig) LoadAuthorities(dir string) error {
+	files, err := ioutil.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".xml" {
+			continue
+		}
+
+		eac, err := ReadEACCPF(filepath.Join(dir, f.Name()))
+		if err != nil {
+			return fmt.Errorf("unable to read authority %s; %w", f.Name(), err)
+		}
+
+		cfg.AddAuthority(eac.Authority())
+	}
+
+	return nil
+}
+
+// AddAuthority registers an Authority so that controlaccess names can be linked to it.
+func (cfg *NodeConfig) AddAuthority(a *Authority) {
+	cfg.m.Lock()
+	defer cfg.m.Unlock()
+
+	if cfg.Authorities == nil {
+		cfg.Authorities = map[string]*Authority{}
+	}
+
+	cfg.Authorities[a.ID] = a
+}
+
+// accessName is a name in a controlaccess section. Nested controlaccess sections are also decoded.
+type accessName struct {
+	XMLName        xml.Name
+	AuthFileNumber string        `xml:"authfilenumber,attr"`
+	Nested         []*accessName `xml:",any"`
+}
+
+// authorityTriples links the controlaccess names to the registered authority records.
+func (cfg *NodeConfig) authorityTriples(s r.Term, controlaccess []*Ccontrolaccess) []*r.Triple {
+	triples := []*r.Triple{}
+
+	if len(cfg.Authorities) == 0 {
+		return triples
+	}
+
+	seen := map[string]bool{}
+
+	var link func(names []*accessName)
+	link = func(names []*accessName) {
+		for _, name := range names {
+			switch name.XMLName.Local {
+			case "persname", "corpname", "famname", "name":
+				id := strings.TrimSpace(name.AuthFileNumber)
+				if _, ok := cfg.Authorities[id]; ok && !seen[id] {
+					seen[id] = true
+
+					triples = append(triples, r.NewTriple(
+						s,
+						NewResource("authority"),
+						r.NewResource(NewAuthoritySubject(cfg.OrgID, id)),
+					))
+				}
+			case "controlaccess":
+				link(name.Nested)
+			}
+		}
+	}
+
+	for _, ca := range controlaccess {
+		var names struct {
+			Names []*accessName `xml:",any"`
+		}
+
+		raw := append([]byte("<controlaccess>"), ca.Raw...)
+		raw = append(raw, []byte("</controlaccess>")...)
+
+		if err := xml.Unmarshal(raw, &names); err != nil {
+			continue
+		}
+
+		link(names.Names)
+	}
+
+	return triples
+}
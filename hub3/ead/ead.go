@@ -78,6 +78,12 @@ type NodeConfig struct {
 	ProcessDigital          bool
 	m                       sync.Mutex
 	Tags                    []string
+	// Authorities are the EAC-CPF records that can be linked from controlaccess names
+	Authorities map[string]*Authority
+	// Extractor extracts named entities from the descriptive text of the c-levels
+	Extractor *EntityExtractor
+	// Reconciler links the extracted entities to a vocabulary
+	Reconciler Reconciler
 }
 
 func (cfg *NodeConfig) Labels() map[string]string {
@@ -566,6 +572,14 @@ func NewNode(cl CLevel, parentIDs []string, cfg *NodeConfig) (*Node, error) {
 	}
 
 	node.triples = append(node.triples, cLevelTriples...)
+	node.triples = append(node.triples, cfg.authorityTriples(subject, c.Ccontrolaccess)...)
+
+	entityTriples, err := cfg.entityTriples(subject, c, node.triples)
+	if err != nil {
+		return nil, err
+	}
+
+	node.triples = append(node.triples, entityTriples...)
 
 	// add nested
 	nested := cl.GetNested()
@@ -0,0 +1,413 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ead
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+// EAD3Namespace is the XML namespace of EAD3 documents.
+const EAD3Namespace = "http://ead3.archivists.org/schema/"
+
+// ead3SniffSize is the number of bytes inspected to detect the EAD version.
+const ead3SniffSize = 4096
+
+// ead3Skip are the EAD3 elements that have no EAD 2002 equivalent and are dropped.
+var ead3Skip = map[string]bool{
+	"otherrecordid":         true,
+	"representation":        true,
+	"maintenancestatus":     true,
+	"publicationstatus":     true,
+	"maintenanceagency":     true,
+	"languagedeclaration":   true,
+	"conventiondeclaration": true,
+	"rightsdeclaration":     true,
+	"localtypedeclaration":  true,
+	"localcontrol":          true,
+	"maintenancehistory":    true,
+	"sources":               true,
+	"relations":             true,
+}
+
+// ead3Rename maps EAD3 element names to their EAD 2002 equivalent.
+var ead3Rename = map[string]string{
+	"control":    "eadheader",
+	"recordid":   "eadid",
+	"didnote":    "note",
+	"footnote":   "note",
+	"datesingle": "date",
+}
+
+// ead3Unwrap are the EAD3 wrapper elements whose content is kept without the wrapper.
+var ead3Unwrap = map[string]bool{
+	"physdescset": true,
+	"daoset":      true,
+}
+
+// ead3AccessTerms are the EAD3 access terms that refer to an authority with the identifier attribute.
+var ead3AccessTerms = map[string]bool{
+	"persname":   true,
+	"corpname":   true,
+	"famname":    true,
+	"name":       true,
+	"geogname":   true,
+	"subject":    true,
+	"function":   true,
+	"occupation": true,
+	"genreform":  true,
+}
+
+// IsEAD3 returns true when the source is an EAD3 document.
+func IsEAD3(src []byte) bool {
+	if len(src) > ead3SniffSize {
+		src = src[:ead3SniffSize]
+	}
+
+	return bytes.Contains(src, []byte(EAD3Namespace)) ||
+		(bytes.Contains(src, []byte("<control")) && !bytes.Contains(src, []byte("<eadheader")))
+}
+
+// ParseEAD parses an EAD 2002 or EAD3 document.
+//
+// EAD3 documents are converted to EAD 2002 first, so both versions produce
+// the same Nodes, Description and RDF triples.
+func ParseEAD(r io.Reader) (*Cead, error) {
+	src, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return eadParse(src)
+}
+
+// ConvertEAD3 converts an EAD3 document to EAD 2002.
+//
+// Elements without an EAD 2002 equivalent, like the maintenance information
+// in the control section and the relations, are dropped.
+func ConvertEAD3(src []byte) ([]byte, error) {
+	control := struct {
+		AgencyCode string `xml:"control>maintenanceagency>agencycode"`
+	}{}
+
+	if err := xml.Unmarshal(src, &control); err != nil {
+		return nil, fmt.Errorf("unable to parse EAD3; %w", err)
+	}
+
+	c := &ead3Converter{
+		dec:        xml.NewDecoder(bytes.NewReader(src)),
+		agencyCode: strings.TrimSpace(control.AgencyCode),
+	}
+
+	if err := c.convert(); err != nil {
+		return nil, fmt.Errorf("unable to convert EAD3; %w", err)
+	}
+
+	return c.buf.Bytes(), nil
+}
+
+type ead3Converter struct {
+	dec        *xml.Decoder
+	buf        bytes.Buffer
+	agencyCode string
+	// partWritten is true when a name part was written in the current access term.
+	partWritten bool
+}
+
+func (c *ead3Converter) convert() error {
+	for {
+		token, err := c.dec.Token()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		switch elem := token.(type) {
+		case xml.StartElement:
+			if err := c.startElement(elem); err != nil {
+				return err
+			}
+		case xml.EndElement:
+			c.endElement(elem)
+		case xml.CharData:
+			_ = xml.EscapeText(&c.buf, elem)
+		}
+	}
+}
+
+func (c *ead3Converter) startElement(elem xml.StartElement) error {
+	name := elem.Name.Local
+
+	switch {
+	case ead3Skip[name]:
+		return c.dec.Skip()
+	case ead3Unwrap[name]:
+		return nil
+	case name == "part":
+		if c.partWritten {
+			c.buf.WriteString(" ")
+		}
+
+		c.partWritten = true
+
+		return nil
+	case name == "unitdatestructured" || name == "daterange" || name == "dateset":
+		return c.writeStructuredDate(elem)
+	case name == "physdescstructured":
+		return c.writeStructuredPhysdesc(elem)
+	}
+
+	if ead3AccessTerms[name] {
+		c.partWritten = false
+	}
+
+	if newName, ok := ead3Rename[name]; ok {
+		name = newName
+	}
+
+	c.buf.WriteString("<" + name)
+
+	for _, attr := range elem.Attr {
+		attrName := c.attrName(elem.Name.Local, attr)
+		if attrName == "" {
+			continue
+		}
+
+		c.writeAttr(attrName, attr.Value)
+	}
+
+	if name == "eadid" && c.agencyCode != "" {
+		c.writeAttr("mainagencycode", c.agencyCode)
+	}
+
+	c.buf.WriteString(">")
+
+	return nil
+}
+
+// attrName returns the EAD 2002 name of the attribute or an empty string when it must be dropped.
+func (c *ead3Converter) attrName(elem string, attr xml.Attr) string {
+	switch {
+	case attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns":
+		return ""
+	case attr.Name.Local == "schemaLocation":
+		return ""
+	case attr.Name.Local == "identifier" && ead3AccessTerms[elem]:
+		return "authfilenumber"
+	case attr.Name.Local == "instanceurl" && elem == "recordid":
+		return "url"
+	case attr.Name.Local == "standarddate":
+		return "normal"
+	case attr.Name.Local == "listtype" && elem == "list":
+		return "type"
+	case attr.Name.Local == "daotype" || attr.Name.Local == "localtype":
+		return ""
+	}
+
+	return attr.Name.Local
+}
+
+func (c *ead3Converter) writeAttr(name, value string) {
+	c.buf.WriteString(" " + name + `="`)
+	_ = xml.EscapeText(&c.buf, []byte(value))
+	c.buf.WriteString(`"`)
+}
+
+func (c *ead3Converter) endElement(elem xml.EndElement) {
+	name := elem.Name.Local
+
+	if ead3Unwrap[name] || name == "part" {
+		return
+	}
+
+	if newName, ok := ead3Rename[name]; ok {
+		name = newName
+	}
+
+	c.buf.WriteString("</" + name + ">")
+}
+
+type ead3Date struct {
+	StandardDate string `xml:"standarddate,attr"`
+	Text         string `xml:",chardata"`
+}
+
+func (d *ead3Date) String() string {
+	return strings.TrimSpace(d.Text)
+}
+
+type ead3DateRange struct {
+	FromDate *ead3Date `xml:"fromdate"`
+	ToDate   *ead3Date `xml:"todate"`
+}
+
+// normal returns the ISO 8601 interval of the range.
+func (dr *ead3DateRange) normal() string {
+	var from, to string
+	if dr.FromDate != nil {
+		from = dr.FromDate.StandardDate
+	}
+
+	if dr.ToDate != nil {
+		to = dr.ToDate.StandardDate
+	}
+
+	switch {
+	case from == "" && to == "":
+		return ""
+	case to == "":
+		return from
+	case from == "":
+		return to
+	}
+
+	return from + "/" + to
+}
+
+func (dr *ead3DateRange) String() string {
+	parts := []string{}
+
+	if dr.FromDate != nil && dr.FromDate.String() != "" {
+		parts = append(parts, dr.FromDate.String())
+	}
+
+	if dr.ToDate != nil && dr.ToDate.String() != "" {
+		parts = append(parts, dr.ToDate.String())
+	}
+
+	return strings.Join(parts, "-")
+}
+
+type ead3StructuredDate struct {
+	AltRender   string           `xml:"altrender,attr"`
+	DateChar    string           `xml:"datechar,attr"`
+	Label       string           `xml:"label,attr"`
+	DateSingle  []*ead3Date      `xml:"datesingle"`
+	DateRange   []*ead3DateRange `xml:"daterange"`
+	FromDate    *ead3Date        `xml:"fromdate"`
+	ToDate      *ead3Date        `xml:"todate"`
+	NestedRange []*ead3DateRange `xml:"dateset>daterange"`
+	NestedDate  []*ead3Date      `xml:"dateset>datesingle"`
+}
+
+// writeStructuredDate writes unitdatestructured as unitdate and a daterange or dateset as date.
+func (c *ead3Converter) writeStructuredDate(elem xml.StartElement) error {
+	var sd ead3StructuredDate
+	if err := c.dec.DecodeElement(&sd, &elem); err != nil {
+		return err
+	}
+
+	normals := []string{}
+	texts := []string{}
+
+	addRange := func(dr *ead3DateRange) {
+		if n := dr.normal(); n != "" {
+			normals = append(normals, n)
+		}
+
+		if s := dr.String(); s != "" {
+			texts = append(texts, s)
+		}
+	}
+
+	addDate := func(d *ead3Date) {
+		if d.StandardDate != "" {
+			normals = append(normals, d.StandardDate)
+		}
+
+		if s := d.String(); s != "" {
+			texts = append(texts, s)
+		}
+	}
+
+	// a daterange element itself
+	if sd.FromDate != nil || sd.ToDate != nil {
+		addRange(&ead3DateRange{FromDate: sd.FromDate, ToDate: sd.ToDate})
+	}
+
+	for _, dr := range append(sd.DateRange, sd.NestedRange...) {
+		addRange(dr)
+	}
+
+	for _, d := range append(sd.DateSingle, sd.NestedDate...) {
+		addDate(d)
+	}
+
+	name := "date"
+	if elem.Name.Local == "unitdatestructured" {
+		name = "unitdate"
+	}
+
+	text := sd.AltRender
+	if text == "" {
+		text = strings.Join(texts, ", ")
+	}
+
+	c.buf.WriteString("<" + name)
+
+	// EAD 2002 only supports a single normalised date
+	if len(normals) == 1 {
+		c.writeAttr("normal", normals[0])
+	}
+
+	if sd.DateChar != "" {
+		c.writeAttr("datechar", sd.DateChar)
+	}
+
+	if sd.Label != "" {
+		c.writeAttr("label", sd.Label)
+	}
+
+	c.buf.WriteString(">")
+	_ = xml.EscapeText(&c.buf, []byte(text))
+	c.buf.WriteString("</" + name + ">")
+
+	return nil
+}
+
+// writeStructuredPhysdesc writes physdescstructured as physdesc.
+func (c *ead3Converter) writeStructuredPhysdesc(elem xml.StartElement) error {
+	var pd struct {
+		Label    string `xml:"label,attr"`
+		Quantity string `xml:"quantity"`
+		UnitType string `xml:"unittype"`
+	}
+
+	if err := c.dec.DecodeElement(&pd, &elem); err != nil {
+		return err
+	}
+
+	c.buf.WriteString("<physdesc")
+
+	if pd.Label != "" {
+		c.writeAttr("label", pd.Label)
+	}
+
+	c.buf.WriteString("><extent>")
+	_ = xml.EscapeText(&c.buf, []byte(strings.TrimSpace(
+		strings.TrimSpace(pd.Quantity)+" "+strings.TrimSpace(pd.UnitType),
+	)))
+	c.buf.WriteString("</extent></physdesc>")
+
+	return nil
+}
@@ -0,0 +1,225 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ead
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+const ead3Source = `<?xml version="1.0" encoding="UTF-8"?>
+<ead xmlns="http://ead3.archivists.org/schema/" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">
+  <control>
+    <recordid instanceurl="http://localhost/ead/test-ead3">test-ead3</recordid>
+    <filedesc>
+      <titlestmt><titleproper>Test EAD3 archive</titleproper></titlestmt>
+    </filedesc>
+    <maintenancestatus value="derived"/>
+    <maintenanceagency><agencycode>NL-HaNA</agencycode><agencyname>Nationaal Archief</agencyname></maintenanceagency>
+    <maintenancehistory><maintenanceevent><eventtype value="created"/></maintenanceevent></maintenancehistory>
+  </control>
+  <archdesc level="fonds">
+    <did>
+      <unittitle>Test EAD3 archive</unittitle>
+      <unitdatestructured><daterange><fromdate standarddate="1900">1900</fromdate><todate standarddate="1950">1950</todate></daterange></unitdatestructured>
+    </did>
+    <dsc>
+      <c01 level="series">
+        <did><unitid>1</unitid><unittitle>first series</unittitle></did>
+        <c02 level="file">
+          <did>
+            <unitid>2</unitid>
+            <unittitle>first file</unittitle>
+            <unitdatestructured><datesingle standarddate="1910">1910</datesingle></unitdatestructured>
+            <physdescstructured coverage="whole" physdescstructuredtype="carrier"><quantity>3</quantity><unittype>boxes</unittype></physdescstructured>
+            <dao daotype="derived" href="http://localhost/mets/2"/>
+          </did>
+          <controlaccess>
+            <persname identifier="NL-HaNA-P1"><part localtype="surname">Jansen</part><part localtype="forename">Jan</part></persname>
+          </controlaccess>
+        </c02>
+      </c01>
+    </dsc>
+  </archdesc>
+</ead>`
+
+const ead2002Source = `<?xml version="1.0" encoding="UTF-8"?>
+<ead>
+  <eadheader>
+    <eadid mainagencycode="NL-HaNA" url="http://localhost/ead/test-ead3">test-ead3</eadid>
+    <filedesc>
+      <titlestmt><titleproper>Test EAD3 archive</titleproper></titlestmt>
+    </filedesc>
+  </eadheader>
+  <archdesc level="fonds">
+    <did>
+      <unittitle>Test EAD3 archive</unittitle>
+      <unitdate normal="1900/1950">1900-1950</unitdate>
+    </did>
+    <dsc>
+      <c01 level="series">
+        <did><unitid>1</unitid><unittitle>first series</unittitle></did>
+        <c02 level="file">
+          <did>
+            <unitid>2</unitid>
+            <unittitle>first file</unittitle>
+            <unitdate normal="1910">1910</unitdate>
+            <physdesc><extent>3 boxes</extent></physdesc>
+            <dao href="http://localhost/mets/2"/>
+          </did>
+          <controlaccess>
+            <persname authfilenumber="NL-HaNA-P1">Jansen Jan</persname>
+          </controlaccess>
+        </c02>
+      </c01>
+    </dsc>
+  </archdesc>
+</ead>`
+
+const eacSource = `<?xml version="1.0" encoding="UTF-8"?>
+<eac-cpf xmlns="urn:isbn:1-931666-33-4" xmlns:xlink="http://www.w3.org/1999/xlink">
+  <control>
+    <recordId>NL-HaNA-P1</recordId>
+    <maintenanceAgency><agencyCode>NL-HaNA</agencyCode><agencyName>Nationaal Archief</agencyName></maintenanceAgency>
+  </control>
+  <cpfDescription>
+    <identity>
+      <entityType>person</entityType>
+      <nameEntry><part localType="surname">Jansen</part><part localType="forename">Jan</part></nameEntry>
+    </identity>
+    <description>
+      <existDates><dateRange><fromDate standardDate="1880">1880</fromDate><toDate standardDate="1945">1945</toDate></dateRange></existDates>
+      <occupations><occupation><term>notaris</term></occupation></occupations>
+      <biogHist><p>Notaris te <b>Leiden</b>.</p></biogHist>
+    </description>
+    <relations>
+      <cpfRelation cpfRelationType="family" xlink:href="NL-HaNA-P2"><relationEntry>Jansen, Piet</relationEntry></cpfRelation>
+    </relations>
+  </cpfDescription>
+</eac-cpf>`
+
+func parseNodes(t *testing.T, src string, authorities ...*Authority) (*Cead, []*Node, *NodeConfig) {
+	t.Helper()
+
+	is := is.New(t)
+
+	cead, err := ParseEAD(strings.NewReader(src))
+	is.NoErr(err)
+
+	cfg := NewNodeConfig(context.Background())
+	cfg.Spec = "test-ead3"
+	cfg.OrgID = "demo"
+
+	for _, a := range authorities {
+		cfg.AddAuthority(a)
+	}
+
+	nl, _, err := cead.Carchdesc.Cdsc.NewNodeList(cfg)
+	is.NoErr(err)
+
+	return cead, nl.Nodes, cfg
+}
+
+func TestIsEAD3(t *testing.T) {
+	is := is.New(t)
+
+	is.True(IsEAD3([]byte(ead3Source)))
+	is.True(!IsEAD3([]byte(ead2002Source)))
+}
+
+func TestParseEAD_ead3(t *testing.T) {
+	is := is.New(t)
+
+	got, gotNodes, gotCfg := parseNodes(t, ead3Source)
+	want, wantNodes, wantCfg := parseNodes(t, ead2002Source)
+
+	is.Equal(got.Ceadheader.Ceadid.EadID, "test-ead3")
+	is.Equal(got.Ceadheader.Ceadid.Attrmainagencycode, "NL-HaNA")
+	is.Equal(got.Ceadheader.GetTitle(), want.Ceadheader.GetTitle())
+	is.Equal(got.Carchdesc.GetNormalPeriods(), want.Carchdesc.GetNormalPeriods())
+
+	is.Equal(len(gotNodes), 1)
+	is.Equal(gotNodes[0].Nodes[0].Header, wantNodes[0].Nodes[0].Header)
+	is.Equal(gotNodes[0].Nodes[0].Header.Physdesc, "3 boxes")
+
+	gotTriples := gotNodes[0].Nodes[0].Triples(gotCfg)
+	wantTriples := wantNodes[0].Nodes[0].Triples(wantCfg)
+	is.Equal(len(gotTriples), len(wantTriples))
+
+	gotDesc, err := NewDescription(got)
+	is.NoErr(err)
+
+	wantDesc, err := NewDescription(want)
+	is.NoErr(err)
+
+	is.Equal(gotDesc.Summary.File.Title, wantDesc.Summary.File.Title)
+}
+
+func TestParseEACCPF(t *testing.T) {
+	is := is.New(t)
+
+	eac, err := ParseEACCPF(strings.NewReader(eacSource))
+	is.NoErr(err)
+
+	a := eac.Authority()
+	is.Equal(a.ID, "NL-HaNA-P1")
+	is.Equal(a.EntityType, "person")
+	is.Equal(a.Names, []string{"Jansen Jan"})
+	is.Equal(a.ExistDates, "1880/1945")
+	is.Equal(a.Occupations, []string{"notaris"})
+	is.Equal(a.BiogHist, "Notaris te Leiden.")
+	is.Equal(a.Related, []string{"NL-HaNA-P2"})
+	is.True(len(a.Triples("demo")) > 5)
+
+	fg, err := a.FragmentGraph("demo")
+	is.NoErr(err)
+	is.Equal(fg.Meta.HubID, "demo_"+AuthoritySpec+"_NL-HaNA-P1")
+	is.Equal(fg.Meta.EntryURI, NewAuthoritySubject("demo", "NL-HaNA-P1"))
+	is.True(strings.Contains(fg.Meta.EntryURI, "/demo/authority/"))
+	is.Equal(len(fg.Resources), 1)
+
+	_, err = ParseEACCPF(strings.NewReader("<eac-cpf><control/></eac-cpf>"))
+	is.Equal(err, ErrNoRecordID)
+}
+
+func TestNodeConfig_authorityTriples(t *testing.T) {
+	is := is.New(t)
+
+	eac, err := ParseEACCPF(strings.NewReader(eacSource))
+	is.NoErr(err)
+
+	hasAuthority := func(nodes []*Node, cfg *NodeConfig) bool {
+		for _, triple := range nodes[0].Nodes[0].Triples(cfg) {
+			if triple.Predicate.RawValue() == eadDomainNS+"/authority" &&
+				triple.Object.RawValue() == NewAuthoritySubject("demo", "NL-HaNA-P1") {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	_, nodes, cfg := parseNodes(t, ead3Source)
+	is.True(!hasAuthority(nodes, cfg))
+
+	_, nodes, cfg = parseNodes(t, ead3Source, eac.Authority())
+	is.True(hasAuthority(nodes, cfg))
+
+	_, nodes, cfg = parseNodes(t, ead2002Source, eac.Authority())
+	is.True(hasAuthority(nodes, cfg))
+}
@@ -0,0 +1,426 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ead
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	cfg "github.com/delving/hub3/config"
+	"github.com/delving/hub3/hub3/fragments"
+	"github.com/delving/hub3/hub3/index"
+	elastic "github.com/olivere/elastic/v7"
+)
+
+// ErrInventoryNotFound is returned when the root of a subtree export cannot be found.
+var ErrInventoryNotFound = errors.New("inventoryID not found in archive tree")
+
+// descType is the tree.Type of the description node of an archive.
+const descType = "desc"
+
+// maxNumberedLevel is the deepest numbered c-level supported by EAD 2002.
+const maxNumberedLevel = 12
+
+// TreeExport holds the indexed tree nodes of an archive for exporting.
+type TreeExport struct {
+	Spec  string
+	Desc  *fragments.Tree
+	Nodes []*fragments.Tree
+}
+
+// ExportNode is a nested representation of a c-level in the archive tree.
+type ExportNode struct {
+	Path     string        `json:"path"`
+	UnitID   string        `json:"unitID,omitempty"`
+	Label    string        `json:"label"`
+	Type     string        `json:"type,omitempty"`
+	Depth    int           `json:"depth"`
+	Periods  []string      `json:"periods,omitempty"`
+	PhysDesc string        `json:"physDesc,omitempty"`
+	DaoLink  string        `json:"daoLink,omitempty"`
+	Access   string        `json:"access,omitempty"`
+	Children []*ExportNode `json:"children,omitempty"`
+}
+
+// NewTreeExport creates a TreeExport from a list of tree nodes.
+//
+// The description node is separated from the c-levels and the c-levels are
+// sorted in the order of the original EAD.
+func NewTreeExport(spec string, trees []*fragments.Tree) *TreeExport {
+	te := &TreeExport{Spec: spec}
+
+	for _, tree := range trees {
+		if tree.Type == descType && tree.CLevel == "" {
+			te.Desc = tree
+			continue
+		}
+
+		te.Nodes = append(te.Nodes, tree)
+	}
+
+	sort.SliceStable(te.Nodes, func(i, j int) bool {
+		return te.Nodes[i].SortKey < te.Nodes[j].SortKey
+	})
+
+	return te
+}
+
+// NewTreeExport creates a TreeExport directly from the EAD without using the index.
+func (cead *Cead) NewTreeExport(cfg *NodeConfig) (*TreeExport, error) {
+	if cead.Carchdesc == nil {
+		return NewTreeExport(cfg.Spec, nil), nil
+	}
+
+	// make sure the nodes are returned as a NodeList
+	cfg.Nodes = nil
+
+	nl, _, err := cead.Carchdesc.Cdsc.NewNodeList(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	trees := []*fragments.Tree{}
+
+	var walk func(nodes []*Node)
+	walk = func(nodes []*Node) {
+		for _, n := range nodes {
+			trees = append(trees, CreateTree(cfg, n, "", n.Path))
+			walk(n.Nodes)
+		}
+	}
+
+	walk(nl.Nodes)
+
+	return NewTreeExport(cfg.Spec, trees), nil
+}
+
+// treePath returns the CLevel path without the CLevelLeader.
+func treePath(tree *fragments.Tree) string {
+	return strings.TrimPrefix(tree.CLevel, CLevelLeader)
+}
+
+// Subtree returns a TreeExport that only contains the c-level with the
+// inventoryID and all its descendants.
+func (te *TreeExport) Subtree(inventoryID string) (*TreeExport, error) {
+	var root *fragments.Tree
+
+	for _, tree := range te.Nodes {
+		if tree.UnitID == inventoryID || treePath(tree) == inventoryID {
+			root = tree
+			break
+		}
+	}
+
+	if root == nil {
+		return nil, ErrInventoryNotFound
+	}
+
+	sub := &TreeExport{
+		Spec: te.Spec,
+		Desc: te.Desc,
+	}
+
+	prefix := root.CLevel + pathSep
+
+	for _, tree := range te.Nodes {
+		if tree.CLevel == root.CLevel || strings.HasPrefix(tree.CLevel, prefix) {
+			sub.Nodes = append(sub.Nodes, tree)
+		}
+	}
+
+	return sub, nil
+}
+
+// Nested returns the c-levels as a nested tree.
+//
+// Nodes whose parent is not part of the export are returned as roots.
+func (te *TreeExport) Nested() []*ExportNode {
+	roots := []*ExportNode{}
+	byCLevel := map[string]*ExportNode{}
+
+	for _, tree := range te.Nodes {
+		node := &ExportNode{
+			Path:     treePath(tree),
+			UnitID:   tree.UnitID,
+			Label:    tree.Label,
+			Type:     tree.Type,
+			Depth:    tree.Depth,
+			Periods:  tree.Periods,
+			PhysDesc: tree.PhysDesc,
+			DaoLink:  tree.DaoLink,
+			Access:   tree.Access,
+		}
+
+		byCLevel[tree.CLevel] = node
+
+		parent, ok := byCLevel[tree.Leaf]
+		if tree.Leaf == "" || !ok {
+			roots = append(roots, node)
+			continue
+		}
+
+		parent.Children = append(parent.Children, node)
+	}
+
+	return roots
+}
+
+// WriteJSON writes the c-levels as a nested JSON tree.
+func (te *TreeExport) WriteJSON(w io.Writer) error {
+	export := struct {
+		Spec  string        `json:"spec"`
+		Title string        `json:"title,omitempty"`
+		Tree  []*ExportNode `json:"tree"`
+	}{
+		Spec: te.Spec,
+		Tree: te.Nested(),
+	}
+
+	if te.Desc != nil {
+		export.Title = te.Desc.Title
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(export)
+}
+
+// WriteCSV writes the c-levels as a flat CSV with one row per c-level.
+func (te *TreeExport) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write([]string{"path", "depth", "type", "unitid", "title", "period"}); err != nil {
+		return err
+	}
+
+	for _, tree := range te.Nodes {
+		row := []string{
+			treePath(tree),
+			strconv.Itoa(tree.Depth),
+			tree.Type,
+			tree.UnitID,
+			tree.Label,
+			strings.Join(tree.Periods, "; "),
+		}
+
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// exportCLevel is the EAD 2002 c-level that is written by WriteEAD.
+type exportCLevel struct {
+	XMLName        xml.Name
+	Level          string           `xml:"level,attr,omitempty"`
+	Did            exportDid        `xml:"did"`
+	AccessRestrict *exportParagraph `xml:"accessrestrict,omitempty"`
+	Children       []*exportCLevel
+}
+
+type exportDid struct {
+	UnitID    string        `xml:"unitid,omitempty"`
+	UnitTitle string        `xml:"unittitle,omitempty"`
+	UnitDate  []string      `xml:"unitdate,omitempty"`
+	PhysDesc  string        `xml:"physdesc,omitempty"`
+	Dao       *exportDaoRef `xml:"dao,omitempty"`
+}
+
+type exportDaoRef struct {
+	Href string `xml:"href,attr"`
+}
+
+type exportParagraph struct {
+	P string `xml:"p"`
+}
+
+type exportEAD struct {
+	XMLName  xml.Name       `xml:"ead"`
+	EadID    string         `xml:"eadheader>eadid"`
+	Title    string         `xml:"eadheader>filedesc>titlestmt>titleproper"`
+	Archdesc exportArchdesc `xml:"archdesc"`
+}
+
+type exportArchdesc struct {
+	Level string          `xml:"level,attr"`
+	Did   exportDid       `xml:"did"`
+	Dsc   []*exportCLevel `xml:"dsc>c01"`
+}
+
+// cLevelName returns the c-level name for the depth relative to the root of the export.
+// The unnumbered 'c' is returned when the c-levels are not numbered.
+func cLevelName(depth int, numbered bool) xml.Name {
+	if !numbered {
+		return xml.Name{Local: "c"}
+	}
+
+	return xml.Name{Local: fmt.Sprintf("c%02d", depth)}
+}
+
+// nestedDepth returns the number of c-levels of the deepest branch of the nodes.
+func nestedDepth(nodes []*ExportNode) int {
+	max := 0
+
+	for _, node := range nodes {
+		if depth := nestedDepth(node.Children) + 1; depth > max {
+			max = depth
+		}
+	}
+
+	return max
+}
+
+func newExportCLevel(node *ExportNode, depth int, numbered bool) *exportCLevel {
+	c := &exportCLevel{
+		XMLName: cLevelName(depth, numbered),
+		Level:   node.Type,
+		Did: exportDid{
+			UnitID:    node.UnitID,
+			UnitTitle: node.Label,
+			UnitDate:  node.Periods,
+			PhysDesc:  node.PhysDesc,
+		},
+	}
+
+	if node.DaoLink != "" {
+		c.Did.Dao = &exportDaoRef{Href: node.DaoLink}
+	}
+
+	if node.Access != "" {
+		c.AccessRestrict = &exportParagraph{P: node.Access}
+	}
+
+	for _, child := range node.Children {
+		c.Children = append(c.Children, newExportCLevel(child, depth+1, numbered))
+	}
+
+	return c
+}
+
+// WriteEAD writes the c-levels as an EAD 2002 XML document.
+//
+// The numbering of the c-levels starts at c01 for the root of the export.
+// EAD 2002 does not allow numbered and unnumbered c-levels to be mixed, so
+// all c-levels are written as unnumbered 'c' when the tree is nested deeper
+// than c12.
+func (te *TreeExport) WriteEAD(w io.Writer) error {
+	doc := &exportEAD{
+		EadID: te.Spec,
+		Archdesc: exportArchdesc{
+			Level: "fonds",
+		},
+	}
+
+	if te.Desc != nil {
+		doc.Title = te.Desc.Title
+		doc.Archdesc.Did = exportDid{
+			UnitID:    te.Desc.InventoryID,
+			UnitTitle: te.Desc.Title,
+			UnitDate:  te.Desc.PeriodDesc,
+		}
+	}
+
+	nodes := te.Nested()
+	numbered := nestedDepth(nodes) <= maxNumberedLevel
+
+	for _, node := range nodes {
+		doc.Archdesc.Dsc = append(doc.Archdesc.Dsc, newExportCLevel(node, 1, numbered))
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+
+	return enc.Flush()
+}
+
+// GetTreeExport retrieves all the indexed tree nodes of an archive from ElasticSearch.
+//
+// When the inventoryID is not empty only the subtree rooted at the inventoryID is returned.
+func GetTreeExport(ctx context.Context, spec, inventoryID string) (*TreeExport, error) {
+	query := elastic.NewBoolQuery().
+		Must(elastic.NewTermQuery(specField, spec)).
+		Must(elastic.NewTermQuery(cfg.Config.ElasticSearch.OrgIDKey, cfg.Config.OrgID)).
+		Must(
+			elastic.NewBoolQuery().
+				Should(elastic.NewTermQuery("meta.tags", "ead")).
+				Should(elastic.NewTermQuery("meta.tags", "eadDesc")),
+		)
+
+	fsc := elastic.NewFetchSourceContext(true)
+	fsc.Include("tree")
+
+	scroll := index.ESClient().Scroll(cfg.Config.ElasticSearch.GetIndexName()).
+		Query(query).
+		FetchSourceContext(fsc).
+		Sort("tree.sortKey", true).
+		Size(500)
+
+	defer func() {
+		_ = scroll.Clear(context.Background())
+	}()
+
+	trees := []*fragments.Tree{}
+
+	for {
+		res, err := scroll.Do(ctx)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, hit := range res.Hits.Hits {
+			var fg fragments.FragmentGraph
+			if err := json.Unmarshal(hit.Source, &fg); err != nil {
+				return nil, err
+			}
+
+			if fg.Tree != nil {
+				trees = append(trees, fg.Tree)
+			}
+		}
+	}
+
+	te := NewTreeExport(spec, trees)
+
+	if inventoryID != "" {
+		return te.Subtree(inventoryID)
+	}
+
+	return te, nil
+}
@@ -0,0 +1,181 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ead_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+
+	"github.com/delving/hub3/hub3/ead"
+	"github.com/delving/hub3/hub3/fragments"
+)
+
+func exportTrees() []*fragments.Tree {
+	return []*fragments.Tree{
+		{CLevel: "@4", UnitID: "4", Label: "last series", Type: "series", Depth: 1, SortKey: 4},
+		{CLevel: "@1~3", Leaf: "@1", UnitID: "3", Label: "second file", Type: "file", Depth: 2, SortKey: 3},
+		{Type: "desc", Title: "Test archive", InventoryID: "test-spec", PeriodDesc: []string{"1900/1950"}},
+		{CLevel: "@1", UnitID: "1", Label: "first series", Type: "series", Depth: 1, SortKey: 1},
+		{
+			CLevel: "@1~2", Leaf: "@1", UnitID: "2", Label: "first, file", Type: "file", Depth: 2, SortKey: 2,
+			Periods: []string{"1900-1910"}, DaoLink: "http://localhost/mets/2",
+		},
+	}
+}
+
+func TestTreeExport_Subtree(t *testing.T) {
+	is := is.New(t)
+
+	te := ead.NewTreeExport("test-spec", exportTrees())
+	is.Equal(te.Desc.Title, "Test archive")
+	is.Equal(len(te.Nodes), 4)
+	is.Equal(te.Nodes[0].UnitID, "1")
+
+	sub, err := te.Subtree("1")
+	is.NoErr(err)
+	is.Equal(len(sub.Nodes), 3)
+
+	sub, err = te.Subtree("1~3")
+	is.NoErr(err)
+	is.Equal(len(sub.Nodes), 1)
+
+	_, err = te.Subtree("99")
+	is.True(errors.Is(err, ead.ErrInventoryNotFound))
+}
+
+func TestTreeExport_WriteCSV(t *testing.T) {
+	is := is.New(t)
+
+	var buf bytes.Buffer
+
+	err := ead.NewTreeExport("test-spec", exportTrees()).WriteCSV(&buf)
+	is.NoErr(err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	is.Equal(len(lines), 5)
+	is.Equal(lines[0], "path,depth,type,unitid,title,period")
+	is.Equal(lines[2], `1~2,2,file,2,"first, file",1900-1910`)
+}
+
+func TestTreeExport_WriteJSON(t *testing.T) {
+	is := is.New(t)
+
+	var buf bytes.Buffer
+
+	err := ead.NewTreeExport("test-spec", exportTrees()).WriteJSON(&buf)
+	is.NoErr(err)
+
+	var got struct {
+		Spec  string
+		Title string
+		Tree  []*ead.ExportNode
+	}
+
+	is.NoErr(json.Unmarshal(buf.Bytes(), &got))
+	is.Equal(got.Title, "Test archive")
+	is.Equal(len(got.Tree), 2)
+	is.Equal(len(got.Tree[0].Children), 2)
+	is.Equal(got.Tree[0].Children[1].Path, "1~3")
+}
+
+func TestTreeExport_WriteEAD(t *testing.T) {
+	is := is.New(t)
+
+	te, err := ead.NewTreeExport("test-spec", exportTrees()).Subtree("1")
+	is.NoErr(err)
+
+	var buf bytes.Buffer
+
+	is.NoErr(te.WriteEAD(&buf))
+
+	// the export must be readable by the EAD 2002 parser
+	cead := new(ead.Cead)
+	is.NoErr(xml.Unmarshal(buf.Bytes(), cead))
+	is.Equal(cead.Ceadheader.GetTitle(), "Test archive")
+	is.Equal(cead.Carchdesc.Attrlevel, "fonds")
+	is.Equal(len(cead.Carchdesc.Cdsc.Numbered), 1)
+	is.Equal(len(cead.Carchdesc.Cdsc.Numbered[0].Numbered), 2)
+
+	file := cead.Carchdesc.Cdsc.Numbered[0].Numbered[0]
+	is.Equal(file.Attrlevel, "file")
+	is.Equal(file.Cdid[0].Cunitid[0].Unitid, "2")
+	is.Equal(file.Cdid[0].Cdao[0].Attrhref, "http://localhost/mets/2")
+
+	report, err := cead.Validate(context.Background(), nil)
+	is.NoErr(err)
+	is.True(report.Valid)
+}
+
+func TestTreeExport_WriteEAD_deep(t *testing.T) {
+	is := is.New(t)
+
+	// a branch of 14 nested c-levels is deeper than c12
+	trees := []*fragments.Tree{{Type: "desc", Title: "Deep archive", InventoryID: "test-spec"}}
+	leaf := ""
+
+	for i := 1; i <= 14; i++ {
+		cLevel := fmt.Sprintf("%s@%d", leaf, i)
+		if leaf != "" {
+			cLevel = fmt.Sprintf("%s~%d", leaf, i)
+		}
+
+		trees = append(trees, &fragments.Tree{
+			CLevel: cLevel, Leaf: leaf, UnitID: strconv.Itoa(i), Label: "level " + strconv.Itoa(i),
+			Type: "series", Depth: i, SortKey: uint64(i),
+		})
+
+		leaf = cLevel
+	}
+
+	var buf bytes.Buffer
+
+	is.NoErr(ead.NewTreeExport("test-spec", trees).WriteEAD(&buf))
+
+	// numbered and unnumbered c-levels are not mixed
+	is.True(!strings.Contains(buf.String(), "<c01"))
+	is.True(!strings.Contains(buf.String(), "<c12"))
+
+	cead := new(ead.Cead)
+	is.NoErr(xml.Unmarshal(buf.Bytes(), cead))
+	is.Equal(len(cead.Carchdesc.Cdsc.Numbered), 0)
+	is.Equal(len(cead.Carchdesc.Cdsc.Cc), 1)
+
+	depth := 0
+	for c := cead.Carchdesc.Cdsc.Cc[0]; c != nil; depth++ {
+		is.Equal(c.Cdid[0].Cunitid[0].Unitid, strconv.Itoa(depth+1))
+
+		if len(c.Cc) == 0 {
+			c = nil
+			continue
+		}
+
+		c = c.Cc[0]
+	}
+
+	is.Equal(depth, 14)
+
+	report, err := cead.Validate(context.Background(), nil)
+	is.NoErr(err)
+	is.True(report.Valid)
+}
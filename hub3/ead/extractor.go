@@ -16,7 +16,17 @@ package ead
 
 import (
 	"bytes"
+	"context"
+	"encoding/csv"
 	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	r "github.com/kiivihal/rdf2go"
 )
 
 type NLPType int
@@ -39,6 +49,7 @@ type Extractor struct {
 	XMLName   xml.Name     `xml:"input,omitempty" json:"input,omitempty"`
 	Cgeogname []*Cgeogname `xml:"geogname,omitempty" json:"geogname,omitempty"`
 	Cpersname []*Cpersname `xml:"persname,omitempty" json:"persname,omitempty"`
+	Ccorpname []*Ccorpname `xml:"corpname,omitempty" json:"corpname,omitempty"`
 	Cdate     []*Cdate     `xml:"date,omitempty" json:"date,omitempty"`
 }
 
@@ -70,6 +81,10 @@ func (e *Extractor) Tokens() []NLPToken {
 		tokens = append(tokens, NLPToken{Text: pers.Persname, Type: Person})
 	}
 
+	for _, corp := range e.Ccorpname {
+		tokens = append(tokens, NLPToken{Text: corp.Corpname, Type: Organization})
+	}
+
 	for _, date := range e.Cdate {
 		tokens = append(tokens, NLPToken{Text: date.Date, Type: DateText})
 
@@ -80,3 +95,278 @@ func (e *Extractor) Tokens() []NLPToken {
 
 	return tokens
 }
+
+// ErrUnknownNLPType is returned when a dictionary contains an unsupported entity type.
+var ErrUnknownNLPType = errors.New("unknown entity type")
+
+// nlpTypeNames are the names of the NLPTypes used in dictionaries.
+var nlpTypeNames = map[string]NLPType{
+	"person":       Person,
+	"place":        GeoLocation,
+	"organization": Organization,
+	"date":         DateText,
+}
+
+// predicate returns the label of the predicate that is used for the extracted entity.
+func (t NLPType) predicate() string {
+	switch t {
+	case Person:
+		return "persname"
+	case GeoLocation:
+		return "geogname"
+	case DateText:
+		return "datetext"
+	case DateIso:
+		return "dateiso"
+	case Organization:
+		return "corpname"
+	case Unknown:
+		return ""
+	}
+
+	return ""
+}
+
+// entityPattern extracts entities that can be recognised by their form.
+type entityPattern struct {
+	re   *regexp.Regexp
+	Type NLPType
+	// normalise converts the submatches to the text of the token
+	normalise func(match []string) string
+}
+
+var months = `januari|februari|maart|april|mei|juni|juli|augustus|september|oktober|november|december|` +
+	`january|february|march|may|june|july|august|october`
+
+var entityPatterns = []*entityPattern{
+	{
+		re:        regexp.MustCompile(`\b(\d{4}-\d{2}-\d{2})\b`),
+		Type:      DateIso,
+		normalise: func(m []string) string { return m[1] },
+	},
+	{
+		re:        regexp.MustCompile(`(?i)\b(\d{1,2} (?:` + months + `) \d{4})\b`),
+		Type:      DateText,
+		normalise: func(m []string) string { return m[1] },
+	},
+	{
+		re:        regexp.MustCompile(`\b(1[0-9]{3}|20[0-9]{2})\s?-\s?(1[0-9]{3}|20[0-9]{2})\b`),
+		Type:      DateIso,
+		normalise: func(m []string) string { return m[1] + "/" + m[2] },
+	},
+}
+
+// EntityExtractor extracts persons, places, organizations and dates from
+// descriptive text.
+//
+// Entities are found from the EAD markup (persname, geogname, corpname and
+// date), from the terms in the dictionary and from date patterns.
+type EntityExtractor struct {
+	dictionary map[string]NLPType
+	terms      *regexp.Regexp
+}
+
+// NewEntityExtractor creates an EntityExtractor. The keys of the dictionary are matched case-insensitive.
+func NewEntityExtractor(dictionary map[string]NLPType) *EntityExtractor {
+	ee := &EntityExtractor{
+		dictionary: map[string]NLPType{},
+	}
+
+	terms := []string{}
+
+	for term, nlpType := range dictionary {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+
+		ee.dictionary[strings.ToLower(term)] = nlpType
+		terms = append(terms, regexp.QuoteMeta(term))
+	}
+
+	if len(terms) > 0 {
+		// longest terms first so the longest match wins
+		sort.Slice(terms, func(i, j int) bool { return len(terms[i]) > len(terms[j]) })
+		ee.terms = regexp.MustCompile(`(?i)\b(` + strings.Join(terms, "|") + `)\b`)
+	}
+
+	return ee
+}
+
+// ReadDictionary reads a CSV dictionary with a term and a type on each row.
+//
+// Supported types are person, place, organization and date.
+func ReadDictionary(rdr io.Reader) (map[string]NLPType, error) {
+	cr := csv.NewReader(rdr)
+	cr.FieldsPerRecord = 2
+	cr.Comment = '#'
+
+	dictionary := map[string]NLPType{}
+
+	for {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		nlpType, ok := nlpTypeNames[strings.ToLower(strings.TrimSpace(row[1]))]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownNLPType, row[1])
+		}
+
+		dictionary[strings.TrimSpace(row[0])] = nlpType
+	}
+
+	return dictionary, nil
+}
+
+// Extract returns the unique entities in the raw XML text.
+func (ee *EntityExtractor) Extract(raw []byte) []NLPToken {
+	tokens := []NLPToken{}
+	seen := map[string]bool{}
+
+	add := func(token NLPToken) {
+		token.Text = strings.Join(strings.Fields(token.Text), " ")
+		if token.Text == "" {
+			return
+		}
+
+		key := fmt.Sprintf("%d:%s", token.Type, strings.ToLower(token.Text))
+		if seen[key] {
+			return
+		}
+
+		seen[key] = true
+
+		tokens = append(tokens, token)
+	}
+
+	if e, err := NewExtractor(raw); err == nil {
+		for _, token := range e.Tokens() {
+			add(token)
+		}
+	}
+
+	text := sanitizer.Sanitize(string(raw))
+
+	if ee.terms != nil {
+		for _, match := range ee.terms.FindAllString(text, -1) {
+			add(NLPToken{Type: ee.dictionary[strings.ToLower(match)], Text: match})
+		}
+	}
+
+	for _, p := range entityPatterns {
+		for _, match := range p.re.FindAllStringSubmatch(text, -1) {
+			add(NLPToken{Type: p.Type, Text: p.normalise(match)})
+		}
+	}
+
+	return tokens
+}
+
+// Reconciler links extracted entities to the concepts in a vocabulary.
+type Reconciler interface {
+	// Reconcile returns the URI of the concept for the token. An empty string is returned when there is no match.
+	Reconcile(ctx context.Context, token NLPToken) (string, error)
+}
+
+// MapReconciler is a Reconciler that looks up the concept URI by the lowercased text of the token.
+type MapReconciler map[string]string
+
+// Reconcile returns the URI of the concept for the token.
+func (mr MapReconciler) Reconcile(ctx context.Context, token NLPToken) (string, error) {
+	return mr[strings.ToLower(token.Text)], nil
+}
+
+// ReadMapReconciler reads a CSV export of a vocabulary with a label and a concept URI on each row.
+func ReadMapReconciler(rdr io.Reader) (MapReconciler, error) {
+	cr := csv.NewReader(rdr)
+	cr.FieldsPerRecord = 2
+	cr.Comment = '#'
+
+	mr := MapReconciler{}
+
+	for {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		mr[strings.ToLower(strings.TrimSpace(row[0]))] = strings.TrimSpace(row[1])
+	}
+
+	return mr, nil
+}
+
+// entityTriples returns the triples for the entities extracted from the unittitles and descriptive paragraphs.
+//
+// Entities for which a triple was already created from the EAD markup are skipped.
+// The entities that are reconciled are linked to the concept with a '<predicate>Link' triple.
+func (cfg *NodeConfig) entityTriples(s r.Term, c *Cc, existing []*r.Triple) ([]*r.Triple, error) {
+	triples := []*r.Triple{}
+
+	if cfg.Extractor == nil {
+		return triples, nil
+	}
+
+	seen := map[string]bool{}
+	for _, t := range existing {
+		seen[t.Predicate.RawValue()+t.Object.RawValue()] = true
+	}
+
+	raws := [][]byte{}
+
+	for _, did := range c.Cdid {
+		for _, title := range did.Cunittitle {
+			raws = append(raws, title.Raw)
+		}
+	}
+
+	for _, sc := range c.Cscopecontent {
+		raws = append(raws, sc.Raw)
+	}
+
+	for _, odd := range c.Codd {
+		raws = append(raws, odd.Raw)
+	}
+
+	for _, bh := range c.Cbioghist {
+		raws = append(raws, bh.Raw)
+	}
+
+	for _, raw := range raws {
+		for _, token := range cfg.Extractor.Extract(raw) {
+			t := addNonEmptyTriple(s, token.Type.predicate(), token.Text, r.NewLiteral)
+			if t == nil || seen[t.Predicate.RawValue()+t.Object.RawValue()] {
+				continue
+			}
+
+			seen[t.Predicate.RawValue()+t.Object.RawValue()] = true
+
+			triples = append(triples, t)
+
+			if cfg.Reconciler == nil {
+				continue
+			}
+
+			uri, err := cfg.Reconciler.Reconcile(cfg.ctx, token)
+			if err != nil {
+				return nil, fmt.Errorf("unable to reconcile %q; %w", token.Text, err)
+			}
+
+			if uri != "" {
+				triples = append(triples, r.NewTriple(s, NewResource(token.Type.predicate()+"Link"), r.NewResource(uri)))
+			}
+		}
+	}
+
+	return triples, nil
+}
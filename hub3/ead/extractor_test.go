@@ -15,9 +15,13 @@
 package ead
 
 import (
+	"context"
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	r "github.com/kiivihal/rdf2go"
 )
 
 func TestExtractor_NewExtractor(t *testing.T) {
@@ -107,3 +111,110 @@ func TestExtractor_NewExtractor(t *testing.T) {
 		})
 	}
 }
+
+func TestEntityExtractor_Extract(t *testing.T) {
+	ee := NewEntityExtractor(map[string]NLPType{
+		"Batavia":                           GeoLocation,
+		"Verenigde Oost-Indische Compagnie": Organization,
+		"Jan Pieterszoon Coen":              Person,
+	})
+
+	tests := []struct {
+		name  string
+		input string
+		want  []NLPToken
+	}{
+		{
+			"markup and dictionary",
+			`Brieven van <persname>Jan Pieterszoon Coen</persname> uit batavia`,
+			[]NLPToken{
+				{Type: Person, Text: "Jan Pieterszoon Coen"},
+				{Type: GeoLocation, Text: "batavia"},
+			},
+		},
+		{
+			"organization",
+			`Resoluties van de Verenigde Oost-Indische Compagnie en <corpname>Heren XVII</corpname>`,
+			[]NLPToken{
+				{Type: Organization, Text: "Heren XVII"},
+				{Type: Organization, Text: "Verenigde Oost-Indische Compagnie"},
+			},
+		},
+		{
+			"date patterns",
+			`Stukken 1619 - 1623, ontvangen 12 maart 1620 en 1621-04-01`,
+			[]NLPToken{
+				{Type: DateIso, Text: "1621-04-01"},
+				{Type: DateText, Text: "12 maart 1620"},
+				{Type: DateIso, Text: "1619/1623"},
+			},
+		},
+		{
+			"no entities",
+			`Notulen`,
+			[]NLPToken{},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, ee.Extract([]byte(tt.input))); diff != "" {
+				t.Errorf("EntityExtractor.Extract() %s = mismatch (-want +got):\n%s", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestReadDictionary(t *testing.T) {
+	got, err := ReadDictionary(strings.NewReader("# term,type\nBatavia,place\nVOC,organization\n"))
+	if err != nil {
+		t.Fatalf("ReadDictionary() unexpected error = %v", err)
+	}
+
+	want := map[string]NLPType{"Batavia": GeoLocation, "VOC": Organization}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReadDictionary() = mismatch (-want +got):\n%s", diff)
+	}
+
+	_, err = ReadDictionary(strings.NewReader("Batavia,ship\n"))
+	if !errors.Is(err, ErrUnknownNLPType) {
+		t.Errorf("ReadDictionary() error = %v, want %v", err, ErrUnknownNLPType)
+	}
+}
+
+func TestNodeConfig_entityTriples(t *testing.T) {
+	cfg := NewNodeConfig(context.Background())
+	cfg.Spec = "test"
+	cfg.Extractor = NewEntityExtractor(map[string]NLPType{"Batavia": GeoLocation})
+	cfg.Reconciler = MapReconciler{"batavia": "http://localhost/concept/batavia"}
+
+	c := &Cc{
+		Cdid: []*Cdid{{Cunittitle: []*Cunittitle{{Raw: []byte("Brieven uit Batavia")}}}},
+		Cscopecontent: []*Cscopecontent{{
+			Raw: []byte("<p>Over <geogname>Batavia</geogname></p>"),
+		}},
+	}
+
+	s := r.NewResource("http://localhost/archive/test/1")
+
+	got, err := cfg.entityTriples(s, c, nil)
+	if err != nil {
+		t.Fatalf("entityTriples() unexpected error = %v", err)
+	}
+
+	want := []string{
+		NewResource("geogname").RawValue() + " Batavia",
+		NewResource("geognameLink").RawValue() + " http://localhost/concept/batavia",
+	}
+
+	gotValues := []string{}
+	for _, triple := range got {
+		gotValues = append(gotValues, triple.Predicate.RawValue()+" "+triple.Object.RawValue())
+	}
+
+	if diff := cmp.Diff(want, gotValues); diff != "" {
+		t.Errorf("entityTriples() = mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -178,6 +178,10 @@ func CreateTree(cfg *NodeConfig, n *Node, hubID string, id string) *fragments.Tr
 		case NewResource("persname").RawValue():
 		case NewResource("datetext").RawValue():
 		case NewResource("dateiso").RawValue():
+		case NewResource("corpname").RawValue():
+		case NewResource("persnameLink").RawValue():
+		case NewResource("geognameLink").RawValue():
+		case NewResource("corpnameLink").RawValue():
 		default:
 			tree.RawContent = append(tree.RawContent, t.Object.RawValue())
 		}
@@ -50,7 +50,7 @@ func sanitizeXMLAsString(b []byte) string {
 	return string(sanitizeXML(b))
 }
 
-// ReadEAD reads an ead2002 XML from a path
+// ReadEAD reads an ead2002 or EAD3 XML from a path
 func ReadEAD(fpath string) (*Cead, error) {
 	rawEAD, err := ioutil.ReadFile(fpath)
 	if err != nil {
@@ -60,8 +60,18 @@ func ReadEAD(fpath string) (*Cead, error) {
 	return eadParse(rawEAD)
 }
 
-// Parse parses a ead2002 XML file into a set of Go structures
+// Parse parses a ead2002 XML file into a set of Go structures.
+// EAD3 sources are converted to ead2002 before parsing.
 func eadParse(src []byte) (*Cead, error) {
+	if IsEAD3(src) {
+		converted, err := ConvertEAD3(src)
+		if err != nil {
+			return nil, err
+		}
+
+		src = converted
+	}
+
 	ead := new(Cead)
 	err := xml.Unmarshal(src, ead)
 	return ead, err
@@ -0,0 +1,414 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ead
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+const (
+	pdfFont       = "Helvetica"
+	pdfLineHeight = 5.0
+	pdfIndent     = 6.0
+	// pdfMaxHeadingDepth is the deepest c-level that is rendered as a heading in the PDF.
+	pdfMaxHeadingDepth = 3
+)
+
+// tocEntry is an entry in the table of contents of the PDF.
+type tocEntry struct {
+	Label string
+	Depth int
+	Page  int
+	link  int
+}
+
+// FindingAidPDF renders a printable finding aid from the Description and the archive tree.
+type FindingAidPDF struct {
+	desc *Description
+	tree *TreeExport
+	// InventoryLabel is the heading of the inventory section.
+	InventoryLabel string
+	// TocLabel is the heading of the table of contents.
+	TocLabel string
+}
+
+// NewFindingAidPDF creates a FindingAidPDF. Both the Description and the TreeExport can be nil.
+func NewFindingAidPDF(desc *Description, tree *TreeExport) *FindingAidPDF {
+	return &FindingAidPDF{
+		desc:           desc,
+		tree:           tree,
+		InventoryLabel: "Inventaris",
+		TocLabel:       "Inhoudsopgave",
+	}
+}
+
+// Write renders the PDF to the io.Writer.
+//
+// The PDF is rendered twice. The first pass determines the page numbers of all
+// the entries in the table of contents. Because the table of contents has the
+// same size in both passes the pagination of the second pass is identical.
+func (fa *FindingAidPDF) Write(w io.Writer) error {
+	toc := fa.tableOfContents()
+
+	if _, err := fa.render(toc, ioutil.Discard); err != nil {
+		return err
+	}
+
+	_, err := fa.render(toc, w)
+
+	return err
+}
+
+// tableOfContents returns the entries of the table of contents without page numbers.
+func (fa *FindingAidPDF) tableOfContents() []*tocEntry {
+	toc := []*tocEntry{}
+
+	for _, section := range fa.sections() {
+		toc = append(toc, &tocEntry{Label: section.title, Depth: 1})
+	}
+
+	if fa.tree != nil && len(fa.tree.Nodes) > 0 {
+		toc = append(toc, &tocEntry{Label: fa.InventoryLabel, Depth: 1})
+
+		for _, node := range fa.tree.Nodes {
+			if isPDFHeading(node.Depth, node.ChildCount) {
+				toc = append(toc, &tocEntry{Label: nodeHeading(node.UnitID, node.Label), Depth: node.Depth + 1})
+			}
+		}
+	}
+
+	return toc
+}
+
+// isPDFHeading returns if a c-level is rendered as a heading.
+func isPDFHeading(depth, children int) bool {
+	return children > 0 && depth <= pdfMaxHeadingDepth
+}
+
+func nodeHeading(unitID, label string) string {
+	label = cleanPDFText(label)
+	if unitID == "" {
+		return label
+	}
+
+	return fmt.Sprintf("%s. %s", unitID, label)
+}
+
+type pdfSection struct {
+	title      string
+	paragraphs []string
+}
+
+// sections converts the Description sections into titled paragraphs.
+func (fa *FindingAidPDF) sections() []*pdfSection {
+	sections := []*pdfSection{}
+
+	if fa.desc == nil {
+		return sections
+	}
+
+	for _, info := range fa.desc.Section {
+		section := &pdfSection{title: cleanPDFText(info.Text)}
+
+		var current strings.Builder
+
+		flush := func() {
+			if text := strings.TrimSpace(current.String()); text != "" {
+				section.paragraphs = append(section.paragraphs, text)
+			}
+
+			current.Reset()
+		}
+
+		for _, item := range fa.desc.Item {
+			if item.Order <= uint64(info.Start) || item.Order > uint64(info.End) {
+				continue
+			}
+
+			text := cleanPDFText(item.Text)
+
+			switch item.Type {
+			case Section:
+				continue
+			case SubSection:
+				flush()
+
+				if text != "" {
+					section.paragraphs = append(section.paragraphs, strings.ToUpper(text))
+				}
+
+				continue
+			case ListItem, ChronItem:
+				flush()
+
+				text = "- " + text
+			}
+
+			if item.Label != "" {
+				text = fmt.Sprintf("%s %s", cleanPDFText(item.Label), text)
+			}
+
+			if item.FlowType != Inline {
+				flush()
+			}
+
+			if text != "" {
+				if current.Len() > 0 {
+					current.WriteString(" ")
+				}
+
+				current.WriteString(text)
+			}
+		}
+
+		flush()
+
+		if section.title == "" && len(section.paragraphs) > 0 {
+			section.title = section.paragraphs[0]
+			section.paragraphs = section.paragraphs[1:]
+		}
+
+		if section.title == "" {
+			continue
+		}
+
+		sections = append(sections, section)
+	}
+
+	return sections
+}
+
+// cleanPDFText removes all markup and normalises the whitespace.
+func cleanPDFText(text string) string {
+	return strings.Join(strings.Fields(html.UnescapeString(sanitizer.Sanitize(text))), " ")
+}
+
+func (fa *FindingAidPDF) title() string {
+	if fa.desc != nil && fa.desc.Summary.File != nil && fa.desc.Summary.File.Title != "" {
+		return fa.desc.Summary.File.Title
+	}
+
+	if fa.tree != nil {
+		if fa.tree.Desc != nil && fa.tree.Desc.Title != "" {
+			return fa.tree.Desc.Title
+		}
+
+		return fa.tree.Spec
+	}
+
+	return ""
+}
+
+// render writes the PDF and records the page numbers in the table of contents.
+func (fa *FindingAidPDF) render(toc []*tocEntry, w io.Writer) (*gofpdf.Fpdf, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	title := tr(cleanPDFText(fa.title()))
+
+	pdf.SetTitle(title, false)
+	pdf.SetCreator("hub3", false)
+	pdf.AliasNbPages("")
+	pdf.SetAutoPageBreak(true, 20)
+
+	pdf.SetHeaderFunc(func() {
+		if pdf.PageNo() == 1 {
+			return
+		}
+
+		pdf.SetFont(pdfFont, "I", 8)
+		pdf.CellFormat(0, pdfLineHeight, title, "B", 1, "L", false, 0, "")
+		pdf.Ln(pdfLineHeight)
+	})
+
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-15)
+		pdf.SetFont(pdfFont, "I", 8)
+		pdf.CellFormat(0, 10, fmt.Sprintf("%d / {nb}", pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+
+	fa.renderTitlePage(pdf, tr, title)
+
+	// table of contents
+	pdf.AddPage()
+	pdf.SetFont(pdfFont, "B", 16)
+	pdf.CellFormat(0, 10, tr(fa.TocLabel), "", 1, "L", false, 0, "")
+	pdf.Ln(pdfLineHeight)
+
+	for _, entry := range toc {
+		entry.link = pdf.AddLink()
+
+		style := ""
+		if entry.Depth == 1 {
+			style = "B"
+		}
+
+		pdf.SetFont(pdfFont, style, 10)
+		pdf.SetX(pdf.GetX() + float64(entry.Depth-1)*pdfIndent)
+
+		pageNr := ""
+		if entry.Page > 0 {
+			pageNr = fmt.Sprintf("%d", entry.Page)
+		}
+
+		width, _ := pdf.GetPageSize()
+		left, _, right, _ := pdf.GetMargins()
+		labelWidth := width - left - right - float64(entry.Depth-1)*pdfIndent - 15
+
+		label := truncatePDFText(pdf, tr(entry.Label), labelWidth)
+
+		pdf.CellFormat(labelWidth, pdfLineHeight, label, "", 0, "L", false, entry.link, "")
+		pdf.CellFormat(15, pdfLineHeight, pageNr, "", 1, "R", false, entry.link, "")
+	}
+
+	nextEntry := 0
+	heading := func(label string, depth int) {
+		if nextEntry < len(toc) {
+			entry := toc[nextEntry]
+			entry.Page = pdf.PageNo()
+			pdf.SetLink(entry.link, -1, -1)
+			nextEntry++
+		}
+
+		size := 16.0 - float64(depth-1)*2
+		pdf.SetFont(pdfFont, "B", size)
+		pdf.MultiCell(0, size/2, tr(label), "", "L", false)
+		pdf.Ln(pdfLineHeight / 2)
+	}
+
+	// description sections
+	for _, section := range fa.sections() {
+		pdf.AddPage()
+		heading(section.title, 1)
+
+		pdf.SetFont(pdfFont, "", 10)
+
+		for _, p := range section.paragraphs {
+			pdf.MultiCell(0, pdfLineHeight, tr(p), "", "L", false)
+			pdf.Ln(pdfLineHeight / 2)
+		}
+	}
+
+	// inventory
+	if fa.tree != nil && len(fa.tree.Nodes) > 0 {
+		pdf.AddPage()
+		heading(fa.InventoryLabel, 1)
+
+		for _, node := range fa.tree.Nodes {
+			if isPDFHeading(node.Depth, node.ChildCount) {
+				pdf.Ln(pdfLineHeight / 2)
+				heading(nodeHeading(node.UnitID, node.Label), node.Depth+1)
+
+				continue
+			}
+
+			fa.renderNode(pdf, tr, node.Depth, node.UnitID, node.Label, node.Periods)
+		}
+	}
+
+	if err := pdf.Output(w); err != nil {
+		return nil, err
+	}
+
+	return pdf, nil
+}
+
+func (fa *FindingAidPDF) renderTitlePage(pdf *gofpdf.Fpdf, tr func(string) string, title string) {
+	pdf.AddPage()
+	pdf.SetY(80)
+	pdf.SetFont(pdfFont, "B", 22)
+	pdf.MultiCell(0, 11, title, "", "C", false)
+
+	if fa.desc == nil || fa.desc.Summary.File == nil {
+		return
+	}
+
+	file := fa.desc.Summary.File
+
+	pdf.Ln(pdfLineHeight * 2)
+	pdf.SetFont(pdfFont, "", 12)
+
+	for _, line := range []string{file.Author, strings.Join(file.Edition, " "), file.Publisher, file.PublicationDate} {
+		if line = cleanPDFText(line); line != "" {
+			pdf.MultiCell(0, 7, tr(line), "", "C", false)
+		}
+	}
+}
+
+func (fa *FindingAidPDF) renderNode(pdf *gofpdf.Fpdf, tr func(string) string, depth int, unitID, label string, periods []string) {
+	indent := float64(depth-1) * pdfIndent
+	if indent > pdfIndent*pdfMaxHeadingDepth {
+		indent = pdfIndent * pdfMaxHeadingDepth
+	}
+
+	left, _, _, _ := pdf.GetMargins()
+
+	pdf.SetFont(pdfFont, "B", 10)
+	pdf.SetX(left + indent)
+	pdf.CellFormat(20, pdfLineHeight, tr(unitID), "", 0, "L", false, 0, "")
+
+	text := cleanPDFText(label)
+	if len(periods) > 0 {
+		text = fmt.Sprintf("%s (%s)", text, strings.Join(periods, ", "))
+	}
+
+	pdf.SetFont(pdfFont, "", 10)
+	pdf.MultiCell(0, pdfLineHeight, tr(text), "", "L", false)
+}
+
+// truncatePDFText shortens the translated text to fit in the width.
+//
+// The text must already be translated to the single-byte encoding of the PDF font.
+func truncatePDFText(pdf *gofpdf.Fpdf, text string, width float64) string {
+	if pdf.GetStringWidth(text) <= width {
+		return text
+	}
+
+	b := []byte(text)
+	for len(b) > 0 && pdf.GetStringWidth(string(b)+"...") > width {
+		b = b[:len(b)-1]
+	}
+
+	return string(b) + "..."
+}
+
+// PDFPath returns the path of the finding aid PDF in dataPath, the data directory of the archive.
+func PDFPath(dataPath, spec string) string {
+	return filepath.Join(dataPath, fmt.Sprintf("%s.pdf", spec))
+}
+
+// WritePDF renders the finding aid PDF and stores it in dataPath, the data directory of the archive.
+func WritePDF(dataPath, spec string, desc *Description, tree *TreeExport) error {
+	err := os.MkdirAll(dataPath, os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+
+	if err := NewFindingAidPDF(desc, tree).Write(&buf); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(PDFPath(dataPath, spec), buf.Bytes(), 0644)
+}
@@ -0,0 +1,80 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ead
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/matryer/is"
+
+	"github.com/delving/hub3/hub3/fragments"
+)
+
+func pdfTestInput() (*Description, *TreeExport) {
+	desc := &Description{
+		Summary: Summary{
+			File: &File{Title: "Inventaris van het archief", Author: "Nationaal Archief"},
+		},
+		Section: []*SectionInfo{
+			{Text: "Inleiding", Start: 0, End: 3, Order: 1},
+		},
+		Item: []*DataItem{
+			{Type: Section, Text: "Inleiding", Order: 1},
+			{Type: Paragraph, Text: "Dit is de <i>inleiding</i> van het archief.", Order: 2},
+			{Type: Paragraph, Text: "Ëen tweede alinea.", Order: 3},
+		},
+	}
+
+	trees := []*fragments.Tree{
+		{CLevel: "@1", UnitID: "1", Label: "Series", Type: "series", Depth: 1, SortKey: 1, ChildCount: 2},
+		{CLevel: "@1~2", Leaf: "@1", UnitID: "2", Label: "File", Type: "file", Depth: 2, SortKey: 2, Periods: []string{"1900"}},
+		{CLevel: "@1~3", Leaf: "@1", UnitID: "3", Label: "Other file", Type: "file", Depth: 2, SortKey: 3},
+	}
+
+	return desc, NewTreeExport("test-spec", trees)
+}
+
+func TestFindingAidPDF_Write(t *testing.T) {
+	is := is.New(t)
+
+	var buf bytes.Buffer
+
+	err := NewFindingAidPDF(pdfTestInput()).Write(&buf)
+	is.NoErr(err)
+	is.True(bytes.HasPrefix(buf.Bytes(), []byte("%PDF")))
+}
+
+func TestFindingAidPDF_tableOfContents(t *testing.T) {
+	is := is.New(t)
+
+	fa := NewFindingAidPDF(pdfTestInput())
+
+	toc := fa.tableOfContents()
+	is.Equal(len(toc), 3)
+	is.Equal(toc[0].Label, "Inleiding")
+	is.Equal(toc[1].Label, "Inventaris")
+	is.Equal(toc[2].Label, "1. Series")
+	is.Equal(toc[2].Depth, 2)
+
+	_, err := fa.render(toc, ioutil.Discard)
+	is.NoErr(err)
+
+	// title page and table of contents precede the first section
+	is.Equal(toc[0].Page, 3)
+	is.Equal(toc[1].Page, 4)
+	is.Equal(toc[2].Page, 4)
+}
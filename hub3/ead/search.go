@@ -321,6 +321,10 @@ func buildSearchRequest(r *http.Request, includeDescription bool) (*SearchReques
 	query := elastic.NewBoolQuery()
 	query = query.Must(tagQuery)
 
+	if restrictedQuery := fragments.SearchRestrictedQuery(); restrictedQuery != nil {
+		query = query.MustNot(restrictedQuery)
+	}
+
 	if sr.RawQuery != "" {
 		// TODO(kiivihal): replace querystring below with search.QueryTerm
 		q, err := fragments.QueryFromSearchFields(sr.RawQuery, cfg.Config.EAD.SearchFields...)
@@ -415,6 +419,10 @@ func PerformClusteredSearch(r *http.Request) (*SearchResponse, error) {
 			"tree.hasDigitalObject",
 			"tree.mimeType",
 			"ead-rdf_genreform",
+			// extracted entities
+			"ead-rdf_persname",
+			"ead-rdf_geogname",
+			"ead-rdf_corpname",
 		}...,
 	)
 
@@ -0,0 +1,442 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ead
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Severity is the impact level of a ValidationIssue.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Validation rules that can be reported in a ValidationReport.
+const (
+	RuleWellFormed    = "xml-wellformed"
+	RuleStructure     = "ead-structure"
+	RuleDateNormal    = "date-normal"
+	RuleDuplicateKey  = "duplicate-key"
+	RuleRequiredField = "required-field"
+	RuleDaoLink       = "dao-link"
+)
+
+// ValidationIssue is a single problem found during validation.
+type ValidationIssue struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Location string   `json:"location"`
+	Message  string   `json:"message"`
+	Value    string   `json:"value,omitempty"`
+}
+
+// ValidationReport is the machine-readable result of validating an EAD.
+type ValidationReport struct {
+	OrgID     string             `json:"orgID,omitempty"`
+	DatasetID string             `json:"datasetID"`
+	Valid     bool               `json:"valid"`
+	Clevels   int                `json:"clevels"`
+	DaoLinks  int                `json:"daoLinks"`
+	Errors    int                `json:"errors"`
+	Warnings  int                `json:"warnings"`
+	Issues    []*ValidationIssue `json:"issues"`
+}
+
+func (vr *ValidationReport) add(rule string, severity Severity, location, value, format string, args ...interface{}) {
+	vr.Issues = append(vr.Issues, &ValidationIssue{
+		Rule:     rule,
+		Severity: severity,
+		Location: location,
+		Message:  fmt.Sprintf(format, args...),
+		Value:    value,
+	})
+
+	switch severity {
+	case SeverityError:
+		vr.Errors++
+	case SeverityWarning:
+		vr.Warnings++
+	}
+}
+
+// requiredFieldRules are the c-level fields that can be required per organization.
+var requiredFieldRules = map[string]func(c *Cc) bool{
+	"level": func(c *Cc) bool {
+		return c.Attrlevel != ""
+	},
+	"unitid": func(c *Cc) bool {
+		for _, id := range c.Cdid[0].Cunitid {
+			if strings.TrimSpace(id.Unitid) != "" {
+				return true
+			}
+		}
+
+		return false
+	},
+	"unittitle": func(c *Cc) bool {
+		for _, title := range c.Cdid[0].Cunittitle {
+			if title.Title() != "" {
+				return true
+			}
+		}
+
+		return false
+	},
+	"unitdate": func(c *Cc) bool {
+		if len(c.Cdid[0].Cunitdate) != 0 {
+			return true
+		}
+
+		for _, title := range c.Cdid[0].Cunittitle {
+			if len(title.Cunitdate) != 0 {
+				return true
+			}
+		}
+
+		return false
+	},
+	"physdesc": func(c *Cc) bool {
+		return len(c.Cdid[0].Cphysdesc) != 0
+	},
+	"dao": func(c *Cc) bool {
+		return len(c.Cdid[0].Cdao) != 0 || len(c.Cdao) != 0
+	},
+	"accessrestrict": func(c *Cc) bool {
+		return len(c.Caccessrestrict) != 0
+	},
+}
+
+// ValidRequiredField returns an error when the field is not a supported required-field rule.
+func ValidRequiredField(field string) error {
+	if _, ok := requiredFieldRules[field]; !ok {
+		return fmt.Errorf("unsupported required field rule: %s", field)
+	}
+
+	return nil
+}
+
+// ValidationConfig holds the options for validating an EAD.
+type ValidationConfig struct {
+	OrgID string
+	// RequiredFields are the c-level fields that must be present in each c-level.
+	RequiredFields []string
+	// CheckDaoLinks enables checking if the dao links are reachable.
+	CheckDaoLinks bool
+	// Client is used to check the dao links. When nil a client with a 10 second timeout is used.
+	Client *http.Client
+}
+
+type validator struct {
+	ctx    context.Context
+	cfg    *ValidationConfig
+	report *ValidationReport
+	keys   map[string]string
+	links  map[string]string
+}
+
+// ValidateEAD reads an EAD from the io.Reader and returns a ValidationReport.
+//
+// An error is only returned when the ValidationConfig is invalid. All problems
+// with the EAD itself are reported as issues in the ValidationReport.
+func ValidateEAD(ctx context.Context, r io.Reader, cfg *ValidationConfig) (*ValidationReport, error) {
+	if cfg == nil {
+		cfg = &ValidationConfig{}
+	}
+
+	for _, field := range cfg.RequiredFields {
+		if err := ValidRequiredField(field); err != nil {
+			return nil, err
+		}
+	}
+
+	report := &ValidationReport{
+		OrgID:  cfg.OrgID,
+		Issues: []*ValidationIssue{},
+	}
+
+	src, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// EAD3 is validated after the conversion to EAD 2002
+	cead, err := eadParse(src)
+	if err != nil {
+		report.add(RuleWellFormed, SeverityError, "/", "", "unable to parse EAD: %s", err)
+		return report, nil
+	}
+
+	return cead.Validate(ctx, cfg)
+}
+
+// Validate runs the structural and business-rule checks on a parsed EAD.
+func (cead *Cead) Validate(ctx context.Context, cfg *ValidationConfig) (*ValidationReport, error) {
+	if cfg == nil {
+		cfg = &ValidationConfig{}
+	}
+
+	v := &validator{
+		ctx: ctx,
+		cfg: cfg,
+		report: &ValidationReport{
+			OrgID:  cfg.OrgID,
+			Issues: []*ValidationIssue{},
+		},
+		keys:  map[string]string{},
+		links: map[string]string{},
+	}
+
+	v.header(cead.Ceadheader)
+	v.archdesc(cead.Carchdesc)
+
+	if cfg.CheckDaoLinks {
+		if err := v.daoLinks(); err != nil {
+			return nil, err
+		}
+	}
+
+	v.report.DaoLinks = len(v.links)
+	v.report.Valid = v.report.Errors == 0
+
+	return v.report, nil
+}
+
+func (v *validator) header(eh *Ceadheader) {
+	const loc = "/ead/eadheader"
+
+	if eh == nil {
+		v.report.add(RuleStructure, SeverityError, loc, "", "eadheader is required")
+		return
+	}
+
+	if eh.Ceadid == nil || strings.TrimSpace(eh.Ceadid.EadID) == "" {
+		v.report.add(RuleStructure, SeverityError, loc+"/eadid", "", "eadid is required and cannot be empty")
+	} else {
+		v.report.DatasetID = strings.TrimSpace(eh.Ceadid.EadID)
+	}
+
+	if eh.GetTitle() == "" {
+		v.report.add(RuleStructure, SeverityError, loc+"/filedesc/titlestmt/titleproper", "", "titleproper is required")
+	}
+}
+
+func (v *validator) archdesc(ad *Carchdesc) {
+	const loc = "/ead/archdesc"
+
+	if ad == nil {
+		v.report.add(RuleStructure, SeverityError, loc, "", "archdesc is required")
+		return
+	}
+
+	if ad.Attrlevel == "" {
+		v.report.add(RuleStructure, SeverityError, loc, "", "archdesc must have a level attribute")
+	}
+
+	if len(ad.Cdid) == 0 {
+		v.report.add(RuleStructure, SeverityError, loc+"/did", "", "archdesc must have a did")
+	}
+
+	for idx, did := range ad.Cdid {
+		v.dates(did, fmt.Sprintf("%s/did[%d]", loc, idx+1))
+	}
+
+	if len(ad.GetNormalPeriods()) == 0 {
+		v.report.add(RuleDateNormal, SeverityWarning, loc+"/did/unitdate", "", "archdesc has no normalised period")
+	}
+
+	if ad.Cdsc == nil {
+		v.report.add(RuleStructure, SeverityWarning, loc+"/dsc", "", "archdesc has no dsc with inventories")
+		return
+	}
+
+	dscLoc := loc + "/dsc"
+	counter := map[string]int{}
+
+	for _, c := range ad.Cdsc.Numbered {
+		counter["c01"]++
+		v.clevel(c, fmt.Sprintf("%s/c01[%d]", dscLoc, counter["c01"]), "c01", "")
+	}
+
+	for _, c := range ad.Cdsc.Cc {
+		counter["c"]++
+		v.clevel(c, fmt.Sprintf("%s/c[%d]", dscLoc, counter["c"]), "c", "")
+	}
+}
+
+// nestedTag returns the tag name of the children of a c-level with the given tag.
+func nestedTag(tag string) string {
+	if tag == "c" {
+		return "c"
+	}
+
+	var depth int
+
+	_, _ = fmt.Sscanf(tag, "c%02d", &depth)
+
+	return fmt.Sprintf("c%02d", depth+1)
+}
+
+func (v *validator) clevel(cl CLevel, loc, tag, parentPath string) {
+	v.report.Clevels++
+
+	c := cl.GetCc()
+
+	if c.Attrlevel == "" {
+		v.report.add(RuleStructure, SeverityWarning, loc, "", "%s has no level attribute", tag)
+	}
+
+	path := parentPath
+
+	switch len(c.Cdid) {
+	case 0:
+		v.report.add(RuleStructure, SeverityError, loc+"/did", "", "%s must have a did", tag)
+	default:
+		if len(c.Cdid) > 1 {
+			v.report.add(RuleStructure, SeverityError, loc+"/did", "", "%s must have exactly one did; found %d", tag, len(c.Cdid))
+		}
+
+		did := c.Cdid[0]
+		v.dates(did, loc+"/did")
+		path = v.uniqueKey(did, loc, parentPath)
+		v.collectLinks(did.Cdao, loc+"/did")
+
+		for _, field := range v.cfg.RequiredFields {
+			if !requiredFieldRules[field](c) {
+				v.report.add(RuleRequiredField, SeverityError, loc, field, "required field %s is missing", field)
+			}
+		}
+	}
+
+	v.collectLinks(c.Cdao, loc)
+
+	counter := map[string]int{}
+	childTag := nestedTag(tag)
+
+	for _, nested := range cl.GetNested() {
+		counter[childTag]++
+		v.clevel(nested, fmt.Sprintf("%s/%s[%d]", loc, childTag, counter[childTag]), childTag, path)
+	}
+}
+
+// uniqueKey checks for duplicate unitids and returns the path of the c-level in the tree.
+func (v *validator) uniqueKey(did *Cdid, loc, parentPath string) string {
+	_, inventoryID, _ := did.NewNodeIDs()
+	if inventoryID == "" || strings.HasPrefix(inventoryID, "---") {
+		return parentPath
+	}
+
+	path := inventoryID
+	if parentPath != "" {
+		path = fmt.Sprintf("%s%s%s", parentPath, pathSep, inventoryID)
+	}
+
+	if first, ok := v.keys[path]; ok {
+		v.report.add(
+			RuleDuplicateKey, SeverityError, loc+"/did/unitid", inventoryID,
+			"duplicate unitid %s; first found at %s", inventoryID, first,
+		)
+
+		return path
+	}
+
+	v.keys[path] = loc + "/did/unitid"
+
+	return path
+}
+
+func (v *validator) dates(did *Cdid, loc string) {
+	check := func(date *Cunitdate, dateLoc string) {
+		nd, _ := date.NewNodeDate()
+		if err := nd.ValidDateNormal(); err != nil {
+			v.report.add(RuleDateNormal, SeverityError, dateLoc+"/@normal", date.Attrnormal, "invalid normal date: %s", err)
+		}
+	}
+
+	for idx, date := range did.Cunitdate {
+		check(date, fmt.Sprintf("%s/unitdate[%d]", loc, idx+1))
+	}
+
+	for idx, title := range did.Cunittitle {
+		for dateIdx, date := range title.Cunitdate {
+			check(date, fmt.Sprintf("%s/unittitle[%d]/unitdate[%d]", loc, idx+1, dateIdx+1))
+		}
+	}
+}
+
+func (v *validator) collectLinks(daos []*Cdao, loc string) {
+	for idx, dao := range daos {
+		daoLoc := fmt.Sprintf("%s/dao[%d]", loc, idx+1)
+
+		if strings.TrimSpace(dao.Attrhref) == "" {
+			v.report.add(RuleDaoLink, SeverityError, daoLoc+"/@href", "", "dao must have a href")
+			continue
+		}
+
+		if _, ok := v.links[dao.Attrhref]; !ok {
+			v.links[dao.Attrhref] = daoLoc + "/@href"
+		}
+	}
+}
+
+// daoLinks checks if all collected dao links are reachable.
+func (v *validator) daoLinks() error {
+	client := v.cfg.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	links := make([]string, 0, len(v.links))
+	for link := range v.links {
+		links = append(links, link)
+	}
+
+	sort.Strings(links)
+
+	for _, link := range links {
+		if err := v.ctx.Err(); err != nil {
+			return err
+		}
+
+		req, err := http.NewRequestWithContext(v.ctx, http.MethodHead, link, nil)
+		if err != nil {
+			v.report.add(RuleDaoLink, SeverityError, v.links[link], link, "invalid dao link: %s", err)
+			continue
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			v.report.add(RuleDaoLink, SeverityError, v.links[link], link, "unable to retrieve dao link: %s", err)
+			continue
+		}
+
+		resp.Body.Close()
+
+		if resp.StatusCode >= http.StatusBadRequest {
+			v.report.add(RuleDaoLink, SeverityError, v.links[link], link, "dao link returned status %d", resp.StatusCode)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,195 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ead_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+
+	"github.com/delving/hub3/hub3/ead"
+)
+
+const validateEAD = `<ead>
+  <eadheader>
+    <eadid>test-ead</eadid>
+    <filedesc><titlestmt><titleproper>Test archive</titleproper></titlestmt></filedesc>
+  </eadheader>
+  <archdesc level="fonds">
+    <did><unitdate normal="1900/1950">1900-1950</unitdate></did>
+    <dsc>
+      <c01 level="series">
+        <did><unitid>1</unitid><unittitle>first</unittitle></did>
+        <c02 level="file">
+          <did><unitid>2</unitid><unittitle>nested</unittitle><unitdate normal="1950/1900">1950-1900</unitdate></did>
+        </c02>
+        <c02 level="file">
+          <did><unitid>2</unitid><unittitle>duplicate</unittitle><dao href="%s/mets/missing"/></did>
+        </c02>
+      </c01>
+      <c01 level="series">
+        <did><unitid>3</unitid><dao href="%s/mets/found"/></did>
+      </c01>
+    </dsc>
+  </archdesc>
+</ead>`
+
+func findIssue(report *ead.ValidationReport, rule string) []*ead.ValidationIssue {
+	issues := []*ead.ValidationIssue{}
+
+	for _, issue := range report.Issues {
+		if issue.Rule == rule {
+			issues = append(issues, issue)
+		}
+	}
+
+	return issues
+}
+
+func TestValidateEAD(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/found") {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		http.NotFound(w, r)
+	}))
+	defer ts.Close()
+
+	src := fmt.Sprintf(validateEAD, ts.URL, ts.URL)
+
+	tests := []struct {
+		name     string
+		src      string
+		cfg      *ead.ValidationConfig
+		valid    bool
+		rule     string
+		issues   int
+		location string
+	}{
+		{
+			"not well-formed",
+			"<ead><eadheader>",
+			nil,
+			false,
+			ead.RuleWellFormed,
+			1,
+			"/",
+		},
+		{
+			"missing archdesc",
+			"<ead><eadheader><eadid>1</eadid></eadheader></ead>",
+			nil,
+			false,
+			ead.RuleStructure,
+			2,
+			"/ead/archdesc",
+		},
+		{
+			"invalid normal date",
+			src,
+			nil,
+			false,
+			ead.RuleDateNormal,
+			1,
+			"/ead/archdesc/dsc/c01[1]/c02[1]/did/unitdate[1]/@normal",
+		},
+		{
+			"duplicate unitid",
+			src,
+			nil,
+			false,
+			ead.RuleDuplicateKey,
+			1,
+			"/ead/archdesc/dsc/c01[1]/c02[2]/did/unitid",
+		},
+		{
+			"required field",
+			src,
+			&ead.ValidationConfig{RequiredFields: []string{"unittitle"}},
+			false,
+			ead.RuleRequiredField,
+			1,
+			"/ead/archdesc/dsc/c01[2]",
+		},
+		{
+			"dao links not checked",
+			src,
+			nil,
+			false,
+			ead.RuleDaoLink,
+			0,
+			"",
+		},
+		{
+			"dao links checked",
+			src,
+			&ead.ValidationConfig{CheckDaoLinks: true, Client: ts.Client()},
+			false,
+			ead.RuleDaoLink,
+			1,
+			"/ead/archdesc/dsc/c01[1]/c02[2]/did/dao[1]/@href",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			report, err := ead.ValidateEAD(context.Background(), strings.NewReader(tt.src), tt.cfg)
+			is.NoErr(err)
+			is.Equal(report.Valid, tt.valid)
+
+			issues := findIssue(report, tt.rule)
+			is.Equal(len(issues), tt.issues)
+
+			if tt.issues > 0 {
+				is.Equal(issues[len(issues)-1].Location, tt.location)
+			}
+		})
+	}
+}
+
+func TestValidateEAD_report(t *testing.T) {
+	is := is.New(t)
+
+	src := fmt.Sprintf(validateEAD, "http://localhost", "http://localhost")
+
+	report, err := ead.ValidateEAD(context.Background(), strings.NewReader(src), nil)
+	is.NoErr(err)
+	is.Equal(report.DatasetID, "test-ead")
+	is.Equal(report.Clevels, 4)
+	is.Equal(report.DaoLinks, 2)
+	is.Equal(report.Errors, 2)
+}
+
+func TestValidateEAD_unknownRequiredField(t *testing.T) {
+	is := is.New(t)
+
+	_, err := ead.ValidateEAD(
+		context.Background(),
+		strings.NewReader("<ead/>"),
+		&ead.ValidationConfig{RequiredFields: []string{"unknown"}},
+	)
+	is.True(err != nil)
+}
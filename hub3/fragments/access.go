@@ -0,0 +1,171 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fragments
+
+import (
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	c "github.com/delving/hub3/config"
+	elastic "github.com/olivere/elastic/v7"
+)
+
+// AccessLoader returns the specs of the datasets that are not accessible
+// through search and linked data.
+type AccessLoader func() (search, lod []string, err error)
+
+// accessTTL is the time after which the access registry is reloaded, so that
+// access changes that are made by other nodes are picked up.
+var accessTTL = time.Minute
+
+// accessRegistry holds the datasets that are indexed but not publicly
+// accessible. Queries filter on it, so access can be changed without
+// reindexing the dataset.
+type accessRegistry struct {
+	sync.RWMutex
+	loader AccessLoader
+	// expires is the time the registry must be reloaded. It is zero before the first load.
+	expires time.Time
+	search  map[string]bool
+	lod     map[string]bool
+}
+
+var restricted = &accessRegistry{
+	search: map[string]bool{},
+	lod:    map[string]bool{},
+}
+
+// RegisterAccessLoader resets the access registry. The loader fills the
+// registry the first time it is used and again when it is older than accessTTL.
+func RegisterAccessLoader(loader AccessLoader) {
+	restricted.Lock()
+	defer restricted.Unlock()
+
+	restricted.loader = loader
+	restricted.expires = time.Time{}
+	restricted.search = map[string]bool{}
+	restricted.lod = map[string]bool{}
+}
+
+// SetDataSetAccess updates the access of the dataset in the access registry.
+func SetDataSetAccess(spec string, search, lod bool) {
+	restricted.load()
+
+	restricted.Lock()
+	defer restricted.Unlock()
+
+	restricted.set(spec, search, lod)
+}
+
+// SearchRestrictedSpecs returns the sorted specs of the datasets that are excluded from search.
+func SearchRestrictedSpecs() []string {
+	restricted.load()
+
+	restricted.RLock()
+	defer restricted.RUnlock()
+
+	specs := []string{}
+	for spec := range restricted.search {
+		specs = append(specs, spec)
+	}
+
+	sort.Strings(specs)
+
+	return specs
+}
+
+// SearchRestrictedQuery returns the query that matches the records of the
+// datasets that are excluded from search. It returns nil when there are none.
+func SearchRestrictedQuery() elastic.Query {
+	specs := SearchRestrictedSpecs()
+	if len(specs) == 0 {
+		return nil
+	}
+
+	values := make([]interface{}, 0, len(specs))
+	for _, spec := range specs {
+		values = append(values, spec)
+	}
+
+	return elastic.NewTermsQuery(c.Config.ElasticSearch.SpecKey, values...)
+}
+
+// IsLODRestricted returns true when the resources of the dataset must not be
+// resolved as linked data.
+func IsLODRestricted(spec string) bool {
+	restricted.load()
+
+	restricted.RLock()
+	defer restricted.RUnlock()
+
+	return restricted.lod[spec]
+}
+
+func (ar *accessRegistry) set(spec string, search, lod bool) {
+	delete(ar.search, spec)
+	delete(ar.lod, spec)
+
+	if !search {
+		ar.search[spec] = true
+	}
+
+	if !lod {
+		ar.lod[spec] = true
+	}
+}
+
+// current returns true when the registry does not have to be (re)loaded.
+func (ar *accessRegistry) current() bool {
+	return ar.loader == nil || time.Now().Before(ar.expires)
+}
+
+// load fills the registry with the loader when it is not loaded yet or is
+// expired. When the loader fails it is retried on the next call.
+func (ar *accessRegistry) load() {
+	ar.RLock()
+	done := ar.current()
+	ar.RUnlock()
+
+	if done {
+		return
+	}
+
+	ar.Lock()
+	defer ar.Unlock()
+
+	if ar.current() {
+		return
+	}
+
+	search, lod, err := ar.loader()
+	if err != nil {
+		log.Printf("unable to load dataset access: %s", err)
+		return
+	}
+
+	ar.search = map[string]bool{}
+	for _, spec := range search {
+		ar.search[spec] = true
+	}
+
+	ar.lod = map[string]bool{}
+	for _, spec := range lod {
+		ar.lod[spec] = true
+	}
+
+	ar.expires = time.Now().Add(accessTTL)
+}
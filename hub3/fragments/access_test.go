@@ -0,0 +1,66 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fragments
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestAccessRegistry(t *testing.T) {
+	is := is.New(t)
+
+	defer RegisterAccessLoader(nil)
+
+	calls := 0
+	RegisterAccessLoader(func() (search, lod []string, err error) {
+		calls++
+		if calls == 1 {
+			return nil, nil, errors.New("storage unavailable")
+		}
+
+		return []string{"embargoed", "internal"}, []string{"embargoed"}, nil
+	})
+
+	// a failing loader is retried
+	is.Equal(SearchRestrictedSpecs(), []string{})
+	is.Equal(SearchRestrictedSpecs(), []string{"embargoed", "internal"})
+	is.True(IsLODRestricted("embargoed"))
+	is.True(!IsLODRestricted("internal"))
+	is.Equal(calls, 2)
+
+	SetDataSetAccess("internal", true, false)
+	is.Equal(SearchRestrictedSpecs(), []string{"embargoed"})
+	is.True(IsLODRestricted("internal"))
+
+	SetDataSetAccess("embargoed", true, true)
+	is.Equal(SearchRestrictedSpecs(), []string{})
+	is.True(SearchRestrictedQuery() == nil)
+	is.True(!IsLODRestricted("embargoed"))
+	is.Equal(calls, 2) // the loader is not used again before the registry expires
+
+	// an expired registry is reloaded
+	restricted.Lock()
+	restricted.expires = time.Now().Add(-time.Second)
+	restricted.Unlock()
+
+	is.Equal(SearchRestrictedSpecs(), []string{"embargoed", "internal"})
+	is.True(IsLODRestricted("embargoed"))
+	is.True(!IsLODRestricted("internal"))
+	is.Equal(calls, 3)
+}
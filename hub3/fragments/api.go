@@ -15,6 +15,7 @@ package fragments
 
 import (
 	"bytes"
+	"context"
 	"encoding/gob"
 	"encoding/hex"
 	"encoding/json"
@@ -27,6 +28,7 @@ import (
 	"strings"
 
 	c "github.com/delving/hub3/config"
+	"github.com/delving/hub3/ikuzo/domain"
 	proto "github.com/golang/protobuf/proto"
 	"github.com/google/go-cmp/cmp"
 	elastic "github.com/olivere/elastic/v7"
@@ -682,6 +684,10 @@ func (sr *SearchRequest) ElasticQuery() (elastic.Query, error) {
 
 	}
 
+	if restrictedQuery := SearchRestrictedQuery(); restrictedQuery != nil {
+		query = query.MustNot(restrictedQuery)
+	}
+
 	if strings.HasPrefix(sr.GetSortBy(), "random") {
 		randomFunc := elastic.NewRandomFunction()
 
@@ -1028,8 +1034,21 @@ func (sr *SearchRequest) DecodeSearchAfter() ([]interface{}, error) {
 	return sa, nil
 }
 
+// IndexName returns the v2 index of the organization in the context.
+// It falls back to the index of the global configuration.
+func IndexName(ctx context.Context) string {
+	if org, ok := domain.GetOrganization(ctx); ok {
+		if name, ok := org.Config.IndexNames()["v2"]; ok {
+			return name
+		}
+	}
+
+	return c.Config.ElasticSearch.GetIndexName()
+}
+
 // ElasticSearchService creates the elastic SearchService for execution
-func (sr *SearchRequest) ElasticSearchService(ec *elastic.Client) (*elastic.SearchService, *FacetURIBuilder, error) {
+// on the index of the organization in the context.
+func (sr *SearchRequest) ElasticSearchService(ctx context.Context, ec *elastic.Client) (*elastic.SearchService, *FacetURIBuilder, error) {
 	idSort := elastic.NewFieldSort("meta.hubID")
 	var fieldSort *elastic.FieldSort
 
@@ -1071,7 +1090,7 @@ func (sr *SearchRequest) ElasticSearchService(ec *elastic.Client) (*elastic.Sear
 	}
 
 	s := ec.Search().
-		Index(c.Config.ElasticSearch.GetIndexName()).
+		Index(IndexName(ctx)).
 		TrackTotalHits(c.Config.ElasticSearch.TrackTotalHits).
 		Preference(sr.GetSessionID()).
 		Size(int(sr.GetResponseSize()))
@@ -15,6 +15,7 @@
 package fragments
 
 import (
+	"context"
 	"encoding/json"
 	fmt "fmt"
 	"io/ioutil"
@@ -24,6 +25,7 @@ import (
 	"testing"
 
 	c "github.com/delving/hub3/config"
+	"github.com/delving/hub3/ikuzo/domain"
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -506,6 +508,31 @@ func Test_isAdvancedSearch(t *testing.T) {
 	}
 }
 
+func TestIndexName(t *testing.T) {
+	ctx := context.TODO()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{"global configuration", ctx, c.Config.ElasticSearch.GetIndexName()},
+		{"organization without index", domain.SetOrganization(ctx, domain.Organization{ID: "demo"}), c.Config.ElasticSearch.GetIndexName()},
+		{
+			"organization index",
+			domain.SetOrganization(ctx, domain.Organization{ID: "demo", Config: domain.OrganizationConfig{IndexName: "Demo"}}),
+			"demov2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IndexName(tt.ctx); got != tt.want {
+				t.Errorf("IndexName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestNewDateRangeFilter(t *testing.T) {
 	type args struct {
 		filter string
@@ -17,53 +17,230 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
 	"time"
 
 	pb "github.com/delving/hub3/hub3/server/grpc/pb/namespacepb"
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
-// namespaceCmd represents the namespace command
-var namespaceCmd = &cobra.Command{
-	Use:   "namespace",
-	Short: "client for hub3 namespaces service.",
-	Long:  ``,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("namespace called")
-		searchLabel()
-	},
-}
+var (
+	// namespaceCmd represents the namespace command
+	namespaceCmd = &cobra.Command{
+		Use:   "namespace",
+		Short: "client for hub3 namespaces service.",
+	}
+
+	// nsSearchLabelCmd subcommand to get the searchLabels of one or more URIs
+	nsSearchLabelCmd = &cobra.Command{
+		Use:   "searchlabel [uri...]",
+		Short: "get the searchLabel for each URI",
+
+		Run: searchLabel,
+	}
+
+	// nsListCmd subcommand to list all namespaces
+	nsListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "list all namespaces",
+
+		Run: listNameSpaces,
+	}
+
+	// nsGetCmd subcommand to get a namespace by prefix or base-URI
+	nsGetCmd = &cobra.Command{
+		Use:   "get",
+		Short: "get the namespace for a prefix or base-URI",
+
+		Run: getNameSpace,
+	}
+
+	// nsAddCmd subcommand to add a prefix and base-URI
+	nsAddCmd = &cobra.Command{
+		Use:   "add",
+		Short: "add a prefix and base-URI",
+
+		Run: addNameSpace,
+	}
+
+	// nsDeleteCmd subcommand to delete a namespace by uuid or prefix
+	nsDeleteCmd = &cobra.Command{
+		Use:   "delete",
+		Short: "delete the namespace with the uuid or prefix",
+
+		Run: deleteNameSpace,
+	}
+
+	nsAddress string
+	nsAPIKey  string
+	nsPrefix  string
+	nsBase    string
+	nsUUID    string
+)
 
 func init() {
 	RootCmd.AddCommand(namespaceCmd)
+
+	namespaceCmd.PersistentFlags().StringVarP(&nsAddress, "address", "a", address, "address of the namespace gRPC service")
+	namespaceCmd.PersistentFlags().StringVarP(&nsAPIKey, "api-key", "k", "", "API key of an admin of the default organization, required to add or delete")
+
+	nsGetCmd.Flags().StringVarP(&nsPrefix, "prefix", "p", "", "prefix of the namespace")
+	nsGetCmd.Flags().StringVarP(&nsBase, "base", "b", "", "base-URI of the namespace")
+	nsAddCmd.Flags().StringVarP(&nsPrefix, "prefix", "p", "", "prefix of the namespace")
+	nsAddCmd.Flags().StringVarP(&nsBase, "base", "b", "", "base-URI of the namespace")
+	nsDeleteCmd.Flags().StringVarP(&nsUUID, "uuid", "", "", "uuid of the namespace")
+	nsDeleteCmd.Flags().StringVarP(&nsPrefix, "prefix", "p", "", "prefix of the namespace")
+
+	namespaceCmd.AddCommand(nsSearchLabelCmd)
+	namespaceCmd.AddCommand(nsListCmd)
+	namespaceCmd.AddCommand(nsGetCmd)
+	namespaceCmd.AddCommand(nsAddCmd)
+	namespaceCmd.AddCommand(nsDeleteCmd)
 }
 
 const (
 	address     = "localhost:50051"
 	defaultName = "http://purl.org/dc/elements/1.1/title"
+	timeout     = 10 * time.Second
 )
 
-func searchLabel() {
-	// Set up a connection to the server.
-	conn, err := grpc.Dial(address, grpc.WithInsecure())
+// namespaceClient connects to the namespace service.
+// The returned function closes the connection.
+func namespaceClient() (pb.NamespaceClient, context.Context, func()) {
+	conn, err := grpc.Dial(nsAddress, grpc.WithInsecure())
 	if err != nil {
 		log.Fatalf("did not connect: %v", err)
 	}
-	defer conn.Close()
-	c := pb.NewNamespaceClient(conn)
-
-	// Contact the server and print out its response.
-	name := defaultName
-	//if len(os.Args) > 1 {
-	//name = os.Args[1]
-	//}
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-	defer cancel()
-	r, err := c.SearchLabel(ctx, &pb.SearchLabelRequest{Uri: name})
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	if nsAPIKey != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-api-key", nsAPIKey)
+	}
+
+	return pb.NewNamespaceClient(conn), ctx, func() {
+		cancel()
+		conn.Close()
+	}
+}
+
+func printNameSpace(ns *pb.NameSpace) {
+	fmt.Printf("%s\t%s\t%s\n", ns.GetUuid(), ns.GetPrefix(), ns.GetBase())
+}
+
+// searchLabel uses a single request for one URI and streams the lookups
+// when more URIs are given.
+func searchLabel(cmd *cobra.Command, args []string) {
+	c, ctx, done := namespaceClient()
+	defer done()
+
+	if len(args) == 0 {
+		args = []string{defaultName}
+	}
+
+	if len(args) == 1 {
+		r, err := c.SearchLabel(ctx, &pb.SearchLabelRequest{Uri: args[0]})
+		if err != nil {
+			log.Fatalf("could not find namespace: %v", err)
+		}
+
+		fmt.Printf("%s\t%s\n", args[0], r.GetLabel())
+
+		return
+	}
+
+	stream, err := c.SearchLabels(ctx)
+	if err != nil {
+		log.Fatalf("unable to start stream: %v", err)
+	}
+
+	go func() {
+		for _, uri := range args {
+			if err := stream.Send(&pb.SearchLabelRequest{Uri: uri}); err != nil {
+				log.Fatalf("unable to send %s: %v", uri, err)
+			}
+		}
+
+		stream.CloseSend()
+	}()
+
+	for {
+		r, err := stream.Recv()
+		if err == io.EOF {
+			return
+		}
+
+		if err != nil {
+			log.Fatalf("unable to receive searchLabel: %v", err)
+		}
+
+		if r.GetError() != "" {
+			fmt.Printf("%s\terror: %s\n", r.GetUri(), r.GetError())
+			continue
+		}
+
+		fmt.Printf("%s\t%s\n", r.GetUri(), r.GetLabel())
+	}
+}
+
+func listNameSpaces(cmd *cobra.Command, args []string) {
+	c, ctx, done := namespaceClient()
+	defer done()
+
+	r, err := c.List(ctx, &pb.ListRequest{})
+	if err != nil {
+		log.Fatalf("unable to list namespaces: %v", err)
+	}
+
+	for _, ns := range r.GetNamespaces() {
+		printNameSpace(ns)
+	}
+}
+
+func getNameSpace(cmd *cobra.Command, args []string) {
+	c, ctx, done := namespaceClient()
+	defer done()
+
+	var (
+		ns  *pb.NameSpace
+		err error
+	)
+
+	switch {
+	case nsPrefix != "":
+		ns, err = c.GetWithPrefix(ctx, &pb.GetWithPrefixRequest{Prefix: nsPrefix})
+	case nsBase != "":
+		ns, err = c.GetWithBase(ctx, &pb.GetWithBaseRequest{Base: nsBase})
+	default:
+		log.Fatalf("either --prefix or --base is required")
+	}
+
 	if err != nil {
 		log.Fatalf("could not find namespace: %v", err)
 	}
-	log.Printf("namespace: %s", r.Label)
+
+	printNameSpace(ns)
+}
+
+func addNameSpace(cmd *cobra.Command, args []string) {
+	c, ctx, done := namespaceClient()
+	defer done()
+
+	ns, err := c.Add(ctx, &pb.AddRequest{Prefix: nsPrefix, Base: nsBase})
+	if err != nil {
+		log.Fatalf("unable to add namespace: %v", err)
+	}
+
+	printNameSpace(ns)
+}
+
+func deleteNameSpace(cmd *cobra.Command, args []string) {
+	c, ctx, done := namespaceClient()
+	defer done()
+
+	if _, err := c.Delete(ctx, &pb.DeleteRequest{Uuid: nsUUID, Prefix: nsPrefix}); err != nil {
+		log.Fatalf("unable to delete namespace: %v", err)
+	}
 }
@@ -15,24 +15,25 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
 	"log"
 	"net"
 
-	"github.com/delving/hub3/hub3/namespace"
-	pb "github.com/delving/hub3/hub3/server/grpc/pb/namespacepb"
+	"github.com/delving/hub3/ikuzo/ikuzoctl/cmd/config"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 // serveCmd represents the serve command
 var serveCmd = &cobra.Command{
 	Use:   "serve",
-	Short: "launches the hub3 grpc and http server.",
-	Long:  ``,
+	Short: "launches the hub3 namespace grpc server.",
+	Long: `launches the namespace gRPC service on 'grpc.port' (default 50051) with
+the namespace store and authentication from the configuration. The http server
+is launched with 'ikuzoctl serve'.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("serve called")
 		serve()
 	},
 }
@@ -41,34 +42,62 @@ func init() {
 	RootCmd.AddCommand(serveCmd)
 }
 
-const (
-	port = ":50051"
-)
+// defaultPort is the gRPC port when 'grpc.port' is not configured.
+const defaultPort = 50051
+
+func serve() {
+	var cfg config.Config
 
-// server is used to implement the namespacepb.NamespaceServer
-type service struct{}
+	config.SetViperDefaults()
+
+	if err := viper.Unmarshal(&cfg); err != nil {
+		log.Fatalf("unable to decode configuration: %v", err)
+	}
 
-// SearchLabel implements the namespacepb.SearchLabel rpc call
-func (s *service) SearchLabel(ctx context.Context, in *pb.SearchLabelRequest) (*pb.SearchLabelResponse, error) {
-	log.Printf("Received: %v", in.Uri)
-	svc, err := namespace.NewService(namespace.WithDefaults())
+	svc, err := cfg.GetNameSpaceService()
 	if err != nil {
-		return nil, err
+		log.Fatalf("failed to create namespace service: %v", err)
 	}
-	label, err := svc.SearchLabel(in.Uri)
+
+	options := []grpc.ServerOption{}
+
+	if cfg.HTTP.CertFile != "" && cfg.HTTP.KeyFile != "" {
+		creds, credsErr := credentials.NewServerTLSFromFile(cfg.HTTP.CertFile, cfg.HTTP.KeyFile)
+		if credsErr != nil {
+			log.Fatalf("failed to load TLS credentials: %v", credsErr)
+		}
+
+		options = append(options, grpc.Creds(creds))
+	}
+
+	authSvc, err := cfg.Auth.NewService(&cfg)
 	if err != nil {
-		return nil, err
+		log.Fatalf("failed to create auth service: %v", err)
 	}
-	return &pb.SearchLabelResponse{Label: label}, nil
-}
 
-func serve() {
-	lis, err := net.Listen("tcp", port)
+	if authSvc != nil {
+		options = append(
+			options,
+			grpc.UnaryInterceptor(authSvc.UnaryServerInterceptor()),
+			grpc.StreamInterceptor(authSvc.StreamServerInterceptor()),
+		)
+	}
+
+	port := cfg.GRPC.Port
+	if port == 0 {
+		port = defaultPort
+	}
+
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
-	s := grpc.NewServer()
-	pb.RegisterNamespaceServer(s, &service{})
+
+	s := grpc.NewServer(options...)
+	svc.RegisterGRPC(s)
+
+	log.Printf("serving the namespace gRPC service on %s", lis.Addr())
+
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
@@ -0,0 +1,71 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"github.com/delving/hub3/hub3/fragments"
+)
+
+// the search and LOD queries filter on the access of the datasets in BoltDB
+// nolint:gochecknoinits
+func init() {
+	fragments.RegisterAccessLoader(restrictedSpecs)
+}
+
+// restrictedSpecs returns the specs of the datasets that are not accessible
+// through search and linked data.
+func restrictedSpecs() (search, lod []string, err error) {
+	sets, err := ListDataSets()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, ds := range sets {
+		if !ds.Access.Search {
+			search = append(search, ds.Spec)
+		}
+
+		if !ds.Access.LOD {
+			lod = append(lod, ds.Spec)
+		}
+	}
+
+	return search, lod, nil
+}
+
+// SetAccess changes the access of the dataset. The records are not reindexed,
+// because search, LOD and OAI-PMH filter on the access of the dataset.
+func (ds *DataSet) SetAccess(access Access) error {
+	ds.Access = access
+	return ds.Save()
+}
+
+// ListOAIPMHDataSets returns the datasets that are published as OAI-PMH sets.
+func ListOAIPMHDataSets() ([]DataSet, error) {
+	sets, err := ListDataSets()
+	if err != nil {
+		return nil, err
+	}
+
+	published := []DataSet{}
+
+	for _, ds := range sets {
+		if ds.Access.OAIPMH && !ds.Deleted {
+			published = append(published, ds)
+		}
+	}
+
+	return published, nil
+}
@@ -230,7 +230,14 @@ func ListDataSets() ([]DataSet, error) {
 // Save saves the DataSet to BoltDB
 func (ds DataSet) Save() error {
 	ds.Modified = time.Now()
-	return ORM().Save(&ds)
+
+	if err := ORM().Save(&ds); err != nil {
+		return err
+	}
+
+	fragments.SetDataSetAccess(ds.Spec, ds.Access.Search, ds.Access.LOD)
+
+	return nil
 }
 
 // Delete deletes the DataSet from BoltDB
@@ -246,7 +253,13 @@ func (ds DataSet) Delete(ctx context.Context, wp *wp.WorkerPool) error {
 		Str("svc", "dataset").
 		Msg("deleting dataset")
 
-	return ORM().DeleteStruct(&ds)
+	if err := ORM().DeleteStruct(&ds); err != nil {
+		return err
+	}
+
+	fragments.SetDataSetAccess(ds.Spec, true, true)
+
+	return nil
 }
 
 // NewDataSetHistogram returns a histogram for dates that items in the index are modified
@@ -540,13 +553,13 @@ func CreateDataSetStats(ctx context.Context, spec string) (DataSetStats, error)
 }
 
 // DeleteGraphsOrphans deletes all the orphaned graphs from the Triple Store linked to this dataset
-func (ds DataSet) deleteGraphsOrphans() (bool, error) {
-	return DeleteGraphsOrphansBySpec(ds.Spec, ds.Revision)
+func (ds DataSet) deleteGraphsOrphans(ctx context.Context) (bool, error) {
+	return DeleteGraphsOrphansBySpec(ctx, ds.Spec, ds.Revision)
 }
 
 // DeleteAllGraphs deletes all the graphs linked to this dataset
-func (ds DataSet) deleteAllGraphs() (bool, error) {
-	return DeleteAllGraphsBySpec(ds.Spec)
+func (ds DataSet) deleteAllGraphs(ctx context.Context) (bool, error) {
+	return DeleteAllGraphsBySpec(ctx, ds.Spec)
 }
 
 // DeleteIndexOrphans deletes all the Orphaned records from the Search Index linked to this dataset
@@ -654,7 +667,7 @@ func (ds DataSet) DropOrphans(ctx context.Context, p *elastic.BulkProcessor, wp
 	// log.Warn().Msgf("Flushed remaining items on the index queue.")
 
 	if c.Config.RDF.RDFStoreEnabled {
-		ok, err := ds.deleteGraphsOrphans()
+		ok, err := ds.deleteGraphsOrphans(ctx)
 		if !ok || err != nil {
 			log.Warn().Msgf("Unable to remove RDF orphan graphs from spec %s: %s", ds.Spec, err)
 			return false, err
@@ -675,7 +688,7 @@ func (ds DataSet) DropRecords(ctx context.Context, wp *wp.WorkerPool) (bool, err
 	var err error
 	ok := true
 	if c.Config.RDF.RDFStoreEnabled {
-		ok, err = ds.deleteAllGraphs()
+		ok, err = ds.deleteAllGraphs(ctx)
 		if !ok || err != nil {
 			log.Warn().Msgf("Unable to drop all graphs for %s", ds.Spec)
 			return ok, err
@@ -705,6 +718,8 @@ func (ds DataSet) DropAll(ctx context.Context, wp *wp.WorkerPool) (bool, error)
 		return false, err
 	}
 
+	fragments.SetDataSetAccess(ds.Spec, true, true)
+
 	cachePath := filepath.Join(c.Config.EAD.CacheDir, ds.Spec)
 
 	err = os.RemoveAll(cachePath)
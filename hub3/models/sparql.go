@@ -16,13 +16,13 @@ package models
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 	"time"
 
 	"github.com/delving/hub3/config"
-	"github.com/delving/hub3/hub3/fragments"
 	"github.com/knakk/rdf"
 	"github.com/knakk/sparql"
 )
@@ -63,38 +63,6 @@ WHERE
 }
 GROUP BY ?revision
 
-# tag: deleteAllGraphsBySpec
-DELETE {
-	GRAPH ?g {
-	?s ?p ?o .
-	}
-}
-WHERE {
-	GRAPH ?g {
-	?subject <http://schemas.delving.eu/nave/terms/datasetSpec> "{{.Spec}}".
-	}
-	GRAPH ?g {
-	?s ?p ?o .
-	}
-};
-
-# tag: deleteOrphanGraphsBySpec
-DELETE {
-	GRAPH ?g {
-	?s ?p ?o .
-	}
-}
-WHERE {
-	GRAPH ?g {
-	?subject <http://schemas.delving.eu/nave/terms/datasetSpec> "{{.Spec}}";
-		<http://schemas.delving.eu/nave/terms/specRevision> ?revision .
-		FILTER (?revision != {{.RevisionNumber}}).
-	}
-	GRAPH ?g {
-	?s ?p ?o .
-	}
-};
-
 # tag: countAllTriples
 SELECT (count(?s) as ?count)
 WHERE {
@@ -157,39 +125,33 @@ func buildRepo(endPoint string) *sparql.Repo {
 	return repo
 }
 
-// DeleteAllGraphsBySpec issues an SPARQL Update query to delete all graphs for a DataSet from the triple store
-func DeleteAllGraphsBySpec(spec string) (bool, error) {
-	query, err := queryBank.Prepare("deleteAllGraphsBySpec", struct{ Spec string }{spec})
+// DeleteAllGraphsBySpec deletes all graphs for a DataSet from the triple store
+func DeleteAllGraphsBySpec(ctx context.Context, spec string) (bool, error) {
+	store, err := TripleStore()
 	if err != nil {
-		log.Printf("Unable to build deleteAllGraphsBySpec query: %s", err)
 		return false, err
 	}
-	log.Println(query)
-	errs := fragments.UpdateViaSparql(query)
-	if errs != nil {
-		log.Printf("Unable query endpoint: %s", errs)
-		return false, errs[0]
+
+	if err := store.DropDataset(ctx, spec); err != nil {
+		log.Printf("Unable to delete graphs for spec %s: %s", spec, err)
+		return false, err
 	}
+
 	return true, nil
 }
 
-// DeleteGraphsOrphansBySpec issues an SPARQL Update query to delete all orphaned graphs
-// for a DataSet from the triple store.
-func DeleteGraphsOrphansBySpec(spec string, revision int) (bool, error) {
-	query, err := queryBank.Prepare("deleteOrphanGraphsBySpec", struct {
-		Spec           string
-		RevisionNumber int
-	}{spec, revision})
+// DeleteGraphsOrphansBySpec deletes all orphaned graphs for a DataSet from the triple store.
+func DeleteGraphsOrphansBySpec(ctx context.Context, spec string, revision int) (bool, error) {
+	store, err := TripleStore()
 	if err != nil {
-		log.Printf("Unable to build deleteOrphanGraphsBySpec query: %s", err)
 		return false, err
 	}
-	log.Println(query)
-	errs := fragments.UpdateViaSparql(query)
-	if errs != nil {
-		log.Printf("Unable query endpoint: %s", errs)
-		return false, errs[0]
+
+	if err := store.DropOrphans(ctx, spec, revision); err != nil {
+		log.Printf("Unable to delete orphan graphs for spec %s: %s", spec, err)
+		return false, err
 	}
+
 	return true, nil
 }
 
@@ -0,0 +1,79 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/delving/hub3/config"
+	"github.com/delving/hub3/ikuzo/service/x/sparql"
+	"github.com/delving/hub3/ikuzo/storage/x/anzograph"
+	"github.com/delving/hub3/ikuzo/storage/x/blazegraph"
+	"github.com/delving/hub3/ikuzo/storage/x/fuseki"
+)
+
+var (
+	tripleStore     sparql.Store
+	tripleStoreOnce sync.Once
+	tripleStoreErr  error
+)
+
+// SetTripleStore sets the sparql.Store that is used for all triple store updates.
+func SetTripleStore(store sparql.Store) {
+	tripleStoreOnce.Do(func() {})
+
+	tripleStore = store
+	tripleStoreErr = nil
+}
+
+// TripleStore returns the sparql.Store. When none is set it is created from
+// the RDF configuration.
+func TripleStore() (sparql.Store, error) {
+	tripleStoreOnce.Do(func() {
+		tripleStore, tripleStoreErr = newTripleStore(&config.Config)
+	})
+
+	return tripleStore, tripleStoreErr
+}
+
+func newTripleStore(cfg *config.RawConfig) (sparql.Store, error) {
+	queryEndpoint := cfg.GetSparqlEndpoint("")
+
+	switch cfg.RDF.TripleStore {
+	case "", "fuseki":
+		graphStore := ""
+		if cfg.RDF.GraphStorePath != "" {
+			graphStore = cfg.GetGraphStoreEndpoint("")
+		}
+
+		return fuseki.NewStore(
+			fuseki.SetEndpoints(queryEndpoint, cfg.GetSparqlUpdateEndpoint(""), graphStore),
+			fuseki.SetBasicAuth(cfg.RDF.SparqlUsername, cfg.RDF.SparqlPassword),
+		)
+	case "blazegraph":
+		return blazegraph.NewStore(
+			blazegraph.SetEndpoint(queryEndpoint),
+			blazegraph.SetBasicAuth(cfg.RDF.SparqlUsername, cfg.RDF.SparqlPassword),
+		)
+	case "anzograph":
+		return anzograph.NewStore(
+			anzograph.SetEndpoint(queryEndpoint),
+			anzograph.SetBasicAuth(cfg.RDF.SparqlUsername, cfg.RDF.SparqlPassword),
+		)
+	}
+
+	return nil, fmt.Errorf("unsupported triple store: %s", cfg.RDF.TripleStore)
+}
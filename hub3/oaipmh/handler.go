@@ -15,9 +15,13 @@
 package harvesting
 
 import (
+	"bytes"
+	"encoding/xml"
 	"fmt"
+	"log"
 	"net/http"
 
+	"github.com/delving/hub3/hub3/models"
 	"github.com/go-chi/render"
 	"github.com/kiivihal/goharvest/oai"
 )
@@ -71,10 +75,22 @@ func ProcessVerb(r *oai.Request) interface{} {
 	case "ListSets":
 		return renderListSets(r)
 	case "ListIdentifiers":
+		if len(publishedSets(r.Set)) == 0 {
+			return noRecordsMatch
+		}
+
 		return "identifiers"
 	case "ListRecords":
+		if len(publishedSets(r.Set)) == 0 {
+			return noRecordsMatch
+		}
+
 		return "records"
 	case "GetRecord":
+		if !isPublishedRecord(r.Identifier) {
+			return idDoesNotExist
+		}
+
 		return "record"
 	default:
 		badVerb := oai.OAIError{
@@ -99,26 +115,99 @@ func renderIdentify(r *oai.Request) interface{} {
 	}
 }
 
+// noRecordsMatch is returned for sets that are not published.
+var noRecordsMatch = oai.OAIError{
+	Code:    "noRecordsMatch",
+	Message: "The combination of the values of the arguments results in an empty list.",
+}
+
+// idDoesNotExist is returned for records of sets that are not published.
+var idDoesNotExist = oai.OAIError{
+	Code:    "idDoesNotExist",
+	Message: "The value of the identifier argument is unknown or illegal in this repository.",
+}
+
+// isPublishedSet returns true when the set is a dataset with OAI-PMH access.
+func isPublishedSet(set string) bool {
+	if set == "" {
+		return false
+	}
+
+	ds, err := models.GetDataSet(set)
+	if err != nil {
+		return false
+	}
+
+	return ds.Access.OAIPMH && !ds.Deleted
+}
+
+// isPublishedRecord returns true when the identifier is the hubID of a record
+// in a published set.
+func isPublishedRecord(identifier string) bool {
+	_, spec, _, err := models.RDFRecord{HubID: identifier}.ExtractHubID()
+	if err != nil {
+		return false
+	}
+
+	return isPublishedSet(spec)
+}
+
+// publishedSets returns the sets a list request is limited to. A request
+// without a set is limited to all the published sets.
+func publishedSets(set string) []string {
+	if set != "" {
+		if !isPublishedSet(set) {
+			return nil
+		}
+
+		return []string{set}
+	}
+
+	datasets, err := models.ListOAIPMHDataSets()
+	if err != nil {
+		log.Printf("Unable to retrieve datasets from the storage layer: %s", err)
+		return nil
+	}
+
+	sets := make([]string, 0, len(datasets))
+	for _, ds := range datasets {
+		sets = append(sets, ds.Spec)
+	}
+
+	return sets
+}
+
 // renderListSets returns a list of all the publicly available sets
 func renderListSets(r *oai.Request) interface{} {
 	sets := []oai.Set{}
-	//datasets, err := models.ListDataSets()
-	//if err != nil {
-	//logger.Errorln("Unable to retrieve datasets from the storage layer.")
-	//return sets
-	//}
-	//for _, ds := range datasets {
-	//if ds.Access.OAIPMH {
-	//sets = append(
-	//sets,
-	//oai.Set{
-	//SetSpec:        ds.Spec,
-	//SetName:        ds.Spec,                                // todo change to name if it has one later
-	//SetDescription: oai.Description{Body: []byte(ds.Spec)}, // TODO change to description from ds later.
-	//},
-	//)
-	//}
-	//}
+
+	datasets, err := models.ListOAIPMHDataSets()
+	if err != nil {
+		log.Printf("Unable to retrieve datasets from the storage layer: %s", err)
+		return oai.ListSets{
+			Set: sets,
+		}
+	}
+
+	for _, ds := range datasets {
+		name := ds.Label
+		if name == "" {
+			name = ds.Spec
+		}
+
+		var description bytes.Buffer
+		_ = xml.EscapeText(&description, []byte(ds.Description))
+
+		sets = append(
+			sets,
+			oai.Set{
+				SetSpec:        ds.Spec,
+				SetName:        name,
+				SetDescription: oai.Description{Body: description.Bytes()},
+			},
+		)
+	}
+
 	return oai.ListSets{
 		Set: sets,
 	}
@@ -13,141 +13,887 @@
 // limitations under the License.
 
 // Code generated by protoc-gen-go. DO NOT EDIT.
-// source: namespace.proto
+// versions:
+// 	protoc-gen-go v1.24.0
+// 	protoc        v3.11.4
+// source: hub3/server/grpc/pb/namespacepb/namespace.proto
 
 package namespacepb
 
 import (
 	context "context"
-	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	grpc "google.golang.org/grpc"
-	math "math"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
 )
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ = proto.Marshal
-var _ = fmt.Errorf
-var _ = math.Inf
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the proto package it is being compiled against.
-// A compilation error at this line likely means your copy of the
-// proto package needs to be updated.
-const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
 
 // SearchLabelRequest message containing the URI to be turned into a searchLabel
 type SearchLabelRequest struct {
-	Uri                  string   `protobuf:"bytes,1,opt,name=uri,proto3" json:"uri,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uri string `protobuf:"bytes,1,opt,name=uri,proto3" json:"uri,omitempty"`
+}
+
+func (x *SearchLabelRequest) Reset() {
+	*x = SearchLabelRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub3_server_grpc_pb_namespacepb_namespace_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchLabelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchLabelRequest) ProtoMessage() {}
+
+func (x *SearchLabelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hub3_server_grpc_pb_namespacepb_namespace_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (m *SearchLabelRequest) Reset()         { *m = SearchLabelRequest{} }
-func (m *SearchLabelRequest) String() string { return proto.CompactTextString(m) }
-func (*SearchLabelRequest) ProtoMessage()    {}
+// Deprecated: Use SearchLabelRequest.ProtoReflect.Descriptor instead.
 func (*SearchLabelRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ecb1e126f615f5dd, []int{0}
+	return file_hub3_server_grpc_pb_namespacepb_namespace_proto_rawDescGZIP(), []int{0}
 }
 
-func (m *SearchLabelRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SearchLabelRequest.Unmarshal(m, b)
+func (x *SearchLabelRequest) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
 }
-func (m *SearchLabelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SearchLabelRequest.Marshal(b, m, deterministic)
+
+// SearchLabelResponse message contains extracted searchLabel
+type SearchLabelResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uri   string `protobuf:"bytes,1,opt,name=uri,proto3" json:"uri,omitempty"`
+	Label string `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	// error is only set by SearchLabels when no label could be created for the uri
+	Error string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
 }
-func (m *SearchLabelRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SearchLabelRequest.Merge(m, src)
+
+func (x *SearchLabelResponse) Reset() {
+	*x = SearchLabelResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub3_server_grpc_pb_namespacepb_namespace_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *SearchLabelRequest) XXX_Size() int {
-	return xxx_messageInfo_SearchLabelRequest.Size(m)
+
+func (x *SearchLabelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *SearchLabelRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_SearchLabelRequest.DiscardUnknown(m)
+
+func (*SearchLabelResponse) ProtoMessage() {}
+
+func (x *SearchLabelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hub3_server_grpc_pb_namespacepb_namespace_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var xxx_messageInfo_SearchLabelRequest proto.InternalMessageInfo
+// Deprecated: Use SearchLabelResponse.ProtoReflect.Descriptor instead.
+func (*SearchLabelResponse) Descriptor() ([]byte, []int) {
+	return file_hub3_server_grpc_pb_namespacepb_namespace_proto_rawDescGZIP(), []int{1}
+}
 
-func (m *SearchLabelRequest) GetUri() string {
-	if m != nil {
-		return m.Uri
+func (x *SearchLabelResponse) GetUri() string {
+	if x != nil {
+		return x.Uri
 	}
 	return ""
 }
 
-// SearchLabelResponse message contains extracted searchLabel
-type SearchLabelResponse struct {
-	Label                string   `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (x *SearchLabelResponse) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
 }
 
-func (m *SearchLabelResponse) Reset()         { *m = SearchLabelResponse{} }
-func (m *SearchLabelResponse) String() string { return proto.CompactTextString(m) }
-func (*SearchLabelResponse) ProtoMessage()    {}
-func (*SearchLabelResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ecb1e126f615f5dd, []int{1}
+func (x *SearchLabelResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// NameSpace is a prefix and base-URI pair with their alternatives
+type NameSpace struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uuid      string   `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Prefix    string   `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Base      string   `protobuf:"bytes,3,opt,name=base,proto3" json:"base,omitempty"`
+	PrefixAlt []string `protobuf:"bytes,4,rep,name=prefix_alt,json=prefixAlt,proto3" json:"prefix_alt,omitempty"`
+	BaseAlt   []string `protobuf:"bytes,5,rep,name=base_alt,json=baseAlt,proto3" json:"base_alt,omitempty"`
+	Schema    string   `protobuf:"bytes,6,opt,name=schema,proto3" json:"schema,omitempty"`
+	Temporary bool     `protobuf:"varint,7,opt,name=temporary,proto3" json:"temporary,omitempty"`
+	Usage     int64    `protobuf:"varint,8,opt,name=usage,proto3" json:"usage,omitempty"`
+}
+
+func (x *NameSpace) Reset() {
+	*x = NameSpace{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub3_server_grpc_pb_namespacepb_namespace_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NameSpace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NameSpace) ProtoMessage() {}
+
+func (x *NameSpace) ProtoReflect() protoreflect.Message {
+	mi := &file_hub3_server_grpc_pb_namespacepb_namespace_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NameSpace.ProtoReflect.Descriptor instead.
+func (*NameSpace) Descriptor() ([]byte, []int) {
+	return file_hub3_server_grpc_pb_namespacepb_namespace_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *NameSpace) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *NameSpace) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *NameSpace) GetBase() string {
+	if x != nil {
+		return x.Base
+	}
+	return ""
+}
+
+func (x *NameSpace) GetPrefixAlt() []string {
+	if x != nil {
+		return x.PrefixAlt
+	}
+	return nil
+}
+
+func (x *NameSpace) GetBaseAlt() []string {
+	if x != nil {
+		return x.BaseAlt
+	}
+	return nil
+}
+
+func (x *NameSpace) GetSchema() string {
+	if x != nil {
+		return x.Schema
+	}
+	return ""
+}
+
+func (x *NameSpace) GetTemporary() bool {
+	if x != nil {
+		return x.Temporary
+	}
+	return false
+}
+
+func (x *NameSpace) GetUsage() int64 {
+	if x != nil {
+		return x.Usage
+	}
+	return 0
+}
+
+// ListRequest message to list all namespaces
+type ListRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListRequest) Reset() {
+	*x = ListRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub3_server_grpc_pb_namespacepb_namespace_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRequest) ProtoMessage() {}
+
+func (x *ListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hub3_server_grpc_pb_namespacepb_namespace_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return file_hub3_server_grpc_pb_namespacepb_namespace_proto_rawDescGZIP(), []int{3}
+}
+
+// ListResponse message contains all namespaces
+type ListResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Namespaces []*NameSpace `protobuf:"bytes,1,rep,name=namespaces,proto3" json:"namespaces,omitempty"`
+}
+
+func (x *ListResponse) Reset() {
+	*x = ListResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub3_server_grpc_pb_namespacepb_namespace_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListResponse) ProtoMessage() {}
+
+func (x *ListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hub3_server_grpc_pb_namespacepb_namespace_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListResponse.ProtoReflect.Descriptor instead.
+func (*ListResponse) Descriptor() ([]byte, []int) {
+	return file_hub3_server_grpc_pb_namespacepb_namespace_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListResponse) GetNamespaces() []*NameSpace {
+	if x != nil {
+		return x.Namespaces
+	}
+	return nil
+}
+
+// GetWithPrefixRequest message contains the prefix to look up
+type GetWithPrefixRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Prefix string `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+}
+
+func (x *GetWithPrefixRequest) Reset() {
+	*x = GetWithPrefixRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub3_server_grpc_pb_namespacepb_namespace_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetWithPrefixRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWithPrefixRequest) ProtoMessage() {}
+
+func (x *GetWithPrefixRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hub3_server_grpc_pb_namespacepb_namespace_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWithPrefixRequest.ProtoReflect.Descriptor instead.
+func (*GetWithPrefixRequest) Descriptor() ([]byte, []int) {
+	return file_hub3_server_grpc_pb_namespacepb_namespace_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetWithPrefixRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+// GetWithBaseRequest message contains the base-URI to look up
+type GetWithBaseRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Base string `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+}
+
+func (x *GetWithBaseRequest) Reset() {
+	*x = GetWithBaseRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub3_server_grpc_pb_namespacepb_namespace_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetWithBaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (m *SearchLabelResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SearchLabelResponse.Unmarshal(m, b)
+func (*GetWithBaseRequest) ProtoMessage() {}
+
+func (x *GetWithBaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hub3_server_grpc_pb_namespacepb_namespace_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWithBaseRequest.ProtoReflect.Descriptor instead.
+func (*GetWithBaseRequest) Descriptor() ([]byte, []int) {
+	return file_hub3_server_grpc_pb_namespacepb_namespace_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetWithBaseRequest) GetBase() string {
+	if x != nil {
+		return x.Base
+	}
+	return ""
 }
-func (m *SearchLabelResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SearchLabelResponse.Marshal(b, m, deterministic)
+
+// AddRequest message contains the prefix and base-URI to add
+type AddRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Prefix string `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Base   string `protobuf:"bytes,2,opt,name=base,proto3" json:"base,omitempty"`
 }
-func (m *SearchLabelResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SearchLabelResponse.Merge(m, src)
+
+func (x *AddRequest) Reset() {
+	*x = AddRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub3_server_grpc_pb_namespacepb_namespace_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *SearchLabelResponse) XXX_Size() int {
-	return xxx_messageInfo_SearchLabelResponse.Size(m)
+
+func (x *AddRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *SearchLabelResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_SearchLabelResponse.DiscardUnknown(m)
+
+func (*AddRequest) ProtoMessage() {}
+
+func (x *AddRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hub3_server_grpc_pb_namespacepb_namespace_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var xxx_messageInfo_SearchLabelResponse proto.InternalMessageInfo
+// Deprecated: Use AddRequest.ProtoReflect.Descriptor instead.
+func (*AddRequest) Descriptor() ([]byte, []int) {
+	return file_hub3_server_grpc_pb_namespacepb_namespace_proto_rawDescGZIP(), []int{7}
+}
 
-func (m *SearchLabelResponse) GetLabel() string {
-	if m != nil {
-		return m.Label
+func (x *AddRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
 	}
 	return ""
 }
 
-func init() {
-	proto.RegisterType((*SearchLabelRequest)(nil), "namespacepb.SearchLabelRequest")
-	proto.RegisterType((*SearchLabelResponse)(nil), "namespacepb.SearchLabelResponse")
+func (x *AddRequest) GetBase() string {
+	if x != nil {
+		return x.Base
+	}
+	return ""
 }
 
-func init() { proto.RegisterFile("namespace.proto", fileDescriptor_ecb1e126f615f5dd) }
+// DeleteRequest message identifies the namespace to remove
+type DeleteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uuid   string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Prefix string `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+}
+
+func (x *DeleteRequest) Reset() {
+	*x = DeleteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub3_server_grpc_pb_namespacepb_namespace_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRequest) ProtoMessage() {}
+
+func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hub3_server_grpc_pb_namespacepb_namespace_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
+	return file_hub3_server_grpc_pb_namespacepb_namespace_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *DeleteRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
 
-var fileDescriptor_ecb1e126f615f5dd = []byte{
-	// 193 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0xcf, 0x4b, 0xcc, 0x4d,
-	0x2d, 0x2e, 0x48, 0x4c, 0x4e, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x86, 0x0b, 0x14,
-	0x24, 0x49, 0xc9, 0xa4, 0xe7, 0xe7, 0xa7, 0xe7, 0xa4, 0xea, 0x27, 0x16, 0x64, 0xea, 0x27, 0xe6,
-	0xe5, 0xe5, 0x97, 0x24, 0x96, 0x64, 0xe6, 0xe7, 0x15, 0x43, 0x94, 0x2a, 0xa9, 0x71, 0x09, 0x05,
-	0xa7, 0x26, 0x16, 0x25, 0x67, 0xf8, 0x24, 0x26, 0xa5, 0xe6, 0x04, 0xa5, 0x16, 0x96, 0xa6, 0x16,
-	0x97, 0x08, 0x09, 0x70, 0x31, 0x97, 0x16, 0x65, 0x4a, 0x30, 0x2a, 0x30, 0x6a, 0x70, 0x06, 0x81,
-	0x98, 0x4a, 0xda, 0x5c, 0xc2, 0x28, 0xea, 0x8a, 0x0b, 0xf2, 0xf3, 0x8a, 0x53, 0x85, 0x44, 0xb8,
-	0x58, 0x73, 0x40, 0x02, 0x12, 0x4c, 0x60, 0xa5, 0x10, 0x8e, 0x51, 0x03, 0x23, 0x17, 0xa7, 0x1f,
-	0xcc, 0x09, 0x42, 0xc5, 0x5c, 0xdc, 0x48, 0x5a, 0x85, 0xe4, 0xf5, 0x90, 0x5c, 0xa7, 0x87, 0x69,
-	0xb9, 0x94, 0x02, 0x6e, 0x05, 0x10, 0x5b, 0x95, 0x14, 0x9b, 0x2e, 0x3f, 0x99, 0xcc, 0x24, 0xad,
-	0x24, 0xa6, 0x0f, 0x57, 0xa9, 0x5f, 0x0c, 0x56, 0x07, 0xb6, 0xdf, 0x8a, 0x51, 0x2b, 0x89, 0x0d,
-	0xec, 0x3d, 0x63, 0x40, 0x00, 0x00, 0x00, 0xff, 0xff, 0x6d, 0xaf, 0x94, 0x6f, 0x1c, 0x01, 0x00,
-	0x00,
+// DeleteResponse message is returned when the namespace is removed
+type DeleteResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeleteResponse) Reset() {
+	*x = DeleteResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub3_server_grpc_pb_namespacepb_namespace_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteResponse) ProtoMessage() {}
+
+func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hub3_server_grpc_pb_namespacepb_namespace_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteResponse.ProtoReflect.Descriptor instead.
+func (*DeleteResponse) Descriptor() ([]byte, []int) {
+	return file_hub3_server_grpc_pb_namespacepb_namespace_proto_rawDescGZIP(), []int{9}
+}
+
+var File_hub3_server_grpc_pb_namespacepb_namespace_proto protoreflect.FileDescriptor
+
+var file_hub3_server_grpc_pb_namespacepb_namespace_proto_rawDesc = []byte{
+	0x0a, 0x2f, 0x68, 0x75, 0x62, 0x33, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x67, 0x72,
+	0x70, 0x63, 0x2f, 0x70, 0x62, 0x2f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x70,
+	0x62, 0x2f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x0b, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x70, 0x62, 0x1a, 0x1c,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x26, 0x0a, 0x12,
+	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x75, 0x72, 0x69, 0x22, 0x53, 0x0a, 0x13, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4c, 0x61,
+	0x62, 0x65, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75,
+	0x72, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x69, 0x12, 0x14, 0x0a,
+	0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61,
+	0x62, 0x65, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xd1, 0x01, 0x0a, 0x09, 0x4e, 0x61,
+	0x6d, 0x65, 0x53, 0x70, 0x61, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x70,
+	0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x65,
+	0x66, 0x69, 0x78, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x65, 0x66, 0x69,
+	0x78, 0x5f, 0x61, 0x6c, 0x74, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x65,
+	0x66, 0x69, 0x78, 0x41, 0x6c, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x61,
+	0x6c, 0x74, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x62, 0x61, 0x73, 0x65, 0x41, 0x6c,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x65, 0x6d,
+	0x70, 0x6f, 0x72, 0x61, 0x72, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x74, 0x65,
+	0x6d, 0x70, 0x6f, 0x72, 0x61, 0x72, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x75, 0x73, 0x61, 0x67, 0x65,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x75, 0x73, 0x61, 0x67, 0x65, 0x22, 0x0d, 0x0a,
+	0x0b, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x46, 0x0a, 0x0c,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x0a,
+	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x70, 0x62, 0x2e, 0x4e,
+	0x61, 0x6d, 0x65, 0x53, 0x70, 0x61, 0x63, 0x65, 0x52, 0x0a, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70,
+	0x61, 0x63, 0x65, 0x73, 0x22, 0x2e, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x57, 0x69, 0x74, 0x68, 0x50,
+	0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72,
+	0x65, 0x66, 0x69, 0x78, 0x22, 0x28, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x57, 0x69, 0x74, 0x68, 0x42,
+	0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x61,
+	0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x22, 0x38,
+	0x0a, 0x0a, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72,
+	0x65, 0x66, 0x69, 0x78, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x22, 0x3b, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x16, 0x0a,
+	0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70,
+	0x72, 0x65, 0x66, 0x69, 0x78, 0x22, 0x10, 0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0xa8, 0x05, 0x0a, 0x09, 0x4e, 0x61, 0x6d, 0x65,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x73, 0x0a, 0x0b, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4c,
+	0x61, 0x62, 0x65, 0x6c, 0x12, 0x1f, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x21, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1b, 0x3a,
+	0x01, 0x2a, 0x22, 0x16, 0x2f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x2f, 0x73,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x57, 0x0a, 0x0c, 0x53, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x1f, 0x2e, 0x6e, 0x61, 0x6d,
+	0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4c,
+	0x61, 0x62, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x6e, 0x61,
+	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28,
+	0x01, 0x30, 0x01, 0x12, 0x4f, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x18, 0x2e, 0x6e, 0x61,
+	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x12, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0c, 0x12, 0x0a, 0x2f, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x12, 0x6e, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x57, 0x69, 0x74, 0x68, 0x50,
+	0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x21, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x57, 0x69, 0x74, 0x68, 0x50, 0x72, 0x65, 0x66, 0x69,
+	0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x70, 0x62, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x53, 0x70, 0x61, 0x63, 0x65,
+	0x22, 0x22, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1c, 0x12, 0x1a, 0x2f, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x2f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x2f, 0x7b, 0x70, 0x72, 0x65,
+	0x66, 0x69, 0x78, 0x7d, 0x12, 0x5f, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x57, 0x69, 0x74, 0x68, 0x42,
+	0x61, 0x73, 0x65, 0x12, 0x1f, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x70,
+	0x62, 0x2e, 0x47, 0x65, 0x74, 0x57, 0x69, 0x74, 0x68, 0x42, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x70, 0x62, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x53, 0x70, 0x61, 0x63, 0x65, 0x22, 0x17, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x11, 0x12, 0x0f, 0x2f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x2f, 0x62, 0x61, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x03, 0x41, 0x64, 0x64, 0x12, 0x17, 0x2e, 0x6e,
+	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x70, 0x62, 0x2e, 0x41, 0x64, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x70, 0x62, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x53, 0x70, 0x61, 0x63, 0x65, 0x22, 0x15, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x0f, 0x3a, 0x01, 0x2a, 0x22, 0x0a, 0x2f, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x12, 0x5c, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x1a,
+	0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x6e, 0x61, 0x6d,
+	0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x19, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x13, 0x2a,
+	0x11, 0x2f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x2f, 0x7b, 0x75, 0x75, 0x69,
+	0x64, 0x7d, 0x42, 0x21, 0x5a, 0x1f, 0x68, 0x75, 0x62, 0x33, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x62, 0x2f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70,
+	0x61, 0x63, 0x65, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_hub3_server_grpc_pb_namespacepb_namespace_proto_rawDescOnce sync.Once
+	file_hub3_server_grpc_pb_namespacepb_namespace_proto_rawDescData = file_hub3_server_grpc_pb_namespacepb_namespace_proto_rawDesc
+)
+
+func file_hub3_server_grpc_pb_namespacepb_namespace_proto_rawDescGZIP() []byte {
+	file_hub3_server_grpc_pb_namespacepb_namespace_proto_rawDescOnce.Do(func() {
+		file_hub3_server_grpc_pb_namespacepb_namespace_proto_rawDescData = protoimpl.X.CompressGZIP(file_hub3_server_grpc_pb_namespacepb_namespace_proto_rawDescData)
+	})
+	return file_hub3_server_grpc_pb_namespacepb_namespace_proto_rawDescData
+}
+
+var file_hub3_server_grpc_pb_namespacepb_namespace_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_hub3_server_grpc_pb_namespacepb_namespace_proto_goTypes = []interface{}{
+	(*SearchLabelRequest)(nil),   // 0: namespacepb.SearchLabelRequest
+	(*SearchLabelResponse)(nil),  // 1: namespacepb.SearchLabelResponse
+	(*NameSpace)(nil),            // 2: namespacepb.NameSpace
+	(*ListRequest)(nil),          // 3: namespacepb.ListRequest
+	(*ListResponse)(nil),         // 4: namespacepb.ListResponse
+	(*GetWithPrefixRequest)(nil), // 5: namespacepb.GetWithPrefixRequest
+	(*GetWithBaseRequest)(nil),   // 6: namespacepb.GetWithBaseRequest
+	(*AddRequest)(nil),           // 7: namespacepb.AddRequest
+	(*DeleteRequest)(nil),        // 8: namespacepb.DeleteRequest
+	(*DeleteResponse)(nil),       // 9: namespacepb.DeleteResponse
+}
+var file_hub3_server_grpc_pb_namespacepb_namespace_proto_depIdxs = []int32{
+	2, // 0: namespacepb.ListResponse.namespaces:type_name -> namespacepb.NameSpace
+	0, // 1: namespacepb.Namespace.SearchLabel:input_type -> namespacepb.SearchLabelRequest
+	0, // 2: namespacepb.Namespace.SearchLabels:input_type -> namespacepb.SearchLabelRequest
+	3, // 3: namespacepb.Namespace.List:input_type -> namespacepb.ListRequest
+	5, // 4: namespacepb.Namespace.GetWithPrefix:input_type -> namespacepb.GetWithPrefixRequest
+	6, // 5: namespacepb.Namespace.GetWithBase:input_type -> namespacepb.GetWithBaseRequest
+	7, // 6: namespacepb.Namespace.Add:input_type -> namespacepb.AddRequest
+	8, // 7: namespacepb.Namespace.Delete:input_type -> namespacepb.DeleteRequest
+	1, // 8: namespacepb.Namespace.SearchLabel:output_type -> namespacepb.SearchLabelResponse
+	1, // 9: namespacepb.Namespace.SearchLabels:output_type -> namespacepb.SearchLabelResponse
+	4, // 10: namespacepb.Namespace.List:output_type -> namespacepb.ListResponse
+	2, // 11: namespacepb.Namespace.GetWithPrefix:output_type -> namespacepb.NameSpace
+	2, // 12: namespacepb.Namespace.GetWithBase:output_type -> namespacepb.NameSpace
+	2, // 13: namespacepb.Namespace.Add:output_type -> namespacepb.NameSpace
+	9, // 14: namespacepb.Namespace.Delete:output_type -> namespacepb.DeleteResponse
+	8, // [8:15] is the sub-list for method output_type
+	1, // [1:8] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_hub3_server_grpc_pb_namespacepb_namespace_proto_init() }
+func file_hub3_server_grpc_pb_namespacepb_namespace_proto_init() {
+	if File_hub3_server_grpc_pb_namespacepb_namespace_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_hub3_server_grpc_pb_namespacepb_namespace_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchLabelRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub3_server_grpc_pb_namespacepb_namespace_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchLabelResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub3_server_grpc_pb_namespacepb_namespace_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NameSpace); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub3_server_grpc_pb_namespacepb_namespace_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub3_server_grpc_pb_namespacepb_namespace_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub3_server_grpc_pb_namespacepb_namespace_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetWithPrefixRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub3_server_grpc_pb_namespacepb_namespace_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetWithBaseRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub3_server_grpc_pb_namespacepb_namespace_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub3_server_grpc_pb_namespacepb_namespace_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub3_server_grpc_pb_namespacepb_namespace_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_hub3_server_grpc_pb_namespacepb_namespace_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_hub3_server_grpc_pb_namespacepb_namespace_proto_goTypes,
+		DependencyIndexes: file_hub3_server_grpc_pb_namespacepb_namespace_proto_depIdxs,
+		MessageInfos:      file_hub3_server_grpc_pb_namespacepb_namespace_proto_msgTypes,
+	}.Build()
+	File_hub3_server_grpc_pb_namespacepb_namespace_proto = out.File
+	file_hub3_server_grpc_pb_namespacepb_namespace_proto_rawDesc = nil
+	file_hub3_server_grpc_pb_namespacepb_namespace_proto_goTypes = nil
+	file_hub3_server_grpc_pb_namespacepb_namespace_proto_depIdxs = nil
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ context.Context
-var _ grpc.ClientConn
+var _ grpc.ClientConnInterface
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+const _ = grpc.SupportPackageIsVersion6
 
 // NamespaceClient is the client API for Namespace service.
 //
@@ -155,13 +901,26 @@ const _ = grpc.SupportPackageIsVersion4
 type NamespaceClient interface {
 	// Requests searchLabel for URI
 	SearchLabel(ctx context.Context, in *SearchLabelRequest, opts ...grpc.CallOption) (*SearchLabelResponse, error)
+	// Streams the searchLabels for a stream of URIs. It is used for bulk lookups during indexing.
+	// Each response contains the requested URI. URIs without a namespace get an error instead of a label.
+	SearchLabels(ctx context.Context, opts ...grpc.CallOption) (Namespace_SearchLabelsClient, error)
+	// Lists all namespaces sorted by prefix
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
+	// Returns the namespace for the default or an alternative prefix
+	GetWithPrefix(ctx context.Context, in *GetWithPrefixRequest, opts ...grpc.CallOption) (*NameSpace, error)
+	// Returns the namespace for the default or an alternative base-URI
+	GetWithBase(ctx context.Context, in *GetWithBaseRequest, opts ...grpc.CallOption) (*NameSpace, error)
+	// Adds a prefix and base-URI. When one of them is known the other is added as an alternative.
+	Add(ctx context.Context, in *AddRequest, opts ...grpc.CallOption) (*NameSpace, error)
+	// Removes the namespace with the uuid or, when the uuid is empty, with the prefix
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
 }
 
 type namespaceClient struct {
-	cc *grpc.ClientConn
+	cc grpc.ClientConnInterface
 }
 
-func NewNamespaceClient(cc *grpc.ClientConn) NamespaceClient {
+func NewNamespaceClient(cc grpc.ClientConnInterface) NamespaceClient {
 	return &namespaceClient{cc}
 }
 
@@ -174,10 +933,125 @@ func (c *namespaceClient) SearchLabel(ctx context.Context, in *SearchLabelReques
 	return out, nil
 }
 
+func (c *namespaceClient) SearchLabels(ctx context.Context, opts ...grpc.CallOption) (Namespace_SearchLabelsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Namespace_serviceDesc.Streams[0], "/namespacepb.Namespace/SearchLabels", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &namespaceSearchLabelsClient{stream}
+	return x, nil
+}
+
+type Namespace_SearchLabelsClient interface {
+	Send(*SearchLabelRequest) error
+	Recv() (*SearchLabelResponse, error)
+	grpc.ClientStream
+}
+
+type namespaceSearchLabelsClient struct {
+	grpc.ClientStream
+}
+
+func (x *namespaceSearchLabelsClient) Send(m *SearchLabelRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *namespaceSearchLabelsClient) Recv() (*SearchLabelResponse, error) {
+	m := new(SearchLabelResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *namespaceClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error) {
+	out := new(ListResponse)
+	err := c.cc.Invoke(ctx, "/namespacepb.Namespace/List", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *namespaceClient) GetWithPrefix(ctx context.Context, in *GetWithPrefixRequest, opts ...grpc.CallOption) (*NameSpace, error) {
+	out := new(NameSpace)
+	err := c.cc.Invoke(ctx, "/namespacepb.Namespace/GetWithPrefix", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *namespaceClient) GetWithBase(ctx context.Context, in *GetWithBaseRequest, opts ...grpc.CallOption) (*NameSpace, error) {
+	out := new(NameSpace)
+	err := c.cc.Invoke(ctx, "/namespacepb.Namespace/GetWithBase", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *namespaceClient) Add(ctx context.Context, in *AddRequest, opts ...grpc.CallOption) (*NameSpace, error) {
+	out := new(NameSpace)
+	err := c.cc.Invoke(ctx, "/namespacepb.Namespace/Add", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *namespaceClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, "/namespacepb.Namespace/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NamespaceServer is the server API for Namespace service.
 type NamespaceServer interface {
 	// Requests searchLabel for URI
 	SearchLabel(context.Context, *SearchLabelRequest) (*SearchLabelResponse, error)
+	// Streams the searchLabels for a stream of URIs. It is used for bulk lookups during indexing.
+	// Each response contains the requested URI. URIs without a namespace get an error instead of a label.
+	SearchLabels(Namespace_SearchLabelsServer) error
+	// Lists all namespaces sorted by prefix
+	List(context.Context, *ListRequest) (*ListResponse, error)
+	// Returns the namespace for the default or an alternative prefix
+	GetWithPrefix(context.Context, *GetWithPrefixRequest) (*NameSpace, error)
+	// Returns the namespace for the default or an alternative base-URI
+	GetWithBase(context.Context, *GetWithBaseRequest) (*NameSpace, error)
+	// Adds a prefix and base-URI. When one of them is known the other is added as an alternative.
+	Add(context.Context, *AddRequest) (*NameSpace, error)
+	// Removes the namespace with the uuid or, when the uuid is empty, with the prefix
+	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+}
+
+// UnimplementedNamespaceServer can be embedded to have forward compatible implementations.
+type UnimplementedNamespaceServer struct {
+}
+
+func (*UnimplementedNamespaceServer) SearchLabel(context.Context, *SearchLabelRequest) (*SearchLabelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchLabel not implemented")
+}
+func (*UnimplementedNamespaceServer) SearchLabels(Namespace_SearchLabelsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SearchLabels not implemented")
+}
+func (*UnimplementedNamespaceServer) List(context.Context, *ListRequest) (*ListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (*UnimplementedNamespaceServer) GetWithPrefix(context.Context, *GetWithPrefixRequest) (*NameSpace, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWithPrefix not implemented")
+}
+func (*UnimplementedNamespaceServer) GetWithBase(context.Context, *GetWithBaseRequest) (*NameSpace, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWithBase not implemented")
+}
+func (*UnimplementedNamespaceServer) Add(context.Context, *AddRequest) (*NameSpace, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Add not implemented")
+}
+func (*UnimplementedNamespaceServer) Delete(context.Context, *DeleteRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
 
 func RegisterNamespaceServer(s *grpc.Server, srv NamespaceServer) {
@@ -202,6 +1076,122 @@ func _Namespace_SearchLabel_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Namespace_SearchLabels_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(NamespaceServer).SearchLabels(&namespaceSearchLabelsServer{stream})
+}
+
+type Namespace_SearchLabelsServer interface {
+	Send(*SearchLabelResponse) error
+	Recv() (*SearchLabelRequest, error)
+	grpc.ServerStream
+}
+
+type namespaceSearchLabelsServer struct {
+	grpc.ServerStream
+}
+
+func (x *namespaceSearchLabelsServer) Send(m *SearchLabelResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *namespaceSearchLabelsServer) Recv() (*SearchLabelRequest, error) {
+	m := new(SearchLabelRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Namespace_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamespaceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/namespacepb.Namespace/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamespaceServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Namespace_GetWithPrefix_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWithPrefixRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamespaceServer).GetWithPrefix(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/namespacepb.Namespace/GetWithPrefix",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamespaceServer).GetWithPrefix(ctx, req.(*GetWithPrefixRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Namespace_GetWithBase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWithBaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamespaceServer).GetWithBase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/namespacepb.Namespace/GetWithBase",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamespaceServer).GetWithBase(ctx, req.(*GetWithBaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Namespace_Add_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamespaceServer).Add(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/namespacepb.Namespace/Add",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamespaceServer).Add(ctx, req.(*AddRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Namespace_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamespaceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/namespacepb.Namespace/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamespaceServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Namespace_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "namespacepb.Namespace",
 	HandlerType: (*NamespaceServer)(nil),
@@ -210,7 +1200,34 @@ var _Namespace_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SearchLabel",
 			Handler:    _Namespace_SearchLabel_Handler,
 		},
+		{
+			MethodName: "List",
+			Handler:    _Namespace_List_Handler,
+		},
+		{
+			MethodName: "GetWithPrefix",
+			Handler:    _Namespace_GetWithPrefix_Handler,
+		},
+		{
+			MethodName: "GetWithBase",
+			Handler:    _Namespace_GetWithBase_Handler,
+		},
+		{
+			MethodName: "Add",
+			Handler:    _Namespace_Add_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _Namespace_Delete_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SearchLabels",
+			Handler:       _Namespace_SearchLabels_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "namespace.proto",
+	Metadata: "hub3/server/grpc/pb/namespacepb/namespace.proto",
 }
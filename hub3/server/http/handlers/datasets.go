@@ -15,6 +15,7 @@
 package handlers
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
@@ -35,6 +36,7 @@ func RegisterDatasets(router chi.Router) {
 	r.Post("/", createDataSet)
 	r.Get("/{spec}", getDataSet)
 	r.Get("/{spec}/stats", getDataSetStats)
+	r.Put("/{spec}/access", updateDataSetAccess)
 	// later change to update dataset
 	r.Post("/{spec}", createDataSet)
 	r.Delete("/{spec}", deleteDataset)
@@ -129,6 +131,77 @@ func getDataSet(w http.ResponseWriter, r *http.Request) {
 	return
 }
 
+// accessUpdate is the request body of updateDataSetAccess.
+// Omitted flags are not changed.
+type accessUpdate struct {
+	OAIPMH *bool `json:"oaipmh"`
+	Search *bool `json:"search"`
+	LOD    *bool `json:"lod"`
+}
+
+// updateDataSetAccess changes the access flags of a dataset. The change is
+// effective immediately, because the records are filtered at query time.
+func updateDataSetAccess(w http.ResponseWriter, r *http.Request) {
+	spec := chi.URLParam(r, "spec")
+
+	ds, err := models.GetDataSet(spec)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err == storm.ErrNotFound {
+			status = http.StatusNotFound
+		}
+
+		render.Status(r, status)
+		render.JSON(w, r, APIErrorMessage{
+			HTTPStatus: status,
+			Message:    fmt.Sprintf("Unable to get dataset %s", spec),
+			Error:      err,
+		})
+
+		return
+	}
+
+	var update accessUpdate
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, APIErrorMessage{
+			HTTPStatus: http.StatusBadRequest,
+			Message:    "Unable to decode access flags",
+			Error:      err,
+		})
+
+		return
+	}
+
+	access := ds.Access
+
+	if update.OAIPMH != nil {
+		access.OAIPMH = *update.OAIPMH
+	}
+
+	if update.Search != nil {
+		access.Search = *update.Search
+	}
+
+	if update.LOD != nil {
+		access.LOD = *update.LOD
+	}
+
+	if err := ds.SetAccess(access); err != nil {
+		log.Printf("Unable to update access for %s: %s", spec, err)
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, APIErrorMessage{
+			HTTPStatus: http.StatusInternalServerError,
+			Message:    fmt.Sprintf("Unable to update access for %s", spec),
+			Error:      err,
+		})
+
+		return
+	}
+
+	render.JSON(w, r, ds)
+}
+
 func deleteDataset(w http.ResponseWriter, r *http.Request) {
 	spec := chi.URLParam(r, "spec")
 
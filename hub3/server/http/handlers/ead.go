@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"net/url"
@@ -44,6 +45,8 @@ func RegisterEAD(r chi.Router) {
 	r.Get("/api/tree/{spec}/{inventoryID:.*$}", TreeList)
 	r.Get("/api/tree/{spec}/stats", treeStats)
 	r.Get("/api/ead/{spec}/download", EADDownload)
+	r.Get("/api/ead/{spec}/export", EADExport)
+	r.Get("/api/ead/{spec}/pdf", PDFDownload)
 	r.Get("/api/ead/{spec}/mets/{inventoryID}", METSDownload)
 	r.Get("/api/ead/{spec}/desc", TreeDescriptionAPI)
 	r.Get("/api/ead/{spec}/desc/index", TreeDescriptionSearch)
@@ -140,10 +143,9 @@ func PDFDownload(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	eadPath := path.Join(c.Config.EAD.CacheDir, spec, fmt.Sprintf("%s.pdf", spec))
-	http.ServeFile(w, r, eadPath)
 	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s.pdf", spec))
 	w.Header().Set("Content-Type", "application/pdf")
-	return
+	http.ServeFile(w, r, eadPath)
 }
 
 // MetsDownload is a handler that returns a stored METS XML for an inventory.
@@ -184,6 +186,70 @@ func EADDownload(w http.ResponseWriter, r *http.Request) {
 	return
 }
 
+// EADExport is a handler that rebuilds an EAD Archive from the indexed tree.
+//
+// The 'format' query parameter selects the output: xml (default), csv or json.
+// The optional 'inventoryID' query parameter limits the export to a subtree.
+func EADExport(w http.ResponseWriter, r *http.Request) {
+	spec := chi.URLParam(r, "spec")
+	if spec == "" {
+		http.Error(w, "spec cannot be empty", http.StatusBadRequest)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "xml"
+	}
+
+	var (
+		contentType string
+		write       func(te *ead.TreeExport, w io.Writer) error
+	)
+
+	switch format {
+	case "xml":
+		contentType = "application/xml"
+		write = (*ead.TreeExport).WriteEAD
+	case "csv":
+		contentType = "text/csv"
+		write = (*ead.TreeExport).WriteCSV
+	case "json":
+		contentType = "application/json"
+		write = (*ead.TreeExport).WriteJSON
+	default:
+		http.Error(w, fmt.Sprintf("unsupported export format: %s", format), http.StatusBadRequest)
+		return
+	}
+
+	te, err := ead.GetTreeExport(r.Context(), spec, r.URL.Query().Get("inventoryID"))
+	if err != nil {
+		if errors.Is(err, ead.ErrInventoryNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	if len(te.Nodes) == 0 && te.Desc == nil {
+		http.Error(w, fmt.Sprintf("archive %s not found", spec), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s.%s", spec, format))
+	w.Header().Set("Content-Type", contentType)
+
+	if err := write(te, w); err != nil {
+		c.Config.Logger.Error().Err(err).
+			Str("spec", spec).
+			Str("format", format).
+			Msg("unable to write EAD export")
+	}
+}
+
 func EADMeta(w http.ResponseWriter, r *http.Request) {
 	spec := chi.URLParam(r, "spec")
 	if spec == "" {
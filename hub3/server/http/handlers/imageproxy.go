@@ -32,6 +32,7 @@ import (
 	"willnorris.com/go/imageproxy"
 
 	c "github.com/delving/hub3/config"
+	ikuzoproxy "github.com/delving/hub3/ikuzo/service/x/imageproxy"
 )
 
 const defaultMemorySize = 100
@@ -50,7 +51,6 @@ func RegisterImageProxy(r chi.Router) {
 	fileCache := diskCache(c.Config.ImageProxy.CacheDir)
 	cache := twotier.New(memCache, fileCache)
 	p = imageproxy.NewProxy(nil, cache)
-	// p.Whitelist = c.Config.ImageProxy.Whitelist
 	p.ScaleUp = c.Config.ImageProxy.ScaleUp
 
 	// WebResource & imageproxy configuration
@@ -74,6 +74,19 @@ func serveProxyImage(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, msg, http.StatusBadRequest)
 		return
 	}
+	if !ikuzoproxy.HostAllowed(req.URL.Hostname(), c.Config.ImageProxy.Whitelist) {
+		http.Error(w, fmt.Sprintf("remote host is not allowed: %s", req.URL.Hostname()), http.StatusForbidden)
+		return
+	}
+
+	if len(c.Config.ImageProxy.Referrer) != 0 {
+		ref, refErr := url.Parse(r.Referer())
+		if refErr != nil || !ikuzoproxy.HostAllowed(ref.Hostname(), c.Config.ImageProxy.Referrer) {
+			http.Error(w, "referrer is not allowed", http.StatusForbidden)
+			return
+		}
+	}
+
 	resp, err := p.Client.Get(req.String())
 	if err != nil {
 		msg := fmt.Sprintf("error fetching remote image: %v", err)
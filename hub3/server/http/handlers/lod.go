@@ -84,6 +84,14 @@ func RenderLODResource(w http.ResponseWriter, r *http.Request) {
 		log.Printf("Unable to find fragments")
 		return
 	}
+
+	for _, frag := range frags {
+		if fragments.IsLODRestricted(frag.GetMeta().GetSpec()) {
+			http.Error(w, "LOD access is disabled for this resource", http.StatusGone)
+			return
+		}
+	}
+
 	w.Header().Set("Content-Type", "text/n-triples")
 	var buffer bytes.Buffer
 	for _, frag := range frags {
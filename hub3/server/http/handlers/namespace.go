@@ -15,20 +15,55 @@
 package handlers
 
 import (
+	"log"
 	"net/http"
 
-	c "github.com/delving/hub3/config"
+	"github.com/delving/hub3/ikuzo/service/x/namespace"
 	"github.com/go-chi/chi"
 	"github.com/go-chi/render"
 )
 
+// nameSpaces is the namespace service that backs the legacy namespace API
+var nameSpaces *namespace.Service
+
+// SetNameSpaceService sets the namespace service that backs the legacy namespace API.
+func SetNameSpaceService(svc *namespace.Service) {
+	nameSpaces = svc
+}
+
+// RegisterNamespace registers the legacy namespace API. When no namespace
+// service is set, a service with the default namespaces is used.
 func RegisterNamespace(router chi.Router) {
+	if nameSpaces == nil {
+		svc, err := namespace.NewService(namespace.WithDefaults())
+		if err != nil {
+			log.Fatalf("Unable to create namespace service: %#v", err)
+		}
+
+		nameSpaces = svc
+	}
+
 	router.Get("/api/namespaces", listNameSpaces)
 }
 
-// listNameSpaces list all currently defined NameSpace object
+// listNameSpaces list all currently defined NameSpace object as a map from
+// prefix to base-URI. Temporary namespaces are not included.
 func listNameSpaces(w http.ResponseWriter, r *http.Request) {
-	render.JSON(w, r, c.Config.NameSpaceMap.ByPrefix())
-	//render.JSON(w, r, c.Config.NameSpaces)
-	return
+	namespaces, err := nameSpaces.List()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	byPrefix := make(map[string]string, len(namespaces))
+
+	for _, ns := range namespaces {
+		if ns.Temporary {
+			continue
+		}
+
+		byPrefix[ns.Prefix] = ns.Base
+	}
+
+	render.JSON(w, r, byPrefix)
 }
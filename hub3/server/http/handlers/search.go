@@ -84,7 +84,7 @@ func GetScrollResult(w http.ResponseWriter, r *http.Request) {
 //
 func ProcessSearchRequest(w http.ResponseWriter, r *http.Request, searchRequest *fragments.SearchRequest) {
 
-	s, fub, err := searchRequest.ElasticSearchService(index.ESClient())
+	s, fub, err := searchRequest.ElasticSearchService(r.Context(), index.ESClient())
 	if err != nil {
 		log.Printf("Unable to create Search Service: %v", err)
 		http.Error(w, err.Error(), http.StatusBadRequest)
@@ -411,7 +411,7 @@ func ProcessSearchRequest(w http.ResponseWriter, r *http.Request, searchRequest
 					http.Error(w, err.Error(), http.StatusBadRequest)
 					return
 				}
-				s, _, err := sr.ElasticSearchService(index.ESClient())
+				s, _, err := sr.ElasticSearchService(r.Context(), index.ESClient())
 				if err != nil {
 					log.Printf("Unable to create Search Service: %v", err)
 					http.Error(w, err.Error(), http.StatusBadRequest)
@@ -453,7 +453,7 @@ func ProcessSearchRequest(w http.ResponseWriter, r *http.Request, searchRequest
 					http.Error(w, err.Error(), http.StatusBadRequest)
 					return
 				}
-				s, _, err := sr.ElasticSearchService(index.ESClient())
+				s, _, err := sr.ElasticSearchService(r.Context(), index.ESClient())
 				if err != nil {
 					log.Printf("Unable to create Search Service: %v", err)
 					http.Error(w, err.Error(), http.StatusBadRequest)
@@ -499,7 +499,7 @@ func ProcessSearchRequest(w http.ResponseWriter, r *http.Request, searchRequest
 				http.Error(w, err.Error(), http.StatusBadRequest)
 				return
 			}
-			s, _, err := sr.ElasticSearchService(index.ESClient())
+			s, _, err := sr.ElasticSearchService(r.Context(), index.ESClient())
 			if err != nil {
 				log.Printf("Unable to create Search Service: %v", err)
 				http.Error(w, err.Error(), http.StatusBadRequest)
@@ -669,7 +669,7 @@ func ProcessSearchRequest(w http.ResponseWriter, r *http.Request, searchRequest
 func getSearchRecord(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	res, err := index.ESClient().Get().
-		Index(config.Config.ElasticSearch.GetIndexName()).
+		Index(fragments.IndexName(r.Context())).
 		Id(id).
 		Do(r.Context())
 	if err != nil {
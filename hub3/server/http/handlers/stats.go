@@ -22,6 +22,7 @@ import (
 	c "github.com/delving/hub3/config"
 	"github.com/delving/hub3/hub3/fragments"
 	"github.com/delving/hub3/hub3/index"
+	"github.com/delving/hub3/ikuzo/domain"
 	"github.com/go-chi/chi"
 	"github.com/go-chi/render"
 	elastic "github.com/olivere/elastic/v7"
@@ -53,17 +54,22 @@ func getResourceEntryStats(field string, r *http.Request) (*elastic.SearchResult
 	searchLabelAgg := elastic.NewNestedAggregation().Path("resources.entries")
 	searchLabelAgg = searchLabelAgg.SubAggregation(field, labelAgg)
 
+	orgID := c.Config.OrgID
+	if org, ok := domain.GetOrganization(r.Context()); ok {
+		orgID = string(org.ID)
+	}
+
 	q := elastic.NewBoolQuery()
 	q = q.Must(
 		elastic.NewTermQuery("meta.docType", fragments.FragmentGraphDocType),
-		elastic.NewTermQuery(c.Config.ElasticSearch.OrgIDKey, c.Config.OrgID),
+		elastic.NewTermQuery(c.Config.ElasticSearch.OrgIDKey, orgID),
 	)
 	spec := r.URL.Query().Get("spec")
 	if spec != "" {
 		q = q.Must(elastic.NewTermQuery(c.Config.ElasticSearch.SpecKey, spec))
 	}
 	res, err := index.ESClient().Search().
-		Index(c.Config.ElasticSearch.GetIndexName()).
+		Index(fragments.IndexName(r.Context())).
 		TrackTotalHits(c.Config.ElasticSearch.TrackTotalHits).
 		Query(q).
 		Size(0).
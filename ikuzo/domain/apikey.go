@@ -0,0 +1,143 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// errors
+var (
+	ErrInvalidRole    = errors.New("role must be reader, ingester or admin")
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	ErrAPIKeyExists   = errors.New("api key name already exists")
+)
+
+// Role determines what an authenticated client is allowed to do within its Organization.
+type Role string
+
+// Each role has the privileges of the roles before it.
+const (
+	RoleReader   Role = "reader"
+	RoleIngester Role = "ingester"
+	RoleAdmin    Role = "admin"
+)
+
+func (r Role) level() int {
+	switch r {
+	case RoleReader:
+		return 1
+	case RoleIngester:
+		return 2
+	case RoleAdmin:
+		return 3
+	}
+
+	return 0
+}
+
+// Valid returns ErrInvalidRole when the role is unknown.
+func (r Role) Valid() error {
+	if r.level() == 0 {
+		return ErrInvalidRole
+	}
+
+	return nil
+}
+
+// Allows returns true when the role has at least the privileges of the required role.
+func (r Role) Allows(required Role) bool {
+	return r.level() != 0 && r.level() >= required.level()
+}
+
+// APIKey grants a Role within an Organization. Only the hash of the key is stored.
+type APIKey struct {
+	Name    string    `json:"name"`
+	Role    Role      `json:"role"`
+	Hash    string    `json:"-"`
+	Created time.Time `json:"created"`
+}
+
+// HashAPIKey returns the hash of the key that is stored in the APIKey.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Matches returns true when the key hashes to the stored hash.
+func (k APIKey) Matches(key string) bool {
+	return subtle.ConstantTimeCompare([]byte(k.Hash), []byte(HashAPIKey(key))) == 1
+}
+
+// APIKeys are the API keys of an Organization.
+type APIKeys []APIKey
+
+// storedAPIKey is the database representation of an APIKey that includes the hash.
+type storedAPIKey struct {
+	Name    string    `json:"name"`
+	Role    Role      `json:"role"`
+	Hash    string    `json:"hash"`
+	Created time.Time `json:"created"`
+}
+
+// Value stores the APIKeys as JSON in the database.
+func (keys APIKeys) Value() (driver.Value, error) {
+	stored := make([]storedAPIKey, 0, len(keys))
+	for _, k := range keys {
+		stored = append(stored, storedAPIKey(k))
+	}
+
+	b, err := json.Marshal(stored)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(b), nil
+}
+
+// Scan reads the APIKeys from JSON in the database.
+func (keys *APIKeys) Scan(src interface{}) error {
+	var data []byte
+
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		return fmt.Errorf("unable to scan %T into APIKeys", src)
+	}
+
+	var stored []storedAPIKey
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return err
+	}
+
+	*keys = make(APIKeys, 0, len(stored))
+	for _, k := range stored {
+		*keys = append(*keys, APIKey(k))
+	}
+
+	return nil
+}
@@ -62,6 +62,10 @@ type NameSpace struct {
 	// Namespaces with prefix collissions will also be given a temporary prefix
 	Temporary bool `json:"temporary,omitempty"`
 
+	// Usage is the number of times the base-URI was seen in ingested RDF since
+	// the NameSpace was discovered. It is only tracked for Temporary namespaces.
+	Usage int `json:"usage,omitempty"`
+
 	// TODO(kiivihal): add function for custom hashing similar to isIdentRune
 }
 
@@ -129,7 +133,8 @@ func (ns *NameSpace) AddBase(base string) error {
 // Prefixes returns all namespace prefix linked to this NameSpace.
 // This includes the default Prefix and all alternative prefixes.
 func (ns *NameSpace) Prefixes() []string {
-	prefixes := append(ns.PrefixAlt, ns.Prefix)
+	// copy so sorting does not reorder PrefixAlt
+	prefixes := append(append([]string{}, ns.PrefixAlt...), ns.Prefix)
 	sort.Slice(prefixes, func(i, j int) bool {
 		return prefixes[i] < prefixes[j]
 	})
@@ -140,7 +145,8 @@ func (ns *NameSpace) Prefixes() []string {
 // BaseURIs returns all namespace base-URIs linked to this NameSpace.
 // This includes the default Base and all alternative base-URIs.
 func (ns *NameSpace) BaseURIs() []string {
-	baseURIs := append(ns.BaseAlt, ns.Base)
+	// copy so sorting does not reorder BaseAlt
+	baseURIs := append(append([]string{}, ns.BaseAlt...), ns.Base)
 	sort.Slice(baseURIs, func(i, j int) bool {
 		return baseURIs[i] < baseURIs[j]
 	})
@@ -15,7 +15,12 @@
 package domain
 
 import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
 	"unicode"
 )
 
@@ -27,6 +32,8 @@ var (
 	ErrIDCannotBeEmpty    = errors.New("empty string is not a valid identifier")
 	ErrIDExists           = errors.New("identifier already exists")
 	ErrOrgNotFound        = errors.New("organization not found")
+	ErrDomainExists       = errors.New("domain is already used by another organization")
+	ErrIndexNameExists    = errors.New("index name is already used by another organization")
 )
 
 var (
@@ -60,8 +67,108 @@ type OrganizationID string
 // Organization is a basic building block for storing information.
 // Everything that is stored by ikuzo must have an organization.ID as part of its metadata.
 type Organization struct {
-	ID          OrganizationID `json:"orgID"`
-	Description string         `json:"description,omitempty"`
+	ID          OrganizationID     `json:"orgID"`
+	Description string             `json:"description,omitempty"`
+	Config      OrganizationConfig `json:"config" gorm:"type:text"`
+	APIKeys     APIKeys            `json:"apiKeys,omitempty" gorm:"type:text"`
+}
+
+// OrganizationConfig contains the settings of an Organization.
+// Services fall back to the global configuration for empty values.
+type OrganizationConfig struct {
+	// Domains are the hostnames that are routed to the Organization.
+	Domains []string `json:"domains,omitempty"`
+	// IndexName is the base of the index aliases of the Organization.
+	IndexName string `json:"indexName,omitempty"`
+	// IndexTypes are the enabled index types: v1, v2 and fragments.
+	IndexTypes []string `json:"indexTypes,omitempty"`
+	// DefaultTags are added to each indexed record.
+	DefaultTags []string `json:"defaultTags,omitempty"`
+	// LODBaseURL is the scheme and host of the resource URIs.
+	LODBaseURL string `json:"lodBaseURL,omitempty"`
+	// PostHooks are the targets that receive the indexed records.
+	PostHooks []PostHookConfig `json:"postHooks,omitempty"`
+}
+
+// PostHookConfig configures a target that receives the indexed records.
+// The APIKey is the credential for the URL; the organization API redacts it.
+type PostHookConfig struct {
+	// Name is the type of posthook, e.g. 'ginger'.
+	Name        string   `json:"name"`
+	URL         string   `json:"url"`
+	APIKey      string   `json:"apiKey,omitempty"`
+	ExcludeSpec []string `json:"excludeSpec,omitempty"`
+}
+
+// IndexNames returns the index aliases of the Organization by index type.
+// An empty map is returned when no IndexName is set.
+func (cfg OrganizationConfig) IndexNames() map[string]string {
+	names := map[string]string{}
+
+	if cfg.IndexName == "" {
+		return names
+	}
+
+	base := strings.ToLower(cfg.IndexName)
+	names["v1"] = base + "v1"
+	names["v2"] = base + "v2"
+	names["fragments"] = base + "v2_frag"
+
+	return names
+}
+
+// HasDomain returns true when the host is one of the Domains.
+// The port of the host is ignored.
+func (cfg OrganizationConfig) HasDomain(host string) bool {
+	if i := strings.LastIndex(host, ":"); i != -1 && !strings.HasSuffix(host, "]") {
+		host = host[:i]
+	}
+
+	for _, domain := range cfg.Domains {
+		if strings.EqualFold(domain, host) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Value stores the OrganizationConfig as JSON in the database.
+func (cfg OrganizationConfig) Value() (driver.Value, error) {
+	b, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(b), nil
+}
+
+// Scan reads the OrganizationConfig from JSON in the database.
+func (cfg *OrganizationConfig) Scan(src interface{}) error {
+	switch data := src.(type) {
+	case nil:
+		return nil
+	case string:
+		return json.Unmarshal([]byte(data), cfg)
+	case []byte:
+		return json.Unmarshal(data, cfg)
+	}
+
+	return fmt.Errorf("unable to scan %T into OrganizationConfig", src)
+}
+
+type orgContextKey struct{}
+
+// SetOrganization returns a copy of the context that carries the Organization.
+func SetOrganization(ctx context.Context, org Organization) context.Context {
+	return context.WithValue(ctx, orgContextKey{}, org)
+}
+
+// GetOrganization returns the Organization from the context.
+// It returns false when the context carries no Organization.
+func GetOrganization(ctx context.Context) (Organization, bool) {
+	org, ok := ctx.Value(orgContextKey{}).(Organization)
+	return org, ok
 }
 
 // NewOrganizationID returns an OrganizationID and an error if the supplied input is invalid.
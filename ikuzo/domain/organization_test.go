@@ -15,7 +15,9 @@
 package domain
 
 import (
+	"context"
 	"errors"
+	"reflect"
 	"testing"
 )
 
@@ -123,3 +125,81 @@ func TestNewOrganizationID(t *testing.T) {
 		})
 	}
 }
+
+func TestOrganizationConfig_IndexNames(t *testing.T) {
+	got := OrganizationConfig{}.IndexNames()
+	if len(got) != 0 {
+		t.Errorf("IndexNames() = %v, want empty", got)
+	}
+
+	got = OrganizationConfig{IndexName: "Demo"}.IndexNames()
+	want := map[string]string{"v1": "demov1", "v2": "demov2", "fragments": "demov2_frag"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("IndexNames() = %v, want %v", got, want)
+	}
+}
+
+func TestOrganizationConfig_HasDomain(t *testing.T) {
+	cfg := OrganizationConfig{Domains: []string{"demo.example.org", "[::1]"}}
+
+	tests := []struct {
+		host string
+		want bool
+	}{
+		{"demo.example.org", true},
+		{"Demo.Example.org:3000", true},
+		{"[::1]", true},
+		{"example.org", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := cfg.HasDomain(tt.host); got != tt.want {
+			t.Errorf("HasDomain(%q) = %v, want %v", tt.host, got, tt.want)
+		}
+	}
+}
+
+func TestOrganizationConfig_Scan(t *testing.T) {
+	cfg := OrganizationConfig{
+		Domains:     []string{"demo.example.org"},
+		IndexName:   "demo",
+		DefaultTags: []string{"demo"},
+		PostHooks:   []PostHookConfig{{Name: "ginger", URL: "http://localhost"}},
+	}
+
+	v, err := cfg.Value()
+	if err != nil {
+		t.Fatalf("Value() error = %v", err)
+	}
+
+	for _, src := range []interface{}{v, []byte(v.(string))} {
+		var got OrganizationConfig
+		if err := got.Scan(src); err != nil {
+			t.Fatalf("Scan() error = %v", err)
+		}
+
+		if !reflect.DeepEqual(got, cfg) {
+			t.Errorf("Scan() = %v, want %v", got, cfg)
+		}
+	}
+
+	var got OrganizationConfig
+	if err := got.Scan(1); err == nil {
+		t.Errorf("Scan() expected error for unsupported type")
+	}
+}
+
+func TestGetOrganization(t *testing.T) {
+	ctx := context.Background()
+
+	if _, ok := GetOrganization(ctx); ok {
+		t.Errorf("GetOrganization() should not find organization in empty context")
+	}
+
+	org, ok := GetOrganization(SetOrganization(ctx, Organization{ID: "demo"}))
+	if !ok || org.ID != "demo" {
+		t.Errorf("GetOrganization() = %v, %v; want demo", org, ok)
+	}
+}
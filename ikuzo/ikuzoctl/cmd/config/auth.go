@@ -0,0 +1,89 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"github.com/delving/hub3/ikuzo"
+	"github.com/delving/hub3/ikuzo/domain"
+	"github.com/delving/hub3/ikuzo/service/x/auth"
+)
+
+type Auth struct {
+	// Enabled enforces authentication on the endpoints that modify data
+	Enabled bool
+	// JWKSFile is a local JSON Web Key Set. When set JWT bearer tokens are accepted.
+	JWKSFile string
+	// Issuer and Audience are the required 'iss' and 'aud' claims of a JWT
+	Issuer   string
+	Audience string
+	// Keys are API keys that are not stored in an organization, e.g. the bootstrap admin key
+	Keys []StaticKey
+}
+
+type StaticKey struct {
+	OrgID string
+	Role  string
+	Key   string
+}
+
+// NewService returns the auth.Service from the configuration.
+// It returns nil when authentication is not enabled.
+func (a *Auth) NewService(cfg *Config) (*auth.Service, error) {
+	if !a.Enabled {
+		return nil, nil
+	}
+
+	orgs, err := cfg.getOrganizationService()
+	if err != nil {
+		return nil, err
+	}
+
+	options := []auth.Option{
+		auth.SetOrganizationService(orgs),
+		auth.SetIssuer(a.Issuer),
+		auth.SetAudience(a.Audience),
+		auth.SetDefaultOrganization(cfg.OrgID),
+	}
+
+	if cfg.ImageProxy.Enabled && cfg.ImageProxy.ProxyPrefix != "" {
+		options = append(options, auth.AddRules(auth.ImageProxyPurgeRule(cfg.ImageProxy.ProxyPrefix)))
+	}
+
+	if a.JWKSFile != "" {
+		options = append(options, auth.SetJWKSFile(a.JWKSFile))
+	}
+
+	for _, key := range a.Keys {
+		orgID := key.OrgID
+		if orgID == "" {
+			orgID = cfg.OrgID
+		}
+
+		options = append(options, auth.SetStaticKey(orgID, domain.Role(key.Role), key.Key))
+	}
+
+	return auth.NewService(options...)
+}
+
+func (a *Auth) AddOptions(cfg *Config) error {
+	svc, err := a.NewService(cfg)
+	if err != nil || svc == nil {
+		return err
+	}
+
+	cfg.options = append(cfg.options, ikuzo.SetAuthService(svc))
+
+	return nil
+}
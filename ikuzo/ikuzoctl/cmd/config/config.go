@@ -16,8 +16,16 @@ package config
 
 import (
 	"github.com/delving/hub3/ikuzo"
+	"github.com/delving/hub3/ikuzo/domain"
 	"github.com/delving/hub3/ikuzo/logger"
+	"github.com/delving/hub3/ikuzo/service/organization"
+	"github.com/delving/hub3/ikuzo/service/x/dataset"
 	"github.com/delving/hub3/ikuzo/service/x/index"
+	"github.com/delving/hub3/ikuzo/service/x/namespace"
+	"github.com/delving/hub3/ikuzo/service/x/revision"
+	"github.com/delving/hub3/ikuzo/storage/memory"
+	storage "github.com/delving/hub3/ikuzo/storage/x/gorm"
+	"github.com/jinzhu/gorm"
 	"github.com/spf13/viper"
 )
 
@@ -31,14 +39,21 @@ type Config struct {
 	DataNodeURL       string `json:"dataNodeURL"`
 	ElasticSearch     `json:"elasticSearch"`
 	HTTP              `json:"http"`
+	GRPC              `json:"grpc"`
 	TimeRevisionStore `json:"timeRevisionStore"`
 	Logging           `json:"logging"`
 	Nats              `json:"nats"`
 	EAD               `json:"ead"`
 	DB                `json:"db"`
 	ImageProxy        `json:"imageProxy"`
+	LOD               `json:"lod"`
+	Sparql            `json:"sparql"`
+	Auth              `json:"auth"`
 	PostHooks         []PostHook `json:"posthooks"`
 	options           []ikuzo.Option
+	orgs              *organization.Service
+	namespaces        *namespace.Service
+	revisions         *revision.Service
 	logger            logger.CustomLogger
 }
 
@@ -53,9 +68,13 @@ func (cfg *Config) Options(cfgOptions ...ConfigOption) ([]ikuzo.Option, error) {
 		cfgOptions = []ConfigOption{
 			&cfg.ElasticSearch, // elastic first because others could depend on the client
 			&cfg.HTTP,
+			&cfg.GRPC,
 			&cfg.TimeRevisionStore,
 			&cfg.EAD,
 			&cfg.ImageProxy,
+			&cfg.LOD,
+			&cfg.Sparql,
+			&cfg.Auth,
 			&cfg.Logging,
 		}
 	}
@@ -81,6 +100,7 @@ func SetViperDefaults() {
 	// setting defaults
 	viper.SetDefault("HTTP.port", 3001)
 	viper.SetDefault("TimeRevisionStore.dataPath", "/tmp/trs")
+	viper.SetDefault("ImageProxy.memoryCache", "memory:500:1h")
 }
 
 func (cfg *Config) GetIndexService() (*index.Service, error) {
@@ -104,25 +124,148 @@ func (cfg *Config) GetIndexService() (*index.Service, error) {
 	return is, nil
 }
 
+// getOrganizationService returns the organization service. It is created on
+// first use, so other config sections can depend on it. The organizations are
+// stored in the database when it is configured and in memory otherwise.
+func (cfg *Config) getOrganizationService() (*organization.Service, error) {
+	if cfg.orgs != nil {
+		return cfg.orgs, nil
+	}
+
+	var store organization.Store
+
+	switch cfg.DB.Type {
+	case "":
+		cfg.logger.Warn().
+			Str("cmp", "config").
+			Msg("no database configured; organizations and datasets are not persisted")
+
+		store = memory.NewOrganizationStore()
+	default:
+		db, err := cfg.getDB()
+		if err != nil {
+			return nil, err
+		}
+
+		store, err = storage.NewOrganizationStore(db)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	orgs, err := organization.NewService(
+		store,
+		organization.SetDefaultOrganizationID(domain.OrganizationID(cfg.OrgID)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.orgs = orgs
+
+	return orgs, nil
+}
+
+// GetNameSpaceService returns the namespace service. It is created on first
+// use and stores the namespaces in the database when it is configured.
+func (cfg *Config) GetNameSpaceService() (*namespace.Service, error) {
+	if cfg.namespaces != nil {
+		return cfg.namespaces, nil
+	}
+
+	options := []namespace.ServiceOptionFunc{namespace.WithDefaults()}
+
+	if cfg.DB.Type != "" {
+		db, err := cfg.getDB()
+		if err != nil {
+			return nil, err
+		}
+
+		store, err := storage.NewNameSpaceStore(db)
+		if err != nil {
+			return nil, err
+		}
+
+		options = append(options, namespace.SetStore(store))
+	}
+
+	ns, err := namespace.NewService(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.namespaces = ns
+
+	return ns, nil
+}
+
+// getRevisionService returns the revision.Service when the TimeRevisionStore is
+// enabled. Otherwise nil is returned.
+func (cfg *Config) getRevisionService() (*revision.Service, error) {
+	if cfg.revisions != nil || !cfg.TimeRevisionStore.Enabled || cfg.TimeRevisionStore.DataPath == "" {
+		return cfg.revisions, nil
+	}
+
+	svc, err := revision.NewService(cfg.TimeRevisionStore.DataPath)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.revisions = svc
+
+	return svc, nil
+}
+
+// getDB returns the database connection and connects on first use.
+func (cfg *Config) getDB() (*gorm.DB, error) {
+	if db, err := cfg.DB.getDB(); err == nil {
+		return db, nil
+	}
+
+	if err := cfg.DB.AddOptions(cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg.DB.getDB()
+}
+
+// defaultOptions wires the organization, dataset and namespace services.
 func (cfg *Config) defaultOptions() error {
-	// db, err := cfg.DB.getDB()
-	// if err != nil {
-	// return err
-	// }
-
-	// // Organization
-	// orgStore, err := gorm.NewOrganizationStore(db)
-	// if err != nil {
-	// return err
-	// }
-
-	// org, err := organization.NewService(orgStore)
-	// if err != nil {
-	// return err
-	// }
-
-	// cfg.options = append(cfg.options, ikuzo.SetOrganisationService(org))
-	// cfg.logger.Debug().Msg("is this called")
+	org, err := cfg.getOrganizationService()
+	if err != nil {
+		return err
+	}
+
+	var datasetStore dataset.Store = memory.NewDataSetStore()
+
+	if cfg.DB.Type != "" {
+		db, dbErr := cfg.getDB()
+		if dbErr != nil {
+			return dbErr
+		}
+
+		datasetStore, err = storage.NewDataSetStore(db)
+		if err != nil {
+			return err
+		}
+	}
+
+	datasets, err := dataset.NewService(datasetStore)
+	if err != nil {
+		return err
+	}
+
+	namespaces, err := cfg.GetNameSpaceService()
+	if err != nil {
+		return err
+	}
+
+	cfg.options = append(
+		cfg.options,
+		ikuzo.SetOrganisationService(org),
+		ikuzo.SetDataSetService(datasets),
+		ikuzo.SetNameSpaceService(namespaces),
+	)
 
 	return nil
 }
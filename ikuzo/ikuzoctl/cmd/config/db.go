@@ -24,9 +24,9 @@ import (
 )
 
 type DB struct {
-	// supported types are "sqlite3" "postgres"
+	// supported types are "postgres"
 	Type string
-	// go sql compatible connection string, e.g. "/tmp/test.db" for sqlit3 or
+	// go sql compatible connection string, e.g.
 	// "host=myhost port=myport user=hub3 dbname=hub3 password=mypassword"
 	Connect string
 	// database
@@ -17,15 +17,87 @@ package config
 import (
 	"expvar"
 	"fmt"
+	"os"
 
+	eadHub3 "github.com/delving/hub3/hub3/ead"
 	"github.com/delving/hub3/ikuzo"
 	"github.com/delving/hub3/ikuzo/service/x/ead"
 )
 
 type EAD struct {
 	CacheDir string `json:"cacheDir"`
-	Metrics  bool   `json:"metrics"`
-	Workers  int    `json:"workers"`
+	// AuthorityDir is where the EAC-CPF records are stored. Defaults to the cacheDir with the '-authorities' suffix
+	AuthorityDir string `json:"authorityDir"`
+	Metrics      bool   `json:"metrics"`
+	Workers      int    `json:"workers"`
+	// GeneratePDF renders a finding aid PDF each time an EAD is processed
+	GeneratePDF bool `json:"generatePDF"`
+	// RequiredFields are the c-level fields that must be present per orgID
+	RequiredFields map[string][]string `json:"requiredFields"`
+	// EntityDictionary is the optional path to the CSV dictionary for the entity extraction
+	EntityDictionary string `json:"entityDictionary"`
+	// EntityVocabulary is the path to the CSV vocabulary the extracted entities are reconciled with
+	EntityVocabulary string `json:"entityVocabulary"`
+}
+
+// EntityExtractor returns the extractor of the named entities in the c-level
+// descriptions. Entities are always extracted from the EAD markup and date
+// patterns; the EntityDictionary adds the terms that are not marked up.
+func (e EAD) EntityExtractor() (*eadHub3.EntityExtractor, error) {
+	dictionary := map[string]eadHub3.NLPType{}
+
+	if e.EntityDictionary != "" {
+		f, err := os.Open(e.EntityDictionary)
+		if err != nil {
+			return nil, fmt.Errorf("unable to open entity dictionary; %w", err)
+		}
+		defer f.Close()
+
+		dictionary, err = eadHub3.ReadDictionary(f)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read entity dictionary; %w", err)
+		}
+	}
+
+	return eadHub3.NewEntityExtractor(dictionary), nil
+}
+
+// ExtractionOptions returns the entity extraction options for the EAD service.
+func (e EAD) ExtractionOptions() ([]ead.Option, error) {
+	extractor, err := e.EntityExtractor()
+	if err != nil {
+		return nil, err
+	}
+
+	options := []ead.Option{ead.SetEntityExtractor(extractor)}
+
+	if e.EntityVocabulary == "" {
+		return options, nil
+	}
+
+	v, err := os.Open(e.EntityVocabulary)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open entity vocabulary; %w", err)
+	}
+	defer v.Close()
+
+	reconciler, err := eadHub3.ReadMapReconciler(v)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read entity vocabulary; %w", err)
+	}
+
+	return append(options, ead.SetReconciler(reconciler)), nil
+}
+
+// ValidationOptions returns the validation options for the EAD service.
+func (e EAD) ValidationOptions() []ead.Option {
+	options := []ead.Option{}
+
+	for orgID, fields := range e.RequiredFields {
+		options = append(options, ead.SetRequiredFields(orgID, fields...))
+	}
+
+	return options
 }
 
 func (e EAD) NewService(cfg *Config) (*ead.Service, error) {
@@ -34,11 +106,22 @@ func (e EAD) NewService(cfg *Config) (*ead.Service, error) {
 		return nil, err
 	}
 
-	svc, err := ead.NewService(
+	options := []ead.Option{
 		ead.SetIndexService(is),
 		ead.SetDataDir(e.CacheDir),
+		ead.SetAuthorityDir(e.AuthorityDir),
 		ead.SetWorkers(e.Workers),
-	)
+		ead.SetGeneratePDF(e.GeneratePDF),
+	}
+
+	extractionOptions, err := e.ExtractionOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	options = append(options, e.ValidationOptions()...)
+
+	svc, err := ead.NewService(append(options, extractionOptions...)...)
 	if err != nil {
 		return nil, err
 	}
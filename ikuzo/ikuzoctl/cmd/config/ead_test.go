@@ -0,0 +1,64 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	eadHub3 "github.com/delving/hub3/hub3/ead"
+	"github.com/matryer/is"
+)
+
+// nolint:gocritic
+func TestEAD_EntityExtractor(t *testing.T) {
+	is := is.New(t)
+
+	raw := []byte(`Brief van <persname>Jan Pietersz. Coen</persname> uit Batavia, 1 januari 1620`)
+
+	// the default settings extract the entities from the markup and the dates
+	var e EAD
+
+	options, err := e.ExtractionOptions()
+	is.NoErr(err)
+	is.Equal(len(options), 1)
+
+	extractor, err := e.EntityExtractor()
+	is.NoErr(err)
+	is.Equal(extractor.Extract(raw), []eadHub3.NLPToken{
+		{Type: eadHub3.Person, Text: "Jan Pietersz. Coen"},
+		{Type: eadHub3.DateText, Text: "1 januari 1620"},
+	})
+
+	// the dictionary adds the terms that are not marked up
+	dir, err := ioutil.TempDir("", "ead-config-*")
+	is.NoErr(err)
+
+	defer os.RemoveAll(dir)
+
+	e.EntityDictionary = filepath.Join(dir, "dictionary.csv")
+	is.NoErr(ioutil.WriteFile(e.EntityDictionary, []byte("Batavia,place\n"), os.ModePerm))
+
+	extractor, err = e.EntityExtractor()
+	is.NoErr(err)
+	is.Equal(len(extractor.Extract(raw)), 3)
+
+	e.EntityDictionary = filepath.Join(dir, "unknown.csv")
+
+	_, err = e.ExtractionOptions()
+	is.True(err != nil)
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/cenkalti/backoff/v4"
 	"github.com/delving/hub3/hub3/models"
 	"github.com/delving/hub3/ikuzo"
+	"github.com/delving/hub3/ikuzo/domain"
 	"github.com/delving/hub3/ikuzo/logger"
 	"github.com/delving/hub3/ikuzo/service/x/bulk"
 	"github.com/delving/hub3/ikuzo/service/x/index"
@@ -71,6 +72,8 @@ type ElasticSearch struct {
 	IndexTypes []string
 	// use FastHTTP transport for communication with the ElasticSearch cluster
 	FastHTTP bool
+	// DiscoverNameSpaces registers unknown namespaces of ingested RDF for review
+	DiscoverNameSpaces bool
 }
 
 func (e *ElasticSearch) normalizedIndexName() string {
@@ -112,18 +115,46 @@ func (e *ElasticSearch) AddOptions(cfg *Config) error {
 		return fmt.Errorf("unable to create posthook service; %w", phErr)
 	}
 
-	bulkSvc, bulkErr := bulk.NewService(
+	orgs, orgErr := cfg.getOrganizationService()
+	if orgErr != nil {
+		return fmt.Errorf("unable to create organization service; %w", orgErr)
+	}
+
+	bulkOptions := []bulk.Option{
 		bulk.SetIndexService(is),
 		bulk.SetIndexTypes(e.IndexTypes...),
 		bulk.SetPostHookService(postHooks...),
-	)
+		bulk.SetPostHookFactory(newPostHook),
+		bulk.SetOrganizationService(orgs),
+	}
+
+	if e.DiscoverNameSpaces {
+		ns, nsErr := cfg.GetNameSpaceService()
+		if nsErr != nil {
+			return fmt.Errorf("unable to create namespace service; %w", nsErr)
+		}
+
+		bulkOptions = append(bulkOptions, bulk.SetNameSpaceService(ns))
+	}
+
+	revisions, revErr := cfg.getRevisionService()
+	if revErr != nil {
+		return fmt.Errorf("unable to start revision store from config: %w", revErr)
+	}
+
+	if revisions != nil {
+		bulkOptions = append(bulkOptions, bulk.SetRevisionService(revisions))
+	}
+
+	bulkSvc, bulkErr := bulk.NewService(bulkOptions...)
 	if bulkErr != nil {
-		return fmt.Errorf("unable to create bulk service; %w", isErr)
+		return fmt.Errorf("unable to create bulk service; %w", bulkErr)
 	}
 
 	cfg.options = append(
 		cfg.options,
 		ikuzo.SetBulkService(bulkSvc),
+		ikuzo.SetShutdownHook("bulk", bulkSvc),
 		ikuzo.SetShutdownHook("elasticsearch", is),
 	)
 
@@ -132,6 +163,16 @@ func (e *ElasticSearch) AddOptions(cfg *Config) error {
 		return err
 	}
 
+	organizations, err := orgs.Filter(context.Background())
+	if err != nil {
+		return fmt.Errorf("unable to list organizations; %w", err)
+	}
+
+	_, err = e.CreateOrganizationMappings(client, organizations...)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -151,16 +192,51 @@ func (e *ElasticSearch) ResetAll(w http.ResponseWriter, r *http.Request) {
 }
 
 func (e *ElasticSearch) CreateDefaultMappings(es *elasticsearch.Client, withAlias bool, withReset bool) ([]string, error) {
+	return e.createMappings(es, e.normalizedIndexName(), e.IndexTypes, withAlias, withReset)
+}
+
+// CreateOrganizationMappings creates the indices of the organizations that have
+// their own IndexName. Existing indices are left untouched.
+func (e *ElasticSearch) CreateOrganizationMappings(es *elasticsearch.Client, orgs ...domain.Organization) ([]string, error) {
+	indexNames := []string{}
+
+	for _, org := range orgs {
+		if org.Config.IndexName == "" {
+			continue
+		}
+
+		indexTypes := org.Config.IndexTypes
+		if len(indexTypes) == 0 {
+			indexTypes = e.IndexTypes
+		}
+
+		names, err := e.createMappings(es, strings.ToLower(org.Config.IndexName), indexTypes, true, false)
+		if err != nil {
+			return indexNames, fmt.Errorf("unable to create indices for %s; %w", org.ID, err)
+		}
+
+		indexNames = append(indexNames, names...)
+	}
+
+	return indexNames, nil
+}
+
+func (e *ElasticSearch) createMappings(
+	es *elasticsearch.Client,
+	base string,
+	indexTypes []string,
+	withAlias, withReset bool,
+) ([]string, error) {
 	mappings := map[string]func(shards, replicas int) string{}
 
-	for _, indexType := range e.IndexTypes {
+	for _, indexType := range indexTypes {
 		switch indexType {
 		case "v1":
-			mappings[fmt.Sprintf("%sv1", e.normalizedIndexName())] = mapping.V1ESMapping
+			mappings[fmt.Sprintf("%sv1", base)] = mapping.V1ESMapping
 		case "v2":
-			mappings[fmt.Sprintf("%sv2", e.normalizedIndexName())] = mapping.V2ESMapping
+			mappings[fmt.Sprintf("%sv2", base)] = mapping.V2ESMapping
 		case "fragments":
-			mappings[fmt.Sprintf("%sv2_frag", e.normalizedIndexName())] = mapping.FragmentESMapping
+			mappings[fmt.Sprintf("%sv2_frag", base)] = mapping.FragmentESMapping
 		default:
 			log.Warn().Msgf("ignoring unknown indexType %s during mapping creation", indexType)
 		}
@@ -13,3 +13,18 @@
 // limitations under the License.
 
 package config
+
+import "github.com/delving/hub3/ikuzo"
+
+type GRPC struct {
+	// Port of the gRPC server. The server is not started when the port is 0.
+	Port int `json:"port"`
+}
+
+func (g *GRPC) AddOptions(cfg *Config) error {
+	if g.Port != 0 {
+		cfg.options = append(cfg.options, ikuzo.SetGRPCPort(g.Port))
+	}
+
+	return nil
+}
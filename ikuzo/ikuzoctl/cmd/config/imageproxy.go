@@ -15,6 +15,10 @@
 package config
 
 import (
+	"expvar"
+	"fmt"
+	"time"
+
 	"github.com/delving/hub3/ikuzo"
 	"github.com/delving/hub3/ikuzo/service/x/imageproxy"
 )
@@ -24,6 +28,26 @@ type ImageProxy struct {
 	CacheDir    string
 	ProxyPrefix string
 	Timeout     int
+	ScaleUp     bool
+	// Deepzoom enables the IIIF Image API tile server at /{proxyPrefix}/iiif/{id}.
+	Deepzoom bool
+	// MemoryCache is the memory tier of the cache in the form "memory:maxSizeMB:maxAge".
+	MemoryCache string
+	// MaxCacheSize is the maximum size of the disk cache in MB. 0 is unbounded.
+	MaxCacheSize int64
+	// CacheTTL is how long images are kept in the disk cache, e.g. "720h". Empty is no expiry.
+	CacheTTL string
+	// EvictionInterval is how often the disk cache is evicted. default: "10m"
+	EvictionInterval string
+	// RevalidateAfter is when cached images are revalidated if the remote server sets no max-age. default: "24h"
+	RevalidateAfter string
+	// Whitelist are the remote hosts images can be proxied from. '*.' allows all subdomains.
+	Whitelist []string
+	// Referrer are the hosts that are allowed to refer to the imageproxy.
+	Referrer []string
+	// SignatureKey requires requests to be signed with an HMAC of the source URL.
+	SignatureKey string
+	Metrics      bool
 }
 
 func (ip *ImageProxy) AddOptions(cfg *Config) error {
@@ -31,17 +55,60 @@ func (ip *ImageProxy) AddOptions(cfg *Config) error {
 		return nil
 	}
 
-	s, err := imageproxy.NewService(
+	options := []imageproxy.Option{
 		imageproxy.SetCacheDir(ip.CacheDir),
 		imageproxy.SetProxyPrefix(ip.ProxyPrefix),
 		imageproxy.SetTimeout(ip.Timeout),
-	)
+		imageproxy.SetScaleUp(ip.ScaleUp),
+		imageproxy.SetDeepzoom(ip.Deepzoom),
+		imageproxy.SetAllowedHosts(ip.Whitelist...),
+		imageproxy.SetAllowedReferrers(ip.Referrer...),
+		imageproxy.SetSignatureKey(ip.SignatureKey),
+		imageproxy.SetMemoryCache(ip.MemoryCache),
+		imageproxy.SetMaxCacheSize(ip.MaxCacheSize),
+	}
+
+	if ip.CacheTTL != "" {
+		ttl, err := time.ParseDuration(ip.CacheTTL)
+		if err != nil {
+			return fmt.Errorf("invalid imageproxy cacheTTL; %w", err)
+		}
 
+		options = append(options, imageproxy.SetCacheTTL(ttl))
+	}
+
+	if ip.EvictionInterval != "" {
+		interval, err := time.ParseDuration(ip.EvictionInterval)
+		if err != nil {
+			return fmt.Errorf("invalid imageproxy evictionInterval; %w", err)
+		}
+
+		options = append(options, imageproxy.SetEvictionInterval(interval))
+	}
+
+	if ip.RevalidateAfter != "" {
+		revalidate, err := time.ParseDuration(ip.RevalidateAfter)
+		if err != nil {
+			return fmt.Errorf("invalid imageproxy revalidateAfter; %w", err)
+		}
+
+		options = append(options, imageproxy.SetRevalidateAfter(revalidate))
+	}
+
+	s, err := imageproxy.NewService(options...)
 	if err != nil {
 		return err
 	}
 
-	cfg.options = append(cfg.options, ikuzo.SetImageProxyService(s))
+	if ip.Metrics {
+		expvar.Publish("hub3-imageproxy-service", expvar.Func(func() interface{} { m := s.Metrics(); return m }))
+	}
+
+	cfg.options = append(
+		cfg.options,
+		ikuzo.SetImageProxyService(s),
+		ikuzo.SetShutdownHook("imageproxy", s),
+	)
 
 	return nil
 }
@@ -0,0 +1,98 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/delving/hub3/ikuzo"
+	"github.com/delving/hub3/ikuzo/service/x/lodresolver"
+)
+
+type LOD struct {
+	Enabled bool
+	// BaseURL is the scheme and host of the resource URIs. Empty uses the request host.
+	BaseURL string
+	// Resource, RDF and HTML are the routing points. default: resource, data and page
+	Resource string
+	RDF      string
+	HTML     string
+	// SingleEndpoint is a regular expression for the first path element of resources
+	// that are resolved from a single endpoint, e.g. 'NL-.*'.
+	SingleEndpoint string
+	// RedirectRegex converts subject URIs to external page views in the form 'regex replacement'.
+	RedirectRegex string
+	// Store is where resources are resolved from: 'fragments' (default) or 'sparql'.
+	Store string
+	// SparqlEndpoint is the SPARQL query endpoint of the triple store.
+	SparqlEndpoint string
+	// ViewConfigDir is a directory with DataSetConfig JSON files whose
+	// DetailViewConfig drives the HTML view of resources with the same type.
+	ViewConfigDir string
+}
+
+func (lod *LOD) AddOptions(cfg *Config) error {
+	if !lod.Enabled {
+		return nil
+	}
+
+	var store lodresolver.Store
+
+	switch lod.Store {
+	case "", "fragments":
+		// the fragment index is queried with the legacy elasticsearch client
+		store = lodresolver.NewFragmentStore(nil)
+	case "sparql":
+		if lod.SparqlEndpoint == "" {
+			return fmt.Errorf("lod sparqlEndpoint is required for the sparql store")
+		}
+
+		store = lodresolver.NewSparqlStore(lod.SparqlEndpoint, nil)
+	default:
+		return fmt.Errorf("unsupported lod store: %s", lod.Store)
+	}
+
+	ns, err := cfg.GetNameSpaceService()
+	if err != nil {
+		return err
+	}
+
+	options := []lodresolver.Option{
+		lodresolver.SetStore(store),
+		lodresolver.SetBaseURL(lod.BaseURL),
+		lodresolver.SetRoutingPoints(lod.Resource, lod.RDF, lod.HTML),
+		lodresolver.SetSingleEndpoint(lod.SingleEndpoint),
+		lodresolver.SetHTMLRedirect(lod.RedirectRegex),
+		lodresolver.SetNameSpaceService(ns),
+	}
+
+	if lod.ViewConfigDir != "" {
+		configs, loadErr := lodresolver.LoadViewConfigs(lod.ViewConfigDir)
+		if loadErr != nil {
+			return loadErr
+		}
+
+		options = append(options, lodresolver.SetViewConfigs(configs...))
+	}
+
+	svc, err := lodresolver.NewService(options...)
+	if err != nil {
+		return err
+	}
+
+	cfg.options = append(cfg.options, ikuzo.SetLODResolver(svc))
+
+	return nil
+}
@@ -1,6 +1,9 @@
 package config
 
 import (
+	"fmt"
+
+	"github.com/delving/hub3/ikuzo/domain"
 	"github.com/delving/hub3/ikuzo/service/x/bulk"
 	"github.com/delving/hub3/ikuzo/storage/x/ginger"
 )
@@ -32,3 +35,17 @@ func (cfg *Config) getPostHookServices() ([]bulk.PostHookService, error) {
 
 	return svc, nil
 }
+
+// newPostHook creates the posthooks that are configured in an organization.
+func newPostHook(orgID string, cfg domain.PostHookConfig) (bulk.PostHookService, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("url is required for posthook %s", cfg.Name)
+	}
+
+	switch cfg.Name {
+	case "ginger":
+		return ginger.NewPostHook(orgID, cfg.URL, cfg.APIKey, cfg.ExcludeSpec...), nil
+	default:
+		return nil, fmt.Errorf("unsupported posthook: %s", cfg.Name)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/delving/hub3/ikuzo"
+	"github.com/delving/hub3/ikuzo/service/x/sparql"
+)
+
+type Sparql struct {
+	Enabled bool
+	// Endpoint is the SPARQL query endpoint of the triple store.
+	Endpoint string
+	// Timeout is the maximum duration of a query in seconds. default: 10
+	Timeout int
+	// DefaultLimit is the LIMIT of queries without one. default: 25
+	DefaultLimit int
+	// MaxLimit is the maximum LIMIT of a query. default: 1000
+	MaxLimit int
+	// MaxOffset is the maximum OFFSET of a query. 0 is unbounded
+	MaxOffset int
+	// CacheTTL is how long query results are cached, e.g. "5m". "0" disables the cache. default: "1m"
+	CacheTTL string
+	// CacheSize is the size of the result cache in MB. default: 50
+	CacheSize int64
+}
+
+func (sp *Sparql) AddOptions(cfg *Config) error {
+	if !sp.Enabled {
+		return nil
+	}
+
+	options := []sparql.Option{
+		sparql.SetEndpoint(sp.Endpoint),
+	}
+
+	if sp.Timeout > 0 {
+		options = append(options, sparql.SetTimeout(time.Duration(sp.Timeout)*time.Second))
+	}
+
+	if sp.DefaultLimit > 0 || sp.MaxLimit > 0 || sp.MaxOffset > 0 {
+		options = append(options, sparql.SetLimits(sp.DefaultLimit, sp.MaxLimit, sp.MaxOffset))
+	}
+
+	if sp.CacheTTL != "" {
+		ttl, err := time.ParseDuration(sp.CacheTTL)
+		if err != nil {
+			return fmt.Errorf("invalid sparql cacheTTL; %w", err)
+		}
+
+		options = append(options, sparql.SetCache(ttl, sp.CacheSize))
+	}
+
+	svc, err := sparql.NewService(options...)
+	if err != nil {
+		return err
+	}
+
+	cfg.options = append(cfg.options, ikuzo.SetSparqlProxy(svc))
+
+	return nil
+}
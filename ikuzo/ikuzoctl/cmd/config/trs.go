@@ -18,7 +18,6 @@ import (
 	"fmt"
 
 	"github.com/delving/hub3/ikuzo"
-	"github.com/delving/hub3/ikuzo/service/x/revision"
 )
 
 type TimeRevisionStore struct {
@@ -27,12 +26,12 @@ type TimeRevisionStore struct {
 }
 
 func (trs *TimeRevisionStore) AddOptions(cfg *Config) error {
-	if trs.Enabled && trs.DataPath != "" {
-		svc, err := revision.NewService(trs.DataPath)
-		if err != nil {
-			return fmt.Errorf("unable to start revision store from config: %w", err)
-		}
+	svc, err := cfg.getRevisionService()
+	if err != nil {
+		return fmt.Errorf("unable to start revision store from config: %w", err)
+	}
 
+	if svc != nil {
 		cfg.options = append(
 			cfg.options,
 			ikuzo.SetRevisionService(svc),
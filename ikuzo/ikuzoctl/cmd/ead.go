@@ -0,0 +1,97 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/delving/hub3/hub3/ead"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+// eadCmd represents the ead command
+var eadCmd = &cobra.Command{
+	Use:   "ead",
+	Short: "Tools for working with EAD finding aids",
+}
+
+// eadValidateCmd represents the ead validate command
+var eadValidateCmd = &cobra.Command{
+	Use:   "validate [path to EAD]",
+	Short: "validate an EAD before submitting it for processing",
+	Long: `This command runs the structural and business-rule checks on an EAD
+	and prints the validation report as JSON.
+
+	The command exits with status 1 when the EAD is not valid.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		valid, err := validateEAD(args[0])
+		if err != nil {
+			log.Fatal().Err(err).Msg("unable to validate EAD")
+		}
+
+		if !valid {
+			os.Exit(1)
+		}
+	},
+}
+
+var (
+	eadOrgID    string
+	eadDaoCheck bool
+)
+
+// nolint:gochecknoinits
+func init() {
+	rootCmd.AddCommand(eadCmd)
+	eadCmd.AddCommand(eadValidateCmd)
+
+	eadValidateCmd.Flags().StringVarP(&eadOrgID, "orgID", "", "", "orgID used to select the required-field rules")
+	eadValidateCmd.Flags().BoolVarP(&eadDaoCheck, "daoCheck", "", false, "check if the dao links are reachable")
+}
+
+func validateEAD(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	if eadOrgID == "" {
+		eadOrgID = cfg.OrgID
+	}
+
+	report, err := ead.ValidateEAD(context.Background(), f, &ead.ValidationConfig{
+		OrgID:          eadOrgID,
+		RequiredFields: cfg.EAD.RequiredFields[eadOrgID],
+		CheckDaoLinks:  eadDaoCheck,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	b, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return false, err
+	}
+
+	fmt.Printf("%s\n", b)
+
+	return report.Valid, nil
+}
@@ -23,10 +23,15 @@ import (
 	"github.com/delving/hub3/config"
 	"github.com/delving/hub3/ikuzo/logger"
 	"github.com/delving/hub3/ikuzo/service/organization"
+	"github.com/delving/hub3/ikuzo/service/x/auth"
 	"github.com/delving/hub3/ikuzo/service/x/bulk"
+	"github.com/delving/hub3/ikuzo/service/x/dataset"
 	"github.com/delving/hub3/ikuzo/service/x/ead"
 	"github.com/delving/hub3/ikuzo/service/x/imageproxy"
+	"github.com/delving/hub3/ikuzo/service/x/lodresolver"
+	"github.com/delving/hub3/ikuzo/service/x/namespace"
 	"github.com/delving/hub3/ikuzo/service/x/revision"
+	"github.com/delving/hub3/ikuzo/service/x/sparql"
 	"github.com/delving/hub3/ikuzo/storage/x/elasticsearch"
 	"github.com/go-chi/chi"
 )
@@ -58,6 +63,16 @@ func SetMetricsPort(port int) Option {
 	}
 }
 
+// SetGRPCPort sets the TCP port for the gRPC server.
+//
+// No default. When set to 0 the gRPC server is not started.
+func SetGRPCPort(port int) Option {
+	return func(s *server) error {
+		s.grpcPort = port
+		return nil
+	}
+}
+
 // SetTLS sets the TLS key and certificate.
 //
 // When both are set the server starts in TLS mode.
@@ -115,6 +130,43 @@ func SetOrganisationService(service *organization.Service) Option {
 	}
 }
 
+// SetAuthService enforces authentication and roles on the routes.
+func SetAuthService(service *auth.Service) Option {
+	return func(s *server) error {
+		s.auth = service
+		return nil
+	}
+}
+
+// SetDataSetService configures the dataset service.
+// The datasets are mounted under each organization.
+func SetDataSetService(service *dataset.Service) Option {
+	return func(s *server) error {
+		s.routerFuncs = append(s.routerFuncs,
+			func(r chi.Router) {
+				r.Mount("/organizations/{orgID}/datasets", service.Routes())
+			},
+		)
+
+		return nil
+	}
+}
+
+// SetNameSpaceService configures the namespace management API.
+// The service is also registered on the gRPC server.
+func SetNameSpaceService(service *namespace.Service) Option {
+	return func(s *server) error {
+		s.routerFuncs = append(s.routerFuncs,
+			func(r chi.Router) {
+				r.Mount("/api/namespaces", service.Routes())
+			},
+		)
+		s.grpcServices = append(s.grpcServices, service.RegisterGRPC)
+
+		return nil
+	}
+}
+
 // SetRevisionService configures the organization service.
 // When no service is set a default transient memory-based service is used.
 func SetRevisionService(service *revision.Service) Option {
@@ -133,6 +185,7 @@ func SetRevisionService(service *revision.Service) Option {
 				r2.URL.Path = p
 				service.ServeHTTP(w, r2)
 			}))
+			r.Mount("/api/revisions", service.Routes())
 		})
 
 		return nil
@@ -191,6 +244,8 @@ func SetEADService(svc *ead.Service) Option {
 		s.routerFuncs = append(s.routerFuncs,
 			func(r chi.Router) {
 				r.Post("/api/ead", svc.Upload)
+				r.Post("/api/ead/validate", svc.ValidateUpload)
+				r.Post("/api/ead/authorities", svc.UploadAuthority)
 				r.Get("/api/ead/tasks", svc.Tasks)
 				r.Get("/api/ead/tasks/{id}", svc.GetTask)
 				r.Delete("/api/ead/tasks/{id}", svc.CancelTask)
@@ -206,6 +261,7 @@ func SetBulkService(svc *bulk.Service) Option {
 		s.routerFuncs = append(s.routerFuncs,
 			func(r chi.Router) {
 				r.Post("/api/index/bulk", svc.Handle)
+				r.Post("/api/revisions/{orgID}/{datasetID}/rollback", svc.HandleRollback)
 			},
 		)
 
@@ -234,6 +290,24 @@ func SetImageProxyService(service *imageproxy.Service) Option {
 	}
 }
 
+// SetLODResolver registers the Linked Open Data routing points.
+func SetLODResolver(svc *lodresolver.Service) Option {
+	return func(s *server) error {
+		s.routerFuncs = append(s.routerFuncs, svc.RegisterRoutes)
+
+		return nil
+	}
+}
+
+// SetSparqlProxy registers the read-only SPARQL endpoint.
+func SetSparqlProxy(svc *sparql.Service) Option {
+	return func(s *server) error {
+		s.routerFuncs = append(s.routerFuncs, svc.RegisterRoutes)
+
+		return nil
+	}
+}
+
 type ProxyRoute struct {
 	Method  string
 	Pattern string
@@ -251,11 +325,15 @@ func SetDataNodeProxy(dataNode string, proxyRoutes ...ProxyRoute) Option {
 
 				// ead
 				r.Post("/api/ead", s.proxyDataNode)
+				r.Post("/api/ead/validate", s.proxyDataNode)
+				r.Post("/api/ead/authorities", s.proxyDataNode)
 				r.Get("/api/ead/tasks", s.proxyDataNode)
 				r.Get("/api/ead/tasks/{id}", s.proxyDataNode)
 				r.Delete("/api/ead/tasks/{id}", s.proxyDataNode)
 				r.Post("/api/index/bulk", s.proxyDataNode)
 				r.Get("/api/ead/{spec}/download", s.proxyDataNode)
+				r.Get("/api/ead/{spec}/export", s.proxyDataNode)
+				r.Get("/api/ead/{spec}/pdf", s.proxyDataNode)
 				r.Get("/api/ead/{spec}/mets/{inventoryID}", s.proxyDataNode)
 				r.Get("/api/ead/{spec}/desc", s.proxyDataNode)
 				r.Get("/api/ead/{spec}/desc/index", s.proxyDataNode)
@@ -267,6 +345,7 @@ func SetDataNodeProxy(dataNode string, proxyRoutes ...ProxyRoute) Option {
 				r.Post("/api/datasets/", s.proxyDataNode)
 				r.Get("/api/datasets/{spec}", s.proxyDataNode)
 				r.Get("/api/datasets/{spec}/stats", s.proxyDataNode)
+				r.Put("/api/datasets/{spec}/access", s.proxyDataNode)
 				// later change to update dataset
 				r.Post("/api/datasets/{spec}", s.proxyDataNode)
 				r.Delete("/api/datasets/{spec}", s.proxyDataNode)
@@ -28,6 +28,10 @@ import (
 	mw "github.com/go-chi/chi/middleware"
 
 	"github.com/delving/hub3/ikuzo/logger"
+	"github.com/delving/hub3/ikuzo/service/organization"
+	"github.com/delving/hub3/ikuzo/service/x/dataset"
+	"github.com/delving/hub3/ikuzo/service/x/namespace"
+	"github.com/delving/hub3/ikuzo/storage/memory"
 	"github.com/matryer/is"
 	"github.com/rs/zerolog/log"
 )
@@ -58,6 +62,26 @@ func TestOptionSetPort(t *testing.T) {
 	is.True(svr == nil)
 }
 
+func TestOptionSetGRPCPort(t *testing.T) {
+	is := is.New(t)
+
+	// default
+	svr, err := newServer()
+	is.NoErr(err)
+	is.Equal(svr.grpcPort, 0)
+
+	ns, err := namespace.NewService()
+	is.NoErr(err)
+
+	svr, err = newServer(
+		SetGRPCPort(50051),
+		SetNameSpaceService(ns),
+	)
+	is.NoErr(err)
+	is.Equal(svr.grpcPort, 50051)
+	is.Equal(len(svr.grpcServices), 1)
+}
+
 func TestOptionSetLoggerConfig(t *testing.T) {
 	is := is.New(t)
 
@@ -135,3 +159,40 @@ func TestSetRouters(t *testing.T) {
 	is.Equal(w.Code, http.StatusOK)
 	is.Equal(w.Body.String(), "router-test")
 }
+
+func TestSetOrganisationAndDataSetService(t *testing.T) {
+	is := is.New(t)
+
+	org, err := organization.NewService(memory.NewOrganizationStore())
+	is.NoErr(err)
+
+	datasets, err := dataset.NewService(memory.NewDataSetStore())
+	is.NoErr(err)
+
+	svr, err := newServer(
+		SetOrganisationService(org),
+		SetDataSetService(datasets),
+		SetDisableRequestLogger(),
+	)
+	is.NoErr(err)
+
+	tests := []struct {
+		method     string
+		path       string
+		body       string
+		wantStatus int
+	}{
+		{http.MethodPost, "/organizations", `{"orgID": "demo"}`, http.StatusCreated},
+		{http.MethodGet, "/organizations/demo", "", http.StatusOK},
+		{http.MethodPost, "/organizations/demo/datasets", `{"datasetID": "spec"}`, http.StatusCreated},
+		{http.MethodGet, "/organizations/demo/datasets/spec", "", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+
+		w := httptest.NewRecorder()
+		svr.ServeHTTP(w, req)
+		is.Equal(w.Code, tt.wantStatus)
+	}
+}
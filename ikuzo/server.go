@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -32,6 +33,7 @@ import (
 	"github.com/delving/hub3/ikuzo/logger"
 	"github.com/delving/hub3/ikuzo/middleware"
 	"github.com/delving/hub3/ikuzo/service/organization"
+	"github.com/delving/hub3/ikuzo/service/x/auth"
 	"github.com/delving/hub3/ikuzo/service/x/revision"
 	"github.com/go-chi/chi"
 	"github.com/rs/xid"
@@ -39,6 +41,8 @@ import (
 	"github.com/rs/zerolog/hlog"
 	"github.com/rs/zerolog/log"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 const (
@@ -70,6 +74,12 @@ type server struct {
 	port int
 	// metricsPort is the port where expvar is hosted
 	metricsPort int
+	// grpcPort is the port of the gRPC server. It is not started when it is 0.
+	grpcPort int
+	// grpcServices register the gRPC services on the gRPC server
+	grpcServices []func(*grpc.Server)
+	// grpcServer is the running gRPC server
+	grpcServer *grpc.Server
 	// TLS certificate
 	certFile string
 	// TLS keyFile
@@ -92,6 +102,8 @@ type server struct {
 	routerFuncs []RouterFunc
 	// service to access the organization store
 	organizations *organization.Service
+	// auth enforces authentication and roles on the routes
+	auth *auth.Service
 	// revision gives access to the file storage
 	revision *revision.Service
 	// shutdownHooks are called on server shutdown
@@ -144,6 +156,16 @@ func newServer(options ...Option) (*server, error) {
 	// recover is not optional
 	s.router.Use(s.recoverer)
 
+	// add the organization of the request to the context
+	if s.organizations != nil {
+		s.router.Use(s.organizations.Middleware)
+	}
+
+	// authentication depends on the organization of the request
+	if s.auth != nil {
+		s.router.Use(s.auth.Middleware)
+	}
+
 	// setting up request logging middleware
 	if !s.disableRequestLogger {
 		s.router.Use(middleware.RequestLogger(&log.Logger))
@@ -202,6 +224,12 @@ func (s *server) listenAndServe(testSignals ...interface{}) error {
 		go http.ListenAndServe(fmt.Sprintf(":%d", s.metricsPort), nil)
 	}
 
+	if s.grpcPort != 0 {
+		if err := s.serveGRPC(errChan); err != nil {
+			return err
+		}
+	}
+
 	// start web-server
 	server := http.Server{Addr: fmt.Sprintf(":%d", s.port), Handler: s}
 
@@ -246,6 +274,51 @@ func (s *server) listenAndServe(testSignals ...interface{}) error {
 	}
 }
 
+// serveGRPC starts the gRPC server with the registered gRPC services.
+// The TLS certificate of the web-server is also used by the gRPC server and
+// the auth service enforces its GRPCRules on the calls.
+func (s *server) serveGRPC(errChan chan<- error) error {
+	options := []grpc.ServerOption{}
+
+	if s.certFile != "" && s.keyFile != "" {
+		creds, err := credentials.NewServerTLSFromFile(s.certFile, s.keyFile)
+		if err != nil {
+			return fmt.Errorf("unable to load gRPC TLS credentials; %w", err)
+		}
+
+		options = append(options, grpc.Creds(creds))
+	}
+
+	if s.auth != nil {
+		options = append(
+			options,
+			grpc.UnaryInterceptor(s.auth.UnaryServerInterceptor()),
+			grpc.StreamInterceptor(s.auth.StreamServerInterceptor()),
+		)
+	}
+
+	s.grpcServer = grpc.NewServer(options...)
+
+	for _, register := range s.grpcServices {
+		register(s.grpcServer)
+	}
+
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", s.grpcPort))
+	if err != nil {
+		return fmt.Errorf("unable to start gRPC listener; %w", err)
+	}
+
+	log.Info().
+		Int("port", s.grpcPort).
+		Msg("starting gRPC server")
+
+	go func() {
+		errChan <- s.grpcServer.Serve(lis)
+	}()
+
+	return nil
+}
+
 func (s *server) shutdown(server *http.Server) error {
 	log.Info().Msg("sending stop signal to background processes")
 
@@ -263,6 +336,13 @@ func (s *server) shutdown(server *http.Server) error {
 
 	g.Go(func() error { return server.Shutdown(ctx) })
 
+	if s.grpcServer != nil {
+		g.Go(func() error {
+			s.grpcServer.GracefulStop()
+			return nil
+		})
+	}
+
 	for _, h := range s.shutdownHooks {
 		h := h
 
@@ -16,32 +16,133 @@ package organization
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/delving/hub3/ikuzo/domain"
 	"github.com/go-chi/chi"
 	"github.com/go-chi/render"
+	"github.com/rs/zerolog/log"
 )
 
 func (s *Service) Routes() chi.Router {
-
 	router := chi.NewRouter()
 
 	router.Get("/", s.handleFilter)
-	router.Get("/{id}", s.handleGet)
+	router.Post("/", s.handleCreate)
 	router.Put("/", s.handlePut)
+	router.Get("/{id}", s.handleGet)
+	router.Put("/{id}", s.handleUpdate)
+	router.Delete("/{id}", s.handleDelete)
+	router.Get("/{id}/apikeys", s.handleListAPIKeys)
+	router.Post("/{id}/apikeys", s.handleCreateAPIKey)
+	router.Delete("/{id}/apikeys/{name}", s.handleDeleteAPIKey)
 
 	return router
 }
 
+// httpError writes the error with the status code that matches the error.
+func httpError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+
+	switch {
+	case errors.Is(err, domain.ErrOrgNotFound),
+		errors.Is(err, domain.ErrAPIKeyNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, domain.ErrIDExists),
+		errors.Is(err, domain.ErrAPIKeyExists),
+		errors.Is(err, domain.ErrDomainExists),
+		errors.Is(err, domain.ErrIndexNameExists):
+		status = http.StatusConflict
+	case errors.Is(err, domain.ErrInvalidRole),
+		errors.Is(err, domain.ErrIDCannotBeEmpty),
+		errors.Is(err, domain.ErrIDTooLong),
+		errors.Is(err, domain.ErrIDNotLowercase),
+		errors.Is(err, domain.ErrIDInvalidCharacter):
+		status = http.StatusBadRequest
+	}
+
+	http.Error(w, err.Error(), status)
+}
+
+func decodeOrganization(w http.ResponseWriter, r *http.Request) (domain.Organization, bool) {
+	var org domain.Organization
+
+	if err := json.NewDecoder(r.Body).Decode(&org); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return org, false
+	}
+
+	return org, true
+}
+
+// redact removes the API keys of the posthooks, because they are credentials
+// of the targets and must not be returned by the API.
+func redact(org domain.Organization) domain.Organization {
+	if len(org.Config.PostHooks) == 0 {
+		return org
+	}
+
+	hooks := make([]domain.PostHookConfig, len(org.Config.PostHooks))
+	for i, hook := range org.Config.PostHooks {
+		hook.APIKey = ""
+		hooks[i] = hook
+	}
+
+	org.Config.PostHooks = hooks
+
+	return org
+}
+
+// keepPostHookKeys sets the API key of the posthooks without a key to the key
+// of the stored posthook with the same name and URL, so a redacted
+// organization can be stored without losing the keys.
+func keepPostHookKeys(org *domain.Organization, current domain.Organization) {
+	for i, hook := range org.Config.PostHooks {
+		if hook.APIKey != "" {
+			continue
+		}
+
+		for _, stored := range current.Config.PostHooks {
+			if stored.Name == hook.Name && stored.URL == hook.URL {
+				org.Config.PostHooks[i].APIKey = stored.APIKey
+				break
+			}
+		}
+	}
+}
+
 func (s *Service) handleFilter(w http.ResponseWriter, r *http.Request) {
-	orgs, err := s.Filter(r.Context())
+	var filter domain.OrganizationFilter
+
+	for key, target := range map[string]*int{"offset": &filter.OffSet, "limit": &filter.Limit} {
+		value := r.URL.Query().Get(key)
+		if value == "" {
+			continue
+		}
+
+		i, err := strconv.Atoi(value)
+		if err != nil || i < 0 {
+			http.Error(w, "invalid "+key+" parameter", http.StatusBadRequest)
+			return
+		}
+
+		*target = i
+	}
+
+	orgs, err := s.Filter(r.Context(), filter)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httpError(w, err)
 		return
 	}
 
-	render.JSON(w, r, orgs)
+	redacted := make([]domain.Organization, 0, len(orgs))
+	for _, org := range orgs {
+		redacted = append(redacted, redact(org))
+	}
+
+	render.JSON(w, r, redacted)
 }
 
 func (s *Service) handleGet(w http.ResponseWriter, r *http.Request) {
@@ -49,35 +150,159 @@ func (s *Service) handleGet(w http.ResponseWriter, r *http.Request) {
 
 	org, err := s.Get(r.Context(), domain.OrganizationID(id))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httpError(w, err)
+		return
+	}
+
+	render.JSON(w, r, redact(org))
+}
+
+func (s *Service) handleCreate(w http.ResponseWriter, r *http.Request) {
+	org, ok := decodeOrganization(w, r)
+	if !ok {
+		return
+	}
+
+	org.APIKeys = nil
+
+	if err := s.Create(r.Context(), org); err != nil {
+		httpError(w, err)
 		return
 	}
 
-	render.JSON(w, r, org)
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, redact(org))
 }
 
+// handlePut creates or replaces the organization in the body.
 func (s *Service) handlePut(w http.ResponseWriter, r *http.Request) {
-	// b, err := ioutil.ReadAll(r.Body)
-	// if err != nil {
-	// http.Error(w, err.Error(), http.StatusInternalServerError)
-	// return
-	// }
+	org, ok := decodeOrganization(w, r)
+	if !ok {
+		return
+	}
 
-	// render.JSON(w, r, string(b))
-	var org domain.Organization
+	// api keys are only managed through the apikeys endpoints
+	org.APIKeys = nil
+	if current, err := s.Get(r.Context(), org.ID); err == nil {
+		org.APIKeys = current.APIKeys
+		keepPostHookKeys(&org, current)
+	}
+
+	if err := s.Put(r.Context(), org); err != nil {
+		httpError(w, err)
+		return
+	}
 
-	err := json.NewDecoder(r.Body).Decode(&org)
+	render.JSON(w, r, redact(org))
+}
+
+// handleUpdate replaces an existing organization. The identifier in the path
+// takes precedence over the identifier in the body.
+func (s *Service) handleUpdate(w http.ResponseWriter, r *http.Request) {
+	org, ok := decodeOrganization(w, r)
+	if !ok {
+		return
+	}
+
+	org.ID = domain.OrganizationID(chi.URLParam(r, "id"))
+
+	current, err := s.Get(r.Context(), org.ID)
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+
+	// api keys are only managed through the apikeys endpoints
+	org.APIKeys = current.APIKeys
+	keepPostHookKeys(&org, current)
+
+	if err := s.Put(r.Context(), org); err != nil {
+		httpError(w, err)
+		return
+	}
+
+	render.JSON(w, r, redact(org))
+}
+
+func (s *Service) handleDelete(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	if err := s.Delete(r.Context(), domain.OrganizationID(id)); err != nil {
+		httpError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Service) handleListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	org, err := s.Get(r.Context(), domain.OrganizationID(chi.URLParam(r, "id")))
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+
+	keys := org.APIKeys
+	if keys == nil {
+		keys = domain.APIKeys{}
+	}
+
+	render.JSON(w, r, keys)
+}
+
+func (s *Service) handleCreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Name string      `json:"name"`
+		Role domain.Role `json:"role"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if req.Name == "" {
+		http.Error(w, "api key name cannot be empty", http.StatusBadRequest)
+		return
+	}
+
+	key, err := s.CreateAPIKey(r.Context(), domain.OrganizationID(chi.URLParam(r, "id")), req.Name, req.Role)
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, map[string]string{
+		"name": req.Name,
+		"role": string(req.Role),
+		"key":  key,
+	})
+}
+
+func (s *Service) handleDeleteAPIKey(w http.ResponseWriter, r *http.Request) {
+	err := s.DeleteAPIKey(r.Context(), domain.OrganizationID(chi.URLParam(r, "id")), chi.URLParam(r, "name"))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httpError(w, err)
 		return
 	}
 
-	render.JSON(w, r, org)
-	// err = s.Put(r.Context(), org)
-	// if err != nil {
-	// http.Error(w, err.Error(), http.StatusInternalServerError)
-	// return
-	// }
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Middleware adds the domain.Organization of the request host to the request
+// context. Services retrieve it with domain.GetOrganization.
+func (s *Service) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		org, err := s.Resolve(r.Context(), r.Host)
+
+		switch {
+		case err == nil:
+			r = r.WithContext(domain.SetOrganization(r.Context(), org))
+		case !errors.Is(err, domain.ErrOrgNotFound):
+			log.Error().Err(err).Str("cmp", "organization").Str("host", r.Host).Msg("unable to resolve organization")
+		}
 
-	// w.WriteHeader(http.StatusNoContent)
+		next.ServeHTTP(w, r)
+	})
 }
@@ -16,7 +16,13 @@ package organization
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/delving/hub3/ikuzo/domain"
 )
@@ -37,23 +43,84 @@ type Store interface {
 	Shutdown(ctx context.Context) error
 }
 
+// cacheTTL is the maximum age of the cached organizations that are used to
+// resolve the organization of a request.
+const cacheTTL = time.Minute
+
+// Option is a closure to configure the Service.
+// It is used in NewService.
+type Option func(*Service) error
+
 // Service manages all interactions with domain.Organization Store
 type Service struct {
-	store Store
+	store        Store
+	defaultOrgID domain.OrganizationID
+	rw           sync.RWMutex
+	cache        []domain.Organization
+	cacheLoaded  time.Time
 }
 
 // NewService creates an organization.Service.
 // The organization.Store implementation is the storage backend for the service.
-func NewService(store Store) (*Service, error) {
+func NewService(store Store, options ...Option) (*Service, error) {
 	if store == nil {
 		return nil, fmt.Errorf("organization.Store implementation cannot be nil")
 	}
 
-	return &Service{store: store}, nil
+	s := &Service{store: store}
+
+	for _, option := range options {
+		if err := option(s); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// SetDefaultOrganizationID sets the organization of requests that do not match
+// the domains of an organization.
+func SetDefaultOrganizationID(id domain.OrganizationID) Option {
+	return func(s *Service) error {
+		s.defaultOrgID = id
+		return nil
+	}
+}
+
+// Create stores a new domain.Organization in the Organization Store.
+// It returns domain.ErrIDExists when the Organization is already stored.
+func (s *Service) Create(ctx context.Context, org domain.Organization) error {
+	if err := org.ID.Valid(); err != nil {
+		return err
+	}
+
+	_, err := s.store.Get(ctx, org.ID)
+	if err == nil {
+		return domain.ErrIDExists
+	}
+
+	if !errors.Is(err, domain.ErrOrgNotFound) {
+		return err
+	}
+
+	if err := s.checkUnique(ctx, org); err != nil {
+		return err
+	}
+
+	s.resetCache()
+
+	return s.store.Put(ctx, org)
 }
 
 // Delete removes the domain.Organization from the Organization Store.
+// It returns domain.ErrOrgNotFound when the Organization is not found.
 func (s *Service) Delete(ctx context.Context, id domain.OrganizationID) error {
+	if _, err := s.store.Get(ctx, id); err != nil {
+		return err
+	}
+
+	s.resetCache()
+
 	return s.store.Delete(ctx, id)
 }
 
@@ -65,20 +132,221 @@ func (s *Service) Get(ctx context.Context, id domain.OrganizationID) (domain.Org
 
 // Filter returns a list of domain.Organization based on the filterOptions.
 //
-// When the filterOptions are nil, the first 10 are returned.
+// The organizations are ordered by ID. When no filter is given, all organizations are returned.
 func (s *Service) Filter(ctx context.Context, filter ...domain.OrganizationFilter) ([]domain.Organization, error) {
 	return s.store.Filter(ctx, filter...)
 }
 
 // Put stores an Organization in the Service Store.
+// It returns domain.ErrDomainExists or domain.ErrIndexNameExists when the
+// configuration claims a domain or index name of another Organization.
 func (s *Service) Put(ctx context.Context, org domain.Organization) error {
 	if err := org.ID.Valid(); err != nil {
 		return err
 	}
 
+	if err := s.checkUnique(ctx, org); err != nil {
+		return err
+	}
+
+	s.resetCache()
+
+	return s.store.Put(ctx, org)
+}
+
+// checkUnique returns an error when another Organization already uses one of
+// the domains or the index name of the Organization, because requests and
+// indexed records would otherwise be routed to the wrong Organization.
+func (s *Service) checkUnique(ctx context.Context, org domain.Organization) error {
+	if len(org.Config.Domains) == 0 && org.Config.IndexName == "" {
+		return nil
+	}
+
+	orgs, err := s.store.Filter(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, other := range orgs {
+		if other.ID == org.ID {
+			continue
+		}
+
+		for _, host := range org.Config.Domains {
+			if other.Config.HasDomain(host) {
+				return fmt.Errorf("%w: %s is used by %s", domain.ErrDomainExists, host, other.ID)
+			}
+		}
+
+		if org.Config.IndexName != "" && strings.EqualFold(org.Config.IndexName, other.Config.IndexName) {
+			return fmt.Errorf("%w: %s is used by %s", domain.ErrIndexNameExists, org.Config.IndexName, other.ID)
+		}
+	}
+
+	return nil
+}
+
+// FindByDomain returns the domain.Organization that has the host as one of its
+// domains. It returns domain.ErrOrgNotFound when no Organization matches.
+func (s *Service) FindByDomain(ctx context.Context, host string) (domain.Organization, error) {
+	orgs, err := s.cached(ctx)
+	if err != nil {
+		return domain.Organization{}, err
+	}
+
+	for _, org := range orgs {
+		if org.Config.HasDomain(host) {
+			return org, nil
+		}
+	}
+
+	return domain.Organization{}, domain.ErrOrgNotFound
+}
+
+// Resolve returns the domain.Organization of the host. When the host does not
+// match the domains of an Organization the default Organization is returned.
+// An unknown default Organization is returned without configuration, so the
+// global configuration applies.
+//
+// It returns domain.ErrOrgNotFound when no default Organization is set.
+func (s *Service) Resolve(ctx context.Context, host string) (domain.Organization, error) {
+	org, err := s.FindByDomain(ctx, host)
+	if !errors.Is(err, domain.ErrOrgNotFound) {
+		return org, err
+	}
+
+	if s.defaultOrgID == "" {
+		return domain.Organization{}, domain.ErrOrgNotFound
+	}
+
+	org, err = s.store.Get(ctx, s.defaultOrgID)
+	if errors.Is(err, domain.ErrOrgNotFound) {
+		return domain.Organization{ID: s.defaultOrgID}, nil
+	}
+
+	return org, err
+}
+
+func (s *Service) cached(ctx context.Context) ([]domain.Organization, error) {
+	s.rw.RLock()
+	orgs, loaded := s.cache, s.cacheLoaded
+	s.rw.RUnlock()
+
+	if !loaded.IsZero() && time.Since(loaded) < cacheTTL {
+		return orgs, nil
+	}
+
+	orgs, err := s.store.Filter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.rw.Lock()
+	s.cache = orgs
+	s.cacheLoaded = time.Now()
+	s.rw.Unlock()
+
+	return orgs, nil
+}
+
+func (s *Service) resetCache() {
+	s.rw.Lock()
+	s.cache = nil
+	s.cacheLoaded = time.Time{}
+	s.rw.Unlock()
+}
+
+// CreateAPIKey adds an API key with the role to the domain.Organization.
+// The generated key is returned; only its hash is stored, so it cannot be retrieved later.
+func (s *Service) CreateAPIKey(ctx context.Context, id domain.OrganizationID, name string, role domain.Role) (string, error) {
+	if name == "" {
+		return "", fmt.Errorf("api key name cannot be empty")
+	}
+
+	if err := role.Valid(); err != nil {
+		return "", err
+	}
+
+	org, err := s.store.Get(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	for _, k := range org.APIKeys {
+		if k.Name == name {
+			return "", domain.ErrAPIKeyExists
+		}
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("unable to generate api key; %w", err)
+	}
+
+	key := hex.EncodeToString(b)
+
+	org.APIKeys = append(org.APIKeys, domain.APIKey{
+		Name:    name,
+		Role:    role,
+		Hash:    domain.HashAPIKey(key),
+		Created: time.Now().UTC(),
+	})
+
+	s.resetCache()
+
+	if err := s.store.Put(ctx, org); err != nil {
+		return "", err
+	}
+
+	return key, nil
+}
+
+// DeleteAPIKey removes the API key from the domain.Organization.
+// It returns domain.ErrAPIKeyNotFound when the Organization has no key with the name.
+func (s *Service) DeleteAPIKey(ctx context.Context, id domain.OrganizationID, name string) error {
+	org, err := s.store.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	keys := domain.APIKeys{}
+
+	for _, k := range org.APIKeys {
+		if k.Name != name {
+			keys = append(keys, k)
+		}
+	}
+
+	if len(keys) == len(org.APIKeys) {
+		return domain.ErrAPIKeyNotFound
+	}
+
+	org.APIKeys = keys
+
+	s.resetCache()
+
 	return s.store.Put(ctx, org)
 }
 
+// FindByAPIKey returns the domain.Organization and the domain.APIKey that match the key.
+// It returns domain.ErrAPIKeyNotFound when no Organization has the key.
+func (s *Service) FindByAPIKey(ctx context.Context, key string) (domain.Organization, domain.APIKey, error) {
+	orgs, err := s.cached(ctx)
+	if err != nil {
+		return domain.Organization{}, domain.APIKey{}, err
+	}
+
+	for _, org := range orgs {
+		for _, k := range org.APIKeys {
+			if k.Matches(key) {
+				return org, k, nil
+			}
+		}
+	}
+
+	return domain.Organization{}, domain.APIKey{}, domain.ErrAPIKeyNotFound
+}
+
 // Shutdown gracefully shutsdown the organization.Service store.
 // The ctx should have a timeout that cancels when the deadline is exceeded.
 func (s *Service) Shutdown(ctx context.Context) error {
@@ -18,6 +18,9 @@ package organization_test
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/delving/hub3/ikuzo/domain"
@@ -154,3 +157,281 @@ func TestService(t *testing.T) {
 	getOrgID, err = svc.Get(ctx, orgID)
 	is.True(errors.Is(err, domain.ErrOrgNotFound))
 }
+
+func TestService_Create(t *testing.T) {
+	is := is.New(t)
+	ctx := context.TODO()
+
+	svc, err := organization.NewService(memory.NewOrganizationStore())
+	is.NoErr(err)
+
+	is.NoErr(svc.Create(ctx, domain.Organization{ID: "demo"}))
+
+	err = svc.Create(ctx, domain.Organization{ID: "demo"})
+	is.True(errors.Is(err, domain.ErrIDExists))
+
+	err = svc.Create(ctx, domain.Organization{ID: "Demo"})
+	is.True(errors.Is(err, domain.ErrIDNotLowercase))
+
+	err = svc.Delete(ctx, "unknown")
+	is.True(errors.Is(err, domain.ErrOrgNotFound))
+}
+
+func TestService_uniqueConfig(t *testing.T) {
+	is := is.New(t)
+	ctx := context.TODO()
+
+	svc, err := organization.NewService(memory.NewOrganizationStore())
+	is.NoErr(err)
+
+	is.NoErr(svc.Create(ctx, domain.Organization{
+		ID:     "demo",
+		Config: domain.OrganizationConfig{Domains: []string{"demo.example.org"}, IndexName: "demo"},
+	}))
+
+	// an organization can update its own configuration
+	is.NoErr(svc.Put(ctx, domain.Organization{
+		ID:     "demo",
+		Config: domain.OrganizationConfig{Domains: []string{"demo.example.org", "www.demo.example.org"}, IndexName: "demo"},
+	}))
+
+	err = svc.Create(ctx, domain.Organization{
+		ID:     "other",
+		Config: domain.OrganizationConfig{Domains: []string{"Demo.example.org"}},
+	})
+	is.True(errors.Is(err, domain.ErrDomainExists))
+
+	err = svc.Put(ctx, domain.Organization{
+		ID:     "other",
+		Config: domain.OrganizationConfig{IndexName: "DEMO"},
+	})
+	is.True(errors.Is(err, domain.ErrIndexNameExists))
+
+	is.NoErr(svc.Put(ctx, domain.Organization{
+		ID:     "other",
+		Config: domain.OrganizationConfig{Domains: []string{"other.example.org"}, IndexName: "other"},
+	}))
+
+	org, err := svc.FindByDomain(ctx, "demo.example.org")
+	is.NoErr(err)
+	is.Equal(org.ID, domain.OrganizationID("demo"))
+}
+
+func TestService_Routes(t *testing.T) {
+	svc, err := organization.NewService(memory.NewOrganizationStore())
+	if err != nil {
+		t.Fatalf("unable to create service; %s", err)
+	}
+
+	router := svc.Routes()
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"create", http.MethodPost, "/", `{"orgID": "demo"}`, http.StatusCreated, `"orgID":"demo"`},
+		{"create duplicate", http.MethodPost, "/", `{"orgID": "demo"}`, http.StatusConflict, ""},
+		{"create protected", http.MethodPost, "/", `{"orgID": "public"}`, http.StatusConflict, ""},
+		{"create invalid", http.MethodPost, "/", `{"orgID": "demo1"}`, http.StatusBadRequest, ""},
+		{"create empty", http.MethodPost, "/", `{}`, http.StatusBadRequest, ""},
+		{"create invalid body", http.MethodPost, "/", `{`, http.StatusBadRequest, ""},
+		{"put", http.MethodPut, "/", `{"orgID": "other"}`, http.StatusOK, `"orgID":"other"`},
+		{"get", http.MethodGet, "/demo", "", http.StatusOK, `"orgID":"demo"`},
+		{"get unknown", http.MethodGet, "/unknown", "", http.StatusNotFound, ""},
+		{"update", http.MethodPut, "/demo", `{"description": "Demo"}`, http.StatusOK, `"description":"Demo"`},
+		{"update unknown", http.MethodPut, "/unknown", `{}`, http.StatusNotFound, ""},
+		{"filter", http.MethodGet, "/", "", http.StatusOK, `[{"orgID":"demo","description":"Demo","config":{}},{"orgID":"other","config":{}}]`},
+		{"filter with limit", http.MethodGet, "/?offset=1&limit=1", "", http.StatusOK, `[{"orgID":"other","config":{}}]`},
+		{"filter with invalid offset", http.MethodGet, "/?offset=-1", "", http.StatusBadRequest, ""},
+		{"set domain", http.MethodPut, "/other", `{"config": {"domains": ["demo.example.org"]}}`, http.StatusOK, ""},
+		{"claim domain of other organization", http.MethodPut, "/demo", `{"description": "Demo", "config": {"domains": ["demo.example.org"]}}`, http.StatusConflict, ""},
+		{"delete", http.MethodDelete, "/demo", "", http.StatusNoContent, ""},
+		{"delete unknown", http.MethodDelete, "/demo", "", http.StatusNotFound, ""},
+	}
+
+	// the tests depend on each other so they must not run in parallel
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		if rr.Code != tt.wantStatus {
+			t.Fatalf("%s: status = %d, want %d; %s", tt.name, rr.Code, tt.wantStatus, rr.Body.String())
+		}
+
+		if tt.wantBody != "" && !strings.Contains(rr.Body.String(), tt.wantBody) {
+			t.Errorf("%s: body = %s, want it to contain %s", tt.name, rr.Body.String(), tt.wantBody)
+		}
+	}
+}
+
+func TestService_Resolve(t *testing.T) {
+	is := is.New(t)
+	ctx := context.TODO()
+
+	svc, err := organization.NewService(memory.NewOrganizationStore())
+	is.NoErr(err)
+
+	_, err = svc.Resolve(ctx, "demo.example.org")
+	is.True(errors.Is(err, domain.ErrOrgNotFound))
+
+	svc, err = organization.NewService(
+		memory.NewOrganizationStore(),
+		organization.SetDefaultOrganizationID("hub"),
+	)
+	is.NoErr(err)
+
+	// unknown default organization has no configuration
+	org, err := svc.Resolve(ctx, "demo.example.org")
+	is.NoErr(err)
+	is.Equal(org.ID, domain.OrganizationID("hub"))
+
+	is.NoErr(svc.Put(ctx, domain.Organization{
+		ID:     "demo",
+		Config: domain.OrganizationConfig{Domains: []string{"demo.example.org"}},
+	}))
+
+	org, err = svc.Resolve(ctx, "demo.example.org:3000")
+	is.NoErr(err)
+	is.Equal(org.ID, domain.OrganizationID("demo"))
+
+	org, err = svc.Resolve(ctx, "other.example.org")
+	is.NoErr(err)
+	is.Equal(org.ID, domain.OrganizationID("hub"))
+
+	// the cache is reset on changes
+	is.NoErr(svc.Delete(ctx, "demo"))
+
+	_, err = svc.FindByDomain(ctx, "demo.example.org")
+	is.True(errors.Is(err, domain.ErrOrgNotFound))
+}
+
+func TestService_Middleware(t *testing.T) {
+	is := is.New(t)
+
+	svc, err := organization.NewService(memory.NewOrganizationStore())
+	is.NoErr(err)
+
+	is.NoErr(svc.Put(context.TODO(), domain.Organization{
+		ID:     "demo",
+		Config: domain.OrganizationConfig{Domains: []string{"demo.example.org"}},
+	}))
+
+	handler := svc.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		org, ok := domain.GetOrganization(r.Context())
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Write([]byte(org.ID))
+	}))
+
+	tests := []struct {
+		host       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"demo.example.org", http.StatusOK, "demo"},
+		{"example.org", http.StatusNotFound, ""},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "http://"+tt.host+"/", nil)
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		is.Equal(rr.Code, tt.wantStatus)
+		is.Equal(rr.Body.String(), tt.wantBody)
+	}
+}
+
+func TestService_APIKeys(t *testing.T) {
+	is := is.New(t)
+	ctx := context.TODO()
+
+	svc, err := organization.NewService(memory.NewOrganizationStore())
+	is.NoErr(err)
+
+	is.NoErr(svc.Create(ctx, domain.Organization{ID: "demo"}))
+
+	_, err = svc.CreateAPIKey(ctx, "demo", "ingest", "owner")
+	is.True(errors.Is(err, domain.ErrInvalidRole))
+
+	key, err := svc.CreateAPIKey(ctx, "demo", "ingest", domain.RoleIngester)
+	is.NoErr(err)
+	is.True(key != "")
+
+	_, err = svc.CreateAPIKey(ctx, "demo", "ingest", domain.RoleAdmin)
+	is.True(errors.Is(err, domain.ErrAPIKeyExists))
+
+	org, apiKey, err := svc.FindByAPIKey(ctx, key)
+	is.NoErr(err)
+	is.Equal(org.ID, domain.OrganizationID("demo"))
+	is.Equal(apiKey.Role, domain.RoleIngester)
+	is.True(apiKey.Hash != key) // only the hash is stored
+
+	_, _, err = svc.FindByAPIKey(ctx, "unknown")
+	is.True(errors.Is(err, domain.ErrAPIKeyNotFound))
+
+	is.NoErr(svc.DeleteAPIKey(ctx, "demo", "ingest"))
+
+	_, _, err = svc.FindByAPIKey(ctx, key)
+	is.True(errors.Is(err, domain.ErrAPIKeyNotFound))
+
+	err = svc.DeleteAPIKey(ctx, "demo", "ingest")
+	is.True(errors.Is(err, domain.ErrAPIKeyNotFound))
+}
+
+func TestService_Routes_redactsPostHookKeys(t *testing.T) {
+	is := is.New(t)
+	ctx := context.TODO()
+
+	svc, err := organization.NewService(memory.NewOrganizationStore())
+	is.NoErr(err)
+
+	router := svc.Routes()
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(method, path, strings.NewReader(body)))
+
+		return rr
+	}
+
+	hook := `{"name":"ginger","url":"http://ginger.example.org","apiKey":"secret"}`
+
+	rr := do(http.MethodPost, "/", `{"orgID":"demo","config":{"postHooks":[`+hook+`]}}`)
+	is.Equal(rr.Code, http.StatusCreated)
+	is.True(!strings.Contains(rr.Body.String(), "secret"))
+
+	for _, path := range []string{"/", "/demo"} {
+		rr = do(http.MethodGet, path, "")
+		is.Equal(rr.Code, http.StatusOK)
+		is.True(strings.Contains(rr.Body.String(), "ginger.example.org"))
+		is.True(!strings.Contains(rr.Body.String(), "secret"))
+	}
+
+	// storing the redacted organization keeps the key
+	rr = do(http.MethodPut, "/demo", `{"description":"Demo","config":{"postHooks":[{"name":"ginger","url":"http://ginger.example.org"}]}}`)
+	is.Equal(rr.Code, http.StatusOK)
+	is.True(!strings.Contains(rr.Body.String(), "secret"))
+
+	org, err := svc.Get(ctx, "demo")
+	is.NoErr(err)
+	is.Equal(org.Config.PostHooks[0].APIKey, "secret")
+
+	// a new key replaces the stored key
+	rr = do(http.MethodPut, "/", `{"orgID":"demo","config":{"postHooks":[{"name":"ginger","url":"http://ginger.example.org","apiKey":"rotated"}]}}`)
+	is.Equal(rr.Code, http.StatusOK)
+
+	org, err = svc.Get(ctx, "demo")
+	is.NoErr(err)
+	is.Equal(org.Config.PostHooks[0].APIKey, "rotated")
+}
@@ -0,0 +1,24 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package auth authenticates clients and enforces role-based access to the
+// HTTP endpoints and gRPC methods.
+//
+// Clients authenticate with an API key of their organization or, when a JWKS
+// is configured, with a signed JWT bearer token. Each Rule requires a minimal
+// domain.Role for the matching requests, and the client must belong to the
+// organization of the request. When the request has no organization, and for
+// Global rules and the gRPC methods, that is the default organization.
+// Requests that match no rule are public.
+package auth
@@ -0,0 +1,145 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+
+	"github.com/delving/hub3/ikuzo/domain"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCRule requires a minimal Role for the calls to a gRPC method. The Method
+// is the full method name, e.g. '/namespacepb.Namespace/Add'.
+//
+// The gRPC methods do not belong to an organization, so only the clients of
+// the default organization are allowed to call them.
+type GRPCRule struct {
+	Method string
+	Role   domain.Role
+}
+
+// DefaultGRPCRules protect the gRPC methods that modify data.
+func DefaultGRPCRules() []GRPCRule {
+	return []GRPCRule{
+		{Method: "/namespacepb.Namespace/Add", Role: domain.RoleAdmin},
+		{Method: "/namespacepb.Namespace/Delete", Role: domain.RoleAdmin},
+	}
+}
+
+// SetGRPCRules replaces the DefaultGRPCRules.
+func SetGRPCRules(rules ...GRPCRule) Option {
+	return func(s *Service) error {
+		for _, rule := range rules {
+			if err := rule.Role.Valid(); err != nil {
+				return err
+			}
+		}
+
+		s.grpcRules = rules
+
+		return nil
+	}
+}
+
+// authenticateMetadata returns the Principal of the credentials in the gRPC
+// metadata. API keys are accepted in the 'x-api-key' key and API keys or JWT
+// bearer tokens in the 'authorization' key.
+func (s *Service) authenticateMetadata(ctx context.Context) (Principal, bool, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return Principal{}, false, nil
+	}
+
+	if keys := md.Get("x-api-key"); len(keys) != 0 && keys[0] != "" {
+		p, err := s.authenticateAPIKey(ctx, keys[0])
+		return p, true, err
+	}
+
+	if headers := md.Get("authorization"); len(headers) != 0 && headers[0] != "" {
+		p, err := s.authenticateBearer(ctx, headers[0])
+		return p, true, err
+	}
+
+	return Principal{}, false, nil
+}
+
+// authorizeGRPC authenticates the call and enforces the GRPCRules. The
+// returned context contains the Principal when the client is authenticated.
+func (s *Service) authorizeGRPC(ctx context.Context, method string) (context.Context, error) {
+	p, found, err := s.authenticateMetadata(ctx)
+	if err != nil {
+		return ctx, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	if found {
+		ctx = NewContext(ctx, p)
+	}
+
+	for _, rule := range s.grpcRules {
+		if rule.Method != method {
+			continue
+		}
+
+		if !found {
+			return ctx, status.Error(codes.Unauthenticated, ErrUnauthenticated.Error())
+		}
+
+		if !p.Role.Allows(rule.Role) || p.OrgID != s.defaultOrgID {
+			return ctx, status.Error(codes.PermissionDenied, ErrForbidden.Error())
+		}
+
+		return ctx, nil
+	}
+
+	return ctx, nil
+}
+
+// UnaryServerInterceptor enforces the GRPCRules on unary calls.
+func (s *Service) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := s.authorizeGRPC(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor enforces the GRPCRules on streaming calls.
+func (s *Service) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := s.authorizeGRPC(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &principalStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// principalStream is a grpc.ServerStream with the context of the Principal.
+type principalStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (ps *principalStream) Context() context.Context {
+	return ps.ctx
+}
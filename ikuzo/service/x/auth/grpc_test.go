@@ -0,0 +1,124 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	pb "github.com/delving/hub3/hub3/server/grpc/pb/namespacepb"
+	"github.com/delving/hub3/ikuzo/domain"
+	"github.com/delving/hub3/ikuzo/service/x/namespace"
+	"github.com/matryer/is"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// nolint:gocritic
+func TestService_ServerInterceptors(t *testing.T) {
+	is := is.New(t)
+
+	svc, err := NewService(
+		SetDefaultOrganization("hub3"),
+		SetStaticKey("hub3", domain.RoleAdmin, "admin-key"),
+		SetStaticKey("hub3", domain.RoleReader, "reader-key"),
+		SetStaticKey("other", domain.RoleAdmin, "other-admin-key"),
+	)
+	is.NoErr(err)
+
+	ns, err := namespace.NewService()
+	is.NoErr(err)
+
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(svc.UnaryServerInterceptor()),
+		grpc.StreamInterceptor(svc.StreamServerInterceptor()),
+	)
+	ns.RegisterGRPC(server)
+
+	go func() {
+		_ = server.Serve(lis)
+	}()
+
+	conn, err := grpc.Dial(
+		"bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithInsecure(),
+	)
+	is.NoErr(err)
+
+	defer func() {
+		conn.Close()
+		server.Stop()
+	}()
+
+	client := pb.NewNamespaceClient(conn)
+	add := &pb.AddRequest{Prefix: "dc", Base: "http://purl.org/dc/elements/1.1/"}
+
+	withKey := func(key string) context.Context {
+		return metadata.AppendToOutgoingContext(context.Background(), "x-api-key", key)
+	}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		wantCode codes.Code
+	}{
+		{"anonymous", context.Background(), codes.Unauthenticated},
+		{"invalid key", withKey("wrong"), codes.Unauthenticated},
+		{"reader", withKey("reader-key"), codes.PermissionDenied},
+		{"admin of other organization", withKey("other-admin-key"), codes.PermissionDenied},
+		{
+			"admin as bearer",
+			metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer admin-key"),
+			codes.OK,
+		},
+	}
+
+	for _, tt := range tests {
+		_, err := client.Add(tt.ctx, add)
+		is.Equal(status.Code(err), tt.wantCode) // add
+	}
+
+	_, err = client.Delete(context.Background(), &pb.DeleteRequest{Prefix: "dc"})
+	is.Equal(status.Code(err), codes.Unauthenticated)
+
+	// read-only methods are public
+	list, err := client.List(context.Background(), &pb.ListRequest{})
+	is.NoErr(err)
+	is.Equal(len(list.GetNamespaces()), 1)
+
+	stream, err := client.SearchLabels(context.Background())
+	is.NoErr(err)
+	is.NoErr(stream.Send(&pb.SearchLabelRequest{Uri: "http://purl.org/dc/elements/1.1/title"}))
+	is.NoErr(stream.CloseSend())
+
+	resp, err := stream.Recv()
+	is.NoErr(err)
+	is.Equal(resp.GetLabel(), "dc_title")
+
+	_, err = stream.Recv()
+	is.Equal(err, io.EOF)
+
+	_, err = client.Delete(withKey("admin-key"), &pb.DeleteRequest{Prefix: "dc"})
+	is.NoErr(err)
+}
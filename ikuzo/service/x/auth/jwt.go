@@ -0,0 +1,331 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"strings"
+	"time"
+
+	// register the hash functions of the supported algorithms
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+)
+
+// errors
+var (
+	ErrInvalidToken     = errors.New("invalid token")
+	ErrUnsupportedAlg   = errors.New("unsupported signing algorithm")
+	ErrUnknownKey       = errors.New("no matching key in JWKS")
+	ErrInvalidSignature = errors.New("invalid token signature")
+	ErrTokenExpired     = errors.New("token is expired")
+	ErrInvalidClaims    = errors.New("invalid token claims")
+)
+
+// leeway is the allowed clock skew when validating the time claims.
+const leeway = 30 * time.Second
+
+// jwk is a JSON Web Key as defined in RFC 7517. Only public RSA and EC keys are supported.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// JWKS is a set of public keys that tokens are verified with.
+type JWKS struct {
+	keys map[string]crypto.PublicKey
+}
+
+// LoadJWKS reads a JSON Web Key Set from a file.
+func LoadJWKS(path string) (*JWKS, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read JWKS; %w", err)
+	}
+
+	return ParseJWKS(data)
+}
+
+// ParseJWKS parses a JSON Web Key Set. Keys that are not used for signatures are ignored.
+func ParseJWKS(data []byte) (*JWKS, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("unable to parse JWKS; %w", err)
+	}
+
+	ks := &JWKS{keys: map[string]crypto.PublicKey{}}
+
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		key, err := k.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse key %q; %w", k.Kid, err)
+		}
+
+		ks.keys[k.Kid] = key
+	}
+
+	if len(ks.keys) == 0 {
+		return nil, fmt.Errorf("JWKS contains no signing keys")
+	}
+
+	return ks, nil
+}
+
+func (k *jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := decodeInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+
+		if !e.IsInt64() || e.Int64() < 3 {
+			return nil, fmt.Errorf("invalid RSA exponent")
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+
+		x, err := decodeInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := decodeInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		if !curve.IsOnCurve(x, y) {
+			return nil, fmt.Errorf("point is not on curve %s", k.Crv)
+		}
+
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+}
+
+func decodeInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
+
+// audience is the 'aud' claim that is either a string or an array of strings.
+type audience []string
+
+func (a *audience) UnmarshalJSON(b []byte) error {
+	var single string
+	if err := json.Unmarshal(b, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+
+	var multi []string
+	if err := json.Unmarshal(b, &multi); err != nil {
+		return err
+	}
+
+	*a = multi
+
+	return nil
+}
+
+// Claims are the claims of a token that are used for authorization.
+//
+// The organization of the client is the 'orgID' claim. The role is the 'role'
+// claim or the highest role in the 'roles' claim.
+type Claims struct {
+	Subject   string   `json:"sub"`
+	Issuer    string   `json:"iss"`
+	Audience  audience `json:"aud"`
+	ExpiresAt int64    `json:"exp"`
+	NotBefore int64    `json:"nbf"`
+	OrgID     string   `json:"orgID"`
+	Role      string   `json:"role"`
+	Roles     []string `json:"roles"`
+}
+
+// Verify verifies the signature of the compact serialized token and validates
+// the time claims. The token must expire.
+func (ks *JWKS) Verify(token string, now time.Time) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidToken
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, err
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	key, err := ks.key(header.Kid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifySignature(header.Alg, key, parts[0]+"."+parts[1], sig); err != nil {
+		return nil, err
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case claims.ExpiresAt == 0:
+		return nil, fmt.Errorf("%w: exp is required", ErrInvalidClaims)
+	case now.Add(-leeway).Unix() >= claims.ExpiresAt:
+		return nil, ErrTokenExpired
+	case claims.NotBefore != 0 && now.Add(leeway).Unix() < claims.NotBefore:
+		return nil, fmt.Errorf("%w: token is not valid yet", ErrInvalidClaims)
+	}
+
+	return &claims, nil
+}
+
+func (ks *JWKS) key(kid string) (crypto.PublicKey, error) {
+	if key, ok := ks.keys[kid]; ok {
+		return key, nil
+	}
+
+	// a token without key id can be verified when there is only one key
+	if kid == "" && len(ks.keys) == 1 {
+		for _, key := range ks.keys {
+			return key, nil
+		}
+	}
+
+	return nil, ErrUnknownKey
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return ErrInvalidToken
+	}
+
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidToken, err)
+	}
+
+	return nil
+}
+
+func verifySignature(alg string, key crypto.PublicKey, signed string, sig []byte) error {
+	var hash crypto.Hash
+
+	switch alg {
+	case "RS256", "ES256":
+		hash = crypto.SHA256
+	case "RS384", "ES384":
+		hash = crypto.SHA384
+	case "RS512", "ES512":
+		hash = crypto.SHA512
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedAlg, alg)
+	}
+
+	h := hash.New()
+	h.Write([]byte(signed))
+	digest := h.Sum(nil)
+
+	switch pub := key.(type) {
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(alg, "RS") {
+			return fmt.Errorf("%w: %s with RSA key", ErrUnsupportedAlg, alg)
+		}
+
+		if err := rsa.VerifyPKCS1v15(pub, hash, digest, sig); err != nil {
+			return ErrInvalidSignature
+		}
+
+		return nil
+	case *ecdsa.PublicKey:
+		if !strings.HasPrefix(alg, "ES") {
+			return fmt.Errorf("%w: %s with EC key", ErrUnsupportedAlg, alg)
+		}
+
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(sig) != 2*size {
+			return ErrInvalidSignature
+		}
+
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+
+		if !ecdsa.Verify(pub, digest, r, s) {
+			return ErrInvalidSignature
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("%w: unsupported key type %T", ErrUnsupportedAlg, key)
+}
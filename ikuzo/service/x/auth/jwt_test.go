@@ -0,0 +1,193 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+// signToken creates a compact serialized JWT for the tests.
+func signToken(t *testing.T, alg, kid string, key crypto.Signer, claims map[string]interface{}) string {
+	t.Helper()
+
+	header, err := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	h := crypto.SHA256.New()
+	h.Write([]byte(signed))
+	digest := h.Sum(nil)
+
+	var sig []byte
+
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		sig, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest)
+		if err != nil {
+			t.Fatal(err)
+		}
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// fixed size big-endian encoding of r and s
+		sig = make([]byte, 64)
+		rb, sb := r.Bytes(), s.Bytes()
+		copy(sig[32-len(rb):32], rb)
+		copy(sig[64-len(sb):], sb)
+	}
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func encodeInt(i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(i.Bytes())
+}
+
+type testKeys struct {
+	rsa  *rsa.PrivateKey
+	ec   *ecdsa.PrivateKey
+	jwks *JWKS
+}
+
+func newTestKeys(t *testing.T) testKeys {
+	t.Helper()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := fmt.Sprintf(`{"keys": [
+		{"kty": "RSA", "kid": "rsa", "use": "sig", "n": %q, "e": %q},
+		{"kty": "EC", "kid": "ec", "crv": "P-256", "x": %q, "y": %q},
+		{"kty": "RSA", "kid": "enc", "use": "enc", "n": "", "e": ""}
+	]}`,
+		encodeInt(rsaKey.N), encodeInt(big.NewInt(int64(rsaKey.E))),
+		encodeInt(ecKey.X), encodeInt(ecKey.Y),
+	)
+
+	jwks, err := ParseJWKS([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return testKeys{rsa: rsaKey, ec: ecKey, jwks: jwks}
+}
+
+func TestJWKS_Verify(t *testing.T) {
+	keys := newTestKeys(t)
+	now := time.Now()
+
+	valid := map[string]interface{}{
+		"sub":   "user",
+		"orgID": "demo",
+		"role":  "ingester",
+		"aud":   []string{"hub3", "other"},
+		"exp":   now.Add(time.Hour).Unix(),
+	}
+
+	claims := func(overrides map[string]interface{}) map[string]interface{} {
+		c := map[string]interface{}{}
+		for k, v := range valid {
+			c[k] = v
+		}
+
+		for k, v := range overrides {
+			c[k] = v
+		}
+
+		return c
+	}
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{"RS256", signToken(t, "RS256", "rsa", keys.rsa, valid), nil},
+		{"ES256", signToken(t, "ES256", "ec", keys.ec, valid), nil},
+		{"wrong key", signToken(t, "RS256", "rsa", otherKey, valid), ErrInvalidSignature},
+		{"unknown kid", signToken(t, "RS256", "other", keys.rsa, valid), ErrUnknownKey},
+		{"alg none", signToken(t, "none", "rsa", keys.rsa, valid), ErrUnsupportedAlg},
+		{"alg mismatch with key", signToken(t, "ES256", "rsa", keys.rsa, valid), ErrUnsupportedAlg},
+		{"expired", signToken(t, "RS256", "rsa", keys.rsa, claims(map[string]interface{}{"exp": now.Add(-time.Hour).Unix()})), ErrTokenExpired},
+		{"no exp", signToken(t, "RS256", "rsa", keys.rsa, claims(map[string]interface{}{"exp": 0})), ErrInvalidClaims},
+		{"not before", signToken(t, "RS256", "rsa", keys.rsa, claims(map[string]interface{}{"nbf": now.Add(time.Hour).Unix()})), ErrInvalidClaims},
+		{"malformed", "abc.def", ErrInvalidToken},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			got, err := keys.jwks.Verify(tt.token, now)
+			if tt.wantErr != nil {
+				is.True(errors.Is(err, tt.wantErr))
+				return
+			}
+
+			is.NoErr(err)
+			is.Equal(got.OrgID, "demo")
+			is.Equal(got.Subject, "user")
+			is.True(got.hasAudience("hub3"))
+		})
+	}
+}
+
+func TestParseJWKS(t *testing.T) {
+	is := is.New(t)
+
+	_, err := ParseJWKS([]byte(`{"keys": []}`))
+	is.True(err != nil)
+
+	_, err = ParseJWKS([]byte(`{"keys": [{"kty": "oct", "kid": "hmac", "k": "c2VjcmV0"}]}`))
+	is.True(err != nil)
+}
@@ -0,0 +1,153 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/delving/hub3/ikuzo/domain"
+)
+
+// Rule requires a minimal Role for the requests that match it.
+//
+// The Pattern is matched per path segment. A '{name}' segment matches any
+// single segment and a trailing '*' matches the remainder of the path. The
+// '{orgID}' segment is the organization the request is authorized against.
+// When Method is empty all methods match. When Query is set, the query
+// parameters must contain it, e.g. 'service=git-receive-pack'.
+//
+// A Global rule protects a route that does not belong to an organization,
+// e.g. maintenance. Only the clients of the default organization may use it.
+type Rule struct {
+	Method  string
+	Pattern string
+	Query   string
+	Role    domain.Role
+	Global  bool
+}
+
+// match returns the captured path parameters and true when the request matches the rule.
+func (rule Rule) match(r *http.Request) (map[string]string, bool) {
+	if rule.Method != "" && !strings.EqualFold(rule.Method, r.Method) {
+		return nil, false
+	}
+
+	if rule.Query != "" {
+		kv := strings.SplitN(rule.Query, "=", 2)
+		if len(kv) != 2 || r.URL.Query().Get(kv[0]) != kv[1] {
+			return nil, false
+		}
+	}
+
+	pattern := splitPath(rule.Pattern)
+	path := splitPath(r.URL.Path)
+	params := map[string]string{}
+
+	for i, segment := range pattern {
+		if segment == "*" && i == len(pattern)-1 {
+			return params, true
+		}
+
+		if i >= len(path) {
+			return nil, false
+		}
+
+		switch {
+		case strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}"):
+			params[strings.Trim(segment, "{}")] = path[i]
+		case segment != path[i]:
+			return nil, false
+		}
+	}
+
+	if len(pattern) != len(path) {
+		return nil, false
+	}
+
+	return params, true
+}
+
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return []string{}
+	}
+
+	return strings.Split(path, "/")
+}
+
+// DefaultRules protect the endpoints that modify data. Read-only endpoints
+// stay public, except for git which exposes the full revision history, and
+// the organizations which contain their configuration.
+func DefaultRules() []Rule {
+	return []Rule{
+		// organization management
+		{Method: http.MethodGet, Pattern: "/organizations", Role: domain.RoleAdmin, Global: true},
+		{Method: http.MethodPost, Pattern: "/organizations", Role: domain.RoleAdmin, Global: true},
+		{Method: http.MethodPut, Pattern: "/organizations", Role: domain.RoleAdmin, Global: true},
+		{Method: http.MethodGet, Pattern: "/organizations/{orgID}", Role: domain.RoleReader},
+		{Method: http.MethodPut, Pattern: "/organizations/{orgID}", Role: domain.RoleAdmin},
+		{Method: http.MethodDelete, Pattern: "/organizations/{orgID}", Role: domain.RoleAdmin},
+		{Pattern: "/organizations/{orgID}/apikeys/*", Role: domain.RoleAdmin},
+		{Method: http.MethodDelete, Pattern: "/organizations/{orgID}/datasets/*", Role: domain.RoleAdmin},
+		{Method: http.MethodPost, Pattern: "/organizations/{orgID}/datasets/*", Role: domain.RoleIngester},
+		{Method: http.MethodPut, Pattern: "/organizations/{orgID}/datasets/*", Role: domain.RoleIngester},
+
+		// datasets
+		{Method: http.MethodDelete, Pattern: "/api/datasets/*", Role: domain.RoleAdmin},
+		{Method: http.MethodPut, Pattern: "/api/datasets/{spec}/access", Role: domain.RoleAdmin},
+		{Method: http.MethodPost, Pattern: "/api/datasets", Role: domain.RoleIngester},
+		{Method: http.MethodPost, Pattern: "/api/datasets/{spec}", Role: domain.RoleIngester},
+
+		// ingestion
+		{Method: http.MethodPost, Pattern: "/api/index/bulk", Role: domain.RoleIngester},
+		{Method: http.MethodPost, Pattern: "/api/index/fuzzed", Role: domain.RoleAdmin},
+		{Method: http.MethodPost, Pattern: "/api/rdf/bulk", Role: domain.RoleIngester},
+		{Method: http.MethodPost, Pattern: "/api/rdf/source", Role: domain.RoleIngester},
+		{Method: http.MethodPost, Pattern: "/api/rdf/csv", Role: domain.RoleIngester},
+		{Method: http.MethodDelete, Pattern: "/api/rdf/csv", Role: domain.RoleIngester},
+		{Method: http.MethodPost, Pattern: "/api/ead", Role: domain.RoleIngester},
+		{Method: http.MethodPost, Pattern: "/api/ead/authorities", Role: domain.RoleIngester},
+		{Method: http.MethodDelete, Pattern: "/api/ead/tasks/{id}", Role: domain.RoleIngester},
+
+		// namespaces
+		{Method: http.MethodPost, Pattern: "/api/namespaces/*", Role: domain.RoleAdmin, Global: true},
+		{Method: http.MethodPut, Pattern: "/api/namespaces/*", Role: domain.RoleAdmin, Global: true},
+		{Method: http.MethodDelete, Pattern: "/api/namespaces/*", Role: domain.RoleAdmin, Global: true},
+
+		// maintenance
+		{Method: http.MethodDelete, Pattern: "/introspect/reset", Role: domain.RoleAdmin, Global: true},
+		ImageProxyPurgeRule("imageproxy"),
+
+		// git
+		{Method: http.MethodPost, Pattern: "/git/{orgID}/{collection}/git-receive-pack", Role: domain.RoleIngester},
+		{Method: http.MethodGet, Pattern: "/git/{orgID}/{collection}/info/refs", Query: "service=git-receive-pack", Role: domain.RoleIngester},
+		{Pattern: "/git/{orgID}/*", Role: domain.RoleReader},
+		{Method: http.MethodGet, Pattern: "/api/revisions/{orgID}/*", Role: domain.RoleReader},
+		{Method: http.MethodPost, Pattern: "/api/revisions/{orgID}/{datasetID}/rollback", Role: domain.RoleAdmin},
+	}
+}
+
+// ImageProxyPurgeRule protects the cache purge of the imageproxy that is
+// mounted at the prefix.
+func ImageProxyPurgeRule(prefix string) Rule {
+	return Rule{
+		Method:  http.MethodDelete,
+		Pattern: "/" + strings.Trim(prefix, "/") + "/cache",
+		Role:    domain.RoleAdmin,
+		Global:  true,
+	}
+}
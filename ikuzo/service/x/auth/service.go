@@ -0,0 +1,413 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/delving/hub3/ikuzo/domain"
+	"github.com/delving/hub3/ikuzo/service/organization"
+	"github.com/rs/zerolog/log"
+)
+
+// errors
+var (
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrUnauthenticated    = errors.New("authentication required")
+	ErrForbidden          = errors.New("insufficient privileges")
+)
+
+// Authentication methods of a Principal.
+const (
+	MethodAPIKey = "apikey"
+	MethodJWT    = "jwt"
+)
+
+// Principal is the authenticated client of a request.
+type Principal struct {
+	OrgID   domain.OrganizationID
+	Role    domain.Role
+	Subject string
+	Method  string
+}
+
+type principalKey struct{}
+
+// GetPrincipal returns the Principal of the request when the client is authenticated.
+func GetPrincipal(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
+
+// NewContext returns a copy of the context with the Principal.
+func NewContext(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// staticKey is an API key from the configuration that is not stored in an Organization.
+type staticKey struct {
+	orgID domain.OrganizationID
+	role  domain.Role
+	hash  string
+}
+
+type Option func(*Service) error
+
+// Service authenticates requests with API keys or JWT bearer tokens and
+// enforces the Rules.
+type Service struct {
+	orgs       *organization.Service
+	jwks       *JWKS
+	issuer     string
+	audience   string
+	rules      []Rule
+	grpcRules  []GRPCRule
+	staticKeys []staticKey
+	// defaultOrgID is the organization that may use the global routes
+	defaultOrgID domain.OrganizationID
+	now          func() time.Time
+}
+
+// NewService creates an auth Service. When no rules are set the DefaultRules are enforced.
+func NewService(options ...Option) (*Service, error) {
+	s := &Service{
+		rules:     DefaultRules(),
+		grpcRules: DefaultGRPCRules(),
+		now:       time.Now,
+	}
+
+	for _, option := range options {
+		if err := option(s); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// SetOrganizationService sets the service where the API keys of each Organization are stored.
+func SetOrganizationService(orgs *organization.Service) Option {
+	return func(s *Service) error {
+		s.orgs = orgs
+		return nil
+	}
+}
+
+// SetJWKS enables JWT bearer tokens that are signed by one of the keys.
+func SetJWKS(jwks *JWKS) Option {
+	return func(s *Service) error {
+		s.jwks = jwks
+		return nil
+	}
+}
+
+// SetJWKSFile enables JWT bearer tokens that are signed by one of the keys in the file.
+func SetJWKSFile(path string) Option {
+	return func(s *Service) error {
+		jwks, err := LoadJWKS(path)
+		if err != nil {
+			return err
+		}
+
+		s.jwks = jwks
+
+		return nil
+	}
+}
+
+// SetIssuer requires the 'iss' claim of a JWT to match the issuer.
+func SetIssuer(issuer string) Option {
+	return func(s *Service) error {
+		s.issuer = issuer
+		return nil
+	}
+}
+
+// SetAudience requires the 'aud' claim of a JWT to contain the audience.
+func SetAudience(audience string) Option {
+	return func(s *Service) error {
+		s.audience = audience
+		return nil
+	}
+}
+
+// AddRules adds rules before the current rules, so they take precedence.
+func AddRules(rules ...Rule) Option {
+	return func(s *Service) error {
+		for _, rule := range rules {
+			if err := rule.Role.Valid(); err != nil {
+				return fmt.Errorf("invalid rule %s %s; %w", rule.Method, rule.Pattern, err)
+			}
+		}
+
+		s.rules = append(append([]Rule{}, rules...), s.rules...)
+
+		return nil
+	}
+}
+
+// SetRules replaces the DefaultRules.
+func SetRules(rules ...Rule) Option {
+	return func(s *Service) error {
+		for _, rule := range rules {
+			if err := rule.Role.Valid(); err != nil {
+				return fmt.Errorf("invalid rule %s %s; %w", rule.Method, rule.Pattern, err)
+			}
+		}
+
+		s.rules = rules
+
+		return nil
+	}
+}
+
+// SetDefaultOrganization sets the organization whose clients may use the
+// routes and gRPC methods that do not belong to an organization, such as the
+// namespace management and maintenance endpoints.
+func SetDefaultOrganization(orgID string) Option {
+	return func(s *Service) error {
+		s.defaultOrgID = domain.OrganizationID(orgID)
+		return nil
+	}
+}
+
+// SetStaticKey adds an API key from the configuration. This is used to
+// bootstrap the admin key that creates the API keys of the organizations.
+func SetStaticKey(orgID string, role domain.Role, key string) Option {
+	return func(s *Service) error {
+		if err := role.Valid(); err != nil {
+			return err
+		}
+
+		if key == "" {
+			return fmt.Errorf("static api key for %s cannot be empty", orgID)
+		}
+
+		s.staticKeys = append(s.staticKeys, staticKey{
+			orgID: domain.OrganizationID(orgID),
+			role:  role,
+			hash:  domain.HashAPIKey(key),
+		})
+
+		return nil
+	}
+}
+
+// Authenticate returns the Principal of the credentials in the request.
+//
+// API keys are accepted in the 'X-API-Key' header, as a bearer token or as
+// the password of basic authentication, which is what git clients send.
+// Bearer tokens that look like a JWT are verified against the JWKS.
+// It returns false when the request has no credentials.
+func (s *Service) Authenticate(r *http.Request) (Principal, bool, error) {
+	if key := r.Header.Get("X-API-Key"); key != "" {
+		p, err := s.authenticateAPIKey(r.Context(), key)
+		return p, true, err
+	}
+
+	if _, password, ok := r.BasicAuth(); ok {
+		p, err := s.authenticateAPIKey(r.Context(), password)
+		return p, true, err
+	}
+
+	header := r.Header.Get("Authorization")
+	if header == "" {
+		return Principal{}, false, nil
+	}
+
+	p, err := s.authenticateBearer(r.Context(), header)
+
+	return p, true, err
+}
+
+// authenticateBearer returns the Principal of the bearer token in the
+// Authorization header. The token is a JWT or an API key.
+func (s *Service) authenticateBearer(ctx context.Context, header string) (Principal, error) {
+	const prefix = "bearer "
+	if len(header) <= len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return Principal{}, ErrInvalidCredentials
+	}
+
+	token := strings.TrimSpace(header[len(prefix):])
+
+	if s.jwks != nil && strings.Count(token, ".") == 2 {
+		return s.authenticateJWT(token)
+	}
+
+	return s.authenticateAPIKey(ctx, token)
+}
+
+func (s *Service) authenticateAPIKey(ctx context.Context, key string) (Principal, error) {
+	hash := domain.HashAPIKey(key)
+
+	for _, sk := range s.staticKeys {
+		if subtle.ConstantTimeCompare([]byte(sk.hash), []byte(hash)) == 1 {
+			return Principal{OrgID: sk.orgID, Role: sk.role, Subject: "config", Method: MethodAPIKey}, nil
+		}
+	}
+
+	if s.orgs == nil {
+		return Principal{}, ErrInvalidCredentials
+	}
+
+	org, apiKey, err := s.orgs.FindByAPIKey(ctx, key)
+	if err != nil {
+		if !errors.Is(err, domain.ErrAPIKeyNotFound) {
+			log.Error().Err(err).Str("cmp", "auth").Msg("unable to lookup api key")
+		}
+
+		return Principal{}, ErrInvalidCredentials
+	}
+
+	return Principal{OrgID: org.ID, Role: apiKey.Role, Subject: apiKey.Name, Method: MethodAPIKey}, nil
+}
+
+func (s *Service) authenticateJWT(token string) (Principal, error) {
+	claims, err := s.jwks.Verify(token, s.now())
+	if err != nil {
+		return Principal{}, fmt.Errorf("%w: %s", ErrInvalidCredentials, err)
+	}
+
+	if s.issuer != "" && claims.Issuer != s.issuer {
+		return Principal{}, fmt.Errorf("%w: unexpected issuer", ErrInvalidCredentials)
+	}
+
+	if s.audience != "" && !claims.hasAudience(s.audience) {
+		return Principal{}, fmt.Errorf("%w: unexpected audience", ErrInvalidCredentials)
+	}
+
+	role := claims.role()
+	if role == "" || claims.OrgID == "" {
+		return Principal{}, fmt.Errorf("%w: orgID and role claims are required", ErrInvalidCredentials)
+	}
+
+	return Principal{
+		OrgID:   domain.OrganizationID(claims.OrgID),
+		Role:    role,
+		Subject: claims.Subject,
+		Method:  MethodJWT,
+	}, nil
+}
+
+func (c *Claims) hasAudience(aud string) bool {
+	for _, a := range c.Audience {
+		if a == aud {
+			return true
+		}
+	}
+
+	return false
+}
+
+// role returns the highest valid role in the claims.
+func (c *Claims) role() domain.Role {
+	var role domain.Role
+
+	for _, r := range append([]string{c.Role}, c.Roles...) {
+		candidate := domain.Role(r)
+		if candidate.Valid() == nil && !role.Allows(candidate) {
+			role = candidate
+		}
+	}
+
+	return role
+}
+
+// Authorize returns the error when the Principal may not make the request.
+// A nil Principal means the request is anonymous.
+func (s *Service) Authorize(r *http.Request, p *Principal) error {
+	for _, rule := range s.rules {
+		params, ok := rule.match(r)
+		if !ok {
+			continue
+		}
+
+		if p == nil {
+			return ErrUnauthenticated
+		}
+
+		if !p.Role.Allows(rule.Role) {
+			return ErrForbidden
+		}
+
+		if rule.Global {
+			if p.OrgID != s.defaultOrgID {
+				return ErrForbidden
+			}
+
+			return nil
+		}
+
+		orgID := domain.OrganizationID(params["orgID"])
+		if orgID == "" {
+			if org, ok := domain.GetOrganization(r.Context()); ok {
+				orgID = org.ID
+			}
+		}
+
+		if orgID == "" {
+			orgID = s.defaultOrgID
+		}
+
+		if orgID != "" && orgID != p.OrgID {
+			return ErrForbidden
+		}
+
+		return nil
+	}
+
+	return nil
+}
+
+// Middleware authenticates the request and enforces the rules. The Principal
+// is added to the request context and can be retrieved with GetPrincipal.
+func (s *Service) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p, found, err := s.Authenticate(r)
+		if err != nil {
+			unauthorized(w, err)
+			return
+		}
+
+		var principal *Principal
+
+		if found {
+			principal = &p
+			r = r.WithContext(NewContext(r.Context(), p))
+		}
+
+		switch err := s.Authorize(r, principal); {
+		case errors.Is(err, ErrUnauthenticated):
+			unauthorized(w, err)
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func unauthorized(w http.ResponseWriter, err error) {
+	w.Header().Set("WWW-Authenticate", `Basic realm="hub3"`)
+	http.Error(w, err.Error(), http.StatusUnauthorized)
+}
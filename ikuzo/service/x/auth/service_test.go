@@ -0,0 +1,212 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/delving/hub3/ikuzo/domain"
+	"github.com/delving/hub3/ikuzo/service/organization"
+	"github.com/delving/hub3/ikuzo/storage/memory"
+	"github.com/matryer/is"
+)
+
+func TestRule_match(t *testing.T) {
+	tests := []struct {
+		name      string
+		rule      Rule
+		method    string
+		target    string
+		wantOrgID string
+		wantMatch bool
+	}{
+		{"exact", Rule{Method: http.MethodPost, Pattern: "/api/index/bulk"}, http.MethodPost, "/api/index/bulk", "", true},
+		{"method mismatch", Rule{Method: http.MethodPost, Pattern: "/api/index/bulk"}, http.MethodGet, "/api/index/bulk", "", false},
+		{"any method", Rule{Pattern: "/git/*"}, http.MethodGet, "/git/demo/spec/info/refs", "", true},
+		{"param", Rule{Pattern: "/organizations/{orgID}"}, http.MethodPut, "/organizations/demo", "demo", true},
+		{"param too short", Rule{Pattern: "/organizations/{orgID}"}, http.MethodPut, "/organizations", "", false},
+		{"param too long", Rule{Pattern: "/organizations/{orgID}"}, http.MethodPut, "/organizations/demo/datasets", "", false},
+		{"wildcard matches empty rest", Rule{Pattern: "/organizations/{orgID}/datasets/*"}, http.MethodPost, "/organizations/demo/datasets", "demo", true},
+		{"wildcard", Rule{Pattern: "/organizations/{orgID}/datasets/*"}, http.MethodPost, "/organizations/demo/datasets/spec/revision", "demo", true},
+		{"query", Rule{Pattern: "/git/{u}/{c}/info/refs", Query: "service=git-receive-pack"}, http.MethodGet, "/git/u/c/info/refs?service=git-receive-pack", "", true},
+		{"query mismatch", Rule{Pattern: "/git/{u}/{c}/info/refs", Query: "service=git-receive-pack"}, http.MethodGet, "/git/u/c/info/refs?service=git-upload-pack", "", false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			params, ok := tt.rule.match(httptest.NewRequest(tt.method, tt.target, nil))
+			is.Equal(ok, tt.wantMatch)
+			is.Equal(params["orgID"], tt.wantOrgID)
+		})
+	}
+}
+
+func TestService_Middleware(t *testing.T) {
+	ctx := context.TODO()
+	keys := newTestKeys(t)
+
+	orgs, err := organization.NewService(memory.NewOrganizationStore())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range []domain.OrganizationID{"demo", "other"} {
+		if err = orgs.Create(ctx, domain.Organization{ID: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ingestKey, err := orgs.CreateAPIKey(ctx, "demo", "ingest", domain.RoleIngester)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	readKey, err := orgs.CreateAPIKey(ctx, "demo", "read", domain.RoleReader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	otherAdminKey, err := orgs.CreateAPIKey(ctx, "other", "admin", domain.RoleAdmin)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	svc, err := NewService(
+		SetOrganizationService(orgs),
+		SetJWKS(keys.jwks),
+		SetAudience("hub3"),
+		SetStaticKey("demo", domain.RoleAdmin, "bootstrap"),
+		SetDefaultOrganization("demo"),
+		AddRules(ImageProxyPurgeRule("/images/")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := time.Now().Add(time.Hour).Unix()
+	adminToken := signToken(t, "RS256", "rsa", keys.rsa, map[string]interface{}{
+		"sub": "user", "orgID": "demo", "roles": []string{"reader", "admin"}, "aud": "hub3", "exp": exp,
+	})
+	wrongAudience := signToken(t, "ES256", "ec", keys.ec, map[string]interface{}{
+		"sub": "user", "orgID": "demo", "role": "admin", "aud": "other", "exp": exp,
+	})
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		header     map[string]string
+		basicAuth  string
+		wantStatus int
+	}{
+		{"public route", http.MethodGet, "/api/search", nil, "", http.StatusOK},
+		{"missing credentials", http.MethodPost, "/api/index/bulk", nil, "", http.StatusUnauthorized},
+		{"invalid api key on public route", http.MethodGet, "/api/search", map[string]string{"X-API-Key": "wrong"}, "", http.StatusUnauthorized},
+		{"ingester key", http.MethodPost, "/api/index/bulk", map[string]string{"X-API-Key": ingestKey}, "", http.StatusOK},
+		{"ingester key as bearer", http.MethodPost, "/api/index/bulk", map[string]string{"Authorization": "Bearer " + ingestKey}, "", http.StatusOK},
+		{"reader key", http.MethodPost, "/api/index/bulk", map[string]string{"X-API-Key": readKey}, "", http.StatusForbidden},
+		{"ingester cannot delete dataset", http.MethodDelete, "/api/datasets/spec", map[string]string{"X-API-Key": ingestKey}, "", http.StatusForbidden},
+		{"ingester in own organization", http.MethodPost, "/organizations/demo/datasets", map[string]string{"X-API-Key": ingestKey}, "", http.StatusOK},
+		{"ingester in other organization", http.MethodPost, "/organizations/other/datasets", map[string]string{"X-API-Key": ingestKey}, "", http.StatusForbidden},
+		{"static admin key", http.MethodPost, "/organizations/demo/apikeys", map[string]string{"X-API-Key": "bootstrap"}, "", http.StatusOK},
+		{"jwt admin", http.MethodDelete, "/api/datasets/spec", map[string]string{"Authorization": "Bearer " + adminToken}, "", http.StatusOK},
+		{"jwt wrong audience", http.MethodDelete, "/api/datasets/spec", map[string]string{"Authorization": "Bearer " + wrongAudience}, "", http.StatusUnauthorized},
+		{"git push with basic auth", http.MethodPost, "/git/demo/spec/git-receive-pack", nil, ingestKey, http.StatusOK},
+		{"git push to own organization", http.MethodPost, "/git/demo/spec.git/git-receive-pack", map[string]string{"X-API-Key": ingestKey}, "", http.StatusOK},
+		{"git push to other organization", http.MethodPost, "/git/other/spec.git/git-receive-pack", map[string]string{"X-API-Key": ingestKey}, "", http.StatusForbidden},
+		{"git push discovery in other organization", http.MethodGet, "/git/other/spec.git/info/refs?service=git-receive-pack", nil, ingestKey, http.StatusForbidden},
+		{"git clone needs reader", http.MethodGet, "/git/demo/spec/info/refs?service=git-upload-pack", nil, "", http.StatusUnauthorized},
+		{"git push discovery as reader", http.MethodGet, "/git/demo/spec/info/refs?service=git-receive-pack", nil, readKey, http.StatusForbidden},
+		{"revision history needs reader", http.MethodGet, "/api/revisions/demo/spec/commits", nil, "", http.StatusUnauthorized},
+		{"revision history as reader", http.MethodGet, "/api/revisions/demo/spec/commits", map[string]string{"X-API-Key": readKey}, "", http.StatusOK},
+		{"ingester cannot rollback", http.MethodPost, "/api/revisions/demo/spec/rollback?sha=HEAD~1", map[string]string{"X-API-Key": ingestKey}, "", http.StatusForbidden},
+		{"organizations are not public", http.MethodGet, "/organizations", nil, "", http.StatusUnauthorized},
+		{"list organizations as admin of other organization", http.MethodGet, "/organizations", map[string]string{"X-API-Key": otherAdminKey}, "", http.StatusForbidden},
+		{"list organizations as default admin", http.MethodGet, "/organizations", map[string]string{"X-API-Key": "bootstrap"}, "", http.StatusOK},
+		{"get own organization as reader", http.MethodGet, "/organizations/demo", map[string]string{"X-API-Key": readKey}, "", http.StatusOK},
+		{"get other organization", http.MethodGet, "/organizations/other", map[string]string{"X-API-Key": readKey}, "", http.StatusForbidden},
+		{"purge imageproxy cache anonymously", http.MethodDelete, "/imageproxy/cache", nil, "", http.StatusUnauthorized},
+		{"purge configured imageproxy cache as ingester", http.MethodDelete, "/images/cache", map[string]string{"X-API-Key": ingestKey}, "", http.StatusForbidden},
+		{"purge configured imageproxy cache as admin", http.MethodDelete, "/images/cache", map[string]string{"X-API-Key": "bootstrap"}, "", http.StatusOK},
+		{"reset as admin of other organization", http.MethodDelete, "/introspect/reset", map[string]string{"X-API-Key": otherAdminKey}, "", http.StatusForbidden},
+		{"reset as default admin", http.MethodDelete, "/introspect/reset", map[string]string{"X-API-Key": "bootstrap"}, "", http.StatusOK},
+		{"bulk without organization as other ingester", http.MethodPost, "/api/index/bulk", map[string]string{"X-API-Key": otherAdminKey}, "", http.StatusForbidden},
+		{"revision history of other organization", http.MethodGet, "/api/revisions/other/spec/commits", map[string]string{"X-API-Key": readKey}, "", http.StatusForbidden},
+	}
+
+	handler := svc.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			r := httptest.NewRequest(tt.method, tt.target, nil)
+			r = r.WithContext(domain.SetOrganization(r.Context(), domain.Organization{ID: "demo"}))
+
+			for k, v := range tt.header {
+				r.Header.Set(k, v)
+			}
+
+			if tt.basicAuth != "" {
+				r.SetBasicAuth("git", tt.basicAuth)
+			}
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			is.Equal(w.Code, tt.wantStatus)
+
+			if tt.wantStatus == http.StatusUnauthorized {
+				is.True(w.Header().Get("WWW-Authenticate") != "")
+			}
+		})
+	}
+}
+
+func TestService_principal(t *testing.T) {
+	is := is.New(t)
+
+	svc, err := NewService(SetStaticKey("demo", domain.RoleIngester, "secret"))
+	is.NoErr(err)
+
+	var got Principal
+
+	handler := svc.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = GetPrincipal(r.Context())
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/api/search", nil)
+	r.Header.Set("X-API-Key", "secret")
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+
+	is.Equal(got.OrgID, domain.OrganizationID("demo"))
+	is.Equal(got.Role, domain.RoleIngester)
+	is.Equal(got.Method, MethodAPIKey)
+
+	_, err = NewService(SetStaticKey("demo", "owner", "secret"))
+	is.True(err != nil)
+}
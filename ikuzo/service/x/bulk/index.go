@@ -0,0 +1,60 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulk
+
+import (
+	"context"
+
+	"github.com/delving/hub3/config"
+	"github.com/delving/hub3/ikuzo/domain/domainpb"
+	"github.com/delving/hub3/ikuzo/service/x/index"
+)
+
+// orgIndex publishes the index messages to the index aliases of the organization
+// instead of the index aliases from the global configuration.
+type orgIndex struct {
+	bi    index.BulkIndex
+	names map[string]string
+}
+
+// newOrgIndex returns an index.BulkIndex that renames the indices by index type.
+// The names are the index aliases of the organization, see domain.OrganizationConfig.IndexNames.
+func newOrgIndex(bi index.BulkIndex, names map[string]string) *orgIndex {
+	es := config.Config.ElasticSearch
+
+	oi := &orgIndex{bi: bi, names: map[string]string{}}
+
+	for global, indexType := range map[string]string{
+		es.GetV1IndexName():    "v1",
+		es.GetIndexName():      "v2",
+		es.FragmentIndexName(): "fragments",
+	} {
+		if name, ok := names[indexType]; ok {
+			oi.names[global] = name
+		}
+	}
+
+	return oi
+}
+
+func (oi *orgIndex) Publish(ctx context.Context, messages ...*domainpb.IndexMessage) error {
+	for _, m := range messages {
+		if name, ok := oi.names[m.GetIndexName()]; ok {
+			m.IndexName = name
+		}
+	}
+
+	return oi.bi.Publish(ctx, messages...)
+}
@@ -0,0 +1,125 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulk
+
+import (
+	"context"
+	"testing"
+
+	"github.com/delving/hub3/config"
+	"github.com/delving/hub3/ikuzo/domain"
+	"github.com/delving/hub3/ikuzo/domain/domainpb"
+	"github.com/delving/hub3/ikuzo/service/organization"
+	"github.com/delving/hub3/ikuzo/storage/memory"
+	"github.com/matryer/is"
+)
+
+type recordingIndex struct {
+	messages []*domainpb.IndexMessage
+}
+
+func (ri *recordingIndex) Publish(ctx context.Context, messages ...*domainpb.IndexMessage) error {
+	ri.messages = append(ri.messages, messages...)
+	return nil
+}
+
+func TestOrgIndex_Publish(t *testing.T) {
+	is := is.New(t)
+
+	es := config.Config.ElasticSearch
+	ri := &recordingIndex{}
+	oi := newOrgIndex(ri, domain.OrganizationConfig{IndexName: "Demo"}.IndexNames())
+
+	err := oi.Publish(
+		context.TODO(),
+		&domainpb.IndexMessage{IndexName: es.GetV1IndexName()},
+		&domainpb.IndexMessage{IndexName: es.GetIndexName()},
+		&domainpb.IndexMessage{IndexName: es.FragmentIndexName()},
+		&domainpb.IndexMessage{IndexName: "other"},
+	)
+	is.NoErr(err)
+
+	is.Equal(len(ri.messages), 4)
+	is.Equal(ri.messages[0].IndexName, "demov1")
+	is.Equal(ri.messages[1].IndexName, "demov2")
+	is.Equal(ri.messages[2].IndexName, "demov2_frag")
+	is.Equal(ri.messages[3].IndexName, "other")
+}
+
+func TestParser_setOrganization(t *testing.T) {
+	is := is.New(t)
+	ctx := context.TODO()
+
+	orgs, err := organization.NewService(memory.NewOrganizationStore())
+	is.NoErr(err)
+
+	is.NoErr(orgs.Put(ctx, domain.Organization{
+		ID: "demo",
+		Config: domain.OrganizationConfig{
+			IndexName:   "demo",
+			IndexTypes:  []string{"v1", "v2"},
+			DefaultTags: []string{"demo"},
+			PostHooks:   []domain.PostHookConfig{{Name: "ginger", URL: "http://localhost"}},
+		},
+	}))
+
+	// global configuration
+	p := &Parser{indexTypes: []string{"v2"}, bi: &recordingIndex{}, orgs: orgs}
+	p.setOrganization(ctx, "hub")
+	is.Equal(p.org.ID, domain.OrganizationID("hub"))
+	is.Equal(p.indexTypes, []string{"v2"})
+	_, renamed := p.bi.(*orgIndex)
+	is.True(!renamed)
+	is.True(p.postHooks == nil)
+	is.Equal(p.v1IndexName(), config.Config.ElasticSearch.GetV1IndexName())
+
+	// organization configuration
+	p = &Parser{indexTypes: []string{"v2"}, bi: &recordingIndex{}, orgs: orgs, orgPostHooks: true}
+	p.setOrganization(ctx, "demo")
+	is.Equal(p.indexTypes, []string{"v1", "v2"})
+	_, renamed = p.bi.(*orgIndex)
+	is.True(renamed)
+	is.True(p.postHooks != nil)
+	is.Equal(p.v1IndexName(), "demov1")
+
+	// the organization from the request context is used without a store
+	p = &Parser{indexTypes: []string{"v2"}}
+	p.setOrganization(domain.SetOrganization(ctx, domain.Organization{
+		ID:     "ctx",
+		Config: domain.OrganizationConfig{IndexTypes: []string{"fragments"}},
+	}), "ctx")
+	is.Equal(p.indexTypes, []string{"fragments"})
+}
+
+func TestRequest_createFragmentBuilderTags(t *testing.T) {
+	is := is.New(t)
+
+	req := &Request{
+		HubID:         "demo_spec_1",
+		OrgID:         "demo",
+		DatasetID:     "spec",
+		NamedGraphURI: "http://data.example.org/resource/1/graph",
+		Graph:         `<http://data.example.org/resource/1> <http://purl.org/dc/elements/1.1/title> "title" .`,
+		GraphMimeType: "text/turtle",
+	}
+
+	fb, err := req.createFragmentBuilder(1)
+	is.NoErr(err)
+	is.Equal(fb.FragmentGraph().Meta.Tags, []string{"narthex", "mdr"})
+
+	fb, err = req.createFragmentBuilder(1, "demo")
+	is.NoErr(err)
+	is.Equal(fb.FragmentGraph().Meta.Tags, []string{"demo"})
+}
@@ -29,7 +29,13 @@ import (
 	"github.com/delving/hub3/config"
 	"github.com/delving/hub3/hub3/fragments"
 	"github.com/delving/hub3/hub3/models"
+	"github.com/delving/hub3/ikuzo/domain"
+	"github.com/delving/hub3/ikuzo/service/organization"
+	"github.com/delving/hub3/ikuzo/service/x/auth"
 	"github.com/delving/hub3/ikuzo/service/x/index"
+	"github.com/delving/hub3/ikuzo/service/x/namespace"
+	"github.com/delving/hub3/ikuzo/service/x/revision"
+	"github.com/delving/hub3/ikuzo/service/x/sparql"
 	"github.com/rs/zerolog/log"
 	"golang.org/x/sync/errgroup"
 
@@ -42,9 +48,26 @@ type Parser struct {
 	stats      *Stats
 	bi         index.BulkIndex
 	indexTypes []string
+	store      sparql.Store
+	orgs       *organization.Service
+	org        domain.Organization
+	// orgPostHooks is true when posthooks can be configured per organization
+	orgPostHooks bool
 	// TODO(kiivihal): find better solution for this
 	sparqlUpdates []fragments.SparqlUpdate // store all the triples here for bulk insert
+	rdfMu         sync.Mutex               // guards sparqlUpdates
 	postHooks     []*PostHookItem
+	namespaces    *namespace.Service
+	// discovered counts the base-URIs of the predicates and classes
+	discovered *namespace.Collector
+	revisions  *revision.Service
+	// repo is the revision store of the dataset
+	repo   *revision.Repository
+	repoMu sync.Mutex // guards the files in repo
+	// pending holds the index messages until the records are committed to repo
+	pending *revisionIndex
+	// commitMessage replaces the default message of the commit to repo
+	commitMessage string
 }
 
 func (p *Parser) Parse(ctx context.Context, r io.Reader) error {
@@ -112,30 +135,81 @@ func (p *Parser) Parse(ctx context.Context, r io.Reader) error {
 		})
 	}
 
-	if err := g.Wait(); err != nil && !errors.Is(err, context.Canceled) {
-		log.Error().Err(err).Msg("workers with errors")
+	waitErr := g.Wait()
+
+	// the processed records are committed and indexed, even when a worker failed
+	if err := p.commitRevisions(ctx); err != nil {
 		return err
 	}
 
-	if config.Config.RDF.RDFStoreEnabled {
-		if errs := p.RDFBulkInsert(); errs != nil {
-			return errs[0]
+	if waitErr != nil && !errors.Is(waitErr, context.Canceled) {
+		log.Error().Err(waitErr).Msg("workers with errors")
+		return waitErr
+	}
+
+	if p.store != nil {
+		if err := p.RDFBulkInsert(ctx); err != nil {
+			return err
 		}
 	}
 
+	p.discoverNameSpaces()
+
 	return nil
 }
 
+// collectNameSpaces counts the base-URIs of the predicates and classes in the graph.
+func (p *Parser) collectNameSpaces(g *rdf.Graph) {
+	if p.discovered == nil || g == nil {
+		return
+	}
+
+	for t := range g.IterTriples() {
+		pred, ok := t.Predicate.(*rdf.Resource)
+		if !ok {
+			continue
+		}
+
+		p.discovered.Add(pred.URI)
+
+		if pred.URI != fragments.RDFType {
+			continue
+		}
+
+		if class, ok := t.Object.(*rdf.Resource); ok {
+			p.discovered.Add(class.URI)
+		}
+	}
+}
+
+// discoverNameSpaces registers the unknown base-URIs as temporary namespaces.
+// Failures are logged because they should not fail the ingestion.
+func (p *Parser) discoverNameSpaces() {
+	if p.discovered == nil {
+		return
+	}
+
+	discovered, err := p.namespaces.Discover(p.discovered.Counts())
+	if err != nil {
+		log.Error().Err(err).Str("svc", "bulk").Msg("unable to register discovered namespaces")
+		return
+	}
+
+	for _, ns := range discovered {
+		log.Info().Str("svc", "bulk").Str("base", ns.Base).Msg("discovered new namespace")
+	}
+}
+
 // RDFBulkInsert inserts all triples from the bulkRequest in one SPARQL update statement
-func (p *Parser) RDFBulkInsert() []error {
-	triplesStored, errs := fragments.RDFBulkInsert(p.sparqlUpdates)
+func (p *Parser) RDFBulkInsert(ctx context.Context) error {
+	triplesStored, err := sparql.InsertGraphs(ctx, p.store, p.sparqlUpdates...)
 	p.sparqlUpdates = nil
 	p.stats.TriplesStored = uint64(triplesStored)
 
-	return errs
+	return err
 }
 
-func (p *Parser) setDataSet(req *Request) {
+func (p *Parser) setDataSet(ctx context.Context, req *Request) {
 	ds, _, dsError := models.GetOrCreateDataSet(req.DatasetID)
 	if dsError != nil {
 		// log error
@@ -146,10 +220,71 @@ func (p *Parser) setDataSet(req *Request) {
 	p.stats.OrgID = req.OrgID
 	req.Revision = ds.Revision
 	p.ds = ds
+
+	p.setOrganization(ctx, domain.OrganizationID(req.OrgID))
+	p.openRepository(req)
+}
+
+// setOrganization applies the configuration of the organization to the parser.
+func (p *Parser) setOrganization(ctx context.Context, orgID domain.OrganizationID) {
+	p.org = domain.Organization{ID: orgID}
+
+	if org, ok := domain.GetOrganization(ctx); ok && org.ID == orgID {
+		p.org = org
+	} else if p.orgs != nil {
+		org, err := p.orgs.Get(ctx, orgID)
+		switch {
+		case err == nil:
+			p.org = org
+		case !errors.Is(err, domain.ErrOrgNotFound):
+			log.Error().Err(err).Str("svc", "bulk").Str("orgID", string(orgID)).Msg("unable to get organization")
+		}
+	}
+
+	cfg := p.org.Config
+
+	if len(cfg.IndexTypes) != 0 {
+		p.indexTypes = cfg.IndexTypes
+	}
+
+	if names := cfg.IndexNames(); len(names) != 0 && p.bi != nil {
+		p.bi = newOrgIndex(p.bi, names)
+	}
+
+	if len(cfg.PostHooks) != 0 && p.orgPostHooks && p.postHooks == nil {
+		p.postHooks = []*PostHookItem{}
+	}
+}
+
+// v1IndexName returns the v1 index of the organization of the request.
+// It falls back to the v1 index of the global configuration.
+func (p *Parser) v1IndexName() string {
+	if name, ok := p.org.Config.IndexNames()["v1"]; ok {
+		return name
+	}
+
+	return config.Config.ElasticSearch.GetV1IndexName()
+}
+
+// authorize returns an auth.ErrForbidden error when the authenticated client
+// does not belong to the organization of the request. The organization in the
+// body is not checked by the auth middleware, which only knows the
+// organization of the host.
+func authorize(ctx context.Context, req *Request) error {
+	principal, ok := auth.GetPrincipal(ctx)
+	if !ok || domain.OrganizationID(req.OrgID) == principal.OrgID {
+		return nil
+	}
+
+	return fmt.Errorf("%w: bulk request for organization %q", auth.ErrForbidden, req.OrgID)
 }
 
 func (p *Parser) process(ctx context.Context, req *Request) error {
-	p.once.Do(func() { p.setDataSet(req) })
+	if err := authorize(ctx, req); err != nil {
+		return err
+	}
+
+	p.once.Do(func() { p.setDataSet(ctx, req) })
 
 	if p.ds == nil {
 		return fmt.Errorf("unable to get dataset")
@@ -177,6 +312,11 @@ func (p *Parser) process(ctx context.Context, req *Request) error {
 			return err
 		}
 
+		if err := p.removeRevisions(p.ds.Revision); err != nil {
+			log.Error().Err(err).Str("datasetID", req.DatasetID).Msg("Unable to remove orphans from revision store")
+			return err
+		}
+
 		p.dropPosthook(req.OrgID, req.DatasetID, p.ds.Revision)
 
 		log.Info().Str("datasetID", req.DatasetID).Int("revision", p.ds.Revision).Msg("mark orphans and delete them")
@@ -197,6 +337,11 @@ func (p *Parser) process(ctx context.Context, req *Request) error {
 			return err
 		}
 
+		if err := p.removeRevisions(-1); err != nil {
+			log.Error().Err(err).Str("datasetID", req.DatasetID).Msg("Unable to remove records from revision store")
+			return err
+		}
+
 		p.dropPosthook(req.OrgID, req.DatasetID, -1)
 
 		log.Info().Str("datasetID", req.DatasetID).Int("revision", p.ds.Revision).Msg("dropped dataset")
@@ -226,7 +371,7 @@ func (p *Parser) Publish(req *Request) error {
 		return err
 	}
 
-	fb, err := req.createFragmentBuilder(req.Revision)
+	fb, err := req.createFragmentBuilder(req.Revision, p.org.Config.DefaultTags...)
 	if err != nil {
 		log.Error().Err(err).Str("datasetID", req.DatasetID).Msg("unable to build fragment builder")
 		return err
@@ -238,18 +383,26 @@ func (p *Parser) Publish(req *Request) error {
 		return err
 	}
 
+	p.collectNameSpaces(fb.Graph)
+
+	bi, err := p.writeRevision(req, fb.Graph)
+	if err != nil {
+		log.Error().Err(err).Str("datasetID", req.DatasetID).Msg("unable to store record revision")
+		return err
+	}
+
 	for _, indexType := range p.indexTypes {
 		switch indexType {
 		case "v1":
-			if err := req.processV1(fb, p.bi); err != nil {
+			if err := req.processV1(fb, bi, p.v1IndexName()); err != nil {
 				return err
 			}
 		case "v2":
-			if err := req.processV2(fb, p.bi); err != nil {
+			if err := req.processV2(fb, bi); err != nil {
 				return err
 			}
 		case "fragments":
-			if err := req.processFragments(fb, p.bi); err != nil {
+			if err := req.processFragments(fb, bi); err != nil {
 				return err
 			}
 		default:
@@ -257,8 +410,7 @@ func (p *Parser) Publish(req *Request) error {
 		}
 	}
 
-	// FIXME(kiivihal): get the configuration values via injection instead of global config
-	if config.Config.RDF.RDFStoreEnabled {
+	if p.store != nil {
 		if err := p.AppendRDFBulkRequest(req, fb.Graph); err != nil {
 			return err
 		}
@@ -299,7 +451,9 @@ func (p *Parser) AppendRDFBulkRequest(req *Request, g *rdf.Graph) error {
 		SpecRevision:  req.Revision,
 	}
 
+	p.rdfMu.Lock()
 	p.sparqlUpdates = append(p.sparqlUpdates, su)
+	p.rdfMu.Unlock()
 
 	return nil
 }
@@ -313,5 +467,7 @@ type Stats struct {
 	RecordsStored uint64 `json:"recordsStored"` // originally json was records_stored
 	JSONErrors    uint64 `json:"jsonErrors"`
 	TriplesStored uint64 `json:"triplesStored"`
+	// RevisionSHA is the commit of the records in the revision store
+	RevisionSHA string `json:"revisionSHA,omitempty"`
 	// ContentHashMatches uint64    `json:"contentHashMatches"` // originally json was content_hash_matches
 }
@@ -0,0 +1,97 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package bulk
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/delving/hub3/ikuzo/domain"
+	"github.com/delving/hub3/ikuzo/service/x/auth"
+	"github.com/delving/hub3/ikuzo/service/x/namespace"
+	"github.com/matryer/is"
+)
+
+func TestParser_discoverNameSpaces(t *testing.T) {
+	is := is.New(t)
+
+	ns, err := namespace.NewService()
+	is.NoErr(err)
+
+	_, err = ns.Add("dc", "http://purl.org/dc/elements/1.1/")
+	is.NoErr(err)
+
+	svc, err := NewService(SetNameSpaceService(ns))
+	is.NoErr(err)
+
+	p := svc.NewParser()
+
+	req := &Request{
+		HubID:         "demo_spec_1",
+		OrgID:         "demo",
+		DatasetID:     "spec",
+		NamedGraphURI: "http://data.example.org/resource/1/graph",
+		Graph: `<http://data.example.org/resource/1> <http://purl.org/dc/elements/1.1/title> "title" ;
+  <http://example.org/ontology/label> "one", "two" ;
+  a <http://example.org/classes#Record> .`,
+		GraphMimeType: "text/turtle",
+	}
+
+	fb, err := req.createFragmentBuilder(1)
+	is.NoErr(err)
+
+	p.collectNameSpaces(fb.Graph)
+	p.discoverNameSpaces()
+
+	queue, err := ns.Review()
+	is.NoErr(err)
+	is.Equal(len(queue), 3) // ontology, classes and the rdf namespace
+
+	is.Equal(queue[0].Base, "http://example.org/ontology/")
+	is.Equal(queue[0].Usage, 2)
+	is.True(queue[0].Temporary)
+
+	// known namespaces are not queued
+	dc, err := ns.GetWithPrefix("dc")
+	is.NoErr(err)
+	is.True(!dc.Temporary)
+	is.Equal(dc.Usage, 0)
+
+	// usage is added on the next ingest
+	p = svc.NewParser()
+	p.collectNameSpaces(fb.Graph)
+	p.discoverNameSpaces()
+
+	queue, err = ns.Review()
+	is.NoErr(err)
+	is.Equal(queue[0].Usage, 4)
+}
+
+func TestService_Handle_otherOrganization(t *testing.T) {
+	is := is.New(t)
+
+	svc, err := NewService()
+	is.NoErr(err)
+
+	body := `{"hubId":"other_spec_1","orgID":"other","dataset":"spec","action":"index"}`
+
+	r := httptest.NewRequest(http.MethodPost, "/api/index/bulk", strings.NewReader(body))
+	r = r.WithContext(auth.NewContext(r.Context(), auth.Principal{OrgID: "demo", Role: domain.RoleIngester}))
+	w := httptest.NewRecorder()
+
+	svc.Handle(w, r)
+	is.Equal(w.Code, http.StatusForbidden)
+}
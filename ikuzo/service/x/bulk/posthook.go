@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/delving/hub3/hub3/fragments"
+	"github.com/delving/hub3/ikuzo/domain"
 )
 
 // PostHookItem holds the input data that a PostHookService can manipulate
@@ -31,3 +32,6 @@ type PostHookService interface {
 	// OrgID returns OrgID that the posthook applies to
 	OrgID() string
 }
+
+// PostHookFactory creates the PostHookService of an organization from its configuration.
+type PostHookFactory func(orgID string, cfg domain.PostHookConfig) (PostHookService, error)
@@ -20,7 +20,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/delving/hub3/config"
 	"github.com/delving/hub3/hub3/fragments"
 	"github.com/delving/hub3/ikuzo/domain/domainpb"
 	"github.com/delving/hub3/ikuzo/service/x/index"
@@ -60,7 +59,14 @@ func (req *Request) valid() error {
 	return nil
 }
 
-func (req *Request) createFragmentBuilder(revision int) (*fragments.FragmentBuilder, error) {
+// defaultTags are added to the records when the organization has no DefaultTags.
+var defaultTags = []string{"narthex", "mdr"}
+
+func (req *Request) createFragmentBuilder(revision int, tags ...string) (*fragments.FragmentBuilder, error) {
+	if len(tags) == 0 {
+		tags = defaultTags
+	}
+
 	fg := fragments.NewFragmentGraph()
 	fg.Meta.OrgID = req.OrgID
 	fg.Meta.HubID = req.HubID
@@ -68,7 +74,7 @@ func (req *Request) createFragmentBuilder(revision int) (*fragments.FragmentBuil
 	fg.Meta.Revision = int32(revision)
 	fg.Meta.NamedGraphURI = req.NamedGraphURI
 	fg.Meta.EntryURI = fg.GetAboutURI()
-	fg.Meta.Tags = []string{"narthex", "mdr"}
+	fg.Meta.Tags = append([]string{}, tags...)
 
 	fb := fragments.NewFragmentBuilder(fg)
 	err := fb.ParseGraph(strings.NewReader(req.Graph), req.GraphMimeType)
@@ -80,7 +86,7 @@ func (req *Request) createFragmentBuilder(revision int) (*fragments.FragmentBuil
 	return fb, nil
 }
 
-func (req *Request) processV1(fb *fragments.FragmentBuilder, bi index.BulkIndex) error {
+func (req *Request) processV1(fb *fragments.FragmentBuilder, bi index.BulkIndex, indexName string) error {
 	fb.GetSortedWebResources()
 
 	indexDoc, err := fragments.CreateV1IndexDoc(fb)
@@ -98,7 +104,7 @@ func (req *Request) processV1(fb *fragments.FragmentBuilder, bi index.BulkIndex)
 		OrganisationID: req.OrgID,
 		DatasetID:      req.DatasetID,
 		RecordID:       req.HubID,
-		IndexName:      config.Config.ElasticSearch.GetV1IndexName(), // TODO(kiivihal): remove config later
+		IndexName:      indexName,
 		Source:         b,
 	}
 
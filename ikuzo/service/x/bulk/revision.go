@@ -0,0 +1,235 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/delving/hub3/ikuzo/domain/domainpb"
+	"github.com/delving/hub3/ikuzo/service/x/index"
+	"github.com/delving/hub3/ikuzo/service/x/revision"
+	rdf "github.com/kiivihal/rdf2go"
+	"github.com/rs/zerolog/log"
+)
+
+// revisionIndex holds the index messages until the records are committed to
+// the revision store, so the messages can refer to the commit.
+type revisionIndex struct {
+	bi       index.BulkIndex
+	mu       sync.Mutex
+	messages []*domainpb.IndexMessage
+}
+
+func newRevisionIndex(bi index.BulkIndex) *revisionIndex {
+	return &revisionIndex{bi: bi, messages: []*domainpb.IndexMessage{}}
+}
+
+func (ri *revisionIndex) Publish(ctx context.Context, messages ...*domainpb.IndexMessage) error {
+	ri.mu.Lock()
+	ri.messages = append(ri.messages, messages...)
+	ri.mu.Unlock()
+
+	return nil
+}
+
+// flush sets the SHA of the commit on the held messages and publishes them.
+func (ri *revisionIndex) flush(ctx context.Context, sha string) error {
+	ri.mu.Lock()
+	messages := ri.messages
+	ri.messages = []*domainpb.IndexMessage{}
+	ri.mu.Unlock()
+
+	for _, m := range messages {
+		if m.Revision != nil {
+			m.Revision.SHA = sha
+		}
+	}
+
+	if ri.bi == nil || len(messages) == 0 {
+		return nil
+	}
+
+	return ri.bi.Publish(ctx, messages...)
+}
+
+// recordIndex sets the path of the record in the revision store on the index messages.
+type recordIndex struct {
+	bi   index.BulkIndex
+	path string
+}
+
+func (ri *recordIndex) Publish(ctx context.Context, messages ...*domainpb.IndexMessage) error {
+	for _, m := range messages {
+		m.Revision = &domainpb.Revision{Path: ri.path}
+	}
+
+	return ri.bi.Publish(ctx, messages...)
+}
+
+// openRepository opens the revision store of the dataset of the request.
+// Failures are logged because they should not fail the ingestion.
+func (p *Parser) openRepository(req *Request) {
+	if p.revisions == nil {
+		return
+	}
+
+	repo, err := p.revisions.GetRepository(req.OrgID, req.DatasetID)
+	if err != nil {
+		log.Error().Err(err).Str("svc", "bulk").Str("datasetID", req.DatasetID).Msg("unable to open revision store")
+		return
+	}
+
+	p.repo = repo
+	p.pending = newRevisionIndex(p.bi)
+	p.bi = p.pending
+}
+
+// sortedNTriples returns the graph as N-Triples with one triple per line in
+// sorted order, so unchanged graphs are stored unchanged.
+func sortedNTriples(g *rdf.Graph) []byte {
+	lines := []string{}
+
+	for t := range g.IterTriples() {
+		lines = append(lines, t.String())
+	}
+
+	sort.Strings(lines)
+
+	var buf bytes.Buffer
+
+	for _, line := range lines {
+		buf.WriteString(line)
+		buf.WriteString("\n")
+	}
+
+	return buf.Bytes()
+}
+
+// writeRevision writes the graph of the record and the request without the
+// graph to the revision store. It returns the index to publish the messages
+// of the record to.
+func (p *Parser) writeRevision(req *Request, g *rdf.Graph) (index.BulkIndex, error) {
+	if p.repo == nil {
+		return p.bi, nil
+	}
+
+	meta := *req
+	meta.Graph = ""
+	meta.GraphMimeType = ""
+
+	b, err := json.Marshal(meta)
+	if err != nil {
+		return nil, err
+	}
+
+	path := revision.RecordPath(req.HubID)
+
+	p.repoMu.Lock()
+	defer p.repoMu.Unlock()
+
+	if err := p.repo.Write(path, bytes.NewReader(sortedNTriples(g))); err != nil {
+		return nil, fmt.Errorf("unable to write record to revision store; %w", err)
+	}
+
+	if err := p.repo.Write(revision.RecordMetaPath(req.HubID), bytes.NewReader(b)); err != nil {
+		return nil, fmt.Errorf("unable to write record to revision store; %w", err)
+	}
+
+	return &recordIndex{bi: p.bi, path: path}, nil
+}
+
+// removeRevisions removes the records from the revision store that are older
+// than the revision. When revision is negative all records are removed.
+func (p *Parser) removeRevisions(revisionNr int) error {
+	if p.repo == nil {
+		return nil
+	}
+
+	p.repoMu.Lock()
+	defer p.repoMu.Unlock()
+
+	if revisionNr < 0 {
+		return p.repo.Remove(revision.RecordDir)
+	}
+
+	paths, err := p.repo.Files(revision.RecordDir)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range paths {
+		hubID, ok := revision.RecordID(path)
+		if !ok {
+			continue
+		}
+
+		b, err := p.repo.ReadFile(revision.RecordMetaPath(hubID))
+		if err != nil {
+			return err
+		}
+
+		var meta Request
+		if err := json.Unmarshal(b, &meta); err != nil {
+			return err
+		}
+
+		if meta.Revision >= revisionNr {
+			continue
+		}
+
+		for _, remove := range []string{path, revision.RecordMetaPath(hubID)} {
+			if err := p.repo.Remove(remove); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// commitRevisions commits the changed records as one commit and publishes
+// the held index messages with the SHA of the commit. When the commit fails
+// the messages are published without the SHA.
+func (p *Parser) commitRevisions(ctx context.Context) error {
+	if p.repo == nil {
+		return nil
+	}
+
+	msg := fmt.Sprintf(
+		"bulk request for %s at revision %d\n\nreceived %d records",
+		p.stats.Spec, p.ds.Revision, p.stats.TotalReceived,
+	)
+
+	if p.commitMessage != "" {
+		msg = p.commitMessage
+	}
+
+	var sha string
+
+	commit, err := p.repo.CommitAll(revision.RecordDir, msg)
+	if err != nil {
+		log.Error().Err(err).Str("svc", "bulk").Str("datasetID", p.stats.Spec).Msg("unable to commit records to revision store")
+	} else if !commit.IsZero() {
+		sha = commit.String()
+		p.stats.RevisionSHA = sha
+	}
+
+	return p.pending.flush(ctx, sha)
+}
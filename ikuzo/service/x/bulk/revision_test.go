@@ -0,0 +1,126 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulk
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/delving/hub3/config"
+	"github.com/delving/hub3/hub3/models"
+	"github.com/delving/hub3/ikuzo/service/x/revision"
+	"github.com/matryer/is"
+)
+
+// nolint:gocritic
+func TestParser_commitRevisions(t *testing.T) {
+	is := is.New(t)
+	ctx := context.TODO()
+
+	// the index documents depend on the legacy configuration
+	config.InitConfig()
+
+	dir, err := ioutil.TempDir("", "bulk-revision")
+	is.NoErr(err)
+
+	defer os.RemoveAll(dir)
+
+	revisions, err := revision.NewService(dir)
+	is.NoErr(err)
+
+	svc, err := NewService(SetRevisionService(revisions))
+	is.NoErr(err)
+
+	req := &Request{
+		HubID:         "demo_spec_1",
+		OrgID:         "demo",
+		DatasetID:     "spec",
+		NamedGraphURI: "http://data.example.org/resource/1/graph",
+		Graph: `<http://data.example.org/resource/1> <http://purl.org/dc/elements/1.1/title> "title" ;
+  <http://purl.org/dc/elements/1.1/subject> "one", "two" .`,
+		GraphMimeType: "text/turtle",
+		Revision:      1,
+	}
+
+	ri := &recordingIndex{}
+
+	ingest := func(revisionNr int, orphans bool) *Parser {
+		p := svc.NewParser()
+		p.bi = ri
+		p.ds = &models.DataSet{Spec: req.DatasetID, Revision: revisionNr}
+		p.stats.Spec = req.DatasetID
+		p.openRepository(req)
+		is.True(p.repo != nil)
+
+		if orphans {
+			is.NoErr(p.removeRevisions(revisionNr))
+		} else {
+			r := *req
+			is.NoErr(p.Publish(&r))
+			// messages are held until the commit
+			is.Equal(len(p.pending.messages), 1)
+		}
+
+		is.NoErr(p.commitRevisions(ctx))
+
+		return p
+	}
+
+	p := ingest(1, false)
+	is.True(p.stats.RevisionSHA != "")
+	is.Equal(len(ri.messages), 1)
+
+	path := revision.RecordPath(req.HubID)
+	is.Equal(ri.messages[0].GetRevision().GetPath(), path)
+	is.Equal(ri.messages[0].GetRevision().GetSHA(), p.stats.RevisionSHA)
+
+	history, err := p.repo.History(path)
+	is.NoErr(err)
+	is.Equal(len(history), 1)
+	is.Equal(history[0].SHA, p.stats.RevisionSHA)
+
+	rc, err := p.repo.Read(path, p.stats.RevisionSHA)
+	is.NoErr(err)
+
+	content, err := ioutil.ReadAll(rc)
+	is.NoErr(err)
+	is.Equal(
+		string(content),
+		`<http://data.example.org/resource/1> <http://purl.org/dc/elements/1.1/subject> "one" .
+<http://data.example.org/resource/1> <http://purl.org/dc/elements/1.1/subject> "two" .
+<http://data.example.org/resource/1> <http://purl.org/dc/elements/1.1/title> "title" .
+`,
+	)
+
+	// unchanged records do not create a new commit
+	first := p.stats.RevisionSHA
+	p = ingest(1, false)
+	is.Equal(p.stats.RevisionSHA, first)
+	is.Equal(ri.messages[1].GetRevision().GetSHA(), first)
+
+	// orphans of an earlier revision are removed
+	p = ingest(2, true)
+	is.True(p.stats.RevisionSHA != first)
+
+	history, err = p.repo.History(path)
+	is.NoErr(err)
+	is.Equal(len(history), 2)
+
+	files, err := p.repo.Files(revision.RecordDir)
+	is.NoErr(err)
+	is.Equal(len(files), 0)
+}
@@ -0,0 +1,290 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/delving/hub3/ikuzo/domain"
+	"github.com/delving/hub3/ikuzo/service/x/auth"
+	"github.com/delving/hub3/ikuzo/service/x/revision"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/rs/zerolog/log"
+)
+
+// ErrRevisionStoreDisabled is returned when a rollback is requested without
+// a revision store.
+var ErrRevisionStoreDisabled = errors.New("revision store is not enabled")
+
+// rollbackRecords writes the records of the dataset at the revision to w as
+// bulk index requests, one per line.
+func rollbackRecords(w io.Writer, repo *revision.Repository, orgID, datasetID, rev string) error {
+	paths, err := repo.FilesAt(revision.RecordDir, rev)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(w)
+
+	for _, path := range paths {
+		hubID, ok := revision.RecordID(path)
+		if !ok {
+			continue
+		}
+
+		req := Request{HubID: hubID}
+
+		meta, err := readRevision(repo, revision.RecordMetaPath(hubID), rev)
+		if err != nil {
+			return err
+		}
+
+		if err := json.Unmarshal(meta, &req); err != nil {
+			return fmt.Errorf("unable to read metadata of %s; %w", hubID, err)
+		}
+
+		graph, err := readRevision(repo, path, rev)
+		if err != nil {
+			return err
+		}
+
+		req.OrgID = orgID
+		req.DatasetID = datasetID
+		req.Action = "index"
+		req.Graph = string(graph)
+		// N-Triples is a subset of turtle
+		req.GraphMimeType = "text/turtle"
+
+		if err := enc.Encode(req); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func readRevision(repo *revision.Repository, path, rev string) ([]byte, error) {
+	rc, err := repo.Read(path, rev)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s at %s; %w", path, rev, err)
+	}
+
+	defer rc.Close()
+
+	return ioutil.ReadAll(rc)
+}
+
+// parse runs the bulk requests from r in a new Parser and applies the posthooks.
+func (s *Service) parse(ctx context.Context, r io.Reader, commitMessage string) (*Parser, error) {
+	p := s.NewParser()
+	p.commitMessage = commitMessage
+
+	if err := p.Parse(ctx, r); err != nil {
+		return nil, err
+	}
+
+	s.applyPostHooks(p)
+
+	return p, nil
+}
+
+// ErrRollbackRunning is returned when a rollback of the dataset is already running.
+var ErrRollbackRunning = errors.New("rollback of dataset is already running")
+
+// rollbackTarget resolves the repository of the dataset and the commit of the
+// revision.
+func (s *Service) rollbackTarget(orgID, datasetID, rev string) (*revision.Repository, string, error) {
+	if s.revisions == nil {
+		return nil, "", ErrRevisionStoreDisabled
+	}
+
+	repo, err := s.revisions.OpenRepository(orgID, datasetID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	commit, err := repo.ResolveRevision(rev)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return repo, commit.String(), nil
+}
+
+// Rollback republishes the records of the dataset at the revision through the
+// index service. The dataset gets a new revision and the records that are not
+// part of the revision are removed as orphans, so the revision store has a
+// new commit with the records of the revision.
+func (s *Service) Rollback(ctx context.Context, orgID, datasetID, rev string) (*Stats, error) {
+	repo, sha, err := s.rollbackTarget(orgID, datasetID, rev)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.rollback(ctx, repo, orgID, datasetID, sha)
+}
+
+func (s *Service) rollback(ctx context.Context, repo *revision.Repository, orgID, datasetID, sha string) (*Stats, error) {
+	msg := fmt.Sprintf("rollback of %s to %s", datasetID, sha)
+
+	action := func(name string) io.Reader {
+		b, _ := json.Marshal(Request{OrgID: orgID, DatasetID: datasetID, Action: name})
+		return bytes.NewReader(b)
+	}
+
+	// the requests are processed concurrently within a Parser, so the
+	// records are published in a separate Parser between the actions
+	if _, err := s.parse(ctx, action("increment_revision"), msg); err != nil {
+		return nil, err
+	}
+
+	pr, pw := io.Pipe()
+
+	go func() {
+		pw.CloseWithError(rollbackRecords(pw, repo, orgID, datasetID, sha))
+	}()
+
+	p, err := s.parse(ctx, pr, msg)
+
+	// unblock the writer when the parser stopped reading
+	pr.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	orphans, err := s.parse(ctx, action("clear_orphans"), msg)
+	if err != nil {
+		return nil, err
+	}
+
+	if orphans.stats.RevisionSHA != "" {
+		p.stats.RevisionSHA = orphans.stats.RevisionSHA
+	}
+
+	log.Info().Str("svc", "bulk").Str("datasetID", datasetID).Str("sha", sha).Msgf("rollback stats: %+v", p.stats)
+
+	return p.stats, nil
+}
+
+// startRollback runs the rollback in the background. An ErrRollbackRunning is
+// returned when a rollback of the dataset is already running.
+//
+// The rollback is detached from the request, so it is not cancelled when the
+// client disconnects. Only the principal and the organization of the request
+// are passed on.
+func (s *Service) startRollback(r *http.Request, repo *revision.Repository, orgID, datasetID, sha string) error {
+	key := orgID + "/" + datasetID
+
+	s.rollbacksMu.Lock()
+	defer s.rollbacksMu.Unlock()
+
+	if s.rollbacks[key] {
+		return fmt.Errorf("%w: %s", ErrRollbackRunning, key)
+	}
+
+	s.rollbacks[key] = true
+
+	ctx := context.Background()
+
+	if principal, ok := auth.GetPrincipal(r.Context()); ok {
+		ctx = auth.NewContext(ctx, principal)
+	}
+
+	if org, ok := domain.GetOrganization(r.Context()); ok {
+		ctx = domain.SetOrganization(ctx, org)
+	}
+
+	s.running.Add(1)
+
+	go func() {
+		defer s.running.Done()
+
+		defer func() {
+			s.rollbacksMu.Lock()
+			delete(s.rollbacks, key)
+			s.rollbacksMu.Unlock()
+		}()
+
+		if _, err := s.rollback(ctx, repo, orgID, datasetID, sha); err != nil {
+			log.Error().Err(err).Str("svc", "bulk").Str("orgID", orgID).Str("datasetID", datasetID).Str("sha", sha).Msg("unable to rollback dataset")
+		}
+	}()
+
+	return nil
+}
+
+type rollbackResponse struct {
+	OrgID     string `json:"orgID"`
+	DatasetID string `json:"datasetID"`
+	SHA       string `json:"sha"`
+	Status    string `json:"status"`
+}
+
+// HandleRollback rolls the dataset back to the revision in the 'sha' query
+// parameter.
+//
+// The revision is resolved before the rollback is started in the background,
+// and the response has status 202 Accepted.
+func (s *Service) HandleRollback(w http.ResponseWriter, r *http.Request) {
+	rev := r.URL.Query().Get("sha")
+	if rev == "" {
+		http.Error(w, "sha is required", http.StatusBadRequest)
+		return
+	}
+
+	orgID, datasetID := chi.URLParam(r, "orgID"), chi.URLParam(r, "datasetID")
+
+	repo, sha, err := s.rollbackTarget(orgID, datasetID, rev)
+	if err == nil {
+		err = s.startRollback(r, repo, orgID, datasetID, sha)
+	}
+
+	if err != nil {
+		status := http.StatusInternalServerError
+
+		switch {
+		case errors.Is(err, revision.ErrRepositoryNotExists),
+			errors.Is(err, revision.ErrRevisionNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, revision.ErrInvalidRepository):
+			status = http.StatusBadRequest
+		case errors.Is(err, ErrRollbackRunning):
+			status = http.StatusConflict
+		case errors.Is(err, ErrRevisionStoreDisabled):
+			status = http.StatusNotImplemented
+		}
+
+		http.Error(w, err.Error(), status)
+
+		return
+	}
+
+	render.Status(r, http.StatusAccepted)
+	render.JSON(w, r, rollbackResponse{
+		OrgID:     orgID,
+		DatasetID: datasetID,
+		SHA:       sha,
+		Status:    "started",
+	})
+}
@@ -0,0 +1,187 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulk
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/delving/hub3/config"
+	"github.com/delving/hub3/hub3/models"
+	"github.com/delving/hub3/ikuzo/service/x/revision"
+	"github.com/go-chi/chi"
+	"github.com/matryer/is"
+)
+
+// nolint:gocritic
+func Test_rollbackRecords(t *testing.T) {
+	is := is.New(t)
+	ctx := context.TODO()
+
+	// the index documents depend on the legacy configuration
+	config.InitConfig()
+
+	dir, err := ioutil.TempDir("", "bulk-rollback")
+	is.NoErr(err)
+
+	defer os.RemoveAll(dir)
+
+	revisions, err := revision.NewService(dir)
+	is.NoErr(err)
+
+	svc, err := NewService(SetRevisionService(revisions))
+	is.NoErr(err)
+
+	record := func(id, title string) *Request {
+		return &Request{
+			HubID:         "demo_spec_" + id,
+			OrgID:         "demo",
+			DatasetID:     "spec",
+			NamedGraphURI: "http://data.example.org/resource/" + id + "/graph",
+			Graph: `<http://data.example.org/resource/` + id + `> <http://purl.org/dc/elements/1.1/title> "` + title + `" ;
+  <http://purl.org/dc/elements/1.1/subject> "one", "two" .`,
+			GraphMimeType: "text/turtle",
+		}
+	}
+
+	ingest := func(revisionNr int, reqs ...*Request) *Parser {
+		p := svc.NewParser()
+		p.bi = &recordingIndex{}
+		p.ds = &models.DataSet{Spec: "spec", Revision: revisionNr}
+		p.stats.Spec = "spec"
+		p.openRepository(reqs[0])
+
+		for _, req := range reqs {
+			req.Revision = revisionNr
+			is.NoErr(p.Publish(req))
+		}
+
+		is.NoErr(p.removeRevisions(revisionNr))
+		is.NoErr(p.commitRevisions(ctx))
+
+		return p
+	}
+
+	first := ingest(1, record("1", "first"), record("2", "first")).stats.RevisionSHA
+	p := ingest(2, record("1", "second"), record("3", "second"))
+
+	diff, err := p.repo.Diff(first, "")
+	is.NoErr(err)
+	is.Equal(diff.Added, []string{"demo_spec_3"})
+	is.Equal(diff.Removed, []string{"demo_spec_2"})
+	is.Equal(len(diff.Changed), 1)
+
+	var buf bytes.Buffer
+
+	is.NoErr(rollbackRecords(&buf, p.repo, "demo", "spec", first))
+
+	reqs := []*Request{}
+	scanner := bufio.NewScanner(&buf)
+
+	for scanner.Scan() {
+		var req Request
+		is.NoErr(json.Unmarshal(scanner.Bytes(), &req))
+		is.Equal(req.Action, "index")
+		is.Equal(req.GraphMimeType, "text/turtle")
+
+		reqs = append(reqs, &req)
+	}
+
+	is.Equal(len(reqs), 2)
+	is.Equal(reqs[0].HubID, "demo_spec_1")
+	is.Equal(reqs[0].NamedGraphURI, "http://data.example.org/resource/1/graph")
+
+	// republishing the records at the first revision restores the records
+	p = ingest(3, reqs...)
+
+	diff, err = p.repo.Diff(first, "")
+	is.NoErr(err)
+	is.Equal(len(diff.Added), 0)
+	is.Equal(len(diff.Removed), 0)
+	is.Equal(len(diff.Changed), 0)
+
+	_, err = svc.Rollback(ctx, "demo", "spec", "unknown")
+	is.True(errors.Is(err, revision.ErrRevisionNotFound))
+
+	_, err = svc.Rollback(ctx, "demo", "unknown", first)
+	is.True(errors.Is(err, revision.ErrRepositoryNotExists))
+}
+
+// nolint:gocritic
+func TestService_HandleRollback(t *testing.T) {
+	is := is.New(t)
+
+	dir, err := ioutil.TempDir("", "bulk-rollback")
+	is.NoErr(err)
+
+	defer os.RemoveAll(dir)
+
+	revisions, err := revision.NewService(dir)
+	is.NoErr(err)
+
+	repo, err := revisions.GetRepository("demo", "spec")
+	is.NoErr(err)
+
+	is.NoErr(repo.Write(revision.RecordPath("demo_spec_1"), strings.NewReader("<urn:1> <urn:p> \"first\" .\n")))
+
+	first, err := repo.CommitAll(revision.RecordDir, "first")
+	is.NoErr(err)
+
+	tests := []struct {
+		name       string
+		options    []Option
+		path       string
+		running    string
+		wantStatus int
+	}{
+		{"no revision store", nil, "/demo/spec/rollback?sha=" + first.String(), "", http.StatusNotImplemented},
+		{"missing sha", []Option{SetRevisionService(revisions)}, "/demo/spec/rollback", "", http.StatusBadRequest},
+		{"unknown sha", []Option{SetRevisionService(revisions)}, "/demo/spec/rollback?sha=unknown", "", http.StatusNotFound},
+		{"unknown dataset", []Option{SetRevisionService(revisions)}, "/demo/other/rollback?sha=" + first.String(), "", http.StatusNotFound},
+		{"invalid organization", []Option{SetRevisionService(revisions)}, "/demo2/spec/rollback?sha=" + first.String(), "", http.StatusBadRequest},
+		{"already running", []Option{SetRevisionService(revisions)}, "/demo/spec/rollback?sha=" + first.String(), "demo/spec", http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			svc, err := NewService(tt.options...)
+			is.NoErr(err)
+
+			if tt.running != "" {
+				svc.rollbacks[tt.running] = true
+			}
+
+			router := chi.NewRouter()
+			router.Post("/{orgID}/{datasetID}/rollback", svc.HandleRollback)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, tt.path, nil))
+			is.Equal(w.Code, tt.wantStatus)
+		})
+	}
+}
@@ -16,10 +16,21 @@ package bulk
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"sync"
 
+	"github.com/delving/hub3/config"
 	"github.com/delving/hub3/hub3/fragments"
+	"github.com/delving/hub3/hub3/models"
+	"github.com/delving/hub3/ikuzo/domain"
+	"github.com/delving/hub3/ikuzo/service/organization"
+	"github.com/delving/hub3/ikuzo/service/x/auth"
 	"github.com/delving/hub3/ikuzo/service/x/index"
+	"github.com/delving/hub3/ikuzo/service/x/namespace"
+	"github.com/delving/hub3/ikuzo/service/x/revision"
+	"github.com/delving/hub3/ikuzo/service/x/sparql"
 	"github.com/go-chi/render"
 	"github.com/rs/zerolog/log"
 )
@@ -27,15 +38,25 @@ import (
 type Option func(*Service) error
 
 type Service struct {
-	index      *index.Service
-	indexTypes []string
-	postHooks  map[string][]PostHookService
+	index           *index.Service
+	indexTypes      []string
+	postHooks       map[string][]PostHookService
+	postHookFactory PostHookFactory
+	store           sparql.Store
+	orgs            *organization.Service
+	namespaces      *namespace.Service
+	revisions       *revision.Service
+	// rollbacks are the datasets with a running rollback
+	rollbacks   map[string]bool
+	rollbacksMu sync.Mutex
+	running     sync.WaitGroup
 }
 
 func NewService(options ...Option) (*Service, error) {
 	s := &Service{
 		indexTypes: []string{"v2"},
 		postHooks:  map[string][]PostHookService{},
+		rollbacks:  map[string]bool{},
 	}
 
 	// apply options
@@ -45,6 +66,15 @@ func NewService(options ...Option) (*Service, error) {
 		}
 	}
 
+	if s.store == nil && config.Config.RDF.RDFStoreEnabled {
+		store, err := models.TripleStore()
+		if err != nil {
+			return nil, fmt.Errorf("unable to create triple store; %w", err)
+		}
+
+		s.store = store
+	}
+
 	return s, nil
 }
 
@@ -62,6 +92,15 @@ func SetIndexTypes(indexTypes ...string) Option {
 	}
 }
 
+// SetSparqlStore sets the triple store the RDF of the records is stored in.
+// default: the configured triple store when rdfStoreEnabled is true
+func SetSparqlStore(store sparql.Store) Option {
+	return func(s *Service) error {
+		s.store = store
+		return nil
+	}
+}
+
 func SetPostHookService(hooks ...PostHookService) Option {
 	return func(s *Service) error {
 		for _, hook := range hooks {
@@ -72,41 +111,116 @@ func SetPostHookService(hooks ...PostHookService) Option {
 	}
 }
 
+// SetOrganizationService sets the service the configuration of the
+// organization of the bulk request is resolved from.
+func SetOrganizationService(orgs *organization.Service) Option {
+	return func(s *Service) error {
+		s.orgs = orgs
+		return nil
+	}
+}
+
+// SetNameSpaceService enables the discovery of namespaces. The unknown
+// base-URIs of the predicates and classes in the ingested RDF are registered
+// as temporary namespaces.
+func SetNameSpaceService(ns *namespace.Service) Option {
+	return func(s *Service) error {
+		s.namespaces = ns
+		return nil
+	}
+}
+
+// SetRevisionService enables the revision store. The graph of each record is
+// written to the git repository of the dataset and each bulk request is
+// committed once. The index messages refer to the commit and the record path.
+func SetRevisionService(revisions *revision.Service) Option {
+	return func(s *Service) error {
+		s.revisions = revisions
+		return nil
+	}
+}
+
+// SetPostHookFactory sets the factory for the posthooks that are configured
+// in the organization.
+func SetPostHookFactory(factory PostHookFactory) Option {
+	return func(s *Service) error {
+		s.postHookFactory = factory
+		return nil
+	}
+}
+
+// orgPostHooks returns the posthooks of the global configuration and the
+// posthooks from the configuration of the organization.
+func (s *Service) orgPostHooks(org domain.Organization) []PostHookService {
+	hooks := append([]PostHookService{}, s.postHooks[string(org.ID)]...)
+
+	if s.postHookFactory == nil {
+		return hooks
+	}
+
+	for _, cfg := range org.Config.PostHooks {
+		hook, err := s.postHookFactory(string(org.ID), cfg)
+		if err != nil {
+			log.Error().Err(err).Str("svc", "bulk").Str("orgID", string(org.ID)).Msg("unable to create posthook")
+			continue
+		}
+
+		hooks = append(hooks, hook)
+	}
+
+	return hooks
+}
+
 // bulkApi receives bulkActions in JSON form (1 per line) and processes them in
 // ingestion pipeline.
 func (s *Service) Handle(w http.ResponseWriter, r *http.Request) {
 	p := s.NewParser()
 	if err := p.Parse(r.Context(), r.Body); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		status := http.StatusBadRequest
+		if errors.Is(err, auth.ErrForbidden) {
+			status = http.StatusForbidden
+		}
+
+		http.Error(w, err.Error(), status)
+
 		return
 	}
 
-	if len(s.postHooks) != 0 && len(p.postHooks) != 0 {
-		applyHooks, ok := s.postHooks[p.stats.OrgID]
-		if ok {
-			go func() {
-				for _, hook := range applyHooks {
-					validHooks := []*PostHookItem{}
+	s.applyPostHooks(p)
 
-					for _, ph := range p.postHooks {
-						if hook.Valid(ph.DatasetID) {
-							validHooks = append(validHooks, ph)
-						}
-					}
+	render.Status(r, http.StatusCreated)
+	log.Info().Msgf("stats: %+v", p.stats)
+	render.JSON(w, r, p.stats)
+}
 
-					if err := hook.Publish(validHooks...); err != nil {
-						log.Error().Err(err).Msg("unable to submit posthooks")
-					}
+// applyPostHooks submits the posthook items of the parser in the background.
+func (s *Service) applyPostHooks(p *Parser) {
+	if len(p.postHooks) == 0 {
+		return
+	}
+
+	applyHooks := s.orgPostHooks(p.org)
+	if len(applyHooks) == 0 {
+		return
+	}
+
+	go func() {
+		for _, hook := range applyHooks {
+			validHooks := []*PostHookItem{}
+
+			for _, ph := range p.postHooks {
+				if hook.Valid(ph.DatasetID) {
+					validHooks = append(validHooks, ph)
 				}
+			}
 
-				log.Debug().Msg("submitted posthooks")
-			}()
+			if err := hook.Publish(validHooks...); err != nil {
+				log.Error().Err(err).Msg("unable to submit posthooks")
+			}
 		}
-	}
 
-	render.Status(r, http.StatusCreated)
-	log.Info().Msgf("stats: %+v", p.stats)
-	render.JSON(w, r, p.stats)
+		log.Debug().Msg("submitted posthooks")
+	}()
 }
 
 func (s *Service) NewParser() *Parser {
@@ -114,9 +228,18 @@ func (s *Service) NewParser() *Parser {
 		stats:         &Stats{},
 		indexTypes:    s.indexTypes,
 		bi:            s.index,
+		store:         s.store,
+		orgs:          s.orgs,
+		revisions:     s.revisions,
+		orgPostHooks:  s.postHookFactory != nil,
 		sparqlUpdates: []fragments.SparqlUpdate{},
 	}
 
+	if s.namespaces != nil {
+		p.namespaces = s.namespaces
+		p.discovered = namespace.NewCollector()
+	}
+
 	if len(s.postHooks) != 0 {
 		p.postHooks = []*PostHookItem{}
 	}
@@ -127,6 +250,19 @@ func (s *Service) NewParser() *Parser {
 func (s *Service) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
+// Shutdown waits for the running rollbacks to finish.
 func (s *Service) Shutdown(ctx context.Context) error {
-	return nil
+	done := make(chan struct{})
+
+	go func() {
+		s.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
@@ -0,0 +1,166 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataset
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/delving/hub3/ikuzo/domain"
+)
+
+// errors
+var (
+	ErrDataSetNotFound = errors.New("dataset not found")
+	ErrDataSetExists   = errors.New("dataset already exists")
+	ErrInvalidID       = errors.New("dataset identifier may only contain letters, digits, '-', '_' and '.'")
+)
+
+// MaxLengthID is the maximum length of a dataset identifier.
+const MaxLengthID = 128
+
+var validID = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// ValidID validates the dataset identifier, which is also known as the spec.
+func ValidID(id string) error {
+	if id == "" {
+		return domain.ErrIDCannotBeEmpty
+	}
+
+	if len(id) > MaxLengthID {
+		return domain.ErrIDTooLong
+	}
+
+	if !validID.MatchString(id) {
+		return ErrInvalidID
+	}
+
+	return nil
+}
+
+// DataSet is a collection of records of an organization.
+// It is identified by the OrgID and the ID, so organizations can use the same ID.
+type DataSet struct {
+	OrgID domain.OrganizationID `json:"orgID" gorm:"primary_key"`
+	// ID is the unique identifier within the organization, also known as the spec.
+	ID string `json:"datasetID" gorm:"primary_key"`
+	// Revision is used to mark the latest version of the ingested records.
+	Revision int `json:"revision"`
+	// FragmentRevision is the latest version of the LOD fragments.
+	FragmentRevision int       `json:"fragmentRevision"`
+	Access           Access    `json:"access" gorm:"embedded;embedded_prefix:access_"`
+	Metadata         Metadata  `json:"metadata" gorm:"type:text"`
+	Stats            Stats     `json:"stats" gorm:"type:text"`
+	Created          time.Time `json:"created"`
+	Modified         time.Time `json:"modified"`
+}
+
+// Access determines which types of access are enabled for the dataset.
+type Access struct {
+	OAIPMH bool `json:"oaipmh"`
+	Search bool `json:"search"`
+	LOD    bool `json:"lod"`
+}
+
+// PublicAccess is the default Access of a new DataSet.
+var PublicAccess = Access{OAIPMH: true, Search: true, LOD: true}
+
+// Metadata is the descriptive information of a dataset.
+type Metadata struct {
+	Label          string   `json:"label,omitempty"`
+	Description    string   `json:"description,omitempty"`
+	Owner          string   `json:"owner,omitempty"`
+	RecordType     string   `json:"recordType,omitempty"`
+	Language       string   `json:"language,omitempty"`
+	Material       string   `json:"material,omitempty"`
+	Abstract       []string `json:"abstract,omitempty"`
+	Period         []string `json:"period,omitempty"`
+	ArchiveCreator []string `json:"archiveCreator,omitempty"`
+	Tags           []string `json:"tags,omitempty"`
+}
+
+// Value stores the Metadata as JSON in the database.
+func (m Metadata) Value() (driver.Value, error) {
+	return jsonValue(m)
+}
+
+// Scan reads the Metadata from JSON in the database.
+func (m *Metadata) Scan(src interface{}) error {
+	return jsonScan(src, m)
+}
+
+// Stats are the counters of the records of a dataset.
+type Stats struct {
+	Records        int       `json:"records"`
+	ValidRecords   int       `json:"validRecords"`
+	InvalidRecords int       `json:"invalidRecords"`
+	Fragments      int       `json:"fragments"`
+	Graphs         int       `json:"graphs"`
+	DigitalObjects int       `json:"digitalObjects"`
+	Updated        time.Time `json:"updated"`
+}
+
+// Value stores the Stats as JSON in the database.
+func (s Stats) Value() (driver.Value, error) {
+	return jsonValue(s)
+}
+
+// Scan reads the Stats from JSON in the database.
+func (s *Stats) Scan(src interface{}) error {
+	return jsonScan(src, s)
+}
+
+func jsonValue(v interface{}) (driver.Value, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(b), nil
+}
+
+func jsonScan(src, v interface{}) error {
+	switch data := src.(type) {
+	case nil:
+		return nil
+	case string:
+		return json.Unmarshal([]byte(data), v)
+	case []byte:
+		return json.Unmarshal(data, v)
+	}
+
+	return fmt.Errorf("unable to scan %T into %T", src, v)
+}
+
+// Update is a partial update of a DataSet. Only the fields that are not nil
+// are changed, together with the Modified time.
+type Update struct {
+	Access   *Access
+	Metadata *Metadata
+	Stats    *Stats
+	Modified time.Time
+}
+
+// Filter selects the datasets of an organization.
+type Filter struct {
+	// OffSet is the start of the results returned
+	OffSet int
+	// Limit is the number of items returned from the filter
+	Limit int
+}
@@ -12,4 +12,11 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
+// Package dataset manages the datasets of an organization.
+//
+// A DataSet is identified by the organization and its identifier (the spec),
+// so different organizations can use the same identifier. The service keeps
+// track of the revisions, access flags, descriptive metadata and record
+// statistics. The Store is implemented in ikuzo/storage/memory and
+// ikuzo/storage/x/gorm.
 package dataset
@@ -0,0 +1,249 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataset
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/delving/hub3/ikuzo/domain"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+)
+
+// Routes returns the dataset routes. They must be mounted under a path
+// that contains the '{orgID}' URL parameter, e.g. '/organizations/{orgID}/datasets'.
+func (s *Service) Routes() chi.Router {
+	router := chi.NewRouter()
+
+	router.Get("/", s.handleFilter)
+	router.Post("/", s.handleCreate)
+	router.Get("/{datasetID}", s.handleGet)
+	router.Put("/{datasetID}", s.handlePut)
+	router.Delete("/{datasetID}", s.handleDelete)
+	router.Post("/{datasetID}/revision", s.handleIncrementRevision)
+	router.Put("/{datasetID}/access", s.handleSetAccess)
+	router.Put("/{datasetID}/metadata", s.handleSetMetadata)
+	router.Put("/{datasetID}/stats", s.handleSetStats)
+
+	return router
+}
+
+func orgID(r *http.Request) domain.OrganizationID {
+	return domain.OrganizationID(chi.URLParam(r, "orgID"))
+}
+
+func datasetID(r *http.Request) string {
+	return chi.URLParam(r, "datasetID")
+}
+
+// httpError writes the error with the status code that matches the error.
+func httpError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+
+	switch {
+	case errors.Is(err, ErrDataSetNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, ErrDataSetExists):
+		status = http.StatusConflict
+	case errors.Is(err, ErrInvalidID),
+		errors.Is(err, domain.ErrIDCannotBeEmpty),
+		errors.Is(err, domain.ErrIDTooLong),
+		errors.Is(err, domain.ErrIDNotLowercase),
+		errors.Is(err, domain.ErrIDExists),
+		errors.Is(err, domain.ErrIDInvalidCharacter):
+		status = http.StatusBadRequest
+	}
+
+	http.Error(w, err.Error(), status)
+}
+
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return false
+	}
+
+	return true
+}
+
+func (s *Service) handleFilter(w http.ResponseWriter, r *http.Request) {
+	var filter Filter
+
+	for key, target := range map[string]*int{"offset": &filter.OffSet, "limit": &filter.Limit} {
+		value := r.URL.Query().Get(key)
+		if value == "" {
+			continue
+		}
+
+		i, err := strconv.Atoi(value)
+		if err != nil || i < 0 {
+			http.Error(w, "invalid "+key+" parameter", http.StatusBadRequest)
+			return
+		}
+
+		*target = i
+	}
+
+	datasets, err := s.Filter(r.Context(), orgID(r), filter)
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+
+	render.JSON(w, r, datasets)
+}
+
+func (s *Service) handleCreate(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID       string   `json:"datasetID"`
+		Metadata Metadata `json:"metadata"`
+	}
+
+	if !decode(w, r, &req) {
+		return
+	}
+
+	ds, err := s.Create(r.Context(), orgID(r), req.ID, req.Metadata)
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, ds)
+}
+
+func (s *Service) handleGet(w http.ResponseWriter, r *http.Request) {
+	ds, err := s.Get(r.Context(), orgID(r), datasetID(r))
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+
+	render.JSON(w, r, ds)
+}
+
+func (s *Service) handlePut(w http.ResponseWriter, r *http.Request) {
+	var ds DataSet
+
+	if !decode(w, r, &ds) {
+		return
+	}
+
+	// the identifiers in the path take precedence over the body
+	ds.OrgID = orgID(r)
+	ds.ID = datasetID(r)
+
+	_, err := s.Get(r.Context(), ds.OrgID, ds.ID)
+
+	switch {
+	case err == nil:
+		// the revisions are only changed by IncrementRevision, so the other
+		// fields are updated without writing the revisions
+		_, err = s.update(r.Context(), ds.OrgID, ds.ID, Update{
+			Access:   &ds.Access,
+			Metadata: &ds.Metadata,
+			Stats:    &ds.Stats,
+		})
+	case errors.Is(err, ErrDataSetNotFound):
+		ds.Revision = 1
+		ds.FragmentRevision = 0
+		ds.Created = time.Time{}
+		err = s.Put(r.Context(), ds)
+	}
+
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+
+	s.handleGet(w, r)
+}
+
+func (s *Service) handleDelete(w http.ResponseWriter, r *http.Request) {
+	if _, err := s.Get(r.Context(), orgID(r), datasetID(r)); err != nil {
+		httpError(w, err)
+		return
+	}
+
+	if err := s.Delete(r.Context(), orgID(r), datasetID(r)); err != nil {
+		httpError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Service) handleIncrementRevision(w http.ResponseWriter, r *http.Request) {
+	ds, err := s.IncrementRevision(r.Context(), orgID(r), datasetID(r))
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+
+	render.JSON(w, r, ds)
+}
+
+func (s *Service) handleSetAccess(w http.ResponseWriter, r *http.Request) {
+	var access Access
+
+	if !decode(w, r, &access) {
+		return
+	}
+
+	ds, err := s.SetAccess(r.Context(), orgID(r), datasetID(r), access)
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+
+	render.JSON(w, r, ds)
+}
+
+func (s *Service) handleSetMetadata(w http.ResponseWriter, r *http.Request) {
+	var metadata Metadata
+
+	if !decode(w, r, &metadata) {
+		return
+	}
+
+	ds, err := s.SetMetadata(r.Context(), orgID(r), datasetID(r), metadata)
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+
+	render.JSON(w, r, ds)
+}
+
+func (s *Service) handleSetStats(w http.ResponseWriter, r *http.Request) {
+	var stats Stats
+
+	if !decode(w, r, &stats) {
+		return
+	}
+
+	ds, err := s.SetStats(r.Context(), orgID(r), datasetID(r), stats)
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+
+	render.JSON(w, r, ds)
+}
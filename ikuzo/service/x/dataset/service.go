@@ -0,0 +1,171 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/delving/hub3/ikuzo/domain"
+)
+
+// Store is the storage interface for the dataset.Service.
+type Store interface {
+	Delete(ctx context.Context, orgID domain.OrganizationID, id string) error
+	Get(ctx context.Context, orgID domain.OrganizationID, id string) (DataSet, error)
+	Filter(ctx context.Context, orgID domain.OrganizationID, filter ...Filter) ([]DataSet, error)
+	Put(ctx context.Context, ds DataSet) error
+	// IncrementRevision atomically increments the revision of the dataset and returns the new revision.
+	IncrementRevision(ctx context.Context, orgID domain.OrganizationID, id string) (int, error)
+	// Update atomically applies the partial Update to the stored dataset.
+	Update(ctx context.Context, orgID domain.OrganizationID, id string, update Update) error
+	Shutdown(ctx context.Context) error
+}
+
+// Service manages all interactions with the DataSet Store
+type Service struct {
+	store Store
+}
+
+// NewService creates a dataset.Service.
+// The dataset.Store implementation is the storage backend for the service.
+func NewService(store Store) (*Service, error) {
+	if store == nil {
+		return nil, fmt.Errorf("dataset.Store implementation cannot be nil")
+	}
+
+	return &Service{store: store}, nil
+}
+
+func valid(orgID domain.OrganizationID, id string) error {
+	if err := orgID.Valid(); err != nil {
+		return err
+	}
+
+	return ValidID(id)
+}
+
+// Create stores a new DataSet with public access and revision 1.
+// It returns ErrDataSetExists when the dataset is already stored.
+func (s *Service) Create(ctx context.Context, orgID domain.OrganizationID, id string, metadata Metadata) (DataSet, error) {
+	if err := valid(orgID, id); err != nil {
+		return DataSet{}, err
+	}
+
+	_, err := s.store.Get(ctx, orgID, id)
+	if err == nil {
+		return DataSet{}, ErrDataSetExists
+	}
+
+	if !errors.Is(err, ErrDataSetNotFound) {
+		return DataSet{}, err
+	}
+
+	now := time.Now().UTC()
+
+	ds := DataSet{
+		OrgID:    orgID,
+		ID:       id,
+		Revision: 1,
+		Access:   PublicAccess,
+		Metadata: metadata,
+		Created:  now,
+		Modified: now,
+	}
+
+	if err := s.store.Put(ctx, ds); err != nil {
+		return DataSet{}, err
+	}
+
+	return ds, nil
+}
+
+// Get returns a DataSet and returns ErrDataSetNotFound when the DataSet is not found.
+func (s *Service) Get(ctx context.Context, orgID domain.OrganizationID, id string) (DataSet, error) {
+	return s.store.Get(ctx, orgID, id)
+}
+
+// Filter returns the datasets of the organization ordered by ID.
+func (s *Service) Filter(ctx context.Context, orgID domain.OrganizationID, filter ...Filter) ([]DataSet, error) {
+	return s.store.Filter(ctx, orgID, filter...)
+}
+
+// Put stores the DataSet in the Service Store.
+func (s *Service) Put(ctx context.Context, ds DataSet) error {
+	if err := valid(ds.OrgID, ds.ID); err != nil {
+		return err
+	}
+
+	if ds.Created.IsZero() {
+		ds.Created = time.Now().UTC()
+	}
+
+	ds.Modified = time.Now().UTC()
+
+	return s.store.Put(ctx, ds)
+}
+
+// Delete removes the DataSet from the Store.
+func (s *Service) Delete(ctx context.Context, orgID domain.OrganizationID, id string) error {
+	return s.store.Delete(ctx, orgID, id)
+}
+
+// IncrementRevision bumps the revision of the DataSet and returns the updated DataSet.
+func (s *Service) IncrementRevision(ctx context.Context, orgID domain.OrganizationID, id string) (DataSet, error) {
+	if _, err := s.store.IncrementRevision(ctx, orgID, id); err != nil {
+		return DataSet{}, err
+	}
+
+	return s.store.Get(ctx, orgID, id)
+}
+
+// update applies the partial Update in the Store and returns the updated DataSet.
+// The other fields are not written, so concurrent updates of other fields are kept.
+func (s *Service) update(ctx context.Context, orgID domain.OrganizationID, id string, u Update) (DataSet, error) {
+	u.Modified = time.Now().UTC()
+
+	if err := s.store.Update(ctx, orgID, id, u); err != nil {
+		return DataSet{}, err
+	}
+
+	return s.store.Get(ctx, orgID, id)
+}
+
+// SetAccess replaces the Access of the DataSet.
+func (s *Service) SetAccess(ctx context.Context, orgID domain.OrganizationID, id string, access Access) (DataSet, error) {
+	return s.update(ctx, orgID, id, Update{Access: &access})
+}
+
+// SetMetadata replaces the Metadata of the DataSet.
+func (s *Service) SetMetadata(ctx context.Context, orgID domain.OrganizationID, id string, metadata Metadata) (DataSet, error) {
+	return s.update(ctx, orgID, id, Update{Metadata: &metadata})
+}
+
+// SetStats replaces the Stats of the DataSet. When Updated is not set the current time is used.
+func (s *Service) SetStats(ctx context.Context, orgID domain.OrganizationID, id string, stats Stats) (DataSet, error) {
+	if stats.Updated.IsZero() {
+		stats.Updated = time.Now().UTC()
+	}
+
+	return s.update(ctx, orgID, id, Update{Stats: &stats})
+}
+
+// Shutdown gracefully shutsdown the dataset.Service store.
+// The ctx should have a timeout that cancels when the deadline is exceeded.
+func (s *Service) Shutdown(ctx context.Context) error {
+	return s.store.Shutdown(ctx)
+}
@@ -0,0 +1,219 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// nolint:gocritic
+package dataset_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/delving/hub3/ikuzo/domain"
+	"github.com/delving/hub3/ikuzo/service/x/dataset"
+	"github.com/delving/hub3/ikuzo/storage/memory"
+	"github.com/go-chi/chi"
+	"github.com/matryer/is"
+)
+
+func TestValidID(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+		want error
+	}{
+		{"simple", "spec", nil},
+		{"with separators", "spec-1_a.b", nil},
+		{"empty", "", domain.ErrIDCannotBeEmpty},
+		{"leading dash", "-spec", dataset.ErrInvalidID},
+		{"slash", "spec/1", dataset.ErrInvalidID},
+		{"too long", strings.Repeat("a", dataset.MaxLengthID+1), domain.ErrIDTooLong},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if err := dataset.ValidID(tt.id); !errors.Is(err, tt.want) {
+				t.Errorf("ValidID() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestService(t *testing.T) {
+	is := is.New(t)
+	ctx := context.TODO()
+
+	_, err := dataset.NewService(nil)
+	is.True(err != nil)
+
+	svc, err := dataset.NewService(memory.NewDataSetStore())
+	is.NoErr(err)
+
+	ds, err := svc.Create(ctx, "demo", "spec", dataset.Metadata{Label: "Spec"})
+	is.NoErr(err)
+	is.Equal(ds.Revision, 1)
+	is.Equal(ds.Access, dataset.PublicAccess)
+
+	_, err = svc.Create(ctx, "demo", "spec", dataset.Metadata{})
+	is.True(errors.Is(err, dataset.ErrDataSetExists))
+
+	_, err = svc.Create(ctx, "Demo", "spec", dataset.Metadata{})
+	is.True(errors.Is(err, domain.ErrIDNotLowercase))
+
+	ds, err = svc.IncrementRevision(ctx, "demo", "spec")
+	is.NoErr(err)
+	is.Equal(ds.Revision, 2)
+
+	ds, err = svc.SetAccess(ctx, "demo", "spec", dataset.Access{Search: true})
+	is.NoErr(err)
+	is.True(!ds.Access.LOD)
+	is.Equal(ds.Metadata.Label, "Spec")
+
+	ds, err = svc.SetStats(ctx, "demo", "spec", dataset.Stats{Records: 10})
+	is.NoErr(err)
+	is.Equal(ds.Stats.Records, 10)
+	is.True(!ds.Stats.Updated.IsZero())
+
+	ds, err = svc.SetMetadata(ctx, "demo", "spec", dataset.Metadata{Label: "New"})
+	is.NoErr(err)
+	is.Equal(ds.Metadata.Label, "New")
+	is.Equal(ds.Stats.Records, 10)
+	is.Equal(ds.Revision, 2)
+
+	_, err = svc.SetAccess(ctx, "demo", "unknown", dataset.PublicAccess)
+	is.True(errors.Is(err, dataset.ErrDataSetNotFound))
+
+	is.NoErr(svc.Delete(ctx, "demo", "spec"))
+
+	_, err = svc.Get(ctx, "demo", "spec")
+	is.True(errors.Is(err, dataset.ErrDataSetNotFound))
+
+	is.NoErr(svc.Shutdown(ctx))
+}
+
+func TestService_concurrentUpdates(t *testing.T) {
+	is := is.New(t)
+	ctx := context.TODO()
+
+	svc, err := dataset.NewService(memory.NewDataSetStore())
+	is.NoErr(err)
+
+	_, err = svc.Create(ctx, "demo", "spec", dataset.Metadata{Label: "Spec"})
+	is.NoErr(err)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 50; i++ {
+		wg.Add(3)
+
+		go func() {
+			defer wg.Done()
+
+			_, err := svc.IncrementRevision(ctx, "demo", "spec")
+			is.NoErr(err)
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			_, err := svc.SetStats(ctx, "demo", "spec", dataset.Stats{Records: 10})
+			is.NoErr(err)
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			_, err := svc.SetAccess(ctx, "demo", "spec", dataset.Access{Search: true})
+			is.NoErr(err)
+		}()
+	}
+
+	wg.Wait()
+
+	// the partial updates do not overwrite the revision
+	ds, err := svc.Get(ctx, "demo", "spec")
+	is.NoErr(err)
+	is.Equal(ds.Revision, 51)
+	is.Equal(ds.Stats.Records, 10)
+	is.Equal(ds.Metadata.Label, "Spec")
+}
+
+func TestService_Routes(t *testing.T) {
+	svc, err := dataset.NewService(memory.NewDataSetStore())
+	if err != nil {
+		t.Fatalf("unable to create service; %s", err)
+	}
+
+	router := chi.NewRouter()
+	router.Mount("/organizations/{orgID}/datasets", svc.Routes())
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"create", http.MethodPost, "/demo/datasets", `{"datasetID": "spec", "metadata": {"label": "Spec"}}`, http.StatusCreated, `"label":"Spec"`},
+		{"create duplicate", http.MethodPost, "/demo/datasets", `{"datasetID": "spec"}`, http.StatusConflict, ""},
+		{"create invalid id", http.MethodPost, "/demo/datasets", `{"datasetID": "spec/1"}`, http.StatusBadRequest, ""},
+		{"create invalid org", http.MethodPost, "/Demo/datasets", `{"datasetID": "spec"}`, http.StatusBadRequest, ""},
+		{"create invalid body", http.MethodPost, "/demo/datasets", `{`, http.StatusBadRequest, ""},
+		{"get", http.MethodGet, "/demo/datasets/spec", "", http.StatusOK, `"datasetID":"spec"`},
+		{"get from other org", http.MethodGet, "/other/datasets/spec", "", http.StatusNotFound, ""},
+		{"revision", http.MethodPost, "/demo/datasets/spec/revision", "", http.StatusOK, `"revision":2`},
+		{"access", http.MethodPut, "/demo/datasets/spec/access", `{"oaipmh": true}`, http.StatusOK, `"access":{"oaipmh":true,"search":false,"lod":false}`},
+		{"metadata", http.MethodPut, "/demo/datasets/spec/metadata", `{"label": "New"}`, http.StatusOK, `"label":"New"`},
+		{"stats", http.MethodPut, "/demo/datasets/spec/stats", `{"records": 5}`, http.StatusOK, `"records":5`},
+		{"put ignores revisions", http.MethodPut, "/demo/datasets/spec", `{"revision": 7, "fragmentRevision": 3, "metadata": {"label": "Put"}}`, http.StatusOK, `"revision":2,"fragmentRevision":0`},
+		{"put new dataset", http.MethodPut, "/demo/datasets/other", `{"revision": 7}`, http.StatusOK, `"revision":1`},
+		{"delete put dataset", http.MethodDelete, "/demo/datasets/other", "", http.StatusNoContent, ""},
+		{"filter", http.MethodGet, "/demo/datasets", "", http.StatusOK, `"datasetID":"spec"`},
+		{"filter with offset", http.MethodGet, "/demo/datasets?offset=1", "", http.StatusOK, `[]`},
+		{"filter with invalid limit", http.MethodGet, "/demo/datasets?limit=a", "", http.StatusBadRequest, ""},
+		{"delete", http.MethodDelete, "/demo/datasets/spec", "", http.StatusNoContent, ""},
+		{"delete unknown", http.MethodDelete, "/demo/datasets/spec", "", http.StatusNotFound, ""},
+	}
+
+	// the tests depend on each other so they must not run in parallel
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, "/organizations"+tt.path, strings.NewReader(tt.body))
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		if rr.Code != tt.wantStatus {
+			t.Fatalf("%s: status = %d, want %d; %s", tt.name, rr.Code, tt.wantStatus, rr.Body.String())
+		}
+
+		if tt.wantBody != "" && !strings.Contains(rr.Body.String(), tt.wantBody) {
+			t.Errorf("%s: body = %s, want it to contain %s", tt.name, rr.Body.String(), tt.wantBody)
+		}
+	}
+
+	ds, err := svc.Filter(context.TODO(), "demo")
+	if err != nil {
+		t.Fatalf("unable to filter datasets; %s", err)
+	}
+
+	if len(ds) != 0 {
+		t.Errorf("expected no datasets after delete, got %d", len(ds))
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ead
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/delving/hub3/config"
+	eadHub3 "github.com/delving/hub3/hub3/ead"
+	"github.com/delving/hub3/ikuzo/domain"
+	"github.com/go-chi/render"
+)
+
+// validRecordID guards the file name of stored authority records.
+var validRecordID = regexp.MustCompile(`^[\w.\-]+$`)
+
+// getAuthorityPath returns the directory where the EAC-CPF records are stored.
+func (s *Service) getAuthorityPath() string {
+	return s.authorityDir
+}
+
+// SaveAuthority stores an EAC-CPF record so it is linked from the archives that are processed afterwards.
+func (s *Service) SaveAuthority(r io.Reader) (*eadHub3.Authority, error) {
+	var buf bytes.Buffer
+
+	eac, err := eadHub3.ParseEACCPF(io.TeeReader(r, &buf))
+	if err != nil {
+		return nil, err
+	}
+
+	a := eac.Authority()
+	if !validRecordID.MatchString(a.ID) {
+		return nil, fmt.Errorf("invalid EAC-CPF recordId: %s", a.ID)
+	}
+
+	if err := os.MkdirAll(s.getAuthorityPath(), os.ModePerm); err != nil {
+		return nil, fmt.Errorf("unable to create authority directory; %w", err)
+	}
+
+	// the decoder can stop before the end of the document
+	if _, err := io.Copy(&buf, r); err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(s.getAuthorityPath(), a.ID+".xml")
+	if err := ioutil.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return nil, fmt.Errorf("unable to store EAC-CPF record; %w", err)
+	}
+
+	return a, nil
+}
+
+// IndexAuthority publishes the triples of the Authority to the index service.
+func (s *Service) IndexAuthority(ctx context.Context, orgID string, a *eadHub3.Authority) error {
+	if s.index == nil {
+		return nil
+	}
+
+	fg, err := a.FragmentGraph(orgID)
+	if err != nil {
+		return fmt.Errorf("unable to create authority fragment graph; %w", err)
+	}
+
+	m, err := fg.IndexMessage()
+	if err != nil {
+		return fmt.Errorf("unable to marshal authority fragment graph; %w", err)
+	}
+
+	return s.index.Publish(ctx, m)
+}
+
+// UploadAuthority is the http.HandlerFunc to submit EAC-CPF records in the 'eac' form file.
+//
+// The record is stored and indexed, and returned as an Authority.
+func (s *Service) UploadAuthority(w http.ResponseWriter, r *http.Request) {
+	in, _, err := r.FormFile("eac")
+	if err != nil {
+		http.Error(w, "cannot find eac form file", http.StatusBadRequest)
+		return
+	}
+
+	defer in.Close()
+	// cleanup upload
+	defer func() {
+		err = r.MultipartForm.RemoveAll()
+	}()
+
+	a, err := s.SaveAuthority(in)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	orgID := config.Config.OrgID
+	if org, ok := domain.GetOrganization(r.Context()); ok {
+		orgID = string(org.ID)
+	}
+
+	if err := s.IndexAuthority(r.Context(), orgID, a); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, a)
+}
@@ -17,6 +17,8 @@ package ead
 import (
 	"fmt"
 	"time"
+
+	"github.com/delving/hub3/config"
 )
 
 type Meta struct {
@@ -37,6 +39,15 @@ type Meta struct {
 	ProcessingDurationFmt string        `json:"processingDurationFmt,omitempty"`
 }
 
+// orgID returns the OrgID or the orgID from the global configuration when it is empty.
+func (m *Meta) orgID() string {
+	if m.OrgID != "" {
+		return m.OrgID
+	}
+
+	return config.Config.OrgID
+}
+
 // getSourcePath returns full path to the source EAD file
 func (m *Meta) getSourcePath() string {
 	return fmt.Sprintf("%s/%s.xml", m.basePath, m.DatasetID)
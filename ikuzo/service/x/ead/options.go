@@ -15,6 +15,9 @@
 package ead
 
 import (
+	"net/http"
+
+	eadHub3 "github.com/delving/hub3/hub3/ead"
 	"github.com/delving/hub3/ikuzo/service/x/index"
 )
 
@@ -27,6 +30,15 @@ func SetDataDir(path string) Option {
 	}
 }
 
+// SetAuthorityDir sets the directory where the EAC-CPF authority records are stored.
+// default: the dataDir with the '-authorities' suffix
+func SetAuthorityDir(path string) Option {
+	return func(s *Service) error {
+		s.authorityDir = path
+		return nil
+	}
+}
+
 func SetIndexService(is *index.Service) Option {
 	return func(s *Service) error {
 		s.index = is
@@ -50,3 +62,50 @@ func SetWorkers(workers int) Option {
 		return nil
 	}
 }
+
+// SetRequiredFields sets the c-level fields that are required for EADs of an organization.
+func SetRequiredFields(orgID string, fields ...string) Option {
+	return func(s *Service) error {
+		for _, field := range fields {
+			if err := eadHub3.ValidRequiredField(field); err != nil {
+				return err
+			}
+		}
+
+		s.rules[orgID] = fields
+
+		return nil
+	}
+}
+
+// SetDaoClient sets the http.Client that is used to check if dao links are reachable.
+func SetDaoClient(client *http.Client) Option {
+	return func(s *Service) error {
+		s.daoClient = client
+		return nil
+	}
+}
+
+// SetGeneratePDF enables generating a printable finding aid PDF each time an EAD is processed.
+func SetGeneratePDF(enabled bool) Option {
+	return func(s *Service) error {
+		s.generatePDF = enabled
+		return nil
+	}
+}
+
+// SetEntityExtractor enables the extraction of named entities from the c-level descriptions.
+func SetEntityExtractor(extractor *eadHub3.EntityExtractor) Option {
+	return func(s *Service) error {
+		s.extractor = extractor
+		return nil
+	}
+}
+
+// SetReconciler sets the Reconciler that links the extracted entities to a vocabulary.
+func SetReconciler(reconciler eadHub3.Reconciler) Option {
+	return func(s *Service) error {
+		s.reconciler = reconciler
+		return nil
+	}
+}
@@ -31,10 +31,10 @@ import (
 	"sync/atomic"
 	"time"
 
-	"github.com/delving/hub3/config"
 	eadHub3 "github.com/delving/hub3/hub3/ead"
 	"github.com/delving/hub3/hub3/fragments"
 	"github.com/delving/hub3/hub3/models"
+	"github.com/delving/hub3/ikuzo/domain"
 	"github.com/delving/hub3/ikuzo/service/x/index"
 	"github.com/go-chi/chi"
 	"github.com/go-chi/render"
@@ -80,6 +80,7 @@ type CreateTreeFn func(cfg *eadHub3.NodeConfig, n *eadHub3.Node, hubID string, i
 type Service struct {
 	index        *index.Service
 	dataDir      string
+	authorityDir string
 	m            Metrics
 	CreateTreeFn CreateTreeFn
 	tasks        map[string]*Task
@@ -87,12 +88,18 @@ type Service struct {
 	workers      int
 	cancel       context.CancelFunc
 	group        *errgroup.Group
+	rules        map[string][]string
+	daoClient    *http.Client
+	generatePDF  bool
+	extractor    *eadHub3.EntityExtractor
+	reconciler   eadHub3.Reconciler
 }
 
 func NewService(options ...Option) (*Service, error) {
 	s := &Service{
 		tasks:   make(map[string]*Task),
 		workers: 1,
+		rules:   make(map[string][]string),
 	}
 
 	// apply options
@@ -106,6 +113,16 @@ func NewService(options ...Option) (*Service, error) {
 		s.CreateTreeFn = eadHub3.CreateTree
 	}
 
+	// the authorities are stored outside the dataDir, where each directory is an EAD
+	if s.authorityDir == "" {
+		base := s.dataDir
+		if base == "" {
+			base = "ead"
+		}
+
+		s.authorityDir = filepath.Clean(base) + "-authorities"
+	}
+
 	// create datadir
 	if s.dataDir != "" {
 		createErr := os.MkdirAll(s.dataDir, os.ModePerm)
@@ -310,14 +327,8 @@ func (s *Service) saveDescription(cfg *eadHub3.NodeConfig, t *Task, ead *eadHub3
 }
 
 func getEAD(r io.Reader) (*eadHub3.Cead, error) {
-	// parse EAD
-	ead := new(eadHub3.Cead)
-
-	if err := xml.NewDecoder(r).Decode(ead); err != nil {
-		return ead, err
-	}
-
-	return ead, nil
+	// parse EAD 2002 or EAD3
+	return eadHub3.ParseEAD(r)
 }
 
 func (s *Service) Process(parentCtx context.Context, t *Task) error {
@@ -373,9 +384,15 @@ func (s *Service) Process(parentCtx context.Context, t *Task) error {
 	cfg := eadHub3.NewNodeConfig(gctx)
 	cfg.CreateTree = s.CreateTreeFn
 	cfg.Spec = t.Meta.DatasetID
-	cfg.OrgID = config.Config.OrgID
+	cfg.OrgID = t.Meta.orgID()
 	cfg.IndexService = s.index
 	cfg.Tags = t.Meta.Tags
+	cfg.Extractor = s.extractor
+	cfg.Reconciler = s.reconciler
+
+	if err := cfg.LoadAuthorities(s.getAuthorityPath()); err != nil {
+		return t.finishWithError(fmt.Errorf("unable to load authority records; %w", err))
+	}
 
 	cfg.Nodes = make(chan *eadHub3.Node, 2000)
 
@@ -561,6 +578,15 @@ func (s *Service) Process(parentCtx context.Context, t *Task) error {
 			return fmt.Errorf("unable to save ead meta for %s; %w", meta.DatasetID, err)
 		}
 
+		// a PDF error should not stop the processing of the EAD
+		if s.generatePDF {
+			if pdfErr := s.writePDF(t); pdfErr != nil {
+				t.log().Error().Err(pdfErr).Msg("unable to generate finding aid PDF")
+			}
+		} else if pdfErr := s.removePDF(t); pdfErr != nil {
+			t.log().Error().Err(pdfErr).Msg("unable to remove stale finding aid PDF")
+		}
+
 		t.Meta.Clevels = cfg.Counter.GetCount()
 		t.Meta.DaoLinks = cfg.MetsCounter.GetCount()
 		t.Meta.RecordsPublished = atomic.LoadUint64(&cfg.RecordsPublishedCounter)
@@ -584,6 +610,55 @@ func (s *Service) Process(parentCtx context.Context, t *Task) error {
 	return nil
 }
 
+// writePDF renders the finding aid PDF from the stored EAD source file.
+func (s *Service) writePDF(t *Task) error {
+	start := time.Now()
+
+	f, err := os.Open(t.Meta.getSourcePath())
+	if err != nil {
+		return fmt.Errorf("unable to find EAD source file: %w", err)
+	}
+	defer f.Close()
+
+	ead, err := getEAD(f)
+	if err != nil {
+		return fmt.Errorf("error during EAD parsing; %w", err)
+	}
+
+	desc, err := eadHub3.NewDescription(ead)
+	if err != nil {
+		return fmt.Errorf("unable to create description; %w", err)
+	}
+
+	cfg := eadHub3.NewNodeConfig(t.ctx)
+	cfg.Spec = t.Meta.DatasetID
+	cfg.OrgID = t.Meta.orgID()
+
+	tree, err := ead.NewTreeExport(cfg)
+	if err != nil {
+		return fmt.Errorf("unable to create tree for PDF; %w", err)
+	}
+
+	if err := eadHub3.WritePDF(s.getDataPath(t.Meta.DatasetID), t.Meta.DatasetID, desc, tree); err != nil {
+		return fmt.Errorf("unable to write PDF; %w", err)
+	}
+
+	t.log().Info().Dur("duration", time.Since(start)).Msg("generated finding aid PDF")
+
+	return nil
+}
+
+// removePDF removes the finding aid PDF of a previous upload, so that it is not
+// served for the new version of the EAD.
+func (s *Service) removePDF(t *Task) error {
+	err := os.Remove(eadHub3.PDFPath(s.getDataPath(t.Meta.DatasetID), t.Meta.DatasetID))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
 type taskResponse struct {
 	TaskID    string `json:"taskID"`
 	OrgID     string `json:"orgID,omitempty"`
@@ -606,8 +681,38 @@ func (s *Service) handleUpload(w http.ResponseWriter, r *http.Request) {
 
 	s.m.incSubmitted()
 
-	_, meta, err := s.SaveEAD(in, header.Size)
+	buf, tmpFile, err := s.storeEAD(in, header.Size)
+	if err != nil {
+		s.m.incFailed()
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	// the upload is validated before it replaces the stored source EAD
+	if strings.EqualFold(r.FormValue("validate"), "true") {
+		report, validErr := s.Validate(r.Context(), bytes.NewReader(buf.Bytes()), "", false)
+		if validErr != nil {
+			os.Remove(tmpFile)
+			http.Error(w, validErr.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		if !report.Valid {
+			os.Remove(tmpFile)
+			s.m.incFailed()
+			render.Status(r, http.StatusUnprocessableEntity)
+			render.JSON(w, r, report)
+
+			return
+		}
+	}
+
+	meta, err := s.moveTmpFile(buf, tmpFile)
 	if err != nil {
+		os.Remove(tmpFile)
+
 		if errors.Is(err, ErrTaskAlreadySubmitted) {
 			http.Error(w, err.Error(), http.StatusConflict)
 			return
@@ -619,6 +724,13 @@ func (s *Service) handleUpload(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	meta.FileSize = uint64(header.Size)
+
+	if org, ok := domain.GetOrganization(r.Context()); ok {
+		meta.OrgID = string(org.ID)
+		meta.Tags = append(meta.Tags, org.Config.DefaultTags...)
+	}
+
 	t, err := s.NewTask(&meta)
 	if err != nil {
 		s.m.incAlreadyQueued()
@@ -680,7 +792,8 @@ L:
 
 		switch elem := t.(type) {
 		case xml.StartElement:
-			if elem.Name.Local == "eadid" {
+			// recordid is the identifier of EAD3
+			if elem.Name.Local == "eadid" || elem.Name.Local == "recordid" {
 				inElem = true
 			}
 
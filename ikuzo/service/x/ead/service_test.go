@@ -16,14 +16,22 @@ package ead
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/delving/hub3/config"
+	eadHub3 "github.com/delving/hub3/hub3/ead"
+	"github.com/delving/hub3/ikuzo/domain"
 	"github.com/matryer/is"
 )
 
@@ -111,6 +119,161 @@ func TestService_SaveEAD(t *testing.T) {
 	is.Equal(info.Size(), size)
 }
 
+// nolint:gocritic
+func TestService_handleUpload_invalid(t *testing.T) {
+	is := is.New(t)
+
+	svc, err := getTestService()
+	is.NoErr(err)
+
+	// remove test tmpDir
+	defer os.RemoveAll(svc.dataDir)
+
+	err = SetRequiredFields("hub3", "unitid", "level")(svc)
+	is.NoErr(err)
+
+	f, size, err := getReader("4.ZHPB2.xml")
+	is.NoErr(err)
+
+	_, meta, err := svc.SaveEAD(f, size)
+	is.NoErr(err)
+
+	stored, err := ioutil.ReadFile(meta.getSourcePath())
+	is.NoErr(err)
+
+	// the upload has the same eadid but no valid c-levels
+	upload := []byte(`<ead><eadheader><eadid>4.ZHPB2</eadid></eadheader>` +
+		`<archdesc level="fonds"><dsc><c><did></did></c></dsc></archdesc></ead>`)
+
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+	fw, err := mw.CreateFormFile("ead", "4.ZHPB2.xml")
+	is.NoErr(err)
+	_, err = fw.Write(upload)
+	is.NoErr(err)
+	is.NoErr(mw.WriteField("validate", "true"))
+	is.NoErr(mw.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/ead", body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	req = req.WithContext(domain.SetOrganization(req.Context(), domain.Organization{ID: "hub3"}))
+
+	w := httptest.NewRecorder()
+	svc.handleUpload(w, req)
+
+	is.Equal(w.Code, http.StatusUnprocessableEntity)
+
+	// the stored source EAD is not replaced
+	got, err := ioutil.ReadFile(meta.getSourcePath())
+	is.NoErr(err)
+	is.True(bytes.Equal(got, stored))
+
+	// no tmpFiles are left behind
+	files, err := ioutil.ReadDir(svc.dataDir)
+	is.NoErr(err)
+
+	for _, f := range files {
+		is.True(f.IsDir())
+	}
+}
+
+// nolint:gocritic
+func TestService_ValidateUpload(t *testing.T) {
+	svc, err := getTestService()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// remove test tmpDir
+	defer os.RemoveAll(svc.dataDir)
+
+	defer func(orgID string) { config.Config.OrgID = orgID }(config.Config.OrgID)
+	config.Config.OrgID = "hub3"
+
+	if err = SetRequiredFields("hub3", "unitid")(svc); err != nil {
+		t.Fatal(err)
+	}
+
+	upload := []byte(`<ead><eadheader><eadid>4.ZHPB2</eadid>` +
+		`<filedesc><titlestmt><titleproper>Test</titleproper></titlestmt></filedesc></eadheader>` +
+		`<archdesc level="fonds"><did><unittitle>Test</unittitle></did>` +
+		`<dsc><c level="file"><did><unittitle>File</unittitle></did></c></dsc></archdesc></ead>`)
+
+	tests := []struct {
+		name       string
+		org        *domain.Organization
+		wantStatus int
+	}{
+		{"without organization the configured orgID is used", nil, http.StatusUnprocessableEntity},
+		{"rules of the request organization", &domain.Organization{ID: "demo"}, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			body := &bytes.Buffer{}
+			mw := multipart.NewWriter(body)
+			fw, err := mw.CreateFormFile("ead", "4.ZHPB2.xml")
+			is.NoErr(err)
+			_, err = fw.Write(upload)
+			is.NoErr(err)
+			is.NoErr(mw.WriteField("orgID", "hub3"))
+			is.NoErr(mw.Close())
+
+			req := httptest.NewRequest(http.MethodPost, "/api/ead/validate", body)
+			req.Header.Set("Content-Type", mw.FormDataContentType())
+
+			if tt.org != nil {
+				req = req.WithContext(domain.SetOrganization(req.Context(), *tt.org))
+			}
+
+			w := httptest.NewRecorder()
+			svc.ValidateUpload(w, req)
+
+			is.Equal(w.Code, tt.wantStatus)
+		})
+	}
+}
+
+// nolint:gocritic
+func TestService_writePDF(t *testing.T) {
+	is := is.New(t)
+
+	svc, err := getTestService()
+	is.NoErr(err)
+
+	// remove test tmpDir
+	defer os.RemoveAll(svc.dataDir)
+
+	f, size, err := getReader("4.ZHPB2.xml")
+	is.NoErr(err)
+
+	_, meta, err := svc.SaveEAD(f, size)
+	is.NoErr(err)
+
+	task, err := svc.NewTask(&meta)
+	is.NoErr(err)
+
+	// the PDF is stored next to the source EAD in the dataDir of the service
+	pdfPath := filepath.Join(svc.dataDir, meta.DatasetID, meta.DatasetID+".pdf")
+
+	is.NoErr(svc.writePDF(task))
+
+	_, err = os.Stat(pdfPath)
+	is.NoErr(err)
+
+	// a stale PDF is removed when the PDF is not regenerated
+	is.NoErr(svc.removePDF(task))
+
+	_, err = os.Stat(pdfPath)
+	is.True(errors.Is(err, os.ErrNotExist))
+
+	is.NoErr(svc.removePDF(task))
+}
+
 func TestService_GetName(t *testing.T) {
 	type args struct {
 		r io.Reader
@@ -181,3 +344,56 @@ func TestService_GetName(t *testing.T) {
 		})
 	}
 }
+
+func TestService_Validate(t *testing.T) {
+	is := is.New(t)
+
+	svc, err := NewService(SetRequiredFields("test-org", "unitid", "unittitle"))
+	is.NoErr(err)
+
+	f, _, err := getReader("4.ZHPB2.xml")
+	is.NoErr(err)
+
+	defer f.Close()
+
+	report, err := svc.Validate(context.Background(), f, "test-org", false)
+	is.NoErr(err)
+	is.Equal(report.DatasetID, "4.ZHPB2")
+	is.Equal(report.OrgID, "test-org")
+	is.True(report.Clevels > 0)
+	is.True(report.Valid)
+
+	_, err = NewService(SetRequiredFields("test-org", "unknown"))
+	is.True(err != nil)
+}
+
+func TestService_SaveAuthority(t *testing.T) {
+	is := is.New(t)
+
+	svc, err := getTestService()
+	is.NoErr(err)
+
+	defer os.RemoveAll(svc.dataDir)
+	defer os.RemoveAll(svc.getAuthorityPath())
+
+	// an EAD with the eadid 'authorities' does not share the directory of the authorities
+	is.True(!strings.HasPrefix(svc.getAuthorityPath(), svc.dataDir+string(filepath.Separator)))
+	is.True(svc.getAuthorityPath() != svc.getDataPath(eadHub3.AuthoritySpec))
+
+	eac := `<eac-cpf>
+  <control><recordId>NL-HaNA-P1</recordId></control>
+  <cpfDescription><identity><entityType>person</entityType><nameEntry><part>Jan Jansen</part></nameEntry></identity></cpfDescription>
+</eac-cpf>`
+
+	a, err := svc.SaveAuthority(strings.NewReader(eac))
+	is.NoErr(err)
+	is.Equal(a.ID, "NL-HaNA-P1")
+	is.Equal(a.Names, []string{"Jan Jansen"})
+
+	stored, err := ioutil.ReadFile(filepath.Join(svc.getAuthorityPath(), "NL-HaNA-P1.xml"))
+	is.NoErr(err)
+	is.Equal(string(stored), eac)
+
+	_, err = svc.SaveAuthority(strings.NewReader(`<eac-cpf><control><recordId>../x</recordId></control></eac-cpf>`))
+	is.True(err != nil)
+}
@@ -0,0 +1,82 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ead
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/delving/hub3/config"
+	eadHub3 "github.com/delving/hub3/hub3/ead"
+	"github.com/delving/hub3/ikuzo/domain"
+	"github.com/go-chi/render"
+)
+
+// Validate validates the EAD without storing it or creating a processing task.
+//
+// The required-field rules are resolved from the orgID. When the orgID is empty
+// the organization of the request is used, and otherwise the orgID from the
+// global configuration.
+func (s *Service) Validate(ctx context.Context, r io.Reader, orgID string, checkDaoLinks bool) (*eadHub3.ValidationReport, error) {
+	if org, ok := domain.GetOrganization(ctx); ok && orgID == "" {
+		orgID = string(org.ID)
+	}
+
+	if orgID == "" {
+		orgID = config.Config.OrgID
+	}
+
+	return eadHub3.ValidateEAD(ctx, r, &eadHub3.ValidationConfig{
+		OrgID:          orgID,
+		RequiredFields: s.rules[orgID],
+		CheckDaoLinks:  checkDaoLinks,
+		Client:         s.daoClient,
+	})
+}
+
+// ValidateUpload is the http.HandlerFunc for the validate-only endpoint.
+//
+// The EAD is submitted in the 'ead' form file. The dao links are only checked
+// when the 'daoCheck' form value is 'true'. When the EAD is valid the
+// ValidationReport is returned with status 200, otherwise with status 422.
+func (s *Service) ValidateUpload(w http.ResponseWriter, r *http.Request) {
+	in, _, err := r.FormFile("ead")
+	if err != nil {
+		http.Error(w, "cannot find ead form file", http.StatusBadRequest)
+		return
+	}
+
+	defer in.Close()
+	// cleanup upload
+	defer func() {
+		err = r.MultipartForm.RemoveAll()
+	}()
+
+	checkDaoLinks := strings.EqualFold(r.FormValue("daoCheck"), "true")
+
+	report, err := s.Validate(r.Context(), in, "", checkDaoLinks)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !report.Valid {
+		render.Status(r, http.StatusUnprocessableEntity)
+	}
+
+	render.JSON(w, r, report)
+}
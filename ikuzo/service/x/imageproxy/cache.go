@@ -132,6 +132,7 @@ func (req *Request) Write(path string, r io.Reader) error {
 	if err != nil {
 		return fmt.Errorf("unable to create file; %w", err)
 	}
+	defer f.Close()
 
 	_, err = io.Copy(f, r)
 	if err != nil {
@@ -0,0 +1,347 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imageproxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/OneOfOne/xxhash"
+	"github.com/rs/zerolog/log"
+)
+
+var ErrInvalidContentType = errors.New("remote resource is not an image")
+
+const defaultRevalidateAfter = 24 * time.Hour
+
+// UpstreamError is returned when the remote server does not respond with the image.
+type UpstreamError struct {
+	StatusCode int
+	URL        string
+}
+
+func (e *UpstreamError) Error() string {
+	return fmt.Sprintf("remote server responded with status %d for %s", e.StatusCode, e.URL)
+}
+
+// SetRevalidateAfter sets after how long cached source images are revalidated with
+// the remote server, when the remote server does not set a max-age.
+func SetRevalidateAfter(d time.Duration) Option {
+	return func(s *Service) error {
+		s.revalidateAfter = d
+		return nil
+	}
+}
+
+// cachedImage is an image with the headers that are served to the client.
+type cachedImage struct {
+	body   []byte
+	header http.Header
+}
+
+func newCachedImage(body []byte, contentType, lastModified string) *cachedImage {
+	img := &cachedImage{
+		body:   body,
+		header: http.Header{},
+	}
+
+	if contentType == "" {
+		contentType = http.DetectContentType(body)
+	}
+
+	img.header.Set("Content-Type", contentType)
+	img.header.Set("Etag", fmt.Sprintf("%q", fmt.Sprintf("%016x", xxhash.Checksum64(body))))
+
+	if lastModified != "" {
+		img.header.Set("Last-Modified", lastModified)
+	}
+
+	return img
+}
+
+// sourceMeta holds the validators of a cached source image.
+type sourceMeta struct {
+	ContentType  string    `json:"contentType"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	Expires      time.Time `json:"expires"`
+}
+
+// metaPath is the relative path of the sourceMeta in the cache.
+func (req *Request) metaPath() string {
+	return path.Join(filepath.ToSlash(req.sourcePath())+"#", "meta.json")
+}
+
+func (s *Service) readMeta(req *Request) *sourceMeta {
+	b, err := s.cache.get(req.metaPath(), false)
+	if err != nil {
+		return nil
+	}
+
+	var meta sourceMeta
+	if err := json.Unmarshal(b, &meta); err != nil {
+		return nil
+	}
+
+	return &meta
+}
+
+func (s *Service) writeMeta(req *Request, meta *sourceMeta) {
+	b, err := json.Marshal(meta)
+	if err == nil {
+		err = s.cache.Set(req.metaPath(), b)
+	}
+
+	if err != nil {
+		log.Error().Err(err).Str("cmp", "imageproxy").Msg("unable to write image metadata to cache")
+	}
+}
+
+// expires returns when the response must be revalidated.
+func (s *Service) expires(resp *http.Response) time.Time {
+	for _, directive := range strings.Split(resp.Header.Get("Cache-Control"), ",") {
+		directive = strings.TrimSpace(directive)
+
+		switch {
+		case directive == "no-cache":
+			return time.Now()
+		case strings.HasPrefix(directive, "max-age="):
+			if seconds, err := strconv.Atoi(strings.TrimPrefix(directive, "max-age=")); err == nil {
+				return time.Now().Add(time.Duration(seconds) * time.Second)
+			}
+		}
+	}
+
+	return time.Now().Add(s.revalidateAfter)
+}
+
+// image returns the requested image. When the request has transform options
+// the derivative is returned instead of the source image.
+func (s *Service) image(ctx context.Context, req *Request) (*cachedImage, error) {
+	if err := s.checkHost(req.sourceURL); err != nil {
+		return nil, err
+	}
+
+	if req.derivativePath() != "" {
+		return s.derivative(ctx, req)
+	}
+
+	return s.source(ctx, req)
+}
+
+// source returns the source image from the cache or the remote server.
+//
+// Concurrent requests for the same source image are coalesced into a single
+// request to the remote server.
+func (s *Service) source(ctx context.Context, req *Request) (*cachedImage, error) {
+	return s.coalesce(ctx, req.sourcePath(), func(ctx context.Context) (*cachedImage, error) {
+		return s.loadSource(ctx, req)
+	})
+}
+
+// coalesce calls fn once for concurrent calls with the same key.
+//
+// fn is not canceled when one of the callers goes away, because its result is shared.
+func (s *Service) coalesce(ctx context.Context, key string, fn func(context.Context) (*cachedImage, error)) (*cachedImage, error) {
+	ch := s.group.DoChan(key, func() (interface{}, error) {
+		return fn(context.Background())
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+
+		return res.Val.(*cachedImage), nil
+	}
+}
+
+func (s *Service) loadSource(ctx context.Context, req *Request) (*cachedImage, error) {
+	cacheKey := filepath.ToSlash(req.sourcePath())
+
+	body, err := s.cache.Get(cacheKey)
+	if err != nil && !errors.Is(err, ErrCacheKeyNotFound) {
+		log.Error().Err(err).Str("cmp", "imageproxy").Msg("unexpected error reading from cache")
+		return nil, err
+	}
+
+	meta := s.readMeta(req)
+
+	var cached *cachedImage
+
+	if err == nil {
+		if meta == nil {
+			// images cached without metadata are not revalidated
+			return newCachedImage(body, "", ""), nil
+		}
+
+		cached = newCachedImage(body, meta.ContentType, meta.LastModified)
+
+		if time.Now().Before(meta.Expires) {
+			return cached, nil
+		}
+	}
+
+	proxyRequest, err := req.GET()
+	if err != nil {
+		log.Error().Err(err).Str("cmp", "imageproxy").Str("url", req.sourceURL).Msg("unable to create GET request")
+		return nil, err
+	}
+
+	proxyRequest = proxyRequest.WithContext(ctx)
+
+	if cached != nil {
+		if meta.ETag != "" {
+			proxyRequest.Header.Set("If-None-Match", meta.ETag)
+		}
+
+		if meta.LastModified != "" {
+			proxyRequest.Header.Set("If-Modified-Since", meta.LastModified)
+		}
+	}
+
+	resp, err := s.client.Do(proxyRequest)
+	if err != nil {
+		if cached != nil {
+			log.Warn().Err(err).Str("cmp", "imageproxy").Str("url", req.sourceURL).Msg("unable to revalidate image; serving stale image")
+			return cached, nil
+		}
+
+		log.Error().Err(err).Str("cmp", "imageproxy").Str("url", req.sourceURL).Msg("unable to make remote request")
+
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		meta.Expires = s.expires(resp)
+		s.writeMeta(req, meta)
+
+		return cached, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		if cached != nil && resp.StatusCode >= http.StatusInternalServerError {
+			log.Warn().Int("status", resp.StatusCode).Str("cmp", "imageproxy").Str("url", req.sourceURL).
+				Msg("unable to revalidate image; serving stale image")
+
+			return cached, nil
+		}
+
+		log.Warn().Int("status", resp.StatusCode).Str("cmp", "imageproxy").Str("url", req.sourceURL).Msg("unexpected status from remote server")
+
+		return nil, &UpstreamError{StatusCode: resp.StatusCode, URL: req.sourceURL}
+	}
+
+	body, err = ioutil.ReadAll(resp.Body)
+	if err != nil {
+		log.Error().Err(err).Str("cmp", "imageproxy").Msg("error reading remote image")
+		return nil, err
+	}
+
+	// only images are cached; e.g. adlib returns XML error messages with a 200 status
+	contentType := resp.Header.Get("Content-Type")
+	if !strings.HasPrefix(contentType, "image/") {
+		contentType = http.DetectContentType(body)
+
+		if !strings.HasPrefix(contentType, "image/") {
+			log.Warn().Str("cmp", "imageproxy").Str("url", req.sourceURL).Str("contentType", resp.Header.Get("Content-Type")).
+				Msg("remote resource is not an image")
+
+			return nil, fmt.Errorf("%w: %s", ErrInvalidContentType, resp.Header.Get("Content-Type"))
+		}
+	}
+
+	if cached != nil {
+		// the source image has changed so its derivatives are stale
+		if _, err := s.Purge(req.sourceURL); err != nil {
+			log.Error().Err(err).Str("cmp", "imageproxy").Msg("unable to purge stale derivatives")
+		}
+	}
+
+	if err := s.cache.Set(cacheKey, body); err != nil {
+		// do not return error here or cache write error
+		log.Error().Err(err).Str("cmp", "imageproxy").Msg("unable to write remote file to cache")
+	}
+
+	meta = &sourceMeta{
+		ContentType:  contentType,
+		ETag:         resp.Header.Get("Etag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		Expires:      s.expires(resp),
+	}
+	s.writeMeta(req, meta)
+
+	return newCachedImage(body, meta.ContentType, meta.LastModified), nil
+}
+
+// errorStatus returns the HTTP status that is returned to the client for the error.
+func errorStatus(err error) int {
+	var (
+		upstream *UpstreamError
+		netErr   net.Error
+	)
+
+	switch {
+	case isForbidden(err):
+		return http.StatusForbidden
+	case errors.Is(err, ErrInvalidIIIFRequest), errors.Is(err, ErrInvalidTransformOptions),
+		errors.Is(err, ErrUnsupportedFormat), errors.Is(err, ErrInvalidCacheKey):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrIIIFNotImplemented):
+		return http.StatusNotImplemented
+	case errors.As(err, &upstream):
+		if upstream.StatusCode >= http.StatusBadRequest && upstream.StatusCode < http.StatusInternalServerError {
+			return upstream.StatusCode
+		}
+
+		return http.StatusBadGateway
+	case errors.Is(err, ErrInvalidContentType):
+		return http.StatusBadGateway
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return http.StatusGatewayTimeout
+	case errors.As(err, &netErr):
+		return http.StatusBadGateway
+	}
+
+	return http.StatusInternalServerError
+}
+
+// writeImage writes the image to the client, or a 304 when the client has a fresh copy.
+func writeImage(w http.ResponseWriter, r *http.Request, img *cachedImage) {
+	copyHeader(w.Header(), img.header, "Content-Type", "Etag", "Last-Modified")
+
+	if should304(r, &http.Response{Header: img.header}) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Length", strconv.Itoa(len(img.body)))
+	_, _ = w.Write(img.body)
+}
@@ -0,0 +1,304 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imageproxy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestErrorStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"forbidden", ErrHostNotAllowed, http.StatusForbidden},
+		{"not found upstream", &UpstreamError{StatusCode: http.StatusNotFound}, http.StatusNotFound},
+		{"gone upstream", fmt.Errorf("wrapped; %w", &UpstreamError{StatusCode: http.StatusGone}), http.StatusGone},
+		{"server error upstream", &UpstreamError{StatusCode: http.StatusServiceUnavailable}, http.StatusBadGateway},
+		{"not an image", ErrInvalidContentType, http.StatusBadGateway},
+		{"timeout", context.DeadlineExceeded, http.StatusGatewayTimeout},
+		{"invalid options", ErrInvalidTransformOptions, http.StatusBadRequest},
+		{"unknown", errors.New("unknown"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(errorStatus(tt.err), tt.want)
+		})
+	}
+}
+
+func TestService_proxyImage_upstream(t *testing.T) {
+	src := testPNG(t, 10, 10)
+
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/missing.png":
+			http.NotFound(w, r)
+		case "/error.png":
+			http.Error(w, "error", http.StatusInternalServerError)
+		case "/adlib.png":
+			w.Header().Set("Content-Type", "text/xml")
+			fmt.Fprint(w, "<adlibXML><diagnostic><error>not found</error></diagnostic></adlibXML>")
+		case "/octet.png":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			_, _ = w.Write(src)
+		default:
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write(src)
+		}
+	}))
+	defer origin.Close()
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantCached bool
+	}{
+		{"image", "/image.png", http.StatusOK, true},
+		{"image without image content-type", "/octet.png", http.StatusOK, true},
+		{"not found", "/missing.png", http.StatusNotFound, false},
+		{"server error", "/error.png", http.StatusBadGateway, false},
+		{"adlib error message", "/adlib.png", http.StatusBadGateway, false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			svc := newTestService(t)
+
+			w := httptest.NewRecorder()
+			svc.Routes().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/imageproxy/raw/"+origin.URL+tt.path, nil))
+			is.Equal(w.Code, tt.wantStatus)
+			is.Equal(len(svc.cache.entries) != 0, tt.wantCached)
+
+			if tt.wantStatus == http.StatusOK {
+				is.Equal(w.Header().Get("Content-Type"), "image/png")
+				is.Equal(w.Body.Len(), len(src))
+			}
+		})
+	}
+}
+
+func TestService_proxyImage_conditional(t *testing.T) {
+	src := testPNG(t, 10, 10)
+	lastModified := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).Format(http.TimeFormat)
+
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Header().Set("Last-Modified", lastModified)
+		_, _ = w.Write(src)
+	}))
+	defer origin.Close()
+
+	svc := newTestService(t)
+
+	get := func(options string, header http.Header) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/imageproxy/"+options+"/"+origin.URL+"/image.png", nil)
+		for k, v := range header {
+			r.Header[k] = v
+		}
+
+		w := httptest.NewRecorder()
+		svc.Routes().ServeHTTP(w, r)
+
+		return w
+	}
+
+	for _, options := range []string{"raw", "5x"} {
+		t.Run(options, func(t *testing.T) {
+			is := is.New(t)
+
+			w := get(options, nil)
+			is.Equal(w.Code, http.StatusOK)
+			is.Equal(w.Header().Get("Last-Modified"), lastModified)
+
+			etag := w.Header().Get("Etag")
+			is.True(etag != "")
+
+			w = get(options, http.Header{"If-None-Match": {etag}})
+			is.Equal(w.Code, http.StatusNotModified)
+			is.Equal(w.Body.Len(), 0)
+
+			w = get(options, http.Header{"If-Modified-Since": {lastModified}})
+			is.Equal(w.Code, http.StatusNotModified)
+
+			w = get(options, http.Header{"If-None-Match": {`"other"`}})
+			is.Equal(w.Code, http.StatusOK)
+		})
+	}
+}
+
+func TestService_Do_revalidate(t *testing.T) {
+	is := is.New(t)
+
+	var (
+		mu          sync.Mutex
+		src         = testPNG(t, 10, 10)
+		etag        = `"v1"`
+		conditional int
+		fail        bool
+	)
+
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if fail {
+			http.Error(w, "error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Cache-Control", "max-age=0")
+
+		if r.Header.Get("If-None-Match") != "" {
+			conditional++
+
+			if r.Header.Get("If-None-Match") == etag {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+
+		w.Header().Set("Content-Type", "image/png")
+		w.Header().Set("Etag", etag)
+		_, _ = w.Write(src)
+	}))
+	defer origin.Close()
+
+	svc := newTestService(t)
+
+	width := func(options string) int {
+		t.Helper()
+
+		transform, err := parseTransformOptions(options)
+		is.NoErr(err)
+
+		req, err := NewRequest(origin.URL+"/image.png", SetTransform(transform))
+		is.NoErr(err)
+
+		img, err := svc.image(context.Background(), req)
+		is.NoErr(err)
+
+		cfg, _, err := image.DecodeConfig(bytes.NewReader(img.body))
+		is.NoErr(err)
+
+		return cfg.Width
+	}
+
+	is.Equal(width("raw"), 10)
+	is.Equal(conditional, 0)
+
+	// the expired source is revalidated and not modified
+	is.Equal(width("raw"), 10)
+	is.Equal(conditional, 1)
+
+	is.Equal(width("cw15,ch15"), 10)
+
+	// the source has changed, so the derivative is generated again
+	mu.Lock()
+	src = testPNG(t, 20, 20)
+	etag = `"v2"`
+	mu.Unlock()
+
+	is.Equal(width("raw"), 20)
+	is.Equal(width("cw15,ch15"), 15)
+
+	// a stale image is served when the remote server fails
+	mu.Lock()
+	fail = true
+	mu.Unlock()
+
+	is.Equal(width("raw"), 20)
+}
+
+func TestService_Do_coalesce(t *testing.T) {
+	is := is.New(t)
+
+	src := testPNG(t, 10, 10)
+
+	var requests int32
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			close(started)
+		}
+
+		<-release
+
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write(src)
+	}))
+	defer origin.Close()
+
+	svc := newTestService(t)
+
+	var wg sync.WaitGroup
+
+	errs := make(chan error, 10)
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			req, err := NewRequest(origin.URL + "/image.png")
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			_, err = svc.image(context.Background(), req)
+			errs <- err
+		}()
+	}
+
+	<-started
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		is.NoErr(err)
+	}
+
+	is.Equal(atomic.LoadInt32(&requests), int32(1))
+}
@@ -0,0 +1,532 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imageproxy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"math"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/disintegration/imaging"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	ErrInvalidIIIFRequest = errors.New("invalid IIIF image request")
+	ErrIIIFNotImplemented = errors.New("IIIF feature is not implemented")
+)
+
+const (
+	iiifContext  = "http://iiif.io/api/image/2/context.json"
+	iiifProtocol = "http://iiif.io/api/image"
+	iiifProfile  = "http://iiif.io/api/image/2/level1.json"
+	// iiifTileSize is the width and height of the tiles advertised in info.json
+	iiifTileSize = 512
+	// iiifMaxSize is the maximum width or height of a generated image
+	iiifMaxSize = 4096
+	iiifQuality = 90
+	// iiifSignatureSeparator separates the signed options from the source in a signed {id}
+	iiifSignatureSeparator = "~"
+)
+
+// SetDeepzoom enables the IIIF Image API tile server.
+func SetDeepzoom(enabled bool) Option {
+	return func(s *Service) error {
+		s.deepzoom = enabled
+		return nil
+	}
+}
+
+// IIIFInfo is the image information document (info.json) of the IIIF Image API 2.1.
+type IIIFInfo struct {
+	Context   string        `json:"@context"`
+	ID        string        `json:"@id"`
+	Protocol  string        `json:"protocol"`
+	Width     int           `json:"width"`
+	Height    int           `json:"height"`
+	MaxWidth  int           `json:"maxWidth,omitempty"`
+	MaxHeight int           `json:"maxHeight,omitempty"`
+	Sizes     []IIIFSize    `json:"sizes,omitempty"`
+	Tiles     []IIIFTiles   `json:"tiles"`
+	Profile   []interface{} `json:"profile"`
+}
+
+// IIIFSize is a width and height that is available for the full image.
+type IIIFSize struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// IIIFTiles describes the tiles that are available at each scale factor.
+type IIIFTiles struct {
+	Width        int   `json:"width"`
+	Height       int   `json:"height,omitempty"`
+	ScaleFactors []int `json:"scaleFactors"`
+}
+
+// newIIIFInfo returns the info.json for an image of width and height.
+func newIIIFInfo(id string, width, height int) *IIIFInfo {
+	info := &IIIFInfo{
+		Context:   iiifContext,
+		ID:        id,
+		Protocol:  iiifProtocol,
+		Width:     width,
+		Height:    height,
+		MaxWidth:  iiifMaxSize,
+		MaxHeight: iiifMaxSize,
+		Profile: []interface{}{
+			iiifProfile,
+			map[string][]string{
+				"formats":   {"jpg", "png"},
+				"qualities": {"default", "color", "gray"},
+				"supports":  {"regionSquare", "rotationBy90s", "sizeByWh", "sizeByConfinedWh"},
+			},
+		},
+	}
+
+	scaleFactors := []int{1}
+
+	for sf := 2; (width+sf-1)/sf >= iiifTileSize || (height+sf-1)/sf >= iiifTileSize; sf *= 2 {
+		scaleFactors = append(scaleFactors, sf)
+	}
+
+	info.Tiles = []IIIFTiles{{Width: iiifTileSize, Height: iiifTileSize, ScaleFactors: scaleFactors}}
+
+	for i := len(scaleFactors) - 1; i >= 0; i-- {
+		sf := scaleFactors[i]
+		w, h := (width+sf-1)/sf, (height+sf-1)/sf
+
+		if w <= iiifMaxSize && h <= iiifMaxSize {
+			info.Sizes = append(info.Sizes, IIIFSize{Width: w, Height: h})
+		}
+	}
+
+	return info
+}
+
+// iiifRequest holds the parameters of an IIIF image request.
+type iiifRequest struct {
+	region   string
+	size     string
+	rotation string
+	quality  string
+	format   string
+}
+
+// cachePath returns the relative path of the generated image in the cache.
+func (ir *iiifRequest) cachePath(req *Request) string {
+	return path.Join(
+		filepath.ToSlash(req.sourcePath())+"#",
+		"iiif",
+		ir.region,
+		ir.size,
+		ir.rotation,
+		ir.quality+"."+ir.format,
+	)
+}
+
+// parseRegion returns the rectangle of the region within the bounds of the image.
+func parseRegion(region string, bounds image.Rectangle) (image.Rectangle, error) {
+	w, h := bounds.Dx(), bounds.Dy()
+
+	switch {
+	case region == "full":
+		return bounds, nil
+	case region == "square":
+		side := w
+		if h < side {
+			side = h
+		}
+
+		x, y := (w-side)/2, (h-side)/2
+
+		return image.Rect(x, y, x+side, y+side), nil
+	}
+
+	pct := strings.HasPrefix(region, "pct:")
+
+	parts := strings.Split(strings.TrimPrefix(region, "pct:"), ",")
+	if len(parts) != 4 {
+		return image.Rectangle{}, fmt.Errorf("%w: region %q", ErrInvalidIIIFRequest, region)
+	}
+
+	var values [4]int
+
+	for i, p := range parts {
+		f, err := strconv.ParseFloat(p, 64)
+		if err != nil || f < 0 {
+			return image.Rectangle{}, fmt.Errorf("%w: region %q", ErrInvalidIIIFRequest, region)
+		}
+
+		if pct {
+			total := w
+			if i%2 == 1 {
+				total = h
+			}
+
+			f = f * float64(total) / 100
+		}
+
+		values[i] = int(math.Round(f))
+	}
+
+	rect := image.Rect(values[0], values[1], values[0]+values[2], values[1]+values[3]).Intersect(bounds)
+	if rect.Empty() {
+		return image.Rectangle{}, fmt.Errorf("%w: region %q is outside the image", ErrInvalidIIIFRequest, region)
+	}
+
+	return rect, nil
+}
+
+// parseSize returns the width and height of the region after scaling.
+func parseSize(size string, rw, rh int, scaleUp bool) (width, height int, err error) {
+	invalid := fmt.Errorf("%w: size %q", ErrInvalidIIIFRequest, size)
+
+	scale := func(f float64) int {
+		return int(math.Max(1, math.Round(f)))
+	}
+
+	switch {
+	case size == "full":
+		width, height = rw, rh
+	case size == "max":
+		width, height = rw, rh
+
+		if width > iiifMaxSize || height > iiifMaxSize {
+			f := math.Min(float64(iiifMaxSize)/float64(width), float64(iiifMaxSize)/float64(height))
+			width, height = scale(float64(width)*f), scale(float64(height)*f)
+		}
+	case strings.HasPrefix(size, "pct:"):
+		pct, parseErr := strconv.ParseFloat(strings.TrimPrefix(size, "pct:"), 64)
+		if parseErr != nil || pct <= 0 {
+			return 0, 0, invalid
+		}
+
+		width, height = scale(float64(rw)*pct/100), scale(float64(rh)*pct/100)
+	default:
+		confined := strings.HasPrefix(size, "!")
+
+		parts := strings.Split(strings.TrimPrefix(size, "!"), ",")
+		if len(parts) != 2 || (parts[0] == "" && parts[1] == "") {
+			return 0, 0, invalid
+		}
+
+		var w, h int
+
+		if parts[0] != "" {
+			if w, err = strconv.Atoi(parts[0]); err != nil || w <= 0 {
+				return 0, 0, invalid
+			}
+		}
+
+		if parts[1] != "" {
+			if h, err = strconv.Atoi(parts[1]); err != nil || h <= 0 {
+				return 0, 0, invalid
+			}
+		}
+
+		switch {
+		case confined:
+			if w == 0 || h == 0 {
+				return 0, 0, invalid
+			}
+
+			f := math.Min(float64(w)/float64(rw), float64(h)/float64(rh))
+			width, height = scale(float64(rw)*f), scale(float64(rh)*f)
+		case w == 0:
+			width, height = scale(float64(rw)*float64(h)/float64(rh)), h
+		case h == 0:
+			width, height = w, scale(float64(rh)*float64(w)/float64(rw))
+		default:
+			width, height = w, h
+		}
+	}
+
+	if width > iiifMaxSize || height > iiifMaxSize {
+		return 0, 0, fmt.Errorf("%w: size %q is larger than %d", ErrInvalidIIIFRequest, size, iiifMaxSize)
+	}
+
+	if !scaleUp && (width > rw || height > rh) {
+		return 0, 0, fmt.Errorf("%w: size %q is larger than the region", ErrInvalidIIIFRequest, size)
+	}
+
+	return width, height, nil
+}
+
+// render generates the image for the IIIF request from the source image.
+func (ir *iiifRequest) render(src []byte, scaleUp bool) ([]byte, error) {
+	var (
+		format imaging.Format
+		opts   []imaging.EncodeOption
+	)
+
+	switch ir.format {
+	case "jpg":
+		format = imaging.JPEG
+		opts = append(opts, imaging.JPEGQuality(iiifQuality))
+	case "png":
+		format = imaging.PNG
+	default:
+		return nil, fmt.Errorf("%w: format %q", ErrIIIFNotImplemented, ir.format)
+	}
+
+	switch ir.quality {
+	case "default", "color", "gray":
+	default:
+		return nil, fmt.Errorf("%w: quality %q", ErrIIIFNotImplemented, ir.quality)
+	}
+
+	switch ir.rotation {
+	case "0", "90", "180", "270":
+	default:
+		return nil, fmt.Errorf("%w: rotation %q", ErrIIIFNotImplemented, ir.rotation)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(src))
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode source image; %w", err)
+	}
+
+	region, err := parseRegion(ir.region, img.Bounds())
+	if err != nil {
+		return nil, err
+	}
+
+	width, height, err := parseSize(ir.size, region.Dx(), region.Dy(), scaleUp)
+	if err != nil {
+		return nil, err
+	}
+
+	var dst image.Image = imaging.Crop(img, region)
+
+	if width != region.Dx() || height != region.Dy() {
+		dst = imaging.Resize(dst, width, height, imaging.Lanczos)
+	}
+
+	// IIIF rotates clockwise and imaging rotates counter-clockwise
+	switch ir.rotation {
+	case "90":
+		dst = imaging.Rotate270(dst)
+	case "180":
+		dst = imaging.Rotate180(dst)
+	case "270":
+		dst = imaging.Rotate90(dst)
+	}
+
+	if ir.quality == "gray" {
+		dst = imaging.Grayscale(dst)
+	}
+
+	var buf bytes.Buffer
+	if err := imaging.Encode(&buf, dst, format, opts...); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// iiifRoutes adds the IIIF Image API routes to the router.
+//
+// IIIF viewers derive the tile URLs from info.json and cannot carry signed options.
+// When a signature key is set the signed options are therefore part of the {id},
+// see SignIIIF.
+func (s *Service) iiifRoutes(router chi.Router) {
+	prefix := fmt.Sprintf("/%s/iiif/{id}", s.proxyPrefix)
+
+	router.Get(prefix, func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, strings.TrimSuffix(r.URL.EscapedPath(), "/")+"/info.json", http.StatusSeeOther)
+	})
+	router.Get(prefix+"/info.json", s.iiifInfo)
+	router.Get(prefix+"/{region}/{size}/{rotation}/{image}", s.iiifImage)
+}
+
+// SignIIIF returns the signed {id} of the IIIF route for the sourceURL.
+// A zero expires creates a signature that does not expire.
+func (s *Service) SignIIIF(sourceURL string, expires time.Time) string {
+	return url.PathEscape(s.Sign(sourceURL, expires) + iiifSignatureSeparator + sourceURL)
+}
+
+// iiifSourceRequest returns the authorized Request for the {id} of the IIIF route.
+//
+// The {id} is either the cache key or the URL-encoded source URL. When a signature
+// key is set the {id} must be prefixed with the signed options, see SignIIIF.
+func (s *Service) iiifSourceRequest(r *http.Request) (*Request, error) {
+	id, err := url.PathUnescape(chi.URLParam(r, "id"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: id %q", ErrInvalidIIIFRequest, chi.URLParam(r, "id"))
+	}
+
+	var rawOptions string
+
+	if len(s.signatureKey) != 0 {
+		if i := strings.Index(id, iiifSignatureSeparator); i != -1 {
+			rawOptions, id = id[:i], id[i+len(iiifSignatureSeparator):]
+		}
+	}
+
+	req, err := NewRequest(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidIIIFRequest, err)
+	}
+
+	if err := s.authorize(r, req, rawOptions); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// iiifError writes the HTTP status that matches the error.
+func iiifError(w http.ResponseWriter, err error) {
+	http.Error(w, err.Error(), errorStatus(err))
+}
+
+func (s *Service) iiifInfo(w http.ResponseWriter, r *http.Request) {
+	req, err := s.iiifSourceRequest(r)
+	if err != nil {
+		log.Warn().Err(err).Str("cmp", "imageproxy").Str("id", chi.URLParam(r, "id")).Msg("rejected IIIF request")
+		iiifError(w, err)
+
+		return
+	}
+
+	src, err := s.source(r.Context(), req)
+	if err != nil {
+		log.Error().Err(err).Str("cmp", "imageproxy").Str("url", req.sourceURL).Msg("unable to get IIIF source image")
+		iiifError(w, err)
+
+		return
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(src.body))
+	if err != nil {
+		log.Error().Err(err).Str("cmp", "imageproxy").Str("url", req.sourceURL).Msg("unable to decode IIIF source image")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+
+	id := fmt.Sprintf("%s://%s%s", scheme, r.Host, strings.TrimSuffix(r.URL.EscapedPath(), "/info.json"))
+
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Link", fmt.Sprintf("<%s>;rel=\"profile\"", iiifProfile))
+
+	if strings.Contains(r.Header.Get("Accept"), "application/ld+json") {
+		w.Header().Set("Content-Type", "application/ld+json")
+	}
+
+	render.JSON(w, r, newIIIFInfo(id, cfg.Width, cfg.Height))
+}
+
+func (s *Service) iiifImage(w http.ResponseWriter, r *http.Request) {
+	quality, format := chi.URLParam(r, "image"), ""
+	if i := strings.LastIndex(quality, "."); i != -1 {
+		quality, format = quality[:i], quality[i+1:]
+	}
+
+	ir := &iiifRequest{
+		region:   chi.URLParam(r, "region"),
+		size:     chi.URLParam(r, "size"),
+		rotation: chi.URLParam(r, "rotation"),
+		quality:  quality,
+		format:   format,
+	}
+
+	req, err := s.iiifSourceRequest(r)
+	if err != nil {
+		log.Warn().Err(err).Str("cmp", "imageproxy").Str("id", chi.URLParam(r, "id")).Msg("rejected IIIF request")
+		iiifError(w, err)
+
+		return
+	}
+
+	img, err := s.coalesce(r.Context(), ir.cachePath(req), func(ctx context.Context) (*cachedImage, error) {
+		return s.loadIIIFImage(ctx, req, ir)
+	})
+	if err != nil {
+		log.Warn().Err(err).Str("cmp", "imageproxy").Str("url", req.sourceURL).Msg("unable to render IIIF image")
+		iiifError(w, err)
+
+		return
+	}
+
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Link", fmt.Sprintf("<%s>;rel=\"profile\"", iiifProfile))
+	writeImage(w, r, img)
+}
+
+// loadIIIFImage returns the IIIF image from the cache or renders it from the source image.
+func (s *Service) loadIIIFImage(ctx context.Context, req *Request, ir *iiifRequest) (*cachedImage, error) {
+	contentType := "image/jpeg"
+	if ir.format == "png" {
+		contentType = "image/png"
+	}
+
+	cachePath := ir.cachePath(req)
+
+	if meta := s.readMeta(req); meta == nil || time.Now().Before(meta.Expires) {
+		b, err := s.cache.Get(cachePath)
+		if err != nil && !errors.Is(err, ErrCacheKeyNotFound) {
+			log.Error().Err(err).Str("cmp", "imageproxy").Msg("unexpected error reading IIIF image from cache")
+		}
+
+		if err == nil {
+			var lastModified string
+			if meta != nil {
+				lastModified = meta.LastModified
+			}
+
+			return newCachedImage(b, contentType, lastModified), nil
+		}
+	}
+
+	src, err := s.source(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := ir.render(src.body, s.scaleUp)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.cache.Set(cachePath, b); err != nil {
+		// do not return error here or cache write error
+		log.Error().Err(err).Str("cmp", "imageproxy").Msg("unable to write IIIF image to cache")
+	}
+
+	return newCachedImage(b, contentType, src.header.Get("Last-Modified")), nil
+}
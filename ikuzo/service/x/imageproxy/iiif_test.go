@@ -0,0 +1,263 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imageproxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestParseRegion(t *testing.T) {
+	bounds := image.Rect(0, 0, 1000, 500)
+
+	tests := []struct {
+		name    string
+		region  string
+		want    image.Rectangle
+		wantErr bool
+	}{
+		{"full", "full", bounds, false},
+		{"square", "square", image.Rect(250, 0, 750, 500), false},
+		{"pixels", "100,50,200,100", image.Rect(100, 50, 300, 150), false},
+		{"pixels clipped to image", "900,400,200,200", image.Rect(900, 400, 1000, 500), false},
+		{"percentage", "pct:10,10,50,50", image.Rect(100, 50, 600, 300), false},
+		{"outside image", "1000,0,10,10", image.Rectangle{}, true},
+		{"missing values", "100,50,200", image.Rectangle{}, true},
+		{"negative value", "-1,0,10,10", image.Rectangle{}, true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			got, err := parseRegion(tt.region, bounds)
+			is.Equal(err != nil, tt.wantErr)
+			is.Equal(got, tt.want)
+		})
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		name       string
+		size       string
+		scaleUp    bool
+		wantWidth  int
+		wantHeight int
+		wantErr    bool
+	}{
+		{"full", "full", false, 1000, 500, false},
+		{"max", "max", false, 1000, 500, false},
+		{"width", "500,", false, 500, 250, false},
+		{"height", ",100", false, 200, 100, false},
+		{"percentage", "pct:10", false, 100, 50, false},
+		{"width and height", "300,300", false, 300, 300, false},
+		{"confined", "!300,300", false, 300, 150, false},
+		{"upscale not allowed", "2000,", false, 0, 0, true},
+		{"upscale allowed", "2000,", true, 2000, 1000, false},
+		{"larger than max size", "5000,", true, 0, 0, true},
+		{"empty", ",", false, 0, 0, true},
+		{"confined without height", "!300,", false, 0, 0, true},
+		{"invalid", "big", false, 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			width, height, err := parseSize(tt.size, 1000, 500, tt.scaleUp)
+			is.Equal(err != nil, tt.wantErr)
+			is.Equal(width, tt.wantWidth)
+			is.Equal(height, tt.wantHeight)
+		})
+	}
+}
+
+func TestNewIIIFInfo(t *testing.T) {
+	is := is.New(t)
+
+	info := newIIIFInfo("http://localhost/iiif/1", 3000, 2000)
+	is.Equal(info.Tiles[0].ScaleFactors, []int{1, 2, 4})
+	is.Equal(info.Sizes, []IIIFSize{{750, 500}, {1500, 1000}, {3000, 2000}})
+
+	info = newIIIFInfo("http://localhost/iiif/1", 100, 100)
+	is.Equal(info.Tiles[0].ScaleFactors, []int{1})
+}
+
+func TestService_iiif(t *testing.T) {
+	src := testPNG(t, 1200, 800)
+
+	requests := 0
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write(src)
+	}))
+	defer origin.Close()
+
+	svc := newTestService(t, SetDeepzoom(true))
+	router := svc.Routes()
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+		return w
+	}
+
+	base := "/imageproxy/iiif/" + url.PathEscape(origin.URL+"/scan.png")
+
+	t.Run("base URI redirects to info.json", func(t *testing.T) {
+		is := is.New(t)
+
+		w := get(base)
+		is.Equal(w.Code, http.StatusSeeOther)
+		is.Equal(w.Header().Get("Location"), base+"/info.json")
+	})
+
+	t.Run("info.json", func(t *testing.T) {
+		is := is.New(t)
+
+		w := get(base + "/info.json")
+		is.Equal(w.Code, http.StatusOK)
+		is.Equal(w.Header().Get("Access-Control-Allow-Origin"), "*")
+
+		var info IIIFInfo
+		is.NoErr(json.Unmarshal(w.Body.Bytes(), &info))
+		is.Equal(info.ID, "http://example.com"+base)
+		is.Equal(info.Width, 1200)
+		is.Equal(info.Height, 800)
+		is.Equal(info.Profile[0], iiifProfile)
+	})
+
+	t.Run("cache key as identifier", func(t *testing.T) {
+		is := is.New(t)
+
+		w := get("/imageproxy/iiif/" + encodeURL(origin.URL+"/scan.png") + "/info.json")
+		is.Equal(w.Code, http.StatusOK)
+	})
+
+	tests := []struct {
+		name         string
+		path         string
+		wantStatus   int
+		wantType     string
+		wantFormat   string
+		wantBounds   image.Rectangle
+		wantCacheHit bool
+	}{
+		{"tile", "/512,512,512,288/256,/0/default.jpg", http.StatusOK, "image/jpeg", "jpeg", image.Rect(0, 0, 256, 144), false},
+		{"cached tile", "/512,512,512,288/256,/0/default.jpg", http.StatusOK, "image/jpeg", "jpeg", image.Rect(0, 0, 256, 144), true},
+		{"rotated png", "/full/!300,300/90/gray.png", http.StatusOK, "image/png", "png", image.Rect(0, 0, 200, 300), false},
+		{"invalid region", "/2000,0,10,10/full/0/default.jpg", http.StatusBadRequest, "", "", image.Rectangle{}, false},
+		{"unsupported quality", "/full/full/0/bitonal.jpg", http.StatusNotImplemented, "", "", image.Rectangle{}, false},
+		{"unsupported format", "/full/full/0/default.gif", http.StatusNotImplemented, "", "", image.Rectangle{}, false},
+		{"mirroring", "/full/full/!0/default.jpg", http.StatusNotImplemented, "", "", image.Rectangle{}, false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			hits := svc.Metrics().CacheHits
+
+			w := get(base + tt.path)
+			is.Equal(w.Code, tt.wantStatus)
+
+			// the source image is fetched only once for all tiles
+			is.Equal(requests, 1)
+
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			is.Equal(w.Header().Get("Content-Type"), tt.wantType)
+
+			img, format, err := image.Decode(bytes.NewReader(w.Body.Bytes()))
+			is.NoErr(err)
+			is.Equal(format, tt.wantFormat)
+			is.Equal(img.Bounds(), tt.wantBounds)
+
+			if tt.wantCacheHit {
+				// only the tile is read from the cache
+				is.Equal(svc.Metrics().CacheHits, hits+1)
+			}
+		})
+	}
+}
+
+func TestService_iiif_disabled(t *testing.T) {
+	is := is.New(t)
+
+	svc := newTestService(t)
+
+	w := httptest.NewRecorder()
+	svc.Routes().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/imageproxy/iiif/abc/info.json", nil))
+	is.True(w.Code != http.StatusOK)
+}
+
+func TestService_iiif_signed(t *testing.T) {
+	src := testPNG(t, 100, 100)
+
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write(src)
+	}))
+	defer origin.Close()
+
+	svc := newTestService(t, SetDeepzoom(true), SetSignatureKey("secret"))
+	router := svc.Routes()
+
+	sourceURL := origin.URL + "/scan.png"
+
+	tests := []struct {
+		name       string
+		id         string
+		wantStatus int
+	}{
+		{"unsigned", url.PathEscape(sourceURL), http.StatusForbidden},
+		{"unsigned cache key", encodeURL(sourceURL), http.StatusForbidden},
+		{"signed", svc.SignIIIF(sourceURL, time.Time{}), http.StatusOK},
+		{"signature of other source", url.PathEscape(svc.Sign(origin.URL+"/other.png", time.Time{}) + iiifSignatureSeparator + sourceURL), http.StatusForbidden},
+		{"expired", svc.SignIIIF(sourceURL, time.Now().Add(-time.Minute)), http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/imageproxy/iiif/"+tt.id+"/full/full/0/default.jpg", nil))
+			is.Equal(w.Code, tt.wantStatus)
+		})
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imageproxy
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrHostNotAllowed     = errors.New("remote host is not allowed")
+	ErrReferrerNotAllowed = errors.New("referrer is not allowed")
+	ErrInvalidSignature   = errors.New("invalid request signature")
+	ErrSignatureExpired   = errors.New("request signature is expired")
+)
+
+// maxRedirects is the number of redirects the upstream client follows, which
+// is the default of the http.Client.
+const maxRedirects = 10
+
+// The signing options are matched on their key including the '=', because the
+// transform options, e.g. 'sc' for scale-up, start with the same letters.
+const (
+	optSignatureKey = "sig="
+	optExpiresKey   = "exp="
+)
+
+// SetAllowedHosts sets the remote hosts images can be proxied from.
+//
+// A host that starts with '*.' allows all its subdomains. When no hosts are set
+// all remote hosts are allowed.
+func SetAllowedHosts(hosts ...string) Option {
+	return func(s *Service) error {
+		s.allowedHosts = append(s.allowedHosts, hosts...)
+		return nil
+	}
+}
+
+// SetAllowedReferrers sets the hosts that are allowed to refer to the imageproxy.
+//
+// The same wildcards as SetAllowedHosts are supported. When no referrers are set
+// all referrers are allowed.
+func SetAllowedReferrers(hosts ...string) Option {
+	return func(s *Service) error {
+		s.allowedReferrers = append(s.allowedReferrers, hosts...)
+		return nil
+	}
+}
+
+// SetSignatureKey requires all requests to be signed with a HMAC of the source URL.
+func SetSignatureKey(key string) Option {
+	return func(s *Service) error {
+		s.signatureKey = []byte(key)
+		return nil
+	}
+}
+
+// HostAllowed returns true when the host matches one of the allowed hosts.
+// An empty list of allowed hosts allows all hosts.
+func HostAllowed(host string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+
+	host = strings.ToLower(host)
+
+	for _, pattern := range allowed {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+
+		if strings.HasPrefix(pattern, "*.") {
+			if strings.HasSuffix(host, pattern[1:]) {
+				return true
+			}
+
+			continue
+		}
+
+		if host == pattern {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkHost returns an ErrHostNotAllowed when the host of the sourceURL is not allowed.
+func (s *Service) checkHost(sourceURL string) error {
+	u, err := url.Parse(sourceURL)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrHostNotAllowed, err)
+	}
+
+	if !HostAllowed(u.Hostname(), s.allowedHosts) {
+		s.m.incRejectedHost()
+		return fmt.Errorf("%w: %s", ErrHostNotAllowed, u.Hostname())
+	}
+
+	return nil
+}
+
+// checkRedirect is the CheckRedirect of the upstream http.Client. The host check
+// is repeated for each redirect, so an allowed host cannot redirect the proxy
+// to a host that is not allowed.
+func (s *Service) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+	}
+
+	return s.checkHost(req.URL.String())
+}
+
+// checkReferrer returns an ErrReferrerNotAllowed when the referrer of the request is not allowed.
+func (s *Service) checkReferrer(r *http.Request) error {
+	if len(s.allowedReferrers) == 0 {
+		return nil
+	}
+
+	u, err := url.Parse(r.Referer())
+	if err != nil || u.Hostname() == "" || !HostAllowed(u.Hostname(), s.allowedReferrers) {
+		s.m.incRejectedReferrer()
+		return fmt.Errorf("%w: %q", ErrReferrerNotAllowed, r.Referer())
+	}
+
+	return nil
+}
+
+// signature returns the base64 encoded HMAC of the sourceURL and the expiry.
+//
+// The sourceURL is length-prefixed, so that the sourceURL and the expiry cannot be
+// shifted into each other.
+func (s *Service) signature(sourceURL string, expires int64) string {
+	mac := hmac.New(sha256.New, s.signatureKey)
+	fmt.Fprintf(mac, "%d:%s:%d", len(sourceURL), sourceURL, expires)
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Sign returns the options that sign the sourceURL. The options must be added to the
+// {options} part of the proxy route. A zero expires creates a signature that does not expire.
+func (s *Service) Sign(sourceURL string, expires time.Time) string {
+	var unix int64
+	if !expires.IsZero() {
+		unix = expires.Unix()
+	}
+
+	options := optSignatureKey + s.signature(sourceURL, unix)
+	if unix != 0 {
+		options += "," + optExpiresKey + strconv.FormatInt(unix, 10)
+	}
+
+	return options
+}
+
+// checkSignature verifies the signature in the raw options of the proxy route.
+func (s *Service) checkSignature(sourceURL, rawOptions string) error {
+	if len(s.signatureKey) == 0 {
+		return nil
+	}
+
+	var (
+		sig     string
+		expires int64
+	)
+
+	for _, opt := range strings.Split(rawOptions, ",") {
+		switch {
+		case strings.HasPrefix(opt, optSignatureKey):
+			sig = strings.TrimPrefix(opt, optSignatureKey)
+		case strings.HasPrefix(opt, optExpiresKey):
+			expires, _ = strconv.ParseInt(strings.TrimPrefix(opt, optExpiresKey), 10, 64)
+		}
+	}
+
+	if sig == "" || !hmac.Equal([]byte(sig), []byte(s.signature(sourceURL, expires))) {
+		s.m.incRejectedSignature()
+		return ErrInvalidSignature
+	}
+
+	if expires != 0 && time.Now().Unix() > expires {
+		s.m.incRejectedSignature()
+		return ErrSignatureExpired
+	}
+
+	return nil
+}
+
+// authorize checks if the request is allowed to be served by the imageproxy.
+func (s *Service) authorize(r *http.Request, req *Request, rawOptions string) error {
+	if err := s.checkReferrer(r); err != nil {
+		return err
+	}
+
+	if err := s.checkSignature(req.sourceURL, rawOptions); err != nil {
+		return err
+	}
+
+	return s.checkHost(req.sourceURL)
+}
+
+// isForbidden returns true when the error is the result of a rejected request.
+func isForbidden(err error) bool {
+	return errors.Is(err, ErrHostNotAllowed) ||
+		errors.Is(err, ErrReferrerNotAllowed) ||
+		errors.Is(err, ErrInvalidSignature) ||
+		errors.Is(err, ErrSignatureExpired)
+}
@@ -0,0 +1,270 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imageproxy
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestHostAllowed(t *testing.T) {
+	tests := []struct {
+		name    string
+		host    string
+		allowed []string
+		want    bool
+	}{
+		{"empty list allows all", "example.com", nil, true},
+		{"exact match", "example.com", []string{"example.com"}, true},
+		{"case insensitive", "Example.COM", []string{"example.com"}, true},
+		{"no match", "example.org", []string{"example.com"}, false},
+		{"wildcard subdomain", "images.example.com", []string{"*.example.com"}, true},
+		{"wildcard nested subdomain", "a.b.example.com", []string{"*.example.com"}, true},
+		{"wildcard excludes apex", "example.com", []string{"*.example.com"}, false},
+		{"wildcard suffix only", "badexample.com", []string{"*.example.com"}, false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(HostAllowed(tt.host, tt.allowed), tt.want)
+		})
+	}
+}
+
+func TestService_proxyImage_authorization(t *testing.T) {
+	src := testPNG(t, 10, 10)
+
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write(src)
+	}))
+	defer origin.Close()
+
+	sourceURL := origin.URL + "/image.png"
+
+	tests := []struct {
+		name     string
+		options  []Option
+		route    func(svc *Service) string
+		referrer string
+		want     int
+		metrics  Metrics
+	}{
+		{
+			"open proxy",
+			nil,
+			func(svc *Service) string { return "raw" },
+			"",
+			http.StatusOK,
+			Metrics{},
+		},
+		{
+			"host allowed",
+			[]Option{SetAllowedHosts("127.0.0.1")},
+			func(svc *Service) string { return "raw" },
+			"",
+			http.StatusOK,
+			Metrics{},
+		},
+		{
+			"host not allowed",
+			[]Option{SetAllowedHosts("*.example.com")},
+			func(svc *Service) string { return "raw" },
+			"",
+			http.StatusForbidden,
+			Metrics{RejectedHost: 1},
+		},
+		{
+			"referrer allowed",
+			[]Option{SetAllowedReferrers("*.example.com")},
+			func(svc *Service) string { return "raw" },
+			"https://www.example.com/page",
+			http.StatusOK,
+			Metrics{},
+		},
+		{
+			"referrer missing",
+			[]Option{SetAllowedReferrers("*.example.com")},
+			func(svc *Service) string { return "raw" },
+			"",
+			http.StatusForbidden,
+			Metrics{RejectedReferrer: 1},
+		},
+		{
+			"valid signature",
+			[]Option{SetSignatureKey("secret")},
+			func(svc *Service) string { return "100x," + svc.Sign(sourceURL, time.Now().Add(time.Hour)) },
+			"",
+			http.StatusOK,
+			Metrics{},
+		},
+		{
+			"signature without expiry",
+			[]Option{SetSignatureKey("secret")},
+			func(svc *Service) string { return svc.Sign(sourceURL, time.Time{}) },
+			"",
+			http.StatusOK,
+			Metrics{},
+		},
+		{
+			"signature with scale-up",
+			[]Option{SetSignatureKey("secret")},
+			func(svc *Service) string { return svc.Sign(sourceURL, time.Time{}) + ",100x,sc" },
+			"",
+			http.StatusOK,
+			Metrics{},
+		},
+		{
+			"missing signature",
+			[]Option{SetSignatureKey("secret")},
+			func(svc *Service) string { return "100x" },
+			"",
+			http.StatusForbidden,
+			Metrics{RejectedSignature: 1},
+		},
+		{
+			"signature for other URL",
+			[]Option{SetSignatureKey("secret")},
+			func(svc *Service) string { return svc.Sign(origin.URL+"/other.png", time.Time{}) },
+			"",
+			http.StatusForbidden,
+			Metrics{RejectedSignature: 1},
+		},
+		{
+			"expired signature",
+			[]Option{SetSignatureKey("secret")},
+			func(svc *Service) string { return svc.Sign(sourceURL, time.Now().Add(-time.Minute)) },
+			"",
+			http.StatusForbidden,
+			Metrics{RejectedSignature: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			cacheDir, err := ioutil.TempDir("", "imageproxy-*")
+			is.NoErr(err)
+
+			defer os.RemoveAll(cacheDir)
+
+			svc, err := NewService(append(tt.options, SetCacheDir(cacheDir))...)
+			is.NoErr(err)
+
+			req := httptest.NewRequest(http.MethodGet, "/imageproxy/"+tt.route(svc)+"/"+sourceURL, nil)
+			if tt.referrer != "" {
+				req.Header.Set("Referer", tt.referrer)
+			}
+
+			w := httptest.NewRecorder()
+			svc.Routes().ServeHTTP(w, req)
+
+			is.Equal(w.Code, tt.want)
+
+			m := svc.Metrics()
+			is.Equal(m.RejectedHost, tt.metrics.RejectedHost)
+			is.Equal(m.RejectedReferrer, tt.metrics.RejectedReferrer)
+			is.Equal(m.RejectedSignature, tt.metrics.RejectedSignature)
+		})
+	}
+}
+
+func TestService_proxyImage_redirect(t *testing.T) {
+	src := testPNG(t, 10, 10)
+
+	var targetHits int32
+
+	// the target is only reachable as 'localhost', which is not an allowed host
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&targetHits, 1)
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write(src)
+	}))
+	defer target.Close()
+
+	targetURL := strings.Replace(target.URL, "127.0.0.1", "localhost", 1)
+
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/internal.png":
+			http.Redirect(w, r, targetURL+"/image.png", http.StatusFound)
+		case "/moved.png":
+			http.Redirect(w, r, "/image.png", http.StatusMovedPermanently)
+		default:
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write(src)
+		}
+	}))
+	defer origin.Close()
+
+	tests := []struct {
+		name         string
+		path         string
+		want         int
+		rejectedHost uint64
+	}{
+		{"redirect to allowed host", "/moved.png", http.StatusOK, 0},
+		{"redirect to host that is not allowed", "/internal.png", http.StatusForbidden, 1},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			cacheDir, err := ioutil.TempDir("", "imageproxy-*")
+			is.NoErr(err)
+
+			defer os.RemoveAll(cacheDir)
+
+			svc, err := NewService(SetAllowedHosts("127.0.0.1"), SetCacheDir(cacheDir))
+			is.NoErr(err)
+
+			req := httptest.NewRequest(http.MethodGet, "/imageproxy/raw/"+origin.URL+tt.path, nil)
+			w := httptest.NewRecorder()
+			svc.Routes().ServeHTTP(w, req)
+
+			is.Equal(w.Code, tt.want)
+			is.Equal(svc.Metrics().RejectedHost, tt.rejectedHost)
+		})
+	}
+
+	is.New(t).Equal(atomic.LoadInt32(&targetHits), int32(0))
+}
+
+func TestService_signature(t *testing.T) {
+	is := is.New(t)
+
+	svc := newTestService(t, SetSignatureKey("secret"))
+
+	// the expiry cannot be moved into the sourceURL
+	is.True(svc.signature("http://example.com/image.png?v=1", 0) != svc.signature("http://example.com/image.png?v=", 1))
+	is.Equal(svc.signature("http://example.com/image.png", 1), svc.signature("http://example.com/image.png", 1))
+}
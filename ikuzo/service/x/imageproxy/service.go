@@ -15,31 +15,92 @@
 package imageproxy
 
 import (
-	"bytes"
 	"context"
-	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"path/filepath"
-	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-chi/chi"
 	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/singleflight"
 )
 
 const defaultMemorySize = 100
 
 type Option func(*Service) error
 
+// Metrics holds the counters of the imageproxy.
+type Metrics struct {
+	RejectedHost      uint64
+	RejectedReferrer  uint64
+	RejectedSignature uint64
+	CacheHits         uint64 // hits in either cache tier
+	CacheMemoryHits   uint64 // hits in the memory tier
+	CacheMisses       uint64
+	CacheEvictions    uint64 // entries evicted from the disk tier
+	CacheBytes        uint64 // size of the disk tier
+}
+
+func (m *Metrics) incRejectedHost() {
+	atomic.AddUint64(&m.RejectedHost, 1)
+}
+
+func (m *Metrics) incRejectedReferrer() {
+	atomic.AddUint64(&m.RejectedReferrer, 1)
+}
+
+func (m *Metrics) incRejectedSignature() {
+	atomic.AddUint64(&m.RejectedSignature, 1)
+}
+
+func (m *Metrics) incCacheHit() {
+	atomic.AddUint64(&m.CacheHits, 1)
+}
+
+func (m *Metrics) incCacheMemoryHit() {
+	atomic.AddUint64(&m.CacheMemoryHits, 1)
+}
+
+func (m *Metrics) incCacheMiss() {
+	atomic.AddUint64(&m.CacheMisses, 1)
+}
+
+func (m *Metrics) incCacheEviction() {
+	atomic.AddUint64(&m.CacheEvictions, 1)
+}
+
+func (m *Metrics) setCacheBytes(size int64) {
+	atomic.StoreUint64(&m.CacheBytes, uint64(size))
+}
+
 type Service struct {
 	client      http.Client
 	cacheDir    string // The path to the imageCache
 	timeOut     int    // timelimit for request served by this proxy. 0 is for no timeout
 	proxyPrefix string // The prefix where we mount the imageproxy. default: imageproxy. default: imageproxy.
-	memoryCache string
-	// deepzoom    bool     // Enable deepzoom of remote images.
+	memoryCache string // The memory tier of the cache. default: memory:500:1h
+	// maxCacheSize is the maximum size in bytes of the disk cache. 0 is unbounded.
+	maxCacheSize int64
+	// cacheTTL is how long images are kept in the disk cache. 0 is no expiry.
+	cacheTTL         time.Duration
+	evictionInterval time.Duration
+	cache            *tieredCache
+	cancel           context.CancelFunc
+	// revalidateAfter is the max-age of source images when the remote server does not set one.
+	revalidateAfter time.Duration
+	// group coalesces concurrent requests for the same image.
+	group   singleflight.Group
+	scaleUp bool // Allow images to scale beyond their original dimensions.
+	// allowedHosts are the remote hosts images can be proxied from. If empty allow all.
+	allowedHosts []string
+	// allowedReferrers are the hosts that can refer to the imageproxy. If empty allow all.
+	allowedReferrers []string
+	// signatureKey is the HMAC key for signed requests. If empty requests are not signed.
+	signatureKey []byte
+	m            Metrics
+	deepzoom     bool // Enable the IIIF Image API tile server.
 }
 
 func SetCacheDir(path string) Option {
@@ -55,6 +116,15 @@ func SetTimeout(duration int) Option {
 		return nil
 	}
 }
+
+// SetScaleUp allows transformations to scale images beyond their original dimensions.
+func SetScaleUp(scaleUp bool) Option {
+	return func(s *Service) error {
+		s.scaleUp = scaleUp
+		return nil
+	}
+}
+
 func SetProxyPrefix(prefix string) Option {
 	return func(s *Service) error {
 		s.proxyPrefix = prefix
@@ -64,10 +134,12 @@ func SetProxyPrefix(prefix string) Option {
 
 func NewService(options ...Option) (*Service, error) {
 	s := &Service{
-		cacheDir:    "/tmp/imageproxy",
-		timeOut:     10,
-		proxyPrefix: "imageproxy",
-		memoryCache: "memory:500:1h",
+		cacheDir:         "/tmp/imageproxy",
+		timeOut:          10,
+		proxyPrefix:      "imageproxy",
+		memoryCache:      "memory:500:1h",
+		evictionInterval: defaultEvictionInterval,
+		revalidateAfter:  defaultRevalidateAfter,
 	}
 
 	// apply options
@@ -77,91 +149,84 @@ func NewService(options ...Option) (*Service, error) {
 		}
 	}
 
-	s.client = http.Client{Timeout: time.Duration(s.timeOut) * time.Second}
+	s.client = http.Client{
+		Timeout:       time.Duration(s.timeOut) * time.Second,
+		CheckRedirect: s.checkRedirect,
+	}
+
+	cache, err := s.newTieredCache()
+	if err != nil {
+		return nil, err
+	}
+
+	s.cache = cache
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	if s.evictionInterval > 0 && (s.maxCacheSize > 0 || s.cacheTTL > 0) {
+		go s.cache.run(ctx, s.evictionInterval)
+	}
 
 	return s, nil
 }
 
+// Metrics returns a snapshot of the imageproxy counters.
+func (s *Service) Metrics() Metrics {
+	return Metrics{
+		RejectedHost:      atomic.LoadUint64(&s.m.RejectedHost),
+		RejectedReferrer:  atomic.LoadUint64(&s.m.RejectedReferrer),
+		RejectedSignature: atomic.LoadUint64(&s.m.RejectedSignature),
+		CacheHits:         atomic.LoadUint64(&s.m.CacheHits),
+		CacheMemoryHits:   atomic.LoadUint64(&s.m.CacheMemoryHits),
+		CacheMisses:       atomic.LoadUint64(&s.m.CacheMisses),
+		CacheEvictions:    atomic.LoadUint64(&s.m.CacheEvictions),
+		CacheBytes:        atomic.LoadUint64(&s.m.CacheBytes),
+	}
+}
+
 func (s *Service) Routes() chi.Router {
 	router := chi.NewRouter()
 
 	proxyPrefix := fmt.Sprintf("/%s/{options}/*", s.proxyPrefix)
 	router.Get(proxyPrefix, s.proxyImage)
+	router.Delete(fmt.Sprintf("/%s/cache", s.proxyPrefix), s.purgeCache)
+
+	if s.deepzoom {
+		s.iiifRoutes(router)
+	}
 
 	return router
 }
 
+// Do writes the requested image to w. When the request has transform options
+// the derivative is written instead of the source image.
 func (s *Service) Do(ctx context.Context, req *Request, w io.Writer) error {
-	_ = ctx
-	cachePath := filepath.Join(s.cacheDir, req.sourcePath())
-	// check cache
-	r, err := req.Read(cachePath)
-	if err != nil && !errors.Is(err, ErrCacheKeyNotFound) {
-		log.Error().Err(err).Str("cmp", "imageproxy").Msg("unexpected error reading from cache")
-		return err
-	}
-
-	if !errors.Is(err, ErrCacheKeyNotFound) {
-		defer r.Close()
-
-		_, err = io.Copy(w, r)
-		if err != nil {
-			log.Error().Err(err).Str("cmp", "imageproxy").Msg("error copying image from cache")
-			return err
-		}
-
-		return nil
-	}
-
-	// make request
-	proxyRequest, err := req.GET()
-	if err != nil {
-		log.Error().Err(err).Str("cmp", "imageproxy").Str("url", req.sourceURL).Msg("unable to create GET request")
-		return err
-	}
-
-	resp, err := s.client.Do(proxyRequest)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		log.Error().Err(err).Str("cmp", "imageproxy").Str("url", req.sourceURL).Msg("unable to make remote request")
-		return err
-	}
-
-	defer resp.Body.Close()
-
-	var buf bytes.Buffer
-	tee := io.TeeReader(resp.Body, &buf)
-
-	// copy to response writer
-	_, err = io.Copy(w, tee)
+	img, err := s.image(ctx, req)
 	if err != nil {
-		log.Error().Err(err).Str("cmp", "imageproxy").Msg("error copying remote image")
-
 		return err
 	}
 
-	if strings.HasPrefix(resp.Header.Get("Content-Type"), "text/xml") && bytes.Contains(buf.Bytes(), []byte("adlibXML")) {
-		// don't cache adlib error messages
-		log.Warn().Str("cmp", "imageproxy").Str("url", req.sourceURL).Msg("adlib error retrieving image")
-		return fmt.Errorf("unable to retrieve adlib result")
-	}
-	// check for adlib error when content type is xml
+	_, err = w.Write(img.body)
 
-	err = req.Write(cachePath, &buf)
-	if err != nil {
-		// do not return error here or cache write error
-		log.Error().Err(err).Str("cmp", "imageproxy").Msg("unable to write remote file to cache")
-	}
-
-	return nil
+	return err
 }
 
 // create handler fuction to serve the proxied images
 func (s *Service) proxyImage(w http.ResponseWriter, r *http.Request) {
 	url := chi.URLParam(r, "*")
+	rawOptions := chi.URLParam(r, "options")
+
+	transformOptions, err := parseTransformOptions(rawOptions)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
 	req, err := NewRequest(
 		url,
 		SetRawQueryString(r.URL.RawQuery),
+		SetTransform(transformOptions),
 	)
 	if err != nil {
 		log.Error().Err(err).Str("cmp", "imageproxy").Str("url", url).Msg("unable to create proxy request")
@@ -170,19 +235,31 @@ func (s *Service) proxyImage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = s.Do(r.Context(), req, w)
+	if err = s.authorize(r, req, rawOptions); err != nil {
+		log.Warn().Err(err).Str("cmp", "imageproxy").Str("url", req.sourceURL).Msg("rejected proxy request")
+		http.Error(w, err.Error(), http.StatusForbidden)
+
+		return
+	}
+
+	img, err := s.image(r.Context(), req)
 	if err != nil {
 		log.Error().Err(err).Str("cmp", "imageproxy").Str("url", req.sourceURL).Msg("unable to make proxy request")
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), errorStatus(err))
 
 		return
 	}
+
+	writeImage(w, r, img)
 }
 
 func (s *Service) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Service) Shutdown(ctx context.Context) error {
+	// stop background eviction
+	s.cancel()
+
 	return nil
 }
 
@@ -0,0 +1,475 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imageproxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/die-net/lrucache"
+	"github.com/go-chi/render"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	ErrInvalidMemoryCache = errors.New("invalid memory cache; format is 'memory:maxSizeMB:maxAge'")
+	ErrEmptyPurgePrefix   = errors.New("purge prefix cannot be empty")
+)
+
+const defaultEvictionInterval = 10 * time.Minute
+
+// SetMemoryCache sets the memory tier of the cache in the form "memory:maxSize:maxAge".
+//
+// maxSize is in megabytes and maxAge is a duration, e.g. "memory:500:1h".
+// An empty string disables the memory tier.
+func SetMemoryCache(spec string) Option {
+	return func(s *Service) error {
+		s.memoryCache = spec
+		return nil
+	}
+}
+
+// SetMaxCacheSize sets the maximum size of the disk cache in megabytes. 0 is unbounded.
+func SetMaxCacheSize(sizeMB int64) Option {
+	return func(s *Service) error {
+		s.maxCacheSize = sizeMB * 1e6
+		return nil
+	}
+}
+
+// SetCacheTTL sets how long images are kept in the disk cache. 0 is no expiry.
+func SetCacheTTL(ttl time.Duration) Option {
+	return func(s *Service) error {
+		s.cacheTTL = ttl
+		return nil
+	}
+}
+
+// SetEvictionInterval sets how often the background eviction of the disk cache runs.
+func SetEvictionInterval(interval time.Duration) Option {
+	return func(s *Service) error {
+		s.evictionInterval = interval
+		return nil
+	}
+}
+
+// parseMemoryCache parses the memory tier specification into its size in bytes and its max age.
+func parseMemoryCache(spec string) (size int64, maxAge time.Duration, err error) {
+	if spec == "memory" {
+		spec = fmt.Sprintf("memory:%d", defaultMemorySize)
+	}
+
+	parts := strings.Split(spec, ":")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] != "memory" {
+		return 0, 0, fmt.Errorf("%w: %q", ErrInvalidMemoryCache, spec)
+	}
+
+	size, err = strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || size <= 0 {
+		return 0, 0, fmt.Errorf("%w: %q", ErrInvalidMemoryCache, spec)
+	}
+
+	if len(parts) == 3 {
+		maxAge, err = time.ParseDuration(parts[2])
+		if err != nil {
+			return 0, 0, fmt.Errorf("%w: %q", ErrInvalidMemoryCache, spec)
+		}
+	}
+
+	return size * 1e6, maxAge, nil
+}
+
+type diskEntry struct {
+	size     int64
+	created  time.Time
+	accessed time.Time
+}
+
+// tieredCache is a cache with a LRU memory tier in front of a disk tier.
+//
+// The disk tier is bounded by maxSize and ttl. Entries that exceed these
+// bounds are removed by evict, which runs in the background.
+type tieredCache struct {
+	dir     string
+	maxSize int64
+	ttl     time.Duration
+	mem     *lrucache.LruCache
+	m       *Metrics
+
+	rw      sync.Mutex
+	entries map[string]*diskEntry
+	size    int64
+
+	evictCh chan struct{}
+}
+
+func (s *Service) newTieredCache() (*tieredCache, error) {
+	c := &tieredCache{
+		dir:     s.cacheDir,
+		maxSize: s.maxCacheSize,
+		ttl:     s.cacheTTL,
+		m:       &s.m,
+		entries: map[string]*diskEntry{},
+		evictCh: make(chan struct{}, 1),
+	}
+
+	if s.memoryCache != "" {
+		size, maxAge, err := parseMemoryCache(s.memoryCache)
+		if err != nil {
+			return nil, err
+		}
+
+		c.mem = lrucache.New(size, int64(maxAge.Seconds()))
+	}
+
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// load builds the index of the disk tier from the files in the cache directory.
+func (c *tieredCache) load() error {
+	err := filepath.Walk(c.dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() || strings.HasSuffix(path, ".tmp") {
+			return nil
+		}
+
+		key, err := filepath.Rel(c.dir, path)
+		if err != nil {
+			return err
+		}
+
+		c.entries[filepath.ToSlash(key)] = &diskEntry{
+			size:     info.Size(),
+			created:  info.ModTime(),
+			accessed: info.ModTime(),
+		}
+		c.size += info.Size()
+
+		return nil
+	})
+
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("unable to load image cache; %w", err)
+	}
+
+	c.m.setCacheBytes(c.size)
+
+	return nil
+}
+
+func (c *tieredCache) expired(e *diskEntry, now time.Time) bool {
+	return c.ttl > 0 && now.Sub(e.created) > c.ttl
+}
+
+// Get returns the cached value for the key, or ErrCacheKeyNotFound.
+func (c *tieredCache) Get(key string) ([]byte, error) {
+	return c.get(key, true)
+}
+
+// get returns the cached value for the key. When track is false the
+// lookup is not counted in the metrics, e.g. for metadata of an image.
+func (c *tieredCache) get(key string, track bool) ([]byte, error) {
+	m := c.m
+	if !track {
+		m = &Metrics{}
+	}
+
+	if c.mem != nil {
+		if b, ok := c.mem.Get(key); ok {
+			m.incCacheHit()
+			m.incCacheMemoryHit()
+
+			return b, nil
+		}
+	}
+
+	c.rw.Lock()
+	e, ok := c.entries[key]
+
+	if ok && c.expired(e, time.Now()) {
+		c.remove(key)
+		c.m.incCacheEviction()
+
+		ok = false
+	}
+	c.rw.Unlock()
+
+	if !ok {
+		m.incCacheMiss()
+		return nil, ErrCacheKeyNotFound
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(c.dir, key))
+	if err != nil {
+		c.rw.Lock()
+		c.remove(key)
+		c.rw.Unlock()
+
+		if os.IsNotExist(err) {
+			m.incCacheMiss()
+			return nil, ErrCacheKeyNotFound
+		}
+
+		return nil, err
+	}
+
+	c.rw.Lock()
+	if e, ok := c.entries[key]; ok {
+		e.accessed = time.Now()
+	}
+	c.rw.Unlock()
+
+	m.incCacheHit()
+
+	if c.mem != nil {
+		c.mem.Set(key, b)
+	}
+
+	return b, nil
+}
+
+// Set stores the value in both tiers of the cache.
+func (c *tieredCache) Set(key string, b []byte) error {
+	if c.mem != nil {
+		c.mem.Set(key, b)
+	}
+
+	path := filepath.Join(c.dir, key)
+
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return fmt.Errorf("unable to create directories; %w", err)
+	}
+
+	// write to a temporary file first so readers never see partial images
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, b, 0644); err != nil { // nolint:gosec
+		return fmt.Errorf("unable to write to file; %w", err)
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("unable to write to file; %w", err)
+	}
+
+	now := time.Now()
+
+	c.rw.Lock()
+	if old, ok := c.entries[key]; ok {
+		c.size -= old.size
+	}
+
+	c.entries[key] = &diskEntry{size: int64(len(b)), created: now, accessed: now}
+	c.size += int64(len(b))
+	c.m.setCacheBytes(c.size)
+	overflow := c.maxSize > 0 && c.size > c.maxSize
+	c.rw.Unlock()
+
+	if overflow {
+		// trigger eviction without waiting for the next interval
+		select {
+		case c.evictCh <- struct{}{}:
+		default:
+		}
+	}
+
+	return nil
+}
+
+// remove deletes the key from both tiers. The caller must hold the lock.
+func (c *tieredCache) remove(key string) {
+	if c.mem != nil {
+		c.mem.Delete(key)
+	}
+
+	e, ok := c.entries[key]
+	if !ok {
+		return
+	}
+
+	if err := os.Remove(filepath.Join(c.dir, key)); err != nil && !os.IsNotExist(err) {
+		log.Error().Err(err).Str("cmp", "imageproxy").Str("key", key).Msg("unable to remove file from cache")
+	}
+
+	delete(c.entries, key)
+	c.size -= e.size
+	c.m.setCacheBytes(c.size)
+}
+
+// evict removes the expired entries and then the least recently used entries
+// until the disk tier fits in maxSize. It returns the number of evicted entries.
+func (c *tieredCache) evict(now time.Time) int {
+	c.rw.Lock()
+	defer c.rw.Unlock()
+
+	var evicted int
+
+	for key, e := range c.entries {
+		if c.expired(e, now) {
+			c.remove(key)
+			evicted++
+		}
+	}
+
+	if c.maxSize > 0 && c.size > c.maxSize {
+		keys := make([]string, 0, len(c.entries))
+		for key := range c.entries {
+			keys = append(keys, key)
+		}
+
+		sort.Slice(keys, func(i, j int) bool {
+			return c.entries[keys[i]].accessed.Before(c.entries[keys[j]].accessed)
+		})
+
+		for _, key := range keys {
+			if c.size <= c.maxSize {
+				break
+			}
+
+			c.remove(key)
+			evicted++
+		}
+	}
+
+	for i := 0; i < evicted; i++ {
+		c.m.incCacheEviction()
+	}
+
+	return evicted
+}
+
+// run evicts entries every interval until the context is canceled.
+func (c *tieredCache) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-c.evictCh:
+		}
+
+		if evicted := c.evict(time.Now()); evicted > 0 {
+			log.Debug().Str("cmp", "imageproxy").Int("evicted", evicted).Msg("evicted images from cache")
+		}
+	}
+}
+
+// purge removes all entries that match from both tiers.
+func (c *tieredCache) purge(match func(key string) bool) int {
+	c.rw.Lock()
+	defer c.rw.Unlock()
+
+	var purged int
+
+	for key := range c.entries {
+		if match(key) {
+			c.remove(key)
+			purged++
+		}
+	}
+
+	return purged
+}
+
+// cacheKeyFromPath returns the cache key of the source image from the relative path of a cache entry.
+func cacheKeyFromPath(key string) string {
+	parts := strings.SplitN(key, "/", 5)
+	if len(parts) < 4 {
+		return ""
+	}
+
+	return strings.TrimSuffix(parts[3], "#")
+}
+
+// Purge removes the source image and all its derivatives from the cache.
+func (s *Service) Purge(sourceURL string) (int, error) {
+	req, err := NewRequest(sourceURL)
+	if err != nil {
+		return 0, err
+	}
+
+	sourcePath := filepath.ToSlash(req.sourcePath())
+
+	purged := s.cache.purge(func(key string) bool {
+		return key == sourcePath || strings.HasPrefix(key, sourcePath+"#/")
+	})
+
+	_ = os.RemoveAll(filepath.Join(s.cacheDir, req.sourcePath()+"#"))
+
+	return purged, nil
+}
+
+// PurgePrefix removes all source images and derivatives from the cache with a cache key
+// that starts with prefix. When prefix starts with 'http' it is matched against the source URL.
+func (s *Service) PurgePrefix(prefix string) (int, error) {
+	if prefix == "" {
+		return 0, ErrEmptyPurgePrefix
+	}
+
+	isURL := strings.HasPrefix(prefix, "http")
+
+	return s.cache.purge(func(key string) bool {
+		cacheKey := cacheKeyFromPath(key)
+
+		if isURL {
+			sourceURL, err := decodeURL(cacheKey)
+			return err == nil && strings.HasPrefix(sourceURL, prefix)
+		}
+
+		return strings.HasPrefix(cacheKey, prefix)
+	}), nil
+}
+
+// purgeCache is the admin handler to purge the cache by 'url' or 'prefix'.
+func (s *Service) purgeCache(w http.ResponseWriter, r *http.Request) {
+	var (
+		purged int
+		err    error
+	)
+
+	switch q := r.URL.Query(); {
+	case q.Get("url") != "":
+		purged, err = s.Purge(q.Get("url"))
+	default:
+		purged, err = s.PurgePrefix(q.Get("prefix"))
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	log.Info().Str("cmp", "imageproxy").Int("purged", purged).Str("query", r.URL.RawQuery).Msg("purged images from cache")
+
+	render.JSON(w, r, map[string]int{"purged": purged})
+}
@@ -0,0 +1,242 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imageproxy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestParseMemoryCache(t *testing.T) {
+	tests := []struct {
+		name       string
+		spec       string
+		wantSize   int64
+		wantMaxAge time.Duration
+		wantErr    bool
+	}{
+		{"size and max age", "memory:500:1h", 500e6, time.Hour, false},
+		{"size only", "memory:10", 10e6, 0, false},
+		{"default size", "memory", defaultMemorySize * 1e6, 0, false},
+		{"wrong scheme", "disk:10:1h", 0, 0, true},
+		{"invalid size", "memory:ten", 0, 0, true},
+		{"invalid max age", "memory:10:forever", 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			size, maxAge, err := parseMemoryCache(tt.spec)
+			is.Equal(err != nil, tt.wantErr)
+
+			if tt.wantErr {
+				is.True(errors.Is(err, ErrInvalidMemoryCache))
+				return
+			}
+
+			is.Equal(size, tt.wantSize)
+			is.Equal(maxAge, tt.wantMaxAge)
+		})
+	}
+}
+
+func newTestService(t *testing.T, options ...Option) *Service {
+	t.Helper()
+
+	is := is.New(t)
+
+	cacheDir, err := ioutil.TempDir("", "imageproxy-*")
+	is.NoErr(err)
+
+	t.Cleanup(func() { os.RemoveAll(cacheDir) })
+
+	svc, err := NewService(append([]Option{SetCacheDir(cacheDir)}, options...)...)
+	is.NoErr(err)
+
+	t.Cleanup(func() { _ = svc.Shutdown(context.Background()) })
+
+	return svc
+}
+
+func TestTieredCache(t *testing.T) {
+	is := is.New(t)
+
+	svc := newTestService(t, SetMemoryCache("memory:1:1h"))
+	c := svc.cache
+
+	_, err := c.Get("abc/def/123/key")
+	is.True(errors.Is(err, ErrCacheKeyNotFound))
+
+	is.NoErr(c.Set("abc/def/123/key", []byte("image")))
+
+	b, err := c.Get("abc/def/123/key")
+	is.NoErr(err)
+	is.Equal(string(b), "image")
+
+	m := svc.Metrics()
+	is.Equal(m.CacheMisses, uint64(1))
+	is.Equal(m.CacheHits, uint64(1))
+	is.Equal(m.CacheMemoryHits, uint64(1))
+	is.Equal(m.CacheBytes, uint64(len("image")))
+
+	// the disk tier is indexed when a new service is started
+	reloaded := newTestService(t, SetCacheDir(svc.cacheDir), SetMemoryCache(""))
+
+	b, err = reloaded.cache.Get("abc/def/123/key")
+	is.NoErr(err)
+	is.Equal(string(b), "image")
+	is.Equal(reloaded.Metrics().CacheHits, uint64(1))
+	is.Equal(reloaded.Metrics().CacheMemoryHits, uint64(0))
+}
+
+func TestTieredCache_evict(t *testing.T) {
+	t.Run("max size", func(t *testing.T) {
+		is := is.New(t)
+
+		svc := newTestService(t, SetMemoryCache(""), SetEvictionInterval(0))
+		c := svc.cache
+		c.maxSize = 10
+
+		is.NoErr(c.Set("a", []byte("12345")))
+		is.NoErr(c.Set("b", []byte("12345")))
+
+		// make 'a' the most recently used entry
+		c.entries["b"].accessed = time.Now().Add(-time.Minute)
+		_, err := c.Get("a")
+		is.NoErr(err)
+
+		is.NoErr(c.Set("c", []byte("12345")))
+		is.Equal(c.evict(time.Now()), 1)
+
+		_, err = c.Get("b")
+		is.True(errors.Is(err, ErrCacheKeyNotFound))
+
+		_, err = c.Get("a")
+		is.NoErr(err)
+
+		m := svc.Metrics()
+		is.Equal(m.CacheEvictions, uint64(1))
+		is.Equal(m.CacheBytes, uint64(10))
+	})
+
+	t.Run("ttl", func(t *testing.T) {
+		is := is.New(t)
+
+		svc := newTestService(t, SetMemoryCache(""), SetCacheTTL(time.Hour), SetEvictionInterval(0))
+		c := svc.cache
+
+		is.NoErr(c.Set("a", []byte("12345")))
+		is.Equal(c.evict(time.Now()), 0)
+		is.Equal(c.evict(time.Now().Add(2*time.Hour)), 1)
+
+		_, err := os.Stat(svc.cacheDir + "/a")
+		is.True(os.IsNotExist(err))
+		is.Equal(svc.Metrics().CacheBytes, uint64(0))
+	})
+
+	t.Run("background", func(t *testing.T) {
+		is := is.New(t)
+
+		svc := newTestService(t, SetMemoryCache(""), SetMaxCacheSize(1), SetEvictionInterval(time.Hour))
+
+		is.NoErr(svc.cache.Set("a", bytes.Repeat([]byte("x"), 1e6)))
+		is.NoErr(svc.cache.Set("b", []byte("x")))
+
+		// eviction is triggered when the disk tier exceeds its max size
+		for i := 0; i < 100 && svc.Metrics().CacheEvictions == 0; i++ {
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		is.Equal(svc.Metrics().CacheEvictions, uint64(1))
+	})
+}
+
+func TestService_Purge(t *testing.T) {
+	src := testPNG(t, 10, 10)
+
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write(src)
+	}))
+	defer origin.Close()
+
+	fill := func(t *testing.T, svc *Service) {
+		t.Helper()
+
+		is := is.New(t)
+
+		for _, path := range []string{
+			"/imageproxy/raw/" + origin.URL + "/1.png",
+			"/imageproxy/5x/" + origin.URL + "/1.png",
+			"/imageproxy/raw/" + origin.URL + "/2.png",
+		} {
+			w := httptest.NewRecorder()
+			svc.Routes().ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+			is.Equal(w.Code, http.StatusOK)
+		}
+
+		// the source images, their metadata and the derivative
+		is.Equal(len(svc.cache.entries), 5)
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantPurged string
+		wantLeft   int
+	}{
+		{"source URL with derivatives", "url=" + origin.URL + "/1.png", http.StatusOK, `{"purged":3}`, 2},
+		{"source URL prefix", "prefix=" + origin.URL + "/", http.StatusOK, `{"purged":5}`, 0},
+		{"cache key prefix", "prefix=" + encodeURL(origin.URL+"/2.png"), http.StatusOK, `{"purged":2}`, 3},
+		{"unknown prefix", "prefix=http://example.com", http.StatusOK, `{"purged":0}`, 5},
+		{"empty prefix", "", http.StatusBadRequest, "", 5},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			svc := newTestService(t)
+			fill(t, svc)
+
+			w := httptest.NewRecorder()
+			svc.Routes().ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/imageproxy/cache?"+tt.query, nil))
+
+			is.Equal(w.Code, tt.wantStatus)
+
+			if tt.wantPurged != "" {
+				is.Equal(strings.TrimSpace(w.Body.String()), tt.wantPurged)
+			}
+
+			is.Equal(len(svc.cache.entries), tt.wantLeft)
+		})
+	}
+}
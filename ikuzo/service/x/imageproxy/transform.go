@@ -0,0 +1,142 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imageproxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"willnorris.com/go/imageproxy"
+)
+
+var (
+	ErrInvalidTransformOptions = errors.New("invalid transform options")
+	ErrUnsupportedFormat       = errors.New("unsupported output format")
+)
+
+// parseTransformOptions parses the {options} part of the proxy route.
+//
+// The options use the syntax of willnorris.com/go/imageproxy, e.g. '200x,fit,q80'.
+// Supported output formats are jpeg, png and tiff.
+// An empty string is returned when the options do not transform the image, for
+// example 'raw'. The returned options are normalised so that equivalent
+// options share a single derivative in the cache.
+func parseTransformOptions(raw string) (string, error) {
+	// WebP sources can be decoded, but there is no pure Go WebP encoder
+	for _, opt := range strings.Split(raw, ",") {
+		if opt == "webp" {
+			return "", fmt.Errorf("%w: %s", ErrUnsupportedFormat, opt)
+		}
+	}
+
+	opts := imageproxy.ParseOptions(raw)
+
+	switch opts.Rotate {
+	case 0, 90, 180, 270:
+	default:
+		return "", fmt.Errorf("%w: rotation must be 90, 180 or 270", ErrInvalidTransformOptions)
+	}
+
+	if opts.Quality < 0 || opts.Quality > 100 {
+		return "", fmt.Errorf("%w: quality must be between 1 and 100", ErrInvalidTransformOptions)
+	}
+
+	if opts.Width < 0 || opts.Height < 0 {
+		return "", fmt.Errorf("%w: size cannot be negative", ErrInvalidTransformOptions)
+	}
+
+	// the signature and scaleUp are not part of the derivative
+	opts.Signature = ""
+	opts.ScaleUp = false
+
+	if opts == (imageproxy.Options{}) {
+		return "", nil
+	}
+
+	return opts.String(), nil
+}
+
+// transform applies the transformOptions of the request to the source image.
+func (s *Service) transform(src []byte, transformOptions string) ([]byte, error) {
+	opts := imageproxy.ParseOptions(transformOptions)
+	opts.ScaleUp = s.scaleUp
+
+	return imageproxy.Transform(src, opts)
+}
+
+// derivative returns the derivative of the source image.
+//
+// Derivatives are cached at the derivativePath of the request. Concurrent
+// requests for the same derivative are coalesced.
+func (s *Service) derivative(ctx context.Context, req *Request) (*cachedImage, error) {
+	return s.coalesce(ctx, req.derivativePath(), func(ctx context.Context) (*cachedImage, error) {
+		return s.loadDerivative(ctx, req)
+	})
+}
+
+func (s *Service) loadDerivative(ctx context.Context, req *Request) (*cachedImage, error) {
+	cacheKey := filepath.ToSlash(req.derivativePath())
+
+	// a cached derivative is served as long as its source image does not need revalidation
+	if meta := s.readMeta(req); meta == nil || time.Now().Before(meta.Expires) {
+		b, err := s.cache.Get(cacheKey)
+		if err != nil && !errors.Is(err, ErrCacheKeyNotFound) {
+			log.Error().Err(err).Str("cmp", "imageproxy").Msg("unexpected error reading derivative from cache")
+			return nil, err
+		}
+
+		if err == nil {
+			var lastModified string
+			if meta != nil {
+				lastModified = meta.LastModified
+			}
+
+			return newCachedImage(b, "", lastModified), nil
+		}
+	}
+
+	// the source is revalidated first, which purges the derivatives when it has changed
+	src, err := s.source(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	lastModified := src.header.Get("Last-Modified")
+
+	b, err := s.cache.Get(cacheKey)
+	if err == nil {
+		return newCachedImage(b, "", lastModified), nil
+	}
+
+	derivative, err := s.transform(src.body, req.transformOptions)
+	if err != nil {
+		log.Error().Err(err).Str("cmp", "imageproxy").Str("url", req.sourceURL).
+			Str("options", req.transformOptions).Msg("unable to transform image")
+
+		return nil, fmt.Errorf("unable to transform image; %w", err)
+	}
+
+	if err := s.cache.Set(cacheKey, derivative); err != nil {
+		// do not return error here or cache write error
+		log.Error().Err(err).Str("cmp", "imageproxy").Msg("unable to write derivative to cache")
+	}
+
+	return newCachedImage(derivative, "", lastModified), nil
+}
@@ -0,0 +1,148 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imageproxy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestParseTransformOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    string
+		wantErr error
+	}{
+		{"raw", "raw", "", nil},
+		{"empty", "", "", nil},
+		{"resize", "200x", "200x0", nil},
+		{"normalised order", "fit,200x100", "200x100,fit", nil},
+		{"scaleUp is ignored", "200x,scaleUp", "200x0", nil},
+		{"format and quality", "200x,q80,png", "200x0,png,q80", nil},
+		{"crop", "cx10,cy20,cw100,ch200", "0x0,ch200,cw100,cx10,cy20", nil},
+		{"rotate", "r90", "0x0,r90", nil},
+		{"invalid rotate", "r45", "", ErrInvalidTransformOptions},
+		{"invalid quality", "q120", "", ErrInvalidTransformOptions},
+		{"webp output", "200x,webp", "", ErrUnsupportedFormat},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			got, err := parseTransformOptions(tt.raw)
+			is.True(errors.Is(err, tt.wantErr))
+			is.Equal(got, tt.want)
+		})
+	}
+}
+
+func testPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestService_Do_transform(t *testing.T) {
+	src := testPNG(t, 100, 50)
+
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write(src)
+	}))
+	defer ts.Close()
+
+	tests := []struct {
+		name       string
+		scaleUp    bool
+		options    string
+		wantWidth  int
+		wantHeight int
+		wantFormat string
+	}{
+		{"original", false, "raw", 100, 50, "png"},
+		{"resize", false, "50x", 50, 25, "png"},
+		{"no scale up", false, "200x", 100, 50, "png"},
+		{"scale up", true, "200x", 200, 100, "png"},
+		{"rotate", false, "r90", 50, 100, "png"},
+		{"crop", false, "cw20,ch10", 20, 10, "png"},
+		{"fit", false, "40x40,fit", 40, 20, "png"},
+		{"format", false, "50x,jpeg,q60", 50, 25, "jpeg"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			cacheDir, err := ioutil.TempDir("", "imageproxy-*")
+			is.NoErr(err)
+
+			defer os.RemoveAll(cacheDir)
+
+			svc, err := NewService(SetCacheDir(cacheDir), SetScaleUp(tt.scaleUp))
+			is.NoErr(err)
+
+			options, err := parseTransformOptions(tt.options)
+			is.NoErr(err)
+
+			req, err := NewRequest(ts.URL+"/image.png", SetTransform(options))
+			is.NoErr(err)
+
+			var buf bytes.Buffer
+			is.NoErr(svc.Do(context.Background(), req, &buf))
+
+			cfg, format, err := image.DecodeConfig(&buf)
+			is.NoErr(err)
+			is.Equal(format, tt.wantFormat)
+			is.Equal(cfg.Width, tt.wantWidth)
+			is.Equal(cfg.Height, tt.wantHeight)
+
+			// the second request is served from the cache
+			before := requests
+
+			buf.Reset()
+			is.NoErr(svc.Do(context.Background(), req, &buf))
+			is.Equal(requests, before)
+
+			if options != "" {
+				_, err = os.Stat(cacheDir + "/" + req.derivativePath())
+				is.NoErr(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lodresolver
+
+import (
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Format is a serialization of a LOD resource.
+type Format struct {
+	Name        string
+	Extension   string
+	ContentType string
+	// mediaTypes are the Accept media types that select this format
+	mediaTypes []string
+}
+
+var (
+	Turtle   = Format{"turtle", "ttl", "text/turtle; charset=utf-8", []string{"text/turtle", "application/x-turtle"}}
+	NTriples = Format{"ntriples", "nt", "application/n-triples", []string{"application/n-triples", "text/n-triples", "text/plain"}}
+	JSONLD   = Format{"jsonld", "jsonld", "application/ld+json", []string{"application/ld+json", "application/json"}}
+	RDFXML   = Format{"rdfxml", "rdf", "application/rdf+xml", []string{"application/rdf+xml", "application/xml", "text/xml"}}
+	HTML     = Format{"html", "html", "text/html; charset=utf-8", []string{"text/html", "application/xhtml+xml"}}
+)
+
+// formats in order of preference when the client accepts them with the same quality.
+var formats = []Format{HTML, Turtle, JSONLD, NTriples, RDFXML}
+
+// IsHTML returns true when the format is meant for human consumption.
+func (f Format) IsHTML() bool {
+	return f.Name == HTML.Name
+}
+
+// formatFromExtension returns the format for the extension of p and p without the extension.
+func formatFromExtension(p string) (Format, string, bool) {
+	ext := strings.TrimPrefix(path.Ext(p), ".")
+	if ext == "" {
+		return Format{}, p, false
+	}
+
+	for _, f := range formats {
+		if f.Extension == ext {
+			return f, strings.TrimSuffix(p, "."+ext), true
+		}
+	}
+
+	return Format{}, p, false
+}
+
+// negotiate returns the format that best matches the Accept header.
+// When nothing matches or the header is empty, HTML is returned.
+func negotiate(accept string) Format {
+	var (
+		best    Format
+		bestQ   float64
+		matched bool
+	)
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, q := parseMediaRange(part)
+		if mediaType == "" || q <= 0 {
+			continue
+		}
+
+		f, ok := formatForMediaType(mediaType)
+		if !ok {
+			continue
+		}
+
+		if !matched || q > bestQ {
+			best, bestQ, matched = f, q, true
+		}
+	}
+
+	if !matched {
+		return HTML
+	}
+
+	return best
+}
+
+// parseMediaRange returns the lowercased media type and the quality of a media range.
+func parseMediaRange(part string) (string, float64) {
+	params := strings.Split(part, ";")
+	mediaType := strings.ToLower(strings.TrimSpace(params[0]))
+	q := 1.0
+
+	for _, param := range params[1:] {
+		kv := strings.SplitN(strings.TrimSpace(param), "=", 2)
+		if len(kv) == 2 && strings.TrimSpace(kv[0]) == "q" {
+			if f, err := strconv.ParseFloat(strings.TrimSpace(kv[1]), 64); err == nil {
+				q = f
+			}
+		}
+	}
+
+	return mediaType, q
+}
+
+func formatForMediaType(mediaType string) (Format, bool) {
+	if mediaType == "*/*" || mediaType == "text/*" {
+		return HTML, true
+	}
+
+	for _, f := range formats {
+		for _, mt := range f.mediaTypes {
+			if mt == mediaType {
+				return f, true
+			}
+		}
+	}
+
+	return Format{}, false
+}
@@ -0,0 +1,82 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lodresolver
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name   string
+		accept string
+		want   string
+	}{
+		{"empty", "", "html"},
+		{"wildcard", "*/*", "html"},
+		{"turtle", "text/turtle", "turtle"},
+		{"ntriples", "application/n-triples", "ntriples"},
+		{"jsonld", "application/ld+json", "jsonld"},
+		{"rdfxml", "application/rdf+xml", "rdfxml"},
+		{"browser", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", "html"},
+		{"quality", "text/turtle;q=0.5, application/ld+json", "jsonld"},
+		{"first with same quality", "application/rdf+xml, text/turtle", "rdfxml"},
+		{"excluded", "text/turtle;q=0, application/n-triples;q=0.1", "ntriples"},
+		{"case insensitive", "Text/Turtle", "turtle"},
+		{"unknown", "image/png", "html"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(negotiate(tt.accept).Name, tt.want)
+		})
+	}
+}
+
+func TestFormatFromExtension(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		want     string
+		wantPath string
+		wantOK   bool
+	}{
+		{"turtle", "/data/123.ttl", "turtle", "/data/123", true},
+		{"ntriples", "/data/123.nt", "ntriples", "/data/123", true},
+		{"jsonld", "/data/123.jsonld", "jsonld", "/data/123", true},
+		{"rdfxml", "/data/123.rdf", "rdfxml", "/data/123", true},
+		{"html", "/data/123.html", "html", "/data/123", true},
+		{"no extension", "/data/123", "", "/data/123", false},
+		{"unknown extension", "/data/123.jpg", "", "/data/123.jpg", false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			f, p, ok := formatFromExtension(tt.path)
+			is.Equal(ok, tt.wantOK)
+			is.Equal(f.Name, tt.want)
+			is.Equal(p, tt.wantPath)
+		})
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lodresolver
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/kiivihal/rdf2go"
+)
+
+const rdfNS = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+
+// xmlEscape returns s escaped for use in XML text and attribute values.
+func xmlEscape(s string) string {
+	var sb strings.Builder
+	_ = xml.EscapeText(&sb, []byte(s))
+
+	return sb.String()
+}
+
+// splitPredicate splits a predicate URI into a namespace and a local name
+// that is a valid XML name.
+func splitPredicate(uri string) (ns, local string, ok bool) {
+	i := strings.LastIndexAny(uri, "#/")
+	if i == -1 || i == len(uri)-1 {
+		return "", "", false
+	}
+
+	ns, local = uri[:i+1], uri[i+1:]
+
+	for j, r := range local {
+		isLetter := r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+		isOther := r == '-' || r == '.' || (r >= '0' && r <= '9')
+
+		if !isLetter && (j == 0 || !isOther) {
+			return "", "", false
+		}
+	}
+
+	return ns, local, true
+}
+
+// writeRDFXML writes the graph as RDF/XML. Triples are grouped by subject
+// and written in a stable order.
+func writeRDFXML(w io.Writer, g *rdf2go.Graph) error {
+	bySubject := map[string][]*rdf2go.Triple{}
+	prefixes := map[string]string{rdfNS: "rdf"}
+
+	var namespaces []string
+
+	for t := range g.IterTriples() {
+		key := t.Subject.String()
+		bySubject[key] = append(bySubject[key], t)
+
+		ns, _, ok := splitPredicate(t.Predicate.RawValue())
+		if !ok {
+			return fmt.Errorf("unable to serialize predicate %s as RDF/XML", t.Predicate.RawValue())
+		}
+
+		if _, seen := prefixes[ns]; !seen {
+			prefixes[ns] = ""
+
+			namespaces = append(namespaces, ns)
+		}
+	}
+
+	sort.Strings(namespaces)
+
+	for i, ns := range namespaces {
+		prefixes[ns] = fmt.Sprintf("ns%d", i)
+	}
+
+	subjects := make([]string, 0, len(bySubject))
+	for s := range bySubject {
+		subjects = append(subjects, s)
+	}
+
+	sort.Strings(subjects)
+
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "<?xml version=\"1.0\" encoding=\"utf-8\"?>\n<rdf:RDF xmlns:rdf=\"%s\"", rdfNS)
+
+	for _, ns := range namespaces {
+		fmt.Fprintf(bw, "\n    xmlns:%s=\"%s\"", prefixes[ns], xmlEscape(ns))
+	}
+
+	bw.WriteString(">\n")
+
+	for _, s := range subjects {
+		triples := bySubject[s]
+		sort.Slice(triples, func(i, j int) bool { return triples[i].String() < triples[j].String() })
+
+		switch subject := triples[0].Subject.(type) {
+		case *rdf2go.BlankNode:
+			fmt.Fprintf(bw, "  <rdf:Description rdf:nodeID=\"%s\">\n", xmlEscape(subject.RawValue()))
+		default:
+			fmt.Fprintf(bw, "  <rdf:Description rdf:about=\"%s\">\n", xmlEscape(subject.RawValue()))
+		}
+
+		for _, t := range triples {
+			ns, local, _ := splitPredicate(t.Predicate.RawValue())
+			name := prefixes[ns] + ":" + local
+
+			switch o := t.Object.(type) {
+			case *rdf2go.Resource:
+				fmt.Fprintf(bw, "    <%s rdf:resource=\"%s\"/>\n", name, xmlEscape(o.URI))
+			case *rdf2go.BlankNode:
+				fmt.Fprintf(bw, "    <%s rdf:nodeID=\"%s\"/>\n", name, xmlEscape(o.RawValue()))
+			case *rdf2go.Literal:
+				var attr string
+
+				switch {
+				case o.Language != "":
+					attr = fmt.Sprintf(" xml:lang=\"%s\"", xmlEscape(o.Language))
+				case o.Datatype != nil:
+					attr = fmt.Sprintf(" rdf:datatype=\"%s\"", xmlEscape(o.Datatype.RawValue()))
+				}
+
+				fmt.Fprintf(bw, "    <%s%s>%s</%s>\n", name, attr, xmlEscape(o.Value), name)
+			}
+		}
+
+		bw.WriteString("  </rdf:Description>\n")
+	}
+
+	bw.WriteString("</rdf:RDF>\n")
+
+	return bw.Flush()
+}
@@ -0,0 +1,190 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lodresolver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"html/template"
+	"io"
+	"sort"
+
+	"github.com/kiivihal/rdf2go"
+)
+
+// render writes the graph to w in the requested RDF format.
+func render(w io.Writer, g *rdf2go.Graph, f Format) error {
+	switch f.Name {
+	case NTriples.Name:
+		return writeNTriples(w, g)
+	case JSONLD.Name:
+		return writeJSONLD(w, g)
+	case RDFXML.Name:
+		return writeRDFXML(w, g)
+	}
+
+	return g.Serialize(w, "text/turtle")
+}
+
+// sortedTriples returns the triples of the graph in N-Triples order.
+func sortedTriples(g *rdf2go.Graph) []*rdf2go.Triple {
+	triples := make([]*rdf2go.Triple, 0, g.Len())
+	for t := range g.IterTriples() {
+		triples = append(triples, t)
+	}
+
+	sort.Slice(triples, func(i, j int) bool { return triples[i].String() < triples[j].String() })
+
+	return triples
+}
+
+func writeNTriples(w io.Writer, g *rdf2go.Graph) error {
+	var buf bytes.Buffer
+
+	for _, t := range sortedTriples(g) {
+		buf.WriteString(t.String())
+		buf.WriteString("\n")
+	}
+
+	_, err := buf.WriteTo(w)
+
+	return err
+}
+
+// jsonldTerm returns the expanded JSON-LD representation of an object.
+func jsonldTerm(t rdf2go.Term) map[string]string {
+	switch o := t.(type) {
+	case *rdf2go.Resource:
+		return map[string]string{"@id": o.URI}
+	case *rdf2go.BlankNode:
+		return map[string]string{"@id": o.String()}
+	case *rdf2go.Literal:
+		v := map[string]string{"@value": o.Value}
+
+		switch {
+		case o.Language != "":
+			v["@language"] = o.Language
+		case o.Datatype != nil:
+			v["@type"] = o.Datatype.RawValue()
+		}
+
+		return v
+	}
+
+	return nil
+}
+
+// writeJSONLD writes the graph as expanded JSON-LD with a node object per subject.
+func writeJSONLD(w io.Writer, g *rdf2go.Graph) error {
+	nodes := []map[string]interface{}{}
+	index := map[string]map[string]interface{}{}
+
+	for _, t := range sortedTriples(g) {
+		id := jsonldTerm(t.Subject)["@id"]
+
+		node, ok := index[id]
+		if !ok {
+			node = map[string]interface{}{"@id": id}
+			index[id] = node
+			nodes = append(nodes, node)
+		}
+
+		if r, ok := t.Object.(*rdf2go.Resource); ok && t.Predicate.RawValue() == rdfNS+"type" {
+			types, _ := node["@type"].([]string)
+			node["@type"] = append(types, r.URI)
+
+			continue
+		}
+
+		p := t.Predicate.RawValue()
+		objects, _ := node[p].([]map[string]string)
+		node[p] = append(objects, jsonldTerm(t.Object))
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(nodes)
+}
+
+var htmlTmpl = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html>
+<head>
+  <meta charset="utf-8">
+  <title>{{ .Label }}</title>
+</head>
+<body>
+  <h1>{{ .Label }}</h1>
+  <p><a href="{{ .Subject }}">{{ .Subject }}</a></p>
+  {{- range .Blocks }}
+  <section class="block"{{ with .CSS }} style="{{ . }}"{{ end }}>
+    {{- with .Label }}
+    <h2>{{ . }}</h2>
+    {{- end }}
+    {{- range .Resources }}{{ template "resource" . }}{{ end }}
+  </section>
+  {{- end }}
+  {{- if .Triples }}
+  <table>
+    <thead><tr><th>Predicate</th><th>Object</th></tr></thead>
+    <tbody>
+    {{- range .Triples }}
+      <tr>
+        <td><a href="{{ .Predicate }}" title="{{ .Predicate }}">{{ .PredLabel }}</a></td>
+        <td>{{ template "value" .Value }}</td>
+      </tr>
+    {{- end }}
+    </tbody>
+  </table>
+  {{- end }}
+</body>
+</html>
+{{- define "resource" }}
+    <dl class="resource" about="{{ .URI }}">
+    {{- range .Fields }}
+      <dt>{{ .Label }}</dt>
+      {{- $css := .CSS }}
+      {{- range .Values }}
+      <dd{{ with $css }} style="{{ . }}"{{ end }}>{{ template "value" . }}</dd>
+      {{- end }}
+    {{- end }}
+    </dl>
+{{- end }}
+{{- define "value" }}
+  {{- if .Image }}<img src="{{ .URI }}" alt="{{ .Text }}">
+  {{- else if .Inline }}
+    {{- if .Modal }}<details><summary>{{ .Text }}</summary>{{ template "resource" .Inline }}</details>
+    {{- else }}{{ if .URI }}<a href="{{ .URI }}">{{ .Text }}</a>{{ end }}{{ template "resource" .Inline }}
+    {{- end }}
+  {{- else if .URI }}<a href="{{ .URI }}">{{ .Text }}</a>
+  {{- else }}{{ .Text }}{{ with .Language }} <small>@{{ . }}</small>{{ end }}
+  {{- end }}
+{{- end }}
+`))
+
+// writeHTML renders the resource with the view config for its type, or as a
+// generic table of predicates and objects when there is none.
+func (s *Service) writeHTML(ctx context.Context, w io.Writer, g *rdf2go.Graph, subject string) error {
+	var buf bytes.Buffer
+	if err := htmlTmpl.Execute(&buf, s.newHTMLView(ctx, g, subject)); err != nil {
+		return err
+	}
+
+	_, err := buf.WriteTo(w)
+
+	return err
+}
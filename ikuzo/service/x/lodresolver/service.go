@@ -0,0 +1,313 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lodresolver
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/delving/hub3/hub3/fragments"
+	"github.com/delving/hub3/ikuzo/domain"
+	"github.com/delving/hub3/ikuzo/service/x/namespace"
+	"github.com/go-chi/chi"
+	"github.com/rs/zerolog/log"
+)
+
+var ErrInvalidHTMLRedirect = errors.New("html redirect must be in the form 'regex replacement'")
+
+type Option func(*Service) error
+
+// Service resolves Linked Open Data resource URIs.
+//
+// Resource URIs are redirected with a 303 See Other to the document URI of
+// the negotiated format. Documents are rendered from the Store.
+type Service struct {
+	store        Store
+	baseURL      string // The scheme and host of the resource URIs. default: from the request
+	resourcePath string // The routing point of resource URIs. default: resource
+	dataPath     string // The routing point of RDF documents. default: data
+	htmlPath     string // The routing point of HTML documents. default: page
+	// singleEndpoint is a regular expression for the first path element of
+	// resources that are resolved from a single endpoint. The document URI is
+	// the resource URI with the format extension.
+	singleEndpoint string
+	// htmlRedirect converts the subject URI into the URI of an external page view.
+	htmlRedirect        *regexp.Regexp
+	htmlRedirectReplace string
+	// views are the DetailViewConfigs for the HTML view by EntryType
+	views map[string]*fragments.DetailViewConfig
+	ns    *namespace.Service
+}
+
+// SetStore sets the Store resources are resolved from. default: FragmentStore
+func SetStore(store Store) Option {
+	return func(s *Service) error {
+		s.store = store
+		return nil
+	}
+}
+
+// SetBaseURL sets the scheme and host of the resource URIs, e.g. 'https://data.example.org'.
+func SetBaseURL(baseURL string) Option {
+	return func(s *Service) error {
+		s.baseURL = strings.TrimSuffix(baseURL, "/")
+		return nil
+	}
+}
+
+// SetRoutingPoints sets the routing points for the resource, RDF and HTML URIs.
+// Empty values are ignored.
+func SetRoutingPoints(resource, rdf, html string) Option {
+	return func(s *Service) error {
+		if resource != "" {
+			s.resourcePath = resource
+		}
+
+		if rdf != "" {
+			s.dataPath = rdf
+		}
+
+		if html != "" {
+			s.htmlPath = html
+		}
+
+		return nil
+	}
+}
+
+// SetSingleEndpoint resolves resources whose first path element matches expr
+// from a single endpoint instead of separate routing points.
+func SetSingleEndpoint(expr string) Option {
+	return func(s *Service) error {
+		if expr == "" {
+			return nil
+		}
+
+		if _, err := regexp.Compile(expr); err != nil {
+			return fmt.Errorf("invalid single endpoint; %w", err)
+		}
+
+		s.singleEndpoint = expr
+
+		return nil
+	}
+}
+
+// SetHTMLRedirect redirects HTML requests for subjects that match the regular
+// expression to an external page view. The expression is given as
+// 'regex replacement', e.g. '^http://data.example.org/resource/(.*)$ https://example.org/$1'.
+func SetHTMLRedirect(expr string) Option {
+	return func(s *Service) error {
+		if expr == "" {
+			return nil
+		}
+
+		parts := strings.Fields(expr)
+		if len(parts) != 2 {
+			return ErrInvalidHTMLRedirect
+		}
+
+		re, err := regexp.Compile(parts[0])
+		if err != nil {
+			return fmt.Errorf("%w; %s", ErrInvalidHTMLRedirect, err)
+		}
+
+		s.htmlRedirect = re
+		s.htmlRedirectReplace = parts[1]
+
+		return nil
+	}
+}
+
+func NewService(options ...Option) (*Service, error) {
+	s := &Service{
+		resourcePath: "resource",
+		dataPath:     "data",
+		htmlPath:     "page",
+	}
+
+	// apply options
+	for _, option := range options {
+		if err := option(s); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.store == nil {
+		s.store = NewFragmentStore(nil)
+	}
+
+	return s, nil
+}
+
+// RegisterRoutes registers the LOD routing points on the router.
+func (s *Service) RegisterRoutes(r chi.Router) {
+	if s.singleEndpoint != "" {
+		r.Get(fmt.Sprintf("/{path:%s}/*", s.singleEndpoint), s.resolveSingleEndpoint)
+		return
+	}
+
+	r.Get(fmt.Sprintf("/%s/*", s.resourcePath), s.resolveResource)
+	r.Get(fmt.Sprintf("/%s/*", s.dataPath), s.renderData)
+	r.Get(fmt.Sprintf("/%s/*", s.htmlPath), s.renderPage)
+}
+
+// base returns the scheme and host of the resource URIs.
+// The LODBaseURL of the organization of the request takes precedence.
+func (s *Service) base(r *http.Request) string {
+	if org, ok := domain.GetOrganization(r.Context()); ok && org.Config.LODBaseURL != "" {
+		return strings.TrimSuffix(org.Config.LODBaseURL, "/")
+	}
+
+	if s.baseURL != "" {
+		return s.baseURL
+	}
+
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+
+	return fmt.Sprintf("%s://%s", scheme, r.Host)
+}
+
+// localPath returns the escaped request path after the routing point.
+func localPath(r *http.Request, routingPoint string) string {
+	return strings.TrimPrefix(r.URL.EscapedPath(), "/"+routingPoint+"/")
+}
+
+// externalPage returns the external page view of the subject, when configured.
+func (s *Service) externalPage(subject string) (string, bool) {
+	if s.htmlRedirect == nil || !s.htmlRedirect.MatchString(subject) {
+		return "", false
+	}
+
+	return s.htmlRedirect.ReplaceAllString(subject, s.htmlRedirectReplace), true
+}
+
+func seeOther(w http.ResponseWriter, r *http.Request, location string) {
+	w.Header().Set("Vary", "Accept")
+	http.Redirect(w, r, location, http.StatusSeeOther)
+}
+
+// resolveResource redirects the resource URI to the document URI of the negotiated format.
+func (s *Service) resolveResource(w http.ResponseWriter, r *http.Request) {
+	p := localPath(r, s.resourcePath)
+
+	f := negotiate(r.Header.Get("Accept"))
+	if f.IsHTML() {
+		if page, ok := s.externalPage(fmt.Sprintf("%s/%s/%s", s.base(r), s.resourcePath, p)); ok {
+			seeOther(w, r, page)
+			return
+		}
+
+		seeOther(w, r, fmt.Sprintf("/%s/%s", s.htmlPath, p))
+
+		return
+	}
+
+	seeOther(w, r, fmt.Sprintf("/%s/%s.%s", s.dataPath, p, f.Extension))
+}
+
+// renderData renders the RDF document. The format is taken from the extension
+// or negotiated from the Accept header.
+func (s *Service) renderData(w http.ResponseWriter, r *http.Request) {
+	f, p, ok := formatFromExtension(localPath(r, s.dataPath))
+	if !ok {
+		f = negotiate(r.Header.Get("Accept"))
+		if f.IsHTML() {
+			f = Turtle
+		}
+
+		w.Header().Set("Vary", "Accept")
+	}
+
+	s.render(w, r, fmt.Sprintf("%s/%s/%s", s.base(r), s.resourcePath, p), f)
+}
+
+// renderPage renders the HTML document.
+func (s *Service) renderPage(w http.ResponseWriter, r *http.Request) {
+	p := localPath(r, s.htmlPath)
+
+	s.render(w, r, fmt.Sprintf("%s/%s/%s", s.base(r), s.resourcePath, p), HTML)
+}
+
+// resolveSingleEndpoint renders documents with a format extension and
+// redirects resource URIs to the document with the negotiated extension.
+func (s *Service) resolveSingleEndpoint(w http.ResponseWriter, r *http.Request) {
+	p := strings.TrimSuffix(r.URL.EscapedPath(), "/")
+
+	if f, subjectPath, ok := formatFromExtension(p); ok {
+		s.render(w, r, s.base(r)+subjectPath, f)
+		return
+	}
+
+	f := negotiate(r.Header.Get("Accept"))
+	if f.IsHTML() {
+		if page, ok := s.externalPage(s.base(r) + p); ok {
+			seeOther(w, r, page)
+			return
+		}
+	}
+
+	seeOther(w, r, fmt.Sprintf("%s.%s", p, f.Extension))
+}
+
+func (s *Service) render(w http.ResponseWriter, r *http.Request, subject string, f Format) {
+	g, err := s.store.Resolve(r.Context(), subject)
+	if err != nil {
+		if errors.Is(err, ErrResourceNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+
+		if errors.Is(err, ErrResourceGone) {
+			http.Error(w, err.Error(), http.StatusGone)
+			return
+		}
+
+		log.Error().Err(err).Str("cmp", "lodresolver").Str("subject", subject).Msg("unable to resolve lod resource")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	var buf bytes.Buffer
+
+	if f.IsHTML() {
+		err = s.writeHTML(r.Context(), &buf, g, subject)
+	} else {
+		err = render(&buf, g, f)
+	}
+
+	if err != nil {
+		log.Error().Err(err).Str("cmp", "lodresolver").Str("subject", subject).Str("format", f.Name).
+			Msg("unable to render lod resource")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", f.ContentType)
+	_, _ = buf.WriteTo(w)
+}
@@ -0,0 +1,325 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lodresolver
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/delving/hub3/ikuzo/domain"
+	"github.com/go-chi/chi"
+	"github.com/kiivihal/rdf2go"
+	"github.com/matryer/is"
+)
+
+const testTurtle = `
+<http://data.example.org/resource/123> <http://purl.org/dc/elements/1.1/title> "Nachtwacht"@nl ;
+  <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://www.europeana.eu/schemas/edm/ProvidedCHO> ;
+  <http://purl.org/dc/terms/extent> "363 x 437"^^<http://www.w3.org/2001/XMLSchema#string> ;
+  <http://purl.org/dc/terms/creator> _:b0 .
+_:b0 <http://xmlns.com/foaf/0.1/name> "Rembrandt & <co>" .
+`
+
+var errStoreUnavailable = errors.New("store unavailable")
+
+// memoryStore is a Store that resolves from a map of subject to Turtle.
+// Subjects without Turtle return errStoreUnavailable and subjects with
+// "gone" return ErrResourceGone.
+type memoryStore map[string]string
+
+func (ms memoryStore) Resolve(ctx context.Context, subject string) (*rdf2go.Graph, error) {
+	data, ok := ms[subject]
+	if !ok {
+		return nil, ErrResourceNotFound
+	}
+
+	switch data {
+	case "":
+		return nil, errStoreUnavailable
+	case "gone":
+		return nil, ErrResourceGone
+	}
+
+	g := rdf2go.NewGraph("")
+	if err := g.Parse(strings.NewReader(data), "text/turtle"); err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+func newTestServer(t *testing.T, options ...Option) *httptest.Server {
+	t.Helper()
+
+	store := memoryStore{
+		"http://data.example.org/resource/123":   testTurtle,
+		"http://data.example.org/NL-HaNA/123":    testTurtle,
+		"http://data.example.org/resource/error": "",
+		"http://data.example.org/resource/gone":  "gone",
+	}
+
+	options = append([]Option{SetStore(store), SetBaseURL("http://data.example.org/")}, options...)
+
+	svc, err := NewService(options...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := chi.NewRouter()
+	svc.RegisterRoutes(r)
+
+	ts := httptest.NewServer(r)
+	t.Cleanup(ts.Close)
+
+	return ts
+}
+
+func TestService_resolve(t *testing.T) {
+	noRedirect := &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	tests := []struct {
+		name            string
+		options         []Option
+		path            string
+		accept          string
+		wantStatus      int
+		wantLocation    string
+		wantContentType string
+		wantBody        string
+	}{
+		{"resource to page", nil, "/resource/123", "text/html", http.StatusSeeOther, "/page/123", "", ""},
+		{"resource to data", nil, "/resource/123", "text/turtle", http.StatusSeeOther, "/data/123.ttl", "", ""},
+		{"resource to jsonld", nil, "/resource/123", "application/ld+json", http.StatusSeeOther, "/data/123.jsonld", "", ""},
+		{
+			"resource to external page",
+			[]Option{SetHTMLRedirect(`^http://data.example.org/resource/(.*)$ https://example.org/detail/$1`)},
+			"/resource/123", "text/html", http.StatusSeeOther, "https://example.org/detail/123", "", "",
+		},
+		{
+			"external page only for html",
+			[]Option{SetHTMLRedirect(`^http://data.example.org/resource/(.*)$ https://example.org/detail/$1`)},
+			"/resource/123", "application/n-triples", http.StatusSeeOther, "/data/123.nt", "", "",
+		},
+		{
+			"ntriples extension", nil, "/data/123.nt", "", http.StatusOK, "", "application/n-triples",
+			`<http://data.example.org/resource/123> <http://purl.org/dc/elements/1.1/title> "Nachtwacht"@nl .`,
+		},
+		{
+			"turtle negotiated", nil, "/data/123", "text/turtle", http.StatusOK, "", "text/turtle; charset=utf-8",
+			`<http://purl.org/dc/elements/1.1/title> "Nachtwacht"@nl`,
+		},
+		{
+			"data defaults to turtle", nil, "/data/123", "text/html", http.StatusOK, "", "text/turtle; charset=utf-8",
+			`<http://purl.org/dc/elements/1.1/title> "Nachtwacht"@nl`,
+		},
+		{
+			"jsonld extension", nil, "/data/123.jsonld", "", http.StatusOK, "", "application/ld+json",
+			`"@id": "http://data.example.org/resource/123"`,
+		},
+		{
+			"rdfxml extension", nil, "/data/123.rdf", "", http.StatusOK, "", "application/rdf+xml",
+			`<rdf:Description rdf:about="http://data.example.org/resource/123">`,
+		},
+		{
+			"html page", nil, "/page/123", "", http.StatusOK, "", "text/html; charset=utf-8",
+			`<h1>Nachtwacht</h1>`,
+		},
+		{"not found", nil, "/data/456.ttl", "", http.StatusNotFound, "", "", ""},
+		{"no lod access", nil, "/data/gone.ttl", "", http.StatusGone, "", "", ""},
+		{"store error", nil, "/data/error.ttl", "", http.StatusInternalServerError, "", "", ""},
+		{
+			"single endpoint to data", []Option{SetSingleEndpoint("NL-.*")},
+			"/NL-HaNA/123", "application/rdf+xml", http.StatusSeeOther, "/NL-HaNA/123.rdf", "", "",
+		},
+		{
+			"single endpoint to page", []Option{SetSingleEndpoint("NL-.*")},
+			"/NL-HaNA/123", "", http.StatusSeeOther, "/NL-HaNA/123.html", "", "",
+		},
+		{
+			"single endpoint to external page",
+			[]Option{SetSingleEndpoint("NL-.*"), SetHTMLRedirect(`^http://data.example.org/(NL-.*)$ https://example.org/$1`)},
+			"/NL-HaNA/123", "text/html", http.StatusSeeOther, "https://example.org/NL-HaNA/123", "", "",
+		},
+		{
+			"single endpoint document", []Option{SetSingleEndpoint("NL-.*")},
+			"/NL-HaNA/123.nt", "", http.StatusOK, "", "application/n-triples",
+			`<http://data.example.org/resource/123> <http://purl.org/dc/elements/1.1/title> "Nachtwacht"@nl .`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			ts := newTestServer(t, tt.options...)
+
+			req, err := http.NewRequest(http.MethodGet, ts.URL+tt.path, nil)
+			is.NoErr(err)
+
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+
+			resp, err := noRedirect.Do(req)
+			is.NoErr(err)
+
+			defer resp.Body.Close()
+
+			body, err := ioutil.ReadAll(resp.Body)
+			is.NoErr(err)
+
+			is.Equal(resp.StatusCode, tt.wantStatus)
+			is.Equal(resp.Header.Get("Location"), tt.wantLocation)
+
+			if tt.wantStatus == http.StatusSeeOther {
+				is.Equal(resp.Header.Get("Vary"), "Accept")
+			}
+
+			if tt.wantContentType != "" {
+				is.Equal(resp.Header.Get("Content-Type"), tt.wantContentType)
+			}
+
+			if !strings.Contains(string(body), tt.wantBody) {
+				t.Errorf("body does not contain %q; got:\n%s", tt.wantBody, body)
+			}
+		})
+	}
+}
+
+func TestSetHTMLRedirect(t *testing.T) {
+	is := is.New(t)
+
+	_, err := NewService(SetHTMLRedirect("^http://example.org/(.*)$"))
+	is.True(errors.Is(err, ErrInvalidHTMLRedirect))
+
+	_, err = NewService(SetHTMLRedirect("^http://example.org/(.*$ https://example.org/$1"))
+	is.True(errors.Is(err, ErrInvalidHTMLRedirect))
+
+	_, err = NewService(SetHTMLRedirect("^http://example.org/(.*)$ https://example.org/$1"))
+	is.NoErr(err)
+}
+
+func TestWriteRDFXML(t *testing.T) {
+	is := is.New(t)
+
+	subject := rdf2go.NewResource("http://data.example.org/resource/123")
+	creator := rdf2go.NewBlankNode("b0")
+
+	g := rdf2go.NewGraph("")
+	g.AddTriple(subject, rdf2go.NewResource("http://purl.org/dc/elements/1.1/title"), rdf2go.NewLiteralWithLanguage("Nachtwacht", "nl"))
+	g.AddTriple(subject, rdf2go.NewResource(rdfNS+"type"), rdf2go.NewResource("http://www.europeana.eu/schemas/edm/ProvidedCHO"))
+	g.AddTriple(
+		subject,
+		rdf2go.NewResource("http://purl.org/dc/terms/extent"),
+		rdf2go.NewLiteralWithDatatype("363 x 437", rdf2go.NewResource("http://www.w3.org/2001/XMLSchema#string")),
+	)
+	g.AddTriple(subject, rdf2go.NewResource("http://purl.org/dc/terms/creator"), creator)
+	g.AddTriple(creator, rdf2go.NewResource("http://xmlns.com/foaf/0.1/name"), rdf2go.NewLiteral("Rembrandt & <co>"))
+
+	var sb strings.Builder
+	is.NoErr(writeRDFXML(&sb, g))
+
+	got := sb.String()
+
+	// the output must be well-formed XML
+	dec := xml.NewDecoder(strings.NewReader(got))
+	for {
+		_, err := dec.Token()
+		if err != nil {
+			is.Equal(err.Error(), "EOF")
+			break
+		}
+	}
+
+	want := `<?xml version="1.0" encoding="utf-8"?>
+<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+    xmlns:ns0="http://purl.org/dc/elements/1.1/"
+    xmlns:ns1="http://purl.org/dc/terms/"
+    xmlns:ns2="http://xmlns.com/foaf/0.1/">
+  <rdf:Description rdf:about="http://data.example.org/resource/123">
+    <ns0:title xml:lang="nl">Nachtwacht</ns0:title>
+    <ns1:creator rdf:nodeID="b0"/>
+    <ns1:extent rdf:datatype="http://www.w3.org/2001/XMLSchema#string">363 x 437</ns1:extent>
+    <rdf:type rdf:resource="http://www.europeana.eu/schemas/edm/ProvidedCHO"/>
+  </rdf:Description>
+  <rdf:Description rdf:nodeID="b0">
+    <ns2:name>Rembrandt &amp; &lt;co&gt;</ns2:name>
+  </rdf:Description>
+</rdf:RDF>
+`
+	is.Equal(got, want)
+}
+
+func TestWriteRDFXML_invalidPredicate(t *testing.T) {
+	is := is.New(t)
+
+	g := rdf2go.NewGraph("")
+	g.AddTriple(rdf2go.NewResource("urn:s"), rdf2go.NewResource("http://example.org/p/"), rdf2go.NewLiteral("o"))
+
+	var sb strings.Builder
+	is.True(writeRDFXML(&sb, g) != nil)
+}
+
+func TestService_base(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		org     *domain.Organization
+		want    string
+	}{
+		{"from request", "", nil, "http://data.example.org"},
+		{"from config", "https://lod.example.org/", nil, "https://lod.example.org"},
+		{
+			"from organization", "https://lod.example.org",
+			&domain.Organization{ID: "demo", Config: domain.OrganizationConfig{LODBaseURL: "https://demo.example.org/"}},
+			"https://demo.example.org",
+		},
+		{
+			"organization without base url", "https://lod.example.org",
+			&domain.Organization{ID: "demo"},
+			"https://lod.example.org",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			s, err := NewService(SetBaseURL(tt.baseURL))
+			is.NoErr(err)
+
+			r := httptest.NewRequest(http.MethodGet, "http://data.example.org/resource/123", nil)
+			if tt.org != nil {
+				r = r.WithContext(domain.SetOrganization(r.Context(), *tt.org))
+			}
+
+			is.Equal(s.base(r), tt.want)
+		})
+	}
+}
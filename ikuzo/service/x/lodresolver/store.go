@@ -0,0 +1,159 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lodresolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/delving/hub3/hub3/fragments"
+	"github.com/delving/hub3/hub3/index"
+	"github.com/delving/hub3/ikuzo/service/x/sparql"
+	"github.com/kiivihal/rdf2go"
+	elastic "github.com/olivere/elastic/v7"
+)
+
+var (
+	ErrResourceNotFound = errors.New("lod resource not found")
+	// ErrResourceGone is returned for resources of datasets without LOD access.
+	ErrResourceGone = errors.New("lod resource is not public")
+)
+
+// Store resolves the RDF description of a subject URI.
+type Store interface {
+	// Resolve returns all triples with the subject. It returns ErrResourceNotFound
+	// when there are none and ErrResourceGone when the dataset of the subject
+	// has no LOD access.
+	Resolve(ctx context.Context, subject string) (*rdf2go.Graph, error)
+}
+
+// FragmentStore resolves subjects from the fragment index.
+type FragmentStore struct {
+	client *elastic.Client
+}
+
+// NewFragmentStore returns a Store backed by the fragment index.
+// When client is nil the legacy elasticsearch client is used.
+func NewFragmentStore(client *elastic.Client) *FragmentStore {
+	return &FragmentStore{client: client}
+}
+
+func (fs *FragmentStore) Resolve(ctx context.Context, subject string) (*rdf2go.Graph, error) {
+	client := fs.client
+	if client == nil {
+		client = index.ESClient()
+	}
+
+	fr := fragments.NewFragmentRequest()
+	fr.Subject = []string{subject}
+
+	frags, _, err := fr.Find(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(frags) == 0 {
+		return nil, ErrResourceNotFound
+	}
+
+	for _, frag := range frags {
+		if fragments.IsLODRestricted(frag.GetMeta().GetSpec()) {
+			return nil, ErrResourceGone
+		}
+	}
+
+	var sb strings.Builder
+	for _, frag := range frags {
+		sb.WriteString(frag.GetTriple())
+		sb.WriteString("\n")
+	}
+
+	g := rdf2go.NewGraph("")
+	if err := g.Parse(strings.NewReader(sb.String()), "text/turtle"); err != nil {
+		return nil, fmt.Errorf("unable to parse fragments for %s; %w", subject, err)
+	}
+
+	return g, nil
+}
+
+// SparqlStore resolves subjects with a SPARQL DESCRIBE query on the triple store.
+type SparqlStore struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewSparqlStore returns a Store backed by the SPARQL endpoint of the triple store.
+// When client is nil a client with a 10 second timeout is used.
+func NewSparqlStore(endpoint string, client *http.Client) *SparqlStore {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	return &SparqlStore{endpoint: endpoint, client: client}
+}
+
+func (ss *SparqlStore) Resolve(ctx context.Context, subject string) (*rdf2go.Graph, error) {
+	form := url.Values{}
+	form.Set("query", fmt.Sprintf("DESCRIBE <%s>", subject))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ss.endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "text/turtle")
+
+	resp, err := ss.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("sparql endpoint responded with status %d", resp.StatusCode)
+	}
+
+	g := rdf2go.NewGraph("")
+	if err := g.Parse(resp.Body, "text/turtle"); err != nil {
+		return nil, fmt.Errorf("unable to parse sparql response for %s; %w", subject, err)
+	}
+
+	if g.Len() == 0 {
+		return nil, ErrResourceNotFound
+	}
+
+	if isRestricted(g) {
+		return nil, ErrResourceGone
+	}
+
+	return g, nil
+}
+
+// isRestricted returns true when the graph links to a dataset without LOD access.
+func isRestricted(g *rdf2go.Graph) bool {
+	for _, t := range g.All(nil, rdf2go.NewResource(sparql.SpecPredicate), nil) {
+		if spec, ok := t.Object.(*rdf2go.Literal); ok && fragments.IsLODRestricted(spec.Value) {
+			return true
+		}
+	}
+
+	return false
+}
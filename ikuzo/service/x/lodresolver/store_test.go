@@ -0,0 +1,69 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lodresolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/delving/hub3/hub3/fragments"
+	"github.com/delving/hub3/ikuzo/service/x/sparql"
+	"github.com/matryer/is"
+)
+
+func TestSparqlStore_Resolve(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimSuffix(strings.TrimPrefix(r.FormValue("query"), "DESCRIBE <http://data.example.org/resource/"), ">")
+
+		w.Header().Set("Content-Type", "text/turtle")
+		fmt.Fprintf(w, "<http://data.example.org/resource/%s> <%s> \"spec-%s\" .\n", name, sparql.SpecPredicate, name)
+	}))
+	defer ts.Close()
+
+	fragments.SetDataSetAccess("spec-embargoed", true, false)
+	defer fragments.SetDataSetAccess("spec-embargoed", true, true)
+
+	tests := []struct {
+		name    string
+		subject string
+		wantErr error
+	}{
+		{"public dataset", "http://data.example.org/resource/public", nil},
+		{"dataset without lod access", "http://data.example.org/resource/embargoed", ErrResourceGone},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			store := NewSparqlStore(ts.URL, nil)
+
+			g, err := store.Resolve(context.Background(), tt.subject)
+			if tt.wantErr != nil {
+				is.True(errors.Is(err, tt.wantErr))
+				return
+			}
+
+			is.NoErr(err)
+			is.Equal(g.Len(), 1)
+		})
+	}
+}
@@ -0,0 +1,478 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lodresolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/delving/hub3/hub3/fragments"
+	"github.com/delving/hub3/ikuzo/service/x/namespace"
+	"github.com/kiivihal/rdf2go"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	rdfType = rdfNS + "type"
+	// maxInlineDepth limits how deep linked resources are rendered inline.
+	maxInlineDepth = 3
+)
+
+// labelPredicates are used in order to find the label of a resource.
+var labelPredicates = []string{
+	"http://www.w3.org/2004/02/skos/core#prefLabel",
+	"http://www.w3.org/2000/01/rdf-schema#label",
+	"http://purl.org/dc/elements/1.1/title",
+	"http://purl.org/dc/terms/title",
+	"http://xmlns.com/foaf/0.1/name",
+	"http://schema.org/name",
+}
+
+// SetViewConfigs sets the DetailViewConfigs that drive the HTML view.
+// A view is selected by matching its EntryType with the rdf:type of the resource.
+func SetViewConfigs(configs ...*fragments.DataSetConfig) Option {
+	return func(s *Service) error {
+		if s.views == nil {
+			s.views = map[string]*fragments.DetailViewConfig{}
+		}
+
+		for _, cfg := range configs {
+			view := cfg.GetViewConfig()
+			if view == nil {
+				continue
+			}
+
+			s.views[view.GetEntryType()] = view
+		}
+
+		return nil
+	}
+}
+
+// SetNameSpaceService sets the namespace.Service that is used to resolve the
+// labels of predicates and the prefixed predicates in the view configs.
+func SetNameSpaceService(svc *namespace.Service) Option {
+	return func(s *Service) error {
+		s.ns = svc
+		return nil
+	}
+}
+
+// LoadViewConfigs reads the DataSetConfig JSON files in dir.
+func LoadViewConfigs(dir string) ([]*fragments.DataSetConfig, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	configs := []*fragments.DataSetConfig{}
+
+	for _, file := range files {
+		b, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		cfg := &fragments.DataSetConfig{}
+		if err := protojson.Unmarshal(b, cfg); err != nil {
+			return nil, fmt.Errorf("unable to read view config %s; %w", file, err)
+		}
+
+		configs = append(configs, cfg)
+	}
+
+	return configs, nil
+}
+
+type htmlView struct {
+	Subject string
+	Label   string
+	Blocks  []*viewBlock
+	// Triples are rendered as a generic table when there is no view config.
+	Triples []*viewTriple
+}
+
+type viewBlock struct {
+	Label     string
+	CSS       template.CSS
+	Resources []*viewResource
+}
+
+type viewResource struct {
+	URI    string
+	Label  string
+	Fields []*viewField
+}
+
+type viewField struct {
+	Label  string
+	CSS    template.CSS
+	Values []*viewValue
+}
+
+type viewValue struct {
+	Text     string
+	URI      string
+	Language string
+	Image    bool
+	Modal    bool
+	Inline   *viewResource
+}
+
+type viewTriple struct {
+	Predicate string
+	PredLabel string
+	Value     *viewValue
+}
+
+// viewBuilder builds the HTML view of a single request.
+type viewBuilder struct {
+	s     *Service
+	ctx   context.Context
+	graph *rdf2go.Graph
+	view  *fragments.DetailViewConfig
+	// resolved caches linked resources that are resolved from the store.
+	resolved map[string]*rdf2go.Graph
+}
+
+func (s *Service) newHTMLView(ctx context.Context, g *rdf2go.Graph, subject string) *htmlView {
+	vb := &viewBuilder{
+		s:        s,
+		ctx:      ctx,
+		graph:    g,
+		resolved: map[string]*rdf2go.Graph{},
+	}
+
+	v := &htmlView{Subject: subject}
+	subj := resourceTerm(subject)
+
+	v.Label = vb.resourceLabel(subj, "")
+	vb.view = s.viewFor(vb.types(subj))
+
+	if vb.view == nil {
+		for _, t := range sortedTriples(g) {
+			if !t.Subject.Equal(subj) {
+				continue
+			}
+
+			v.Triples = append(v.Triples, &viewTriple{
+				Predicate: t.Predicate.RawValue(),
+				PredLabel: s.predicateLabel(t.Predicate.RawValue()),
+				Value:     vb.value(t.Object, fragments.FieldType_LITERAL, fragments.InlineType_NONE, 0),
+			})
+		}
+
+		return v
+	}
+
+	blocks := append([]*fragments.DetailBlock{}, vb.view.GetBlocks()...)
+	sort.SliceStable(blocks, func(i, j int) bool { return blocks[i].GetOrder() < blocks[j].GetOrder() })
+
+	for _, block := range blocks {
+		vBlock := &viewBlock{
+			Label: block.GetI18NLabel().GetName(),
+			CSS:   template.CSS(block.GetInlineCSS()), // nolint:gosec // view configs are trusted
+		}
+
+		for _, term := range vb.blockResources(subj, block) {
+			vBlock.Resources = append(vBlock.Resources, vb.resource(term, block, 0))
+		}
+
+		if len(vBlock.Resources) > 0 {
+			v.Blocks = append(v.Blocks, vBlock)
+		}
+	}
+
+	return v
+}
+
+// viewFor returns the view config for the first type that has one.
+func (s *Service) viewFor(types []string) *fragments.DetailViewConfig {
+	for _, t := range types {
+		if view, ok := s.views[t]; ok {
+			return view
+		}
+
+		if label := s.predicateLabel(t); label != t {
+			if view, ok := s.views[label]; ok {
+				return view
+			}
+		}
+	}
+
+	return s.views[""]
+}
+
+// predicateLabel returns the prefixed label of the URI. The URI is returned
+// when it can't be resolved through the namespace service.
+func (s *Service) predicateLabel(uri string) string {
+	if s.ns == nil {
+		return uri
+	}
+
+	label, err := s.ns.Label(uri)
+	if err != nil {
+		return uri
+	}
+
+	return label
+}
+
+// expand returns the URI for a predicate from a view config, which may be prefixed.
+func (s *Service) expand(predicate string) string {
+	if predicate == "" || strings.Contains(predicate, "://") || s.ns == nil {
+		return predicate
+	}
+
+	uri, err := s.ns.Expand(predicate)
+	if err != nil {
+		return predicate
+	}
+
+	return uri
+}
+
+func resourceTerm(id string) rdf2go.Term {
+	if strings.HasPrefix(id, "_:") {
+		return rdf2go.NewBlankNode(strings.TrimPrefix(id, "_:"))
+	}
+
+	return rdf2go.NewResource(id)
+}
+
+func termID(t rdf2go.Term) string {
+	if bn, ok := t.(*rdf2go.BlankNode); ok {
+		return bn.String()
+	}
+
+	return t.RawValue()
+}
+
+// graphFor returns the graph that describes the term. Linked resources that
+// are not part of the request graph are resolved from the store.
+func (vb *viewBuilder) graphFor(t rdf2go.Term) *rdf2go.Graph {
+	if len(vb.graph.All(t, nil, nil)) > 0 {
+		return vb.graph
+	}
+
+	r, ok := t.(*rdf2go.Resource)
+	if !ok {
+		return vb.graph
+	}
+
+	if g, ok := vb.resolved[r.URI]; ok {
+		return g
+	}
+
+	g, err := vb.s.store.Resolve(vb.ctx, r.URI)
+	if err != nil {
+		if !errors.Is(err, ErrResourceNotFound) && !errors.Is(err, ErrResourceGone) {
+			log.Warn().Err(err).Str("cmp", "lodresolver").Str("subject", r.URI).Msg("unable to resolve linked resource")
+		}
+
+		g = rdf2go.NewGraph("")
+	}
+
+	vb.resolved[r.URI] = g
+
+	return g
+}
+
+func (vb *viewBuilder) objects(t rdf2go.Term, predicate string) []rdf2go.Term {
+	triples := vb.graphFor(t).All(t, rdf2go.NewResource(predicate), nil)
+	sort.Slice(triples, func(i, j int) bool { return triples[i].String() < triples[j].String() })
+
+	objects := make([]rdf2go.Term, 0, len(triples))
+	for _, triple := range triples {
+		objects = append(objects, triple.Object)
+	}
+
+	return objects
+}
+
+func (vb *viewBuilder) types(t rdf2go.Term) []string {
+	types := []string{}
+	for _, o := range vb.objects(t, rdfType) {
+		types = append(types, o.RawValue())
+	}
+
+	return types
+}
+
+// resourceLabel returns the label of the resource from the predicate or the
+// default label predicates. The URI is returned when there is no label.
+func (vb *viewBuilder) resourceLabel(t rdf2go.Term, predicate string) string {
+	predicates := labelPredicates
+	if predicate != "" {
+		predicates = append([]string{vb.s.expand(predicate)}, labelPredicates...)
+	}
+
+	for _, p := range predicates {
+		if objects := vb.objects(t, p); len(objects) > 0 {
+			return objects[0].RawValue()
+		}
+	}
+
+	return termID(t)
+}
+
+// blockResources returns the resources the block is rendered for. Blocks
+// without a resource type describe the subject of the request.
+func (vb *viewBuilder) blockResources(subject rdf2go.Term, block *fragments.DetailBlock) []rdf2go.Term {
+	resourceType := vb.s.expand(block.GetResourceType())
+	if resourceType == "" {
+		return []rdf2go.Term{subject}
+	}
+
+	triples := vb.graph.All(nil, rdf2go.NewResource(rdfType), rdf2go.NewResource(resourceType))
+	sort.Slice(triples, func(i, j int) bool { return triples[i].String() < triples[j].String() })
+
+	resources := make([]rdf2go.Term, 0, len(triples))
+	for _, t := range triples {
+		resources = append(resources, t.Subject)
+	}
+
+	return resources
+}
+
+// blockForType returns the block of the view that is configured for the types.
+func (vb *viewBuilder) blockForType(types []string) *fragments.DetailBlock {
+	for _, block := range vb.view.GetBlocks() {
+		resourceType := vb.s.expand(block.GetResourceType())
+		if resourceType == "" {
+			continue
+		}
+
+		for _, t := range types {
+			if t == resourceType {
+				return block
+			}
+		}
+	}
+
+	return nil
+}
+
+// resource renders the fields of the block for the resource. Without a block
+// all predicates of the resource are rendered.
+func (vb *viewBuilder) resource(t rdf2go.Term, block *fragments.DetailBlock, depth int) *viewResource {
+	vr := &viewResource{
+		URI:   termID(t),
+		Label: vb.resourceLabel(t, block.GetResourceLabel()),
+	}
+
+	if block == nil {
+		for _, triple := range sortedTriples(vb.graphFor(t)) {
+			if !triple.Subject.Equal(t) {
+				continue
+			}
+
+			p := triple.Predicate.RawValue()
+
+			if n := len(vr.Fields); n > 0 && vr.Fields[n-1].Label == vb.s.predicateLabel(p) {
+				vr.Fields[n-1].Values = append(vr.Fields[n-1].Values, vb.value(triple.Object, fragments.FieldType_LITERAL, fragments.InlineType_NONE, depth))
+				continue
+			}
+
+			vr.Fields = append(vr.Fields, &viewField{
+				Label:  vb.s.predicateLabel(p),
+				Values: []*viewValue{vb.value(triple.Object, fragments.FieldType_LITERAL, fragments.InlineType_NONE, depth)},
+			})
+		}
+
+		return vr
+	}
+
+	fields := append([]*fragments.PresentationField{}, block.GetFields()...)
+	sort.SliceStable(fields, func(i, j int) bool { return fields[i].GetOrder() < fields[j].GetOrder() })
+
+	for _, field := range fields {
+		predicate := vb.s.expand(field.GetPredicate())
+
+		objects := vb.objects(t, predicate)
+		if len(objects) == 0 {
+			continue
+		}
+
+		if field.GetSingle() {
+			objects = objects[:1]
+		}
+
+		vf := &viewField{
+			Label: field.GetI18NLabel().GetName(),
+			CSS:   template.CSS(field.GetInlineCSS()), // nolint:gosec // view configs are trusted
+		}
+
+		if vf.Label == "" {
+			vf.Label = vb.s.predicateLabel(predicate)
+		}
+
+		for _, o := range objects {
+			vf.Values = append(vf.Values, vb.value(o, field.GetFieldType(), field.GetInlineType(), depth))
+		}
+
+		vr.Fields = append(vr.Fields, vf)
+	}
+
+	return vr
+}
+
+// value renders an object according to the field and inline type.
+func (vb *viewBuilder) value(o rdf2go.Term, fieldType fragments.FieldType, inline fragments.InlineType, depth int) *viewValue {
+	switch term := o.(type) {
+	case *rdf2go.Literal:
+		return &viewValue{Text: term.Value, Language: term.Language}
+	case *rdf2go.Resource:
+		if fieldType == fragments.FieldType_DIGITAL_OBJECT {
+			return &viewValue{Text: term.URI, URI: term.URI, Image: true}
+		}
+	}
+
+	id := termID(o)
+	v := &viewValue{Text: id}
+
+	if _, ok := o.(*rdf2go.Resource); ok {
+		v.URI = id
+	}
+
+	switch inline {
+	case fragments.InlineType_URI_ONLY:
+		v.URI = ""
+	case fragments.InlineType_LABEL:
+		v.Text = vb.resourceLabel(o, "")
+	case fragments.InlineType_MODAL, fragments.InlineType_INLINE_DETAIL_BLOCK:
+		if depth >= maxInlineDepth {
+			break
+		}
+
+		v.Text = vb.resourceLabel(o, "")
+		v.Modal = inline == fragments.InlineType_MODAL
+		v.Inline = vb.resource(o, vb.blockForType(vb.types(o)), depth+1)
+	case fragments.InlineType_NONE:
+		if _, ok := o.(*rdf2go.BlankNode); ok && depth < maxInlineDepth {
+			// blank nodes can't be resolved so they are always shown inline
+			v.Inline = vb.resource(o, nil, depth+1)
+		}
+	}
+
+	return v
+}
@@ -0,0 +1,243 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lodresolver
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/delving/hub3/hub3/fragments"
+	"github.com/delving/hub3/ikuzo/domain"
+	"github.com/delving/hub3/ikuzo/service/x/namespace"
+	"github.com/matryer/is"
+)
+
+const viewTurtle = `
+<http://data.example.org/resource/456> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://www.europeana.eu/schemas/edm/ProvidedCHO> ;
+  <http://purl.org/dc/elements/1.1/title> "Het Joodse bruidje"@nl ;
+  <http://purl.org/dc/elements/1.1/subject> "portret", "echtpaar" ;
+  <http://purl.org/dc/elements/1.1/creator> <http://data.example.org/resource/rembrandt> ;
+  <http://purl.org/dc/terms/spatial> <http://data.example.org/resource/amsterdam> ;
+  <http://purl.org/dc/terms/provenance> <http://data.example.org/resource/rijksmuseum> ;
+  <http://www.europeana.eu/schemas/edm/isShownBy> <http://images.example.org/456.jpg> .
+`
+
+const viewConfig = `{
+  "title": "Paintings",
+  "viewConfig": {
+    "entryType": "edm:ProvidedCHO",
+    "blocks": [
+      {
+        "i18nLabel": {"lang": "en", "name": "Images"},
+        "order": 2,
+        "fields": [
+          {"predicate": "edm:isShownBy", "fieldType": "DIGITAL_OBJECT"}
+        ]
+      },
+      {
+        "i18nLabel": {"lang": "en", "name": "Description"},
+        "order": 1,
+        "inlineCSS": "color: red",
+        "fields": [
+          {"i18nLabel": {"lang": "en", "name": "Title"}, "predicate": "dc:title", "order": 1},
+          {"predicate": "http://purl.org/dc/elements/1.1/subject", "single": true, "order": 2},
+          {"i18nLabel": {"lang": "en", "name": "Creator"}, "predicate": "dc:creator", "inlineType": "LABEL", "order": 3},
+          {"i18nLabel": {"lang": "en", "name": "Place"}, "predicate": "dcterms:spatial", "inlineType": "INLINE_DETAIL_BLOCK", "order": 4},
+          {"i18nLabel": {"lang": "en", "name": "Provenance"}, "predicate": "dcterms:provenance", "inlineType": "MODAL", "order": 5},
+          {"predicate": "dc:description", "order": 6}
+        ]
+      },
+      {
+        "resourceType": "edm:Place",
+        "fields": [
+          {"i18nLabel": {"lang": "en", "name": "Coordinates"}, "predicate": "wgs84_pos:lat"}
+        ]
+      }
+    ]
+  }
+}`
+
+func newViewService(t *testing.T) *Service {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "viewconfig-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	if err = ioutil.WriteFile(filepath.Join(dir, "paintings.json"), []byte(viewConfig), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	configs, err := LoadViewConfigs(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ns, err := namespace.NewService()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for prefix, base := range map[string]string{
+		"dc":        "http://purl.org/dc/elements/1.1/",
+		"dcterms":   "http://purl.org/dc/terms/",
+		"edm":       "http://www.europeana.eu/schemas/edm/",
+		"skos":      "http://www.w3.org/2004/02/skos/core#",
+		"wgs84_pos": "http://www.w3.org/2003/01/geo/wgs84_pos#",
+	} {
+		if err = ns.Set(&domain.NameSpace{Prefix: prefix, Base: base}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	store := memoryStore{
+		"http://data.example.org/resource/456": viewTurtle,
+		"http://data.example.org/resource/rembrandt": `<http://data.example.org/resource/rembrandt>
+			<http://www.w3.org/2004/02/skos/core#prefLabel> "Rembrandt van Rijn" .`,
+		"http://data.example.org/resource/amsterdam": `<http://data.example.org/resource/amsterdam>
+			<http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://www.europeana.eu/schemas/edm/Place> ;
+			<http://www.w3.org/2004/02/skos/core#prefLabel> "Amsterdam" ;
+			<http://www.w3.org/2003/01/geo/wgs84_pos#lat> "52.37" .`,
+		"http://data.example.org/resource/rijksmuseum": `<http://data.example.org/resource/rijksmuseum>
+			<http://www.w3.org/2004/02/skos/core#prefLabel> "Rijksmuseum" ;
+			<http://purl.org/dc/terms/spatial> "Amsterdam" .`,
+	}
+
+	svc, err := NewService(SetStore(store), SetViewConfigs(configs...), SetNameSpaceService(ns))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return svc
+}
+
+func TestService_newHTMLView(t *testing.T) {
+	is := is.New(t)
+
+	svc := newViewService(t)
+	ctx := context.Background()
+
+	subject := "http://data.example.org/resource/456"
+	g, err := svc.store.Resolve(ctx, subject)
+	is.NoErr(err)
+
+	v := svc.newHTMLView(ctx, g, subject)
+	is.Equal(v.Label, "Het Joodse bruidje")
+	is.Equal(len(v.Triples), 0)
+
+	// blocks are ordered and blocks without resources are left out
+	is.Equal(len(v.Blocks), 2)
+	is.Equal(v.Blocks[0].Label, "Description")
+	is.Equal(string(v.Blocks[0].CSS), "color: red")
+	is.Equal(v.Blocks[1].Label, "Images")
+
+	fields := v.Blocks[0].Resources[0].Fields
+	is.Equal(len(fields), 5) // dc:description has no values
+
+	// i18n label and prefixed predicate
+	is.Equal(fields[0].Label, "Title")
+	is.Equal(fields[0].Values[0].Text, "Het Joodse bruidje")
+	is.Equal(fields[0].Values[0].Language, "nl")
+
+	// label from the namespace service and single value
+	is.Equal(fields[1].Label, "dc:subject")
+	is.Equal(len(fields[1].Values), 1)
+
+	// linked resource is resolved for its label
+	is.Equal(fields[2].Values[0].Text, "Rembrandt van Rijn")
+	is.Equal(fields[2].Values[0].URI, "http://data.example.org/resource/rembrandt")
+	is.True(fields[2].Values[0].Inline == nil)
+
+	// inline detail block with the block for the type of the linked resource
+	place := fields[3].Values[0]
+	is.Equal(place.Text, "Amsterdam")
+	is.True(!place.Modal)
+	is.Equal(len(place.Inline.Fields), 1)
+	is.Equal(place.Inline.Fields[0].Label, "Coordinates")
+	is.Equal(place.Inline.Fields[0].Values[0].Text, "52.37")
+
+	// modal without a block shows all predicates
+	provenance := fields[4].Values[0]
+	is.True(provenance.Modal)
+	is.Equal(provenance.Text, "Rijksmuseum")
+	is.Equal(len(provenance.Inline.Fields), 2)
+	is.Equal(provenance.Inline.Fields[0].Label, "dcterms:spatial")
+
+	// digital objects
+	image := v.Blocks[1].Resources[0].Fields[0].Values[0]
+	is.True(image.Image)
+	is.Equal(image.URI, "http://images.example.org/456.jpg")
+
+	var sb strings.Builder
+	is.NoErr(svc.writeHTML(ctx, &sb, g, subject))
+
+	for _, want := range []string{
+		`<h1>Het Joodse bruidje</h1>`,
+		`<section class="block" style="color: red">`,
+		`<a href="http://data.example.org/resource/rembrandt">Rembrandt van Rijn</a>`,
+		`<details><summary>Rijksmuseum</summary>`,
+		`<img src="http://images.example.org/456.jpg"`,
+	} {
+		if !strings.Contains(sb.String(), want) {
+			t.Errorf("html does not contain %q; got:\n%s", want, sb.String())
+		}
+	}
+}
+
+func TestService_newHTMLView_fallback(t *testing.T) {
+	is := is.New(t)
+
+	svc := newViewService(t)
+	ctx := context.Background()
+
+	subject := "http://data.example.org/resource/rijksmuseum"
+	g, err := svc.store.Resolve(ctx, subject)
+	is.NoErr(err)
+
+	v := svc.newHTMLView(ctx, g, subject)
+	is.Equal(len(v.Blocks), 0)
+	is.Equal(len(v.Triples), 2)
+	is.Equal(v.Triples[0].PredLabel, "dcterms:spatial")
+	is.Equal(v.Triples[1].PredLabel, "skos:prefLabel")
+}
+
+func TestLoadViewConfigs(t *testing.T) {
+	is := is.New(t)
+
+	dir, err := ioutil.TempDir("", "viewconfig-*")
+	is.NoErr(err)
+
+	defer os.RemoveAll(dir)
+
+	is.NoErr(ioutil.WriteFile(filepath.Join(dir, "paintings.json"), []byte(viewConfig), 0600))
+
+	configs, err := LoadViewConfigs(dir)
+	is.NoErr(err)
+	is.Equal(len(configs), 1)
+	is.Equal(configs[0].GetViewConfig().GetEntryType(), "edm:ProvidedCHO")
+	is.Equal(configs[0].GetViewConfig().GetBlocks()[1].GetFields()[2].GetInlineType(), fragments.InlineType_LABEL)
+
+	is.NoErr(ioutil.WriteFile(filepath.Join(dir, "invalid.json"), []byte(`{"viewConfig": []}`), 0600))
+
+	_, err = LoadViewConfigs(dir)
+	is.True(err != nil)
+}
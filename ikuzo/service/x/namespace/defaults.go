@@ -20,7 +20,7 @@ var customNS = map[string]string{
 	"abm":       "http://purl.org/abm/sen",
 	"custom":    "http://www.delving.eu/namespaces/custom/",
 	"delving":   "http://schemas.delving.eu/",
-	"ead-rdf":   "http://archief.nl/def/ead/",
+	"ead-rdf":   "https://archief.nl/def/ead/",
 	"europeana": "http://www.europeana.eu/schemas/ese/",
 	"icn":       "http://www.icn.nl/schemas/icn/",
 	"naa":       "https://archief.nl/def/",
@@ -0,0 +1,164 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/delving/hub3/ikuzo/domain"
+)
+
+// Collector counts the base-URIs of the URIs seen during ingestion.
+// It is safe for concurrent use.
+type Collector struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+// NewCollector creates a Collector.
+func NewCollector() *Collector {
+	return &Collector{counts: map[string]int{}}
+}
+
+// Add counts the base-URI of the URI. URIs that cannot be split are ignored.
+func (c *Collector) Add(uri string) {
+	if !strings.HasPrefix(uri, "http://") && !strings.HasPrefix(uri, "https://") {
+		return
+	}
+
+	base, name := domain.SplitURI(uri)
+	if base == "" || name == "" {
+		return
+	}
+
+	c.mu.Lock()
+	c.counts[base]++
+	c.mu.Unlock()
+}
+
+// Counts returns the number of URIs seen per base-URI.
+func (c *Collector) Counts() map[string]int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	counts := make(map[string]int, len(c.counts))
+	for base, n := range c.counts {
+		counts[base] = n
+	}
+
+	return counts
+}
+
+// Discover registers the unknown base-URIs as Temporary namespaces and adds
+// the counts to the Usage of the Temporary namespaces. Known namespaces are not
+// changed. The newly discovered namespaces are returned.
+func (s *Service) Discover(counts map[string]int) ([]*domain.NameSpace, error) {
+	s.checkStore()
+
+	s.discoverMu.Lock()
+	defer s.discoverMu.Unlock()
+
+	discovered := []*domain.NameSpace{}
+
+	for base, n := range counts {
+		ns, err := s.store.GetWithBase(base)
+
+		switch {
+		case errors.Is(err, domain.ErrNameSpaceNotFound):
+			ns, err = s.Add("", base)
+			if err != nil {
+				return discovered, err
+			}
+
+			discovered = append(discovered, ns)
+		case err != nil:
+			return discovered, err
+		case !ns.Temporary:
+			continue
+		}
+
+		ns.Usage += n
+
+		if err := s.store.Set(ns); err != nil {
+			return discovered, err
+		}
+	}
+
+	return discovered, nil
+}
+
+// Review returns the Temporary namespaces that are waiting for a prefix,
+// the most used first.
+func (s *Service) Review() ([]*domain.NameSpace, error) {
+	namespaces, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+
+	queue := []*domain.NameSpace{}
+
+	for _, ns := range namespaces {
+		if ns.Temporary {
+			queue = append(queue, ns)
+		}
+	}
+
+	sort.SliceStable(queue, func(i, j int) bool {
+		if queue[i].Usage != queue[j].Usage {
+			return queue[i].Usage > queue[j].Usage
+		}
+
+		return queue[i].Base < queue[j].Base
+	})
+
+	return queue, nil
+}
+
+// Promote gives the Temporary NameSpace with the given UUID a real prefix.
+// When the prefix is already used by another NameSpace, an
+// ErrNameSpaceDuplicateEntry error is returned.
+func (s *Service) Promote(id, prefix string) (*domain.NameSpace, error) {
+	if prefix == "" {
+		return nil, domain.ErrNameSpaceNotValid
+	}
+
+	ns, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ns.Temporary {
+		return nil, fmt.Errorf("namespace %s is not temporary; %w", ns.Prefix, domain.ErrNameSpaceNotValid)
+	}
+
+	other, err := s.store.GetWithPrefix(prefix)
+
+	switch {
+	case err == nil && other.GetID() != ns.GetID():
+		return nil, fmt.Errorf("prefix %s is linked to %s; %w", prefix, other.Base, domain.ErrNameSpaceDuplicateEntry)
+	case err != nil && !errors.Is(err, domain.ErrNameSpaceNotFound):
+		return nil, err
+	}
+
+	if err := s.promote(ns, prefix); err != nil {
+		return nil, err
+	}
+
+	return ns, nil
+}
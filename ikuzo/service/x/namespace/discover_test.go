@@ -0,0 +1,89 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package namespace
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/delving/hub3/ikuzo/domain"
+	"github.com/matryer/is"
+)
+
+// nolint:gocritic
+func TestService_Discover(t *testing.T) {
+	is := is.New(t)
+
+	svc, err := NewService()
+	is.NoErr(err)
+
+	_, err = svc.Add("dc", "http://purl.org/dc/elements/1.1/")
+	is.NoErr(err)
+
+	c := NewCollector()
+	for _, uri := range []string{
+		"http://purl.org/dc/elements/1.1/title",
+		"http://example.org/ontology#label",
+		"http://example.org/ontology#type",
+		"http://example.org/vocab/term",
+		"urn:isbn:123",
+		"http://example.org/vocab/",
+	} {
+		c.Add(uri)
+	}
+
+	is.Equal(c.Counts(), map[string]int{
+		"http://purl.org/dc/elements/1.1/": 1,
+		"http://example.org/ontology#":     2,
+		"http://example.org/vocab/":        1,
+	})
+
+	discovered, err := svc.Discover(c.Counts())
+	is.NoErr(err)
+	is.Equal(len(discovered), 2)
+
+	_, err = svc.Discover(map[string]int{"http://example.org/vocab/": 5})
+	is.NoErr(err)
+
+	queue, err := svc.Review()
+	is.NoErr(err)
+	is.Equal(len(queue), 2)
+	is.Equal(queue[0].Base, "http://example.org/vocab/")
+	is.Equal(queue[0].Usage, 6)
+	is.Equal(queue[1].Usage, 2)
+
+	// the temporary prefix can be used until it is promoted
+	label, err := svc.SearchLabel("http://example.org/vocab/term")
+	is.NoErr(err)
+	is.Equal(label, queue[0].Prefix+"_term")
+
+	_, err = svc.Promote(queue[0].UUID, "dc")
+	is.True(errors.Is(err, domain.ErrNameSpaceDuplicateEntry))
+
+	ns, err := svc.Promote(queue[0].UUID, "vocab")
+	is.NoErr(err)
+	is.True(!ns.Temporary)
+	is.Equal(ns.Usage, 0)
+
+	_, err = svc.Promote(ns.UUID, "other")
+	is.True(errors.Is(err, domain.ErrNameSpaceNotValid))
+
+	queue, err = svc.Review()
+	is.NoErr(err)
+	is.Equal(len(queue), 1)
+
+	label, err = svc.SearchLabel("http://example.org/vocab/term")
+	is.NoErr(err)
+	is.Equal(label, "vocab_term")
+}
@@ -0,0 +1,173 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	pb "github.com/delving/hub3/hub3/server/grpc/pb/namespacepb"
+	"github.com/delving/hub3/ikuzo/domain"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// compile time check to see if full interface is implemented
+var _ pb.NamespaceServer = (*grpcServer)(nil)
+
+// grpcServer exposes the Service as the namespacepb.Namespace gRPC service.
+type grpcServer struct {
+	svc *Service
+}
+
+// RegisterGRPC registers the Service as the namespacepb.Namespace service.
+func (s *Service) RegisterGRPC(server *grpc.Server) {
+	pb.RegisterNamespaceServer(server, &grpcServer{svc: s})
+}
+
+// grpcError converts the error to a gRPC status error.
+func grpcError(err error) error {
+	code := codes.Internal
+
+	switch {
+	case errors.Is(err, domain.ErrNameSpaceNotFound):
+		code = codes.NotFound
+	case errors.Is(err, domain.ErrNameSpaceDuplicateEntry):
+		code = codes.AlreadyExists
+	case errors.Is(err, domain.ErrNameSpaceNotValid):
+		code = codes.InvalidArgument
+	}
+
+	return status.Error(code, err.Error())
+}
+
+func toProto(ns *domain.NameSpace) *pb.NameSpace {
+	return &pb.NameSpace{
+		Uuid:      ns.UUID,
+		Prefix:    ns.Prefix,
+		Base:      ns.Base,
+		PrefixAlt: ns.PrefixAlt,
+		BaseAlt:   ns.BaseAlt,
+		Schema:    ns.Schema,
+		Temporary: ns.Temporary,
+		Usage:     int64(ns.Usage),
+	}
+}
+
+func (g *grpcServer) SearchLabel(ctx context.Context, in *pb.SearchLabelRequest) (*pb.SearchLabelResponse, error) {
+	label, err := g.svc.SearchLabel(in.GetUri())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	return &pb.SearchLabelResponse{Uri: in.GetUri(), Label: label}, nil
+}
+
+// SearchLabels responds to each URI in the stream. A URI without a namespace
+// does not end the stream but gets a response with an error.
+func (g *grpcServer) SearchLabels(stream pb.Namespace_SearchLabelsServer) error {
+	for {
+		in, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		resp := &pb.SearchLabelResponse{Uri: in.GetUri()}
+
+		resp.Label, err = g.svc.SearchLabel(in.GetUri())
+		if err != nil {
+			resp.Error = err.Error()
+		}
+
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+func (g *grpcServer) List(ctx context.Context, in *pb.ListRequest) (*pb.ListResponse, error) {
+	namespaces, err := g.svc.List()
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	resp := &pb.ListResponse{Namespaces: make([]*pb.NameSpace, 0, len(namespaces))}
+
+	for _, ns := range namespaces {
+		resp.Namespaces = append(resp.Namespaces, toProto(ns))
+	}
+
+	return resp, nil
+}
+
+func (g *grpcServer) GetWithPrefix(ctx context.Context, in *pb.GetWithPrefixRequest) (*pb.NameSpace, error) {
+	ns, err := g.svc.GetWithPrefix(in.GetPrefix())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	return toProto(ns), nil
+}
+
+func (g *grpcServer) GetWithBase(ctx context.Context, in *pb.GetWithBaseRequest) (*pb.NameSpace, error) {
+	ns, err := g.svc.GetWithBase(in.GetBase())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	return toProto(ns), nil
+}
+
+func (g *grpcServer) Add(ctx context.Context, in *pb.AddRequest) (*pb.NameSpace, error) {
+	ns, err := g.svc.Add(in.GetPrefix(), in.GetBase())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	return toProto(ns), nil
+}
+
+// Delete removes the NameSpace with the UUID or, when the UUID is empty, with the prefix.
+func (g *grpcServer) Delete(ctx context.Context, in *pb.DeleteRequest) (*pb.DeleteResponse, error) {
+	var (
+		ns  *domain.NameSpace
+		err error
+	)
+
+	switch {
+	case in.GetUuid() != "":
+		ns, err = g.svc.Get(in.GetUuid())
+	case in.GetPrefix() != "":
+		ns, err = g.svc.GetWithPrefix(in.GetPrefix())
+	default:
+		err = domain.ErrNameSpaceNotValid
+	}
+
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	if err := g.svc.Delete(ns); err != nil {
+		return nil, grpcError(err)
+	}
+
+	return &pb.DeleteResponse{}, nil
+}
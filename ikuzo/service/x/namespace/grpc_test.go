@@ -0,0 +1,156 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	pb "github.com/delving/hub3/hub3/server/grpc/pb/namespacepb"
+	"github.com/matryer/is"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newTestClient(t *testing.T, svc *Service) pb.NamespaceClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+
+	server := grpc.NewServer()
+	svc.RegisterGRPC(server)
+
+	go func() {
+		_ = server.Serve(lis)
+	}()
+
+	conn, err := grpc.Dial(
+		"bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithInsecure(),
+	)
+	if err != nil {
+		t.Fatalf("unable to dial bufnet; %s", err)
+	}
+
+	t.Cleanup(func() {
+		conn.Close()
+		server.Stop()
+	})
+
+	return pb.NewNamespaceClient(conn)
+}
+
+// nolint:gocritic
+func TestService_RegisterGRPC(t *testing.T) {
+	is := is.New(t)
+
+	svc, err := NewService()
+	is.NoErr(err)
+
+	client := newTestClient(t, svc)
+	ctx := context.Background()
+
+	dc, err := client.Add(ctx, &pb.AddRequest{Prefix: "dc", Base: "http://purl.org/dc/elements/1.1/"})
+	is.NoErr(err)
+	is.Equal(dc.GetPrefix(), "dc")
+	is.True(dc.GetUuid() != "")
+
+	_, err = client.Add(ctx, &pb.AddRequest{Prefix: "dce", Base: "http://purl.org/dc/elements/1.1/"})
+	is.NoErr(err)
+
+	ns, err := client.GetWithPrefix(ctx, &pb.GetWithPrefixRequest{Prefix: "dce"})
+	is.NoErr(err)
+	is.Equal(ns.GetUuid(), dc.GetUuid())
+	is.Equal(ns.GetPrefixAlt(), []string{"dce"})
+
+	ns, err = client.GetWithBase(ctx, &pb.GetWithBaseRequest{Base: "http://purl.org/dc/elements/1.1/"})
+	is.NoErr(err)
+	is.Equal(ns.GetPrefix(), "dc")
+
+	_, err = client.GetWithBase(ctx, &pb.GetWithBaseRequest{Base: "http://example.org/unknown/"})
+	is.Equal(status.Code(err), codes.NotFound)
+
+	_, err = client.Add(ctx, &pb.AddRequest{Prefix: "skos"})
+	is.Equal(status.Code(err), codes.InvalidArgument)
+
+	_, err = client.Add(ctx, &pb.AddRequest{Prefix: "skos", Base: "http://www.w3.org/2004/02/skos/core#"})
+	is.NoErr(err)
+
+	list, err := client.List(ctx, &pb.ListRequest{})
+	is.NoErr(err)
+	is.Equal(len(list.GetNamespaces()), 2)
+	is.Equal(list.GetNamespaces()[0].GetPrefix(), "dc")
+
+	label, err := client.SearchLabel(ctx, &pb.SearchLabelRequest{Uri: "http://purl.org/dc/elements/1.1/title"})
+	is.NoErr(err)
+	is.Equal(label.GetLabel(), "dc_title")
+
+	stream, err := client.SearchLabels(ctx)
+	is.NoErr(err)
+
+	uris := []string{
+		"http://purl.org/dc/elements/1.1/title",
+		"http://example.org/unknown/label",
+		"http://www.w3.org/2004/02/skos/core#prefLabel",
+	}
+
+	for _, uri := range uris {
+		is.NoErr(stream.Send(&pb.SearchLabelRequest{Uri: uri}))
+	}
+
+	is.NoErr(stream.CloseSend())
+
+	responses := []*pb.SearchLabelResponse{}
+
+	for {
+		resp, recvErr := stream.Recv()
+		if recvErr == io.EOF {
+			break
+		}
+
+		is.NoErr(recvErr)
+
+		responses = append(responses, resp)
+	}
+
+	is.Equal(len(responses), 3)
+	is.Equal(responses[0].GetLabel(), "dc_title")
+	is.Equal(responses[1].GetUri(), "http://example.org/unknown/label")
+	is.True(responses[1].GetError() != "")
+	is.Equal(responses[2].GetLabel(), "skos_prefLabel")
+
+	_, err = client.Delete(ctx, &pb.DeleteRequest{Prefix: "skos"})
+	is.NoErr(err)
+
+	_, err = client.Delete(ctx, &pb.DeleteRequest{Uuid: dc.GetUuid()})
+	is.NoErr(err)
+
+	_, err = client.Delete(ctx, &pb.DeleteRequest{Uuid: dc.GetUuid()})
+	is.Equal(status.Code(err), codes.NotFound)
+
+	_, err = client.Delete(ctx, &pb.DeleteRequest{})
+	is.Equal(status.Code(err), codes.InvalidArgument)
+
+	list, err = client.List(ctx, &pb.ListRequest{})
+	is.NoErr(err)
+	is.Equal(len(list.GetNamespaces()), 0)
+}
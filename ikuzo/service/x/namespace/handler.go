@@ -0,0 +1,247 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/delving/hub3/ikuzo/domain"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+)
+
+// Routes returns the management API for namespaces.
+func (s *Service) Routes() chi.Router {
+	router := chi.NewRouter()
+
+	router.Get("/", s.handleList)
+	router.Post("/", s.handleCreate)
+	router.Get("/review", s.handleReview)
+	router.Post("/import", s.handleImport)
+	router.Get("/{id}", s.handleGet)
+	router.Put("/{id}", s.handlePut)
+	router.Delete("/{id}", s.handleDelete)
+	router.Post("/{id}/merge", s.handleMerge)
+	router.Post("/{id}/promote", s.handlePromote)
+
+	return router
+}
+
+// httpError writes the error with the status code that matches the error.
+func httpError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+
+	switch {
+	case errors.Is(err, domain.ErrNameSpaceNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, domain.ErrNameSpaceDuplicateEntry):
+		status = http.StatusConflict
+	case errors.Is(err, domain.ErrNameSpaceNotValid),
+		errors.Is(err, ErrUnsupportedFormat):
+		status = http.StatusBadRequest
+	}
+
+	http.Error(w, err.Error(), status)
+}
+
+func decodeNameSpace(w http.ResponseWriter, r *http.Request) (*domain.NameSpace, bool) {
+	var ns domain.NameSpace
+
+	if err := json.NewDecoder(r.Body).Decode(&ns); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+
+	return &ns, true
+}
+
+// handleList returns all namespaces. The 'prefix' and 'base' query parameters
+// return the namespace that has the prefix or base-URI as default or alternative.
+func (s *Service) handleList(w http.ResponseWriter, r *http.Request) {
+	var (
+		ns  *domain.NameSpace
+		err error
+	)
+
+	switch {
+	case r.URL.Query().Get("prefix") != "":
+		ns, err = s.GetWithPrefix(r.URL.Query().Get("prefix"))
+	case r.URL.Query().Get("base") != "":
+		ns, err = s.GetWithBase(r.URL.Query().Get("base"))
+	default:
+		namespaces, listErr := s.List()
+		if listErr != nil {
+			httpError(w, listErr)
+			return
+		}
+
+		render.JSON(w, r, namespaces)
+
+		return
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrNameSpaceNotFound):
+		render.JSON(w, r, []*domain.NameSpace{})
+	case err != nil:
+		httpError(w, err)
+	default:
+		render.JSON(w, r, []*domain.NameSpace{ns})
+	}
+}
+
+func (s *Service) handleGet(w http.ResponseWriter, r *http.Request) {
+	ns, err := s.Get(chi.URLParam(r, "id"))
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+
+	render.JSON(w, r, ns)
+}
+
+// handleCreate adds the prefix and base-URI of the body with the same rules as
+// Service.Add. The alternatives and schema of the body are merged into the result.
+func (s *Service) handleCreate(w http.ResponseWriter, r *http.Request) {
+	body, ok := decodeNameSpace(w, r)
+	if !ok {
+		return
+	}
+
+	ns, err := s.Add(body.Prefix, body.Base)
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+
+	if len(body.PrefixAlt) != 0 || len(body.BaseAlt) != 0 || body.Schema != "" {
+		body.UUID = ""
+		body.Prefix = ns.Prefix
+		body.Base = ns.Base
+		body.Temporary = false
+
+		ns, err = s.Merge(ns.UUID, body)
+		if err != nil {
+			httpError(w, err)
+			return
+		}
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, ns)
+}
+
+// handlePut replaces a namespace. The identifier in the path takes precedence
+// over the identifier in the body.
+func (s *Service) handlePut(w http.ResponseWriter, r *http.Request) {
+	ns, ok := decodeNameSpace(w, r)
+	if !ok {
+		return
+	}
+
+	ns.UUID = chi.URLParam(r, "id")
+
+	if err := s.Put(ns); err != nil {
+		httpError(w, err)
+		return
+	}
+
+	render.JSON(w, r, ns)
+}
+
+func (s *Service) handleDelete(w http.ResponseWriter, r *http.Request) {
+	ns, err := s.Get(chi.URLParam(r, "id"))
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+
+	if err := s.Delete(ns); err != nil {
+		httpError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleMerge merges the namespace in the body into the namespace in the path.
+// When the body has the 'uuid' of a stored namespace, that namespace is merged
+// and removed.
+func (s *Service) handleMerge(w http.ResponseWriter, r *http.Request) {
+	other, ok := decodeNameSpace(w, r)
+	if !ok {
+		return
+	}
+
+	ns, err := s.Merge(chi.URLParam(r, "id"), other)
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+
+	render.JSON(w, r, ns)
+}
+
+// handleReview returns the temporary namespaces that were discovered during
+// ingestion, the most used first.
+func (s *Service) handleReview(w http.ResponseWriter, r *http.Request) {
+	queue, err := s.Review()
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+
+	render.JSON(w, r, queue)
+}
+
+// handlePromote gives a temporary namespace the prefix in the body.
+func (s *Service) handlePromote(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Prefix string `json:"prefix"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ns, err := s.Promote(chi.URLParam(r, "id"), req.Prefix)
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+
+	render.JSON(w, r, ns)
+}
+
+// handleImport imports the prefix mappings in the body. The format is set
+// with the 'format' query parameter or derived from the Content-Type.
+// Conflicts are reported in the ImportReport.
+func (s *Service) handleImport(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = FormatFromContentType(r.Header.Get("Content-Type"))
+	}
+
+	report, err := s.Import(r.Body, format)
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+
+	render.JSON(w, r, report)
+}
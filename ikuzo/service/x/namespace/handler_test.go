@@ -0,0 +1,105 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/delving/hub3/ikuzo/domain"
+	"github.com/matryer/is"
+)
+
+// nolint:gocritic
+func TestService_Routes(t *testing.T) {
+	is := is.New(t)
+
+	svc, err := NewService()
+	is.NoErr(err)
+
+	router := svc.Routes()
+
+	do := func(method, target, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, target, strings.NewReader(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+
+		return w
+	}
+
+	w := do(http.MethodPost, "/", `{"prefix": "dc", "base": "http://purl.org/dc/elements/1.1/", "prefixAlt": ["dce"]}`)
+	is.Equal(w.Code, http.StatusCreated)
+
+	var dc domain.NameSpace
+	is.NoErr(json.Unmarshal(w.Body.Bytes(), &dc))
+	is.Equal(dc.Prefix, "dc")
+	is.Equal(dc.PrefixAlt, []string{"dce"})
+
+	w = do(http.MethodPost, "/", `{"prefix": "dc"}`)
+	is.Equal(w.Code, http.StatusBadRequest)
+
+	w = do(http.MethodPost, "/", `{"base": "http://www.w3.org/2004/02/skos/core#"}`)
+	is.Equal(w.Code, http.StatusCreated)
+
+	var skos domain.NameSpace
+	is.NoErr(json.Unmarshal(w.Body.Bytes(), &skos))
+	is.True(skos.Temporary)
+
+	// search by alternative prefix
+	w = do(http.MethodGet, "/?prefix=dce", "")
+	is.Equal(w.Code, http.StatusOK)
+
+	var found []domain.NameSpace
+	is.NoErr(json.Unmarshal(w.Body.Bytes(), &found))
+	is.Equal(len(found), 1)
+	is.Equal(found[0].UUID, dc.UUID)
+
+	w = do(http.MethodGet, "/?base=http://unknown.org/", "")
+	is.Equal(w.Code, http.StatusOK)
+	is.Equal(strings.TrimSpace(w.Body.String()), "[]")
+
+	w = do(http.MethodGet, "/", "")
+	is.NoErr(json.Unmarshal(w.Body.Bytes(), &found))
+	is.Equal(len(found), 2)
+
+	w = do(http.MethodPost, "/"+dc.UUID+"/merge", `{"uuid": "`+skos.UUID+`"}`)
+	is.Equal(w.Code, http.StatusOK)
+
+	w = do(http.MethodGet, "/?base=http://www.w3.org/2004/02/skos/core%23", "")
+	is.NoErr(json.Unmarshal(w.Body.Bytes(), &found))
+	is.Equal(len(found), 1)
+	is.Equal(found[0].UUID, dc.UUID)
+	is.Equal(found[0].PrefixAlt, []string{"dce"}) // temporary prefix is not merged
+
+	w = do(http.MethodPut, "/"+dc.UUID, `{"prefix": "dc", "base": "http://purl.org/dc/elements/1.1/", "schema": "http://purl.org/dc/elements/1.1/dcelements.rdf"}`)
+	is.Equal(w.Code, http.StatusOK)
+
+	w = do(http.MethodGet, "/"+dc.UUID, "")
+	is.Equal(w.Code, http.StatusOK)
+	is.NoErr(json.Unmarshal(w.Body.Bytes(), &dc))
+	is.Equal(dc.Schema, "http://purl.org/dc/elements/1.1/dcelements.rdf")
+
+	w = do(http.MethodDelete, "/"+dc.UUID, "")
+	is.Equal(w.Code, http.StatusNoContent)
+
+	w = do(http.MethodGet, "/"+dc.UUID, "")
+	is.Equal(w.Code, http.StatusNotFound)
+
+	w = do(http.MethodPut, "/"+dc.UUID, `{"prefix": "dc", "base": "http://purl.org/dc/elements/1.1/"}`)
+	is.Equal(w.Code, http.StatusNotFound)
+}
@@ -0,0 +1,285 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/delving/hub3/ikuzo/domain"
+)
+
+// Supported import formats.
+const (
+	FormatTurtle = "turtle"
+	FormatJSONLD = "jsonld"
+	FormatJSON   = "json"
+	FormatCSV    = "csv"
+)
+
+// ErrUnsupportedFormat is returned when the import format is unknown.
+var ErrUnsupportedFormat = errors.New("unsupported namespace import format")
+
+// Mapping is a prefix and base-URI pair from an import.
+type Mapping struct {
+	Prefix string `json:"prefix"`
+	Base   string `json:"base"`
+}
+
+// ImportConflict is a Mapping that could not be imported.
+type ImportConflict struct {
+	Mapping
+	// Existing is the base-URI the prefix is already linked to
+	Existing string `json:"existing,omitempty"`
+	Error    string `json:"error"`
+}
+
+// ImportReport summarizes the result of an import.
+type ImportReport struct {
+	Added     int              `json:"added"`
+	Promoted  int              `json:"promoted"`
+	Updated   int              `json:"updated"`
+	Unchanged int              `json:"unchanged"`
+	Conflicts []ImportConflict `json:"conflicts"`
+}
+
+// FormatFromContentType returns the import format for a MIME-type.
+func FormatFromContentType(contentType string) string {
+	mime := strings.TrimSpace(strings.Split(contentType, ";")[0])
+
+	switch mime {
+	case "text/turtle", "application/x-turtle":
+		return FormatTurtle
+	case "application/ld+json":
+		return FormatJSONLD
+	case "application/json":
+		return FormatJSON
+	case "text/csv":
+		return FormatCSV
+	}
+
+	return ""
+}
+
+// ParseMappings reads the prefix mappings from the input.
+//
+// The turtle format reads the '@prefix' and SPARQL 'PREFIX' declarations.
+// The json format reads a prefix.cc JSON dump where each prefix is a key with
+// the base-URI as value. The jsonld format reads the term definitions of the
+// '@context' that are prefixes. The csv format reads 'prefix,base' rows as in
+// the prefix.cc CSV dump.
+func ParseMappings(r io.Reader, format string) ([]Mapping, error) {
+	switch format {
+	case FormatTurtle, "ttl":
+		return parseTurtlePrefixes(r)
+	case FormatJSON, FormatJSONLD:
+		return parseJSONContext(r)
+	case FormatCSV:
+		return parseCSV(r)
+	}
+
+	return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+}
+
+var turtlePrefix = regexp.MustCompile(`(?i)^\s*@?prefix\s+([^\s:]*):\s*<([^>]*)>`)
+
+func parseTurtlePrefixes(r io.Reader) ([]Mapping, error) {
+	mappings := []Mapping{}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		match := turtlePrefix.FindStringSubmatch(scanner.Text())
+		if match == nil || match[1] == "" {
+			continue
+		}
+
+		mappings = append(mappings, Mapping{Prefix: match[1], Base: match[2]})
+	}
+
+	return mappings, scanner.Err()
+}
+
+func parseJSONContext(r io.Reader) ([]Mapping, error) {
+	var doc map[string]interface{}
+
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("unable to parse json; %w", err)
+	}
+
+	contexts := []interface{}{doc}
+	if ctx, ok := doc["@context"]; ok {
+		contexts = []interface{}{ctx}
+		if list, ok := ctx.([]interface{}); ok {
+			contexts = list
+		}
+	}
+
+	mappings := []Mapping{}
+
+	for _, ctx := range contexts {
+		// remote contexts are not resolved
+		terms, ok := ctx.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		for term, def := range terms {
+			if strings.HasPrefix(term, "@") || strings.Contains(term, ":") {
+				continue
+			}
+
+			var base string
+
+			switch v := def.(type) {
+			case string:
+				base = v
+			case map[string]interface{}:
+				id, _ := v["@id"].(string)
+				if isPrefix, ok := v["@prefix"].(bool); ok && !isPrefix {
+					continue
+				}
+
+				base = id
+			}
+
+			// only IRIs ending with a gen-delim are prefixes
+			if !strings.HasSuffix(base, "/") && !strings.HasSuffix(base, "#") {
+				continue
+			}
+
+			mappings = append(mappings, Mapping{Prefix: term, Base: base})
+		}
+	}
+
+	sort.Slice(mappings, func(i, j int) bool {
+		return mappings[i].Prefix < mappings[j].Prefix
+	})
+
+	return mappings, nil
+}
+
+func parseCSV(r io.Reader) ([]Mapping, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	mappings := []Mapping{}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse csv; %w", err)
+		}
+
+		if len(record) < 2 || record[0] == "" || strings.EqualFold(record[0], "prefix") {
+			continue
+		}
+
+		mappings = append(mappings, Mapping{Prefix: record[0], Base: record[1]})
+	}
+
+	return mappings, nil
+}
+
+// Import registers the prefix mappings from the input. See ParseMappings for
+// the supported formats.
+//
+// Base-URIs of Temporary namespaces are promoted and known base-URIs get the
+// prefix as alternative. When the prefix is already linked to another
+// base-URI the mapping is reported as a conflict with an
+// ErrNameSpaceDuplicateEntry error and the stored namespaces are not changed.
+func (s *Service) Import(r io.Reader, format string) (*ImportReport, error) {
+	mappings, err := ParseMappings(r, format)
+	if err != nil {
+		return nil, err
+	}
+
+	s.checkStore()
+
+	report := &ImportReport{Conflicts: []ImportConflict{}}
+
+	for _, m := range mappings {
+		if err := s.importMapping(m, report); err != nil {
+			return report, err
+		}
+	}
+
+	return report, nil
+}
+
+func (s *Service) importMapping(m Mapping, report *ImportReport) error {
+	if m.Prefix == "" || m.Base == "" {
+		report.Conflicts = append(report.Conflicts, newImportConflict(m, "", domain.ErrNameSpaceNotValid))
+		return nil
+	}
+
+	byPrefix, err := s.store.GetWithPrefix(m.Prefix)
+	if err != nil && !errors.Is(err, domain.ErrNameSpaceNotFound) {
+		return err
+	}
+
+	byBase, err := s.store.GetWithBase(m.Base)
+	if err != nil && !errors.Is(err, domain.ErrNameSpaceNotFound) {
+		return err
+	}
+
+	switch {
+	case byPrefix != nil && byBase != nil && byPrefix.GetID() == byBase.GetID():
+		report.Unchanged++
+	case byPrefix != nil:
+		report.Conflicts = append(report.Conflicts, newImportConflict(m, byPrefix.Base, domain.ErrNameSpaceDuplicateEntry))
+	case byBase != nil && byBase.Temporary:
+		if err := s.promote(byBase, m.Prefix); err != nil {
+			return err
+		}
+
+		report.Promoted++
+	case byBase != nil:
+		if _, err := s.Add(m.Prefix, m.Base); err != nil {
+			return err
+		}
+
+		report.Updated++
+	default:
+		if _, err := s.Add(m.Prefix, m.Base); err != nil {
+			return err
+		}
+
+		report.Added++
+	}
+
+	return nil
+}
+
+func newImportConflict(m Mapping, existing string, err error) ImportConflict {
+	return ImportConflict{
+		Mapping:  m,
+		Existing: existing,
+		Error:    err.Error(),
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package namespace
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/delving/hub3/ikuzo/domain"
+	"github.com/matryer/is"
+)
+
+func TestParseMappings(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		input   string
+		want    []Mapping
+		wantErr bool
+	}{
+		{
+			"turtle",
+			FormatTurtle,
+			`@prefix dc: <http://purl.org/dc/elements/1.1/> .
+PREFIX skos: <http://www.w3.org/2004/02/skos/core#>
+@prefix : <http://example.org/default/> .
+<http://example.org/1> dc:title "prefix in literal: <http://no.org/>" .`,
+			[]Mapping{
+				{Prefix: "dc", Base: "http://purl.org/dc/elements/1.1/"},
+				{Prefix: "skos", Base: "http://www.w3.org/2004/02/skos/core#"},
+			},
+			false,
+		},
+		{
+			"json-ld context",
+			FormatJSONLD,
+			`{"@context": [
+				"http://remote.org/context.jsonld",
+				{
+					"@vocab": "http://schema.org/",
+					"dc": "http://purl.org/dc/elements/1.1/",
+					"edm": {"@id": "http://www.europeana.eu/schemas/edm/", "@prefix": true},
+					"title": "http://purl.org/dc/elements/1.1/title",
+					"noprefix": {"@id": "http://example.org/", "@prefix": false},
+					"dc:creator": {"@type": "@id"}
+				}
+			]}`,
+			[]Mapping{
+				{Prefix: "dc", Base: "http://purl.org/dc/elements/1.1/"},
+				{Prefix: "edm", Base: "http://www.europeana.eu/schemas/edm/"},
+			},
+			false,
+		},
+		{
+			"prefix.cc json",
+			FormatJSON,
+			`{"foaf": "http://xmlns.com/foaf/0.1/", "owl": "http://www.w3.org/2002/07/owl#"}`,
+			[]Mapping{
+				{Prefix: "foaf", Base: "http://xmlns.com/foaf/0.1/"},
+				{Prefix: "owl", Base: "http://www.w3.org/2002/07/owl#"},
+			},
+			false,
+		},
+		{
+			"prefix.cc csv",
+			FormatCSV,
+			"prefix,uri\nfoaf,http://xmlns.com/foaf/0.1/\nowl,http://www.w3.org/2002/07/owl#\n",
+			[]Mapping{
+				{Prefix: "foaf", Base: "http://xmlns.com/foaf/0.1/"},
+				{Prefix: "owl", Base: "http://www.w3.org/2002/07/owl#"},
+			},
+			false,
+		},
+		{"invalid json", FormatJSON, `{"foaf": `, nil, true},
+		{"unsupported format", "xml", "", nil, true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			got, err := ParseMappings(strings.NewReader(tt.input), tt.format)
+			if tt.wantErr {
+				is.True(err != nil)
+				return
+			}
+
+			is.NoErr(err)
+			is.Equal(got, tt.want)
+		})
+	}
+}
+
+// nolint:gocritic
+func TestService_Import(t *testing.T) {
+	is := is.New(t)
+
+	svc, err := NewService()
+	is.NoErr(err)
+
+	_, err = svc.Add("dc", "http://purl.org/dc/elements/1.1/")
+	is.NoErr(err)
+
+	tmp, err := svc.Add("", "http://xmlns.com/foaf/0.1/")
+	is.NoErr(err)
+
+	_, err = svc.Import(strings.NewReader(""), "xml")
+	is.True(errors.Is(err, ErrUnsupportedFormat))
+
+	input := `foaf,http://xmlns.com/foaf/0.1/
+dc,http://purl.org/dc/elements/1.1/
+dce,http://purl.org/dc/elements/1.1/
+dc,http://purl.org/dc/terms/
+owl,http://www.w3.org/2002/07/owl#
+empty,
+`
+
+	report, err := svc.Import(strings.NewReader(input), FormatCSV)
+	is.NoErr(err)
+	is.Equal(report.Added, 1)     // owl
+	is.Equal(report.Promoted, 1)  // foaf
+	is.Equal(report.Updated, 1)   // dce
+	is.Equal(report.Unchanged, 1) // dc
+	is.Equal(len(report.Conflicts), 2)
+
+	conflict := report.Conflicts[0]
+	is.Equal(conflict.Prefix, "dc")
+	is.Equal(conflict.Existing, "http://purl.org/dc/elements/1.1/")
+	is.Equal(conflict.Error, domain.ErrNameSpaceDuplicateEntry.Error())
+	is.Equal(report.Conflicts[1].Error, domain.ErrNameSpaceNotValid.Error())
+
+	foaf, err := svc.GetWithBase("http://xmlns.com/foaf/0.1/")
+	is.NoErr(err)
+	is.Equal(foaf.UUID, tmp.UUID)
+	is.Equal(foaf.Prefix, "foaf")
+	is.True(!foaf.Temporary)
+
+	// the conflicting mapping does not change the stored namespace
+	_, err = svc.GetWithBase("http://purl.org/dc/terms/")
+	is.True(errors.Is(err, domain.ErrNameSpaceNotFound))
+}
@@ -16,6 +16,9 @@ package namespace
 
 import (
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
 
 	"github.com/delving/hub3/ikuzo/domain"
 	"github.com/delving/hub3/ikuzo/storage/memory"
@@ -64,6 +67,9 @@ type Service struct {
 	// loadDefaults determines if the defaults are loaded into the store
 	// when it is empty.
 	loadDefaults bool
+
+	// discoverMu guards the usage counts of discovered namespaces
+	discoverMu sync.Mutex
 }
 
 // NewService creates a new client to work with namespaces.
@@ -93,7 +99,7 @@ func NewService(options ...ServiceOptionFunc) (*Service, error) {
 		}
 	}
 
-	if s.loadDefaults {
+	if s.loadDefaults && s.Len() == 0 {
 		for _, nsMap := range []map[string]string{defaultNS, customNS} {
 			for prefix, base := range nsMap {
 				if _, err := s.Add(prefix, base); err != nil {
@@ -192,6 +198,10 @@ func (s *Service) Add(prefix, base string) (*domain.NameSpace, error) {
 	}
 
 	if ns != nil {
+		if ns.Temporary {
+			return ns, s.promote(ns, prefix)
+		}
+
 		err = ns.AddPrefix(prefix)
 		if err != nil {
 			return nil, err
@@ -218,21 +228,159 @@ func (s *Service) Add(prefix, base string) (*domain.NameSpace, error) {
 	return ns, nil
 }
 
+// promote replaces the temporary prefix of the NameSpace with the registered prefix.
+// The temporary prefix is removed from the store.
+func (s *Service) promote(ns *domain.NameSpace, prefix string) error {
+	if err := s.store.Delete(ns); err != nil {
+		return err
+	}
+
+	alt := []string{}
+
+	for _, p := range ns.PrefixAlt {
+		if p != prefix {
+			alt = append(alt, p)
+		}
+	}
+
+	ns.Prefix = prefix
+	ns.PrefixAlt = alt
+	ns.Temporary = false
+	ns.Usage = 0
+
+	return s.store.Set(ns)
+}
+
 // Delete removes a namespace from the store
 func (s *Service) Delete(ns *domain.NameSpace) error {
+	s.checkStore()
 	return s.store.Delete(ns)
 }
 
+// Get returns the NameSpace with the given UUID.
+// When the NameSpace is not found, an ErrNameSpaceNotFound error is returned.
+func (s *Service) Get(id string) (*domain.NameSpace, error) {
+	namespaces, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ns := range namespaces {
+		if ns.UUID == id {
+			return ns, nil
+		}
+	}
+
+	return nil, domain.ErrNameSpaceNotFound
+}
+
+// GetWithPrefix returns the NameSpace for the default or an alternative prefix.
+func (s *Service) GetWithPrefix(prefix string) (*domain.NameSpace, error) {
+	s.checkStore()
+	return s.store.GetWithPrefix(prefix)
+}
+
+// GetWithBase returns the NameSpace for the default or an alternative base-URI.
+func (s *Service) GetWithBase(base string) (*domain.NameSpace, error) {
+	s.checkStore()
+	return s.store.GetWithBase(base)
+}
+
+// Put replaces the stored NameSpace with the same UUID.
+// When the NameSpace is not found, an ErrNameSpaceNotFound error is returned.
+func (s *Service) Put(ns *domain.NameSpace) error {
+	if ns.Prefix == "" || ns.Base == "" {
+		return domain.ErrNameSpaceNotValid
+	}
+
+	current, err := s.Get(ns.UUID)
+	if err != nil {
+		return err
+	}
+
+	// remove the current prefixes and base-URIs before storing the new ones
+	if err := s.store.Delete(current); err != nil {
+		return err
+	}
+
+	return s.store.Set(ns)
+}
+
+// Merge merges the prefixes and base-URIs of other into the NameSpace with the given UUID.
+// When other is a stored NameSpace it is removed after the merge.
+func (s *Service) Merge(id string, other *domain.NameSpace) (*domain.NameSpace, error) {
+	ns, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if other.UUID == id {
+		return nil, fmt.Errorf("cannot merge namespace with itself; %w", domain.ErrNameSpaceNotValid)
+	}
+
+	if other.UUID != "" {
+		stored, getErr := s.Get(other.UUID)
+		if getErr != nil {
+			return nil, getErr
+		}
+
+		if err = s.store.Delete(stored); err != nil {
+			return nil, err
+		}
+
+		other = stored
+	}
+
+	if err = s.store.Delete(ns); err != nil {
+		return nil, err
+	}
+
+	// temporary prefixes are not merged as alternatives
+	if other.Temporary {
+		other = &domain.NameSpace{
+			Prefix:    ns.Prefix,
+			Base:      other.Base,
+			BaseAlt:   other.BaseAlt,
+			PrefixAlt: other.PrefixAlt,
+		}
+	}
+
+	if err = ns.Merge(other); err != nil {
+		return nil, err
+	}
+
+	if ns.Schema == "" {
+		ns.Schema = other.Schema
+	}
+
+	if err = s.store.Set(ns); err != nil {
+		return nil, err
+	}
+
+	return ns, nil
+}
+
 // Len returns the number of namespaces in the Service
 func (s *Service) Len() int {
 	s.checkStore()
 	return s.store.Len()
 }
 
-// List returns a list of all stored NameSpace objects.
+// List returns a list of all stored NameSpace objects sorted by prefix.
 // An error is returned when the underlying storage can't be accessed.
 func (s *Service) List() ([]*domain.NameSpace, error) {
-	return s.store.List()
+	s.checkStore()
+
+	namespaces, err := s.store.List()
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(namespaces, func(i, j int) bool {
+		return namespaces[i].Prefix < namespaces[j].Prefix
+	})
+
+	return namespaces, nil
 }
 
 // SearchLabel returns the URI in a short namespaced form.
@@ -255,6 +403,42 @@ func (s *Service) SearchLabel(uri string) (string, error) {
 	return fmt.Sprintf("%s_%s", ns.Prefix, label), nil
 }
 
+// Label returns the URI in the prefixed form that is shown to users, e.g. "dc:title".
+func (s *Service) Label(uri string) (string, error) {
+	s.checkStore()
+
+	base, label := domain.SplitURI(uri)
+
+	ns, err := s.store.GetWithBase(base)
+	if err != nil {
+		return "", fmt.Errorf("unable to retrieve namespace for %s; %w", base, err)
+	}
+
+	return fmt.Sprintf("%s:%s", ns.Prefix, label), nil
+}
+
+// Expand returns the URI for a label in the prefixed form, e.g. "dc:title",
+// or in the SearchLabel form, e.g. "dc_title".
+func (s *Service) Expand(label string) (string, error) {
+	s.checkStore()
+
+	i := strings.Index(label, ":")
+	if i == -1 {
+		i = strings.Index(label, "_")
+	}
+
+	if i < 1 {
+		return "", fmt.Errorf("unable to expand %s; %w", label, domain.ErrNameSpaceNotValid)
+	}
+
+	ns, err := s.store.GetWithPrefix(label[:i])
+	if err != nil {
+		return "", fmt.Errorf("unable to retrieve namespace for %s; %w", label[:i], err)
+	}
+
+	return ns.Base + label[i+1:], nil
+}
+
 // Set sets the default prefix and base-URI for a namespace.
 // When the namespace is already present it will be overwritten.
 // When the NameSpace contains an unknown prefix and base-URI pair but one of them
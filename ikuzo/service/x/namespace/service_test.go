@@ -15,6 +15,7 @@
 package namespace
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/matryer/is"
@@ -251,3 +252,124 @@ func TestListDelete(t *testing.T) {
 
 	is.Equal(len(namespaces), 2014)
 }
+
+func TestService_Label(t *testing.T) {
+	is := is.New(t)
+
+	s := &Service{}
+	is.NoErr(s.Set(&domain.NameSpace{Base: "http://purl.org/dc/elements/1.1/", Prefix: "dc"}))
+
+	got, err := s.Label("http://purl.org/dc/elements/1.1/title")
+	is.NoErr(err)
+	is.Equal(got, "dc:title")
+
+	_, err = s.Label("http://purl.org/unknown/elements/1.1/title")
+	is.True(errors.Is(err, domain.ErrNameSpaceNotFound))
+}
+
+func TestService_Expand(t *testing.T) {
+	s := &Service{}
+	if err := s.Set(&domain.NameSpace{Base: "http://purl.org/dc/elements/1.1/", Prefix: "dc"}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		label   string
+		want    string
+		wantErr error
+	}{
+		{"prefixed", "dc:title", "http://purl.org/dc/elements/1.1/title", nil},
+		{"search label", "dc_title", "http://purl.org/dc/elements/1.1/title", nil},
+		{"local name with underscore", "dc:date_created", "http://purl.org/dc/elements/1.1/date_created", nil},
+		{"unknown prefix", "dce:title", "", domain.ErrNameSpaceNotFound},
+		{"no prefix", "title", "", domain.ErrNameSpaceNotValid},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			got, err := s.Expand(tt.label)
+			is.True(errors.Is(err, tt.wantErr))
+			is.Equal(got, tt.want)
+		})
+	}
+}
+
+// nolint:gocritic
+func TestService_promote(t *testing.T) {
+	is := is.New(t)
+
+	svc, err := NewService()
+	is.NoErr(err)
+
+	base := "http://purl.org/dc/elements/1.1/"
+
+	tmp, err := svc.Add("", base)
+	is.NoErr(err)
+	is.True(tmp.Temporary)
+
+	tmpPrefix := tmp.Prefix
+
+	ns, err := svc.Add("dc", base)
+	is.NoErr(err)
+	is.True(!ns.Temporary)
+	is.Equal(ns.Prefix, "dc")
+	is.Equal(ns.UUID, tmp.UUID)
+	is.Equal(svc.Len(), 1)
+
+	// the temporary prefix no longer resolves
+	_, err = svc.GetWithPrefix(tmpPrefix)
+	is.True(errors.Is(err, domain.ErrNameSpaceNotFound))
+
+	label, err := svc.SearchLabel(base + "title")
+	is.NoErr(err)
+	is.Equal(label, "dc_title")
+}
+
+// nolint:gocritic
+func TestService_PutMerge(t *testing.T) {
+	is := is.New(t)
+
+	svc, err := NewService()
+	is.NoErr(err)
+
+	dc, err := svc.Add("dc", "http://purl.org/dc/elements/1.1/")
+	is.NoErr(err)
+
+	dce, err := svc.Add("dce", "http://purl.org/dc/elements/1.1#")
+	is.NoErr(err)
+
+	merged, err := svc.Merge(dc.UUID, &domain.NameSpace{UUID: dce.UUID})
+	is.NoErr(err)
+	is.Equal(merged.PrefixAlt, []string{"dce"})
+	is.Equal(merged.BaseAlt, []string{"http://purl.org/dc/elements/1.1#"})
+	is.Equal(svc.Len(), 1)
+
+	ns, err := svc.GetWithPrefix("dce")
+	is.NoErr(err)
+	is.Equal(ns.UUID, dc.UUID)
+
+	_, err = svc.Merge(dc.UUID, &domain.NameSpace{UUID: dc.UUID})
+	is.True(errors.Is(err, domain.ErrNameSpaceNotValid))
+
+	_, err = svc.Merge("unknown", &domain.NameSpace{})
+	is.True(errors.Is(err, domain.ErrNameSpaceNotFound))
+
+	// replacing drops the old prefixes
+	err = svc.Put(&domain.NameSpace{UUID: dc.UUID, Prefix: "dcterms", Base: "http://purl.org/dc/terms/"})
+	is.NoErr(err)
+
+	_, err = svc.GetWithPrefix("dce")
+	is.True(errors.Is(err, domain.ErrNameSpaceNotFound))
+
+	ns, err = svc.GetWithBase("http://purl.org/dc/terms/")
+	is.NoErr(err)
+	is.Equal(ns.Prefix, "dcterms")
+
+	err = svc.Put(&domain.NameSpace{UUID: "unknown", Prefix: "x", Base: "http://x.org/"})
+	is.True(errors.Is(err, domain.ErrNameSpaceNotFound))
+}
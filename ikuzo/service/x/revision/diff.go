@@ -0,0 +1,327 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package revision
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/utils/merkletrie"
+)
+
+// ErrRevisionNotFound is returned when a revision can not be resolved to a commit.
+var ErrRevisionNotFound = errors.New("revision not found")
+
+// RecordDiff is the difference between the graphs of a record at two
+// revisions. The triples are N-Triples lines.
+type RecordDiff struct {
+	HubID   string   `json:"hubID"`
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+}
+
+// DatasetDiff is the difference between the records of a dataset at two revisions.
+type DatasetDiff struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	// Added and Removed contain the hubIDs of the records
+	Added   []string     `json:"added"`
+	Removed []string     `json:"removed"`
+	Changed []RecordDiff `json:"changed"`
+}
+
+// diffLines returns the lines that are only in to as added and the lines that
+// are only in from as removed. Because the graphs are stored as sorted
+// N-Triples the difference of the lines is the difference of the triples.
+func diffLines(from, to string) (added, removed []string) {
+	lines := func(s string) map[string]bool {
+		set := map[string]bool{}
+
+		for _, line := range strings.Split(s, "\n") {
+			if line != "" {
+				set[line] = true
+			}
+		}
+
+		return set
+	}
+
+	fromLines, toLines := lines(from), lines(to)
+
+	added, removed = []string{}, []string{}
+
+	for line := range toLines {
+		if !fromLines[line] {
+			added = append(added, line)
+		}
+	}
+
+	for line := range fromLines {
+		if !toLines[line] {
+			removed = append(removed, line)
+		}
+	}
+
+	sort.Strings(added)
+	sort.Strings(removed)
+
+	return added, removed
+}
+
+// ResolveRevision returns the hash of the commit of the revision.
+// The revision can be a SHA, a branch, a tag or an expression like 'HEAD~1'.
+// An empty revision or 'head' is resolved to the HEAD commit.
+func (repo *Repository) ResolveRevision(rev string) (plumbing.Hash, error) {
+	if rev == "" || strings.EqualFold(rev, "head") {
+		rev = "HEAD"
+	}
+
+	r, err := repo.gitRepo()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	hash, err := r.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("%w: %s", ErrRevisionNotFound, rev)
+	}
+
+	return *hash, nil
+}
+
+// commit returns the commit of the revision.
+func (repo *Repository) commit(rev string) (*object.Commit, error) {
+	hash, err := repo.ResolveRevision(rev)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := repo.gitRepo()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.CommitObject(hash)
+}
+
+// tree returns the tree of the commit of the revision.
+func (repo *Repository) tree(rev string) (*object.Tree, error) {
+	c, err := repo.commit(rev)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Tree()
+}
+
+// FilesAt returns the paths of the files in the directory dir at the revision.
+// When dir does not exist at the revision no paths are returned.
+func (repo *Repository) FilesAt(dir, rev string) ([]string, error) {
+	tree, err := repo.tree(rev)
+	if err != nil {
+		return nil, err
+	}
+
+	sub, err := tree.Tree(dir)
+	if err != nil {
+		if errors.Is(err, object.ErrDirectoryNotFound) {
+			return []string{}, nil
+		}
+
+		return nil, err
+	}
+
+	paths := []string{}
+
+	for _, entry := range sub.Entries {
+		if entry.Mode.IsFile() {
+			paths = append(paths, dir+"/"+entry.Name)
+		}
+	}
+
+	return paths, nil
+}
+
+// fileContents returns the content of the file at path in the tree.
+// When the file does not exist an empty string is returned.
+func fileContents(tree *object.Tree, path string) (string, error) {
+	f, err := tree.File(path)
+	if err != nil {
+		if errors.Is(err, object.ErrFileNotFound) {
+			return "", nil
+		}
+
+		return "", err
+	}
+
+	return f.Contents()
+}
+
+// parentTree returns the tree of the first parent of the commit. A commit
+// without parents is compared to an empty tree.
+func parentTree(c *object.Commit) (*object.Tree, error) {
+	if c.NumParents() == 0 {
+		return &object.Tree{}, nil
+	}
+
+	parent, err := c.Parent(0)
+	if err != nil {
+		return nil, err
+	}
+
+	return parent.Tree()
+}
+
+// diffTrees returns the trees of the revisions from and to.
+// When from is empty the parent of to is used.
+func (repo *Repository) diffTrees(from, to string) (fromTree, toTree *object.Tree, err error) {
+	toCommit, err := repo.commit(to)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	toTree, err = toCommit.Tree()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if from == "" {
+		fromTree, err = parentTree(toCommit)
+	} else {
+		fromTree, err = repo.tree(from)
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return fromTree, toTree, nil
+}
+
+// Diff returns the records that are added, removed and changed between the
+// revisions from and to. When to is empty HEAD is used and when from is
+// empty the parent of to is used, so the changes of the last commit are returned.
+func (repo *Repository) Diff(from, to string) (*DatasetDiff, error) {
+	fromTree, toTree, err := repo.diffTrees(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := object.DiffTree(fromTree, toTree)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &DatasetDiff{
+		From:    from,
+		To:      to,
+		Added:   []string{},
+		Removed: []string{},
+		Changed: []RecordDiff{},
+	}
+
+	for _, change := range changes {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+
+		name := change.To.Name
+		if action == merkletrie.Delete {
+			name = change.From.Name
+		}
+
+		hubID, ok := RecordID(name)
+		if !ok {
+			continue
+		}
+
+		switch action {
+		case merkletrie.Insert:
+			diff.Added = append(diff.Added, hubID)
+		case merkletrie.Delete:
+			diff.Removed = append(diff.Removed, hubID)
+		case merkletrie.Modify:
+			fromFile, toFile, err := change.Files()
+			if err != nil {
+				return nil, err
+			}
+
+			rd, err := recordDiff(hubID, fromFile, toFile)
+			if err != nil {
+				return nil, err
+			}
+
+			diff.Changed = append(diff.Changed, rd)
+		}
+	}
+
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	sort.Slice(diff.Changed, func(i, j int) bool {
+		return diff.Changed[i].HubID < diff.Changed[j].HubID
+	})
+
+	return diff, nil
+}
+
+func recordDiff(hubID string, fromFile, toFile *object.File) (RecordDiff, error) {
+	rd := RecordDiff{HubID: hubID}
+
+	fromContent, err := fromFile.Contents()
+	if err != nil {
+		return rd, err
+	}
+
+	toContent, err := toFile.Contents()
+	if err != nil {
+		return rd, err
+	}
+
+	rd.Added, rd.Removed = diffLines(fromContent, toContent)
+
+	return rd, nil
+}
+
+// RecordDiff returns the triples of the record that are added and removed
+// between the revisions from and to. The defaults of from and to are the same
+// as for Diff. A record that does not exist at a revision has no triples.
+func (repo *Repository) RecordDiff(hubID, from, to string) (*RecordDiff, error) {
+	fromTree, toTree, err := repo.diffTrees(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	path := RecordPath(hubID)
+
+	fromContent, err := fileContents(fromTree, path)
+	if err != nil {
+		return nil, err
+	}
+
+	toContent, err := fileContents(toTree, path)
+	if err != nil {
+		return nil, err
+	}
+
+	rd := &RecordDiff{HubID: hubID}
+	rd.Added, rd.Removed = diffLines(fromContent, toContent)
+
+	return rd, nil
+}
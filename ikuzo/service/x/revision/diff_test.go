@@ -0,0 +1,134 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package revision
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func Test_diffLines(t *testing.T) {
+	tests := []struct {
+		name        string
+		from        string
+		to          string
+		wantAdded   []string
+		wantRemoved []string
+	}{
+		{"unchanged", "a\nb\n", "a\nb\n", []string{}, []string{}},
+		{"new record", "", "a\nb\n", []string{"a", "b"}, []string{}},
+		{"removed record", "a\nb\n", "", []string{}, []string{"a", "b"}},
+		{"changed triples", "a\nb\nc\n", "a\nc\nd\n", []string{"d"}, []string{"b"}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			added, removed := diffLines(tt.from, tt.to)
+			is.Equal(added, tt.wantAdded)
+			is.Equal(removed, tt.wantRemoved)
+		})
+	}
+}
+
+// nolint:gocritic
+func TestRepository_Diff(t *testing.T) {
+	is := is.New(t)
+
+	dir, err := ioutil.TempDir("", "revision-diff")
+	is.NoErr(err)
+
+	defer os.RemoveAll(dir)
+
+	s, err := NewService(dir)
+	is.NoErr(err)
+
+	repo, err := s.GetRepository("demo", "spec")
+	is.NoErr(err)
+
+	write := func(hubID, content string) {
+		is.NoErr(repo.Write(RecordPath(hubID), strings.NewReader(content)))
+		is.NoErr(repo.Write(RecordMetaPath(hubID), strings.NewReader(`{"hubId":"`+hubID+`"}`)))
+	}
+
+	write("demo_spec_1", "<urn:1> <urn:p> \"a\" .\n<urn:1> <urn:p> \"b\" .\n")
+	write("demo_spec_2", "<urn:2> <urn:p> \"a\" .\n")
+	first, err := repo.CommitAll(RecordDir, "first")
+	is.NoErr(err)
+
+	write("demo_spec_1", "<urn:1> <urn:p> \"a\" .\n<urn:1> <urn:p> \"c\" .\n")
+	write("demo_spec_3", "<urn:3> <urn:p> \"a\" .\n")
+	is.NoErr(repo.Remove(RecordPath("demo_spec_2")))
+	is.NoErr(repo.Remove(RecordMetaPath("demo_spec_2")))
+	second, err := repo.CommitAll(RecordDir, "second")
+	is.NoErr(err)
+
+	resolved, err := repo.ResolveRevision("HEAD~1")
+	is.NoErr(err)
+	is.Equal(resolved, first)
+
+	_, err = repo.ResolveRevision("unknown")
+	is.True(errors.Is(err, ErrRevisionNotFound))
+
+	diff, err := repo.Diff(first.String(), second.String())
+	is.NoErr(err)
+	is.Equal(diff.Added, []string{"demo_spec_3"})
+	is.Equal(diff.Removed, []string{"demo_spec_2"})
+	is.Equal(diff.Changed, []RecordDiff{
+		{
+			HubID:   "demo_spec_1",
+			Added:   []string{"<urn:1> <urn:p> \"c\" ."},
+			Removed: []string{"<urn:1> <urn:p> \"b\" ."},
+		},
+	})
+
+	// the default diff is the last commit
+	last, err := repo.Diff("", "")
+	is.NoErr(err)
+	is.Equal(last.Added, diff.Added)
+	is.Equal(last.Changed, diff.Changed)
+
+	// the first commit is compared to an empty tree
+	initial, err := repo.Diff("", first.String())
+	is.NoErr(err)
+	is.Equal(initial.Added, []string{"demo_spec_1", "demo_spec_2"})
+	is.Equal(len(initial.Changed), 0)
+
+	rd, err := repo.RecordDiff("demo_spec_2", first.String(), "head")
+	is.NoErr(err)
+	is.Equal(rd.Added, []string{})
+	is.Equal(rd.Removed, []string{"<urn:2> <urn:p> \"a\" ."})
+
+	files, err := repo.FilesAt(RecordDir, first.String())
+	is.NoErr(err)
+	is.Equal(files, []string{
+		"records/demo_spec_1.json",
+		"records/demo_spec_1.nt",
+		"records/demo_spec_2.json",
+		"records/demo_spec_2.nt",
+	})
+
+	files, err = repo.FilesAt("unknown", first.String())
+	is.NoErr(err)
+	is.Equal(len(files), 0)
+}
@@ -0,0 +1,158 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package revision
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"code.gitea.io/gitea/modules/git"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+)
+
+// Routes returns the API to the history of the dataset repositories.
+func (s *Service) Routes() chi.Router {
+	router := chi.NewRouter()
+
+	router.Get("/{orgID}/{datasetID}/commits", s.handleCommits)
+	router.Get("/{orgID}/{datasetID}/diff", s.handleDiff)
+	router.Get("/{orgID}/{datasetID}/records/{hubID}", s.handleRecordHistory)
+	router.Get("/{orgID}/{datasetID}/records/{hubID}/diff", s.handleRecordDiff)
+	router.Get("/{orgID}/{datasetID}/records/{hubID}/{sha}", s.handleRecord)
+
+	return router
+}
+
+// httpError writes the error with the status code that matches the error.
+func httpError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+
+	switch {
+	case errors.Is(err, ErrRepositoryNotExists),
+		errors.Is(err, ErrRevisionNotFound),
+		git.IsErrNotExist(err):
+		status = http.StatusNotFound
+	case errors.Is(err, ErrInvalidRepository):
+		status = http.StatusBadRequest
+	}
+
+	http.Error(w, err.Error(), status)
+}
+
+func (s *Service) requestRepository(r *http.Request) (*Repository, error) {
+	return s.OpenRepository(chi.URLParam(r, "orgID"), chi.URLParam(r, "datasetID"))
+}
+
+// handleCommits returns the commits of the dataset, the most recent first.
+func (s *Service) handleCommits(w http.ResponseWriter, r *http.Request) {
+	repo, err := s.requestRepository(r)
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+
+	commits, err := repo.History("")
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+
+	render.JSON(w, r, commits)
+}
+
+// handleDiff returns the records that differ between the revisions in the
+// 'from' and 'to' query parameters. See Repository.Diff for the defaults.
+func (s *Service) handleDiff(w http.ResponseWriter, r *http.Request) {
+	repo, err := s.requestRepository(r)
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+
+	diff, err := repo.Diff(r.URL.Query().Get("from"), r.URL.Query().Get("to"))
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+
+	render.JSON(w, r, diff)
+}
+
+// handleRecordDiff returns the triples of the record that differ between the
+// revisions in the 'from' and 'to' query parameters.
+func (s *Service) handleRecordDiff(w http.ResponseWriter, r *http.Request) {
+	repo, err := s.requestRepository(r)
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+
+	diff, err := repo.RecordDiff(chi.URLParam(r, "hubID"), r.URL.Query().Get("from"), r.URL.Query().Get("to"))
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+
+	render.JSON(w, r, diff)
+}
+
+// handleRecordHistory returns the revisions of the record, the most recent first.
+func (s *Service) handleRecordHistory(w http.ResponseWriter, r *http.Request) {
+	repo, err := s.requestRepository(r)
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+
+	commits, err := repo.History(RecordPath(chi.URLParam(r, "hubID")))
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+
+	if len(commits) == 0 {
+		http.Error(w, "record not found", http.StatusNotFound)
+		return
+	}
+
+	render.JSON(w, r, commits)
+}
+
+// handleRecord returns the graph of the record at the revision as N-Triples.
+// The revision 'head' returns the current version.
+func (s *Service) handleRecord(w http.ResponseWriter, r *http.Request) {
+	repo, err := s.requestRepository(r)
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+
+	rc, err := repo.Read(RecordPath(chi.URLParam(r, "hubID")), chi.URLParam(r, "sha"))
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+
+	defer rc.Close()
+
+	w.Header().Set("Content-Type", "application/n-triples; charset=utf-8")
+
+	if _, err := io.Copy(w, rc); err != nil {
+		httpError(w, err)
+		return
+	}
+}
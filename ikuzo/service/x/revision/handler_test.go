@@ -0,0 +1,128 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package revision
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+// nolint:gocritic
+func TestService_Routes(t *testing.T) {
+	is := is.New(t)
+
+	dir, err := ioutil.TempDir("", "revision-routes")
+	is.NoErr(err)
+
+	defer os.RemoveAll(dir)
+
+	s, err := NewService(dir)
+	is.NoErr(err)
+
+	repo, err := s.GetRepository("demo", "spec")
+	is.NoErr(err)
+
+	// an empty repository has no history
+	head, err := repo.CommitAll(RecordDir, "nothing to commit")
+	is.NoErr(err)
+	is.True(head.IsZero())
+
+	path := RecordPath("demo_spec_1")
+	hubID, ok := RecordID(path)
+	is.True(ok)
+	is.Equal(hubID, "demo_spec_1")
+
+	is.NoErr(repo.Write(path, strings.NewReader("<urn:1> <urn:p> \"first\" .\n")))
+	first, err := repo.CommitAll(RecordDir, "first")
+	is.NoErr(err)
+
+	is.NoErr(repo.Write(path, strings.NewReader("<urn:1> <urn:p> \"second\" .\n")))
+	is.NoErr(repo.Write(RecordPath("demo_spec_2"), strings.NewReader("<urn:2> <urn:p> \"other\" .\n")))
+	second, err := repo.CommitAll(RecordDir, "second")
+	is.NoErr(err)
+	is.True(first != second)
+
+	router := s.Routes()
+
+	do := func(target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+
+		return w
+	}
+
+	var commits []CommitInfo
+
+	w := do("/demo/spec/commits")
+	is.Equal(w.Code, http.StatusOK)
+	is.NoErr(json.Unmarshal(w.Body.Bytes(), &commits))
+	is.Equal(len(commits), 2)
+	is.Equal(commits[0].SHA, second.String())
+
+	w = do("/demo/spec/records/demo_spec_2")
+	is.Equal(w.Code, http.StatusOK)
+	is.NoErr(json.Unmarshal(w.Body.Bytes(), &commits))
+	is.Equal(len(commits), 1)
+
+	w = do("/demo/spec/records/demo_spec_1")
+	is.Equal(w.Code, http.StatusOK)
+	is.NoErr(json.Unmarshal(w.Body.Bytes(), &commits))
+	is.Equal(len(commits), 2)
+	is.Equal(commits[1].SHA, first.String())
+	is.Equal(commits[1].Author, "hub3")
+
+	w = do("/demo/spec/records/demo_spec_1/" + first.String())
+	is.Equal(w.Code, http.StatusOK)
+	is.Equal(w.Body.String(), "<urn:1> <urn:p> \"first\" .\n")
+
+	w = do("/demo/spec/records/demo_spec_1/head")
+	is.Equal(w.Code, http.StatusOK)
+	is.Equal(w.Body.String(), "<urn:1> <urn:p> \"second\" .\n")
+
+	w = do("/demo/spec/records/demo_spec_2/" + first.String())
+	is.Equal(w.Code, http.StatusNotFound)
+
+	var diff DatasetDiff
+
+	w = do("/demo/spec/diff?from=" + first.String())
+	is.Equal(w.Code, http.StatusOK)
+	is.NoErr(json.Unmarshal(w.Body.Bytes(), &diff))
+	is.Equal(diff.Added, []string{"demo_spec_2"})
+	is.Equal(len(diff.Changed), 1)
+
+	var rd RecordDiff
+
+	w = do("/demo/spec/records/demo_spec_1/diff?from=" + first.String() + "&to=" + second.String())
+	is.Equal(w.Code, http.StatusOK)
+	is.NoErr(json.Unmarshal(w.Body.Bytes(), &rd))
+	is.Equal(rd.Added, []string{"<urn:1> <urn:p> \"second\" ."})
+	is.Equal(rd.Removed, []string{"<urn:1> <urn:p> \"first\" ."})
+
+	w = do("/demo/spec/diff?from=unknown")
+	is.Equal(w.Code, http.StatusNotFound)
+
+	w = do("/demo/spec/records/unknown")
+	is.Equal(w.Code, http.StatusNotFound)
+
+	w = do("/demo/unknown/commits")
+	is.Equal(w.Code, http.StatusNotFound)
+}
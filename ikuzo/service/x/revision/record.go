@@ -0,0 +1,51 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package revision
+
+import (
+	"net/url"
+	"path"
+	"strings"
+)
+
+// RecordDir is the directory of the dataset Repository the records are stored in.
+const RecordDir = "records"
+
+// RecordPath returns the path of the graph of the record in the dataset Repository.
+// The graph is stored as sorted N-Triples, so each line is a triple.
+func RecordPath(hubID string) string {
+	return path.Join(RecordDir, url.PathEscape(hubID)+".nt")
+}
+
+// RecordMetaPath returns the path of the metadata of the record that is
+// stored next to its graph.
+func RecordMetaPath(hubID string) string {
+	return path.Join(RecordDir, url.PathEscape(hubID)+".json")
+}
+
+// RecordID returns the hubID of the record for a path returned by RecordPath.
+// When the path is not a record graph, ok is false.
+func RecordID(p string) (hubID string, ok bool) {
+	if path.Dir(p) != RecordDir || !strings.HasSuffix(p, ".nt") {
+		return "", false
+	}
+
+	hubID, err := url.PathUnescape(strings.TrimSuffix(path.Base(p), ".nt"))
+	if err != nil {
+		return "", false
+	}
+
+	return hubID, true
+}
@@ -15,11 +15,14 @@
 package revision
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"code.gitea.io/gitea/modules/git"
@@ -33,7 +36,19 @@ type Repository struct {
 	dataset      string
 	path         string
 	gr           *git.Repository
+	r            *gitgo.Repository
 	w            *gitgo.Worktree
+	// mu guards the staging area and commits. It is shared by all
+	// Repository instances with the same path.
+	mu *sync.Mutex
+}
+
+// CommitInfo describes a commit in the Repository.
+type CommitInfo struct {
+	SHA     string    `json:"sha"`
+	Message string    `json:"message"`
+	Author  string    `json:"author"`
+	When    time.Time `json:"when"`
 }
 
 // SingleFlight writes io.Reader to path and creates a commit with commitMessage.
@@ -69,6 +84,8 @@ func (repo *Repository) Write(path string, r io.Reader) error {
 		return fmt.Errorf("unable to create file; %w", err)
 	}
 
+	defer f.Close()
+
 	_, err = io.Copy(f, r)
 	if err != nil {
 		return fmt.Errorf("unable to write to file; %w", err)
@@ -116,17 +133,178 @@ func (repo *Repository) Commit(msg string, options *gitgo.CommitOptions) (plumbi
 }
 
 // Add adds all files with path to the staging area.
+// Files that are removed from path are also staged. When path is neither in
+// the work tree nor in the index there is nothing to add.
 func (repo *Repository) Add(path string) error {
 	if path == "" {
 		path = "."
 	}
 
-	return git.AddChanges(repo.path, false, path)
+	if _, err := os.Stat(filepath.Join(repo.path, path)); os.IsNotExist(err) {
+		tracked, trackErr := repo.isTracked(path)
+		if trackErr != nil || !tracked {
+			return trackErr
+		}
+	}
+
+	return git.AddChanges(repo.path, true, path)
+}
+
+// isTracked returns true when the index has a file at or below path.
+func (repo *Repository) isTracked(path string) (bool, error) {
+	r, err := repo.gitRepo()
+	if err != nil {
+		return false, err
+	}
+
+	idx, err := r.Storer.Index()
+	if err != nil {
+		return false, err
+	}
+
+	path = strings.TrimSuffix(filepath.ToSlash(path), "/")
+
+	for _, entry := range idx.Entries {
+		if entry.Name == path || strings.HasPrefix(entry.Name, path+"/") {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Remove removes the file or directory at path from the work tree.
+// The removal is staged with Add.
+func (repo *Repository) Remove(path string) error {
+	return os.RemoveAll(filepath.Join(repo.path, path))
+}
+
+// ReadFile returns the content of the file at path in the work tree,
+// including the changes that are not committed yet.
+func (repo *Repository) ReadFile(path string) ([]byte, error) {
+	return ioutil.ReadFile(filepath.Join(repo.path, path))
+}
+
+// Files returns the paths of the files in the directory dir of the work tree.
+// When dir does not exist no paths are returned.
+func (repo *Repository) Files(dir string) ([]string, error) {
+	infos, err := ioutil.ReadDir(filepath.Join(repo.path, dir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
+
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(infos))
+
+	for _, info := range infos {
+		if !info.IsDir() {
+			paths = append(paths, filepath.ToSlash(filepath.Join(dir, info.Name())))
+		}
+	}
+
+	return paths, nil
+}
+
+// CommitAll stages all changes in path and commits them with msg.
+// When nothing has changed no commit is made and the current HEAD is returned.
+func (repo *Repository) CommitAll(path, msg string) (plumbing.Hash, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if err := repo.Add(path); err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("unable to add files to staging; %w", err)
+	}
+
+	status, err := repo.Status()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	if status.IsClean() {
+		return repo.Head()
+	}
+
+	return repo.Commit(msg, nil)
+}
+
+// Head returns the hash of the current HEAD commit.
+// For an empty Repository the plumbing.ZeroHash is returned.
+func (repo *Repository) Head() (plumbing.Hash, error) {
+	r, err := repo.gitRepo()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	ref, err := r.Head()
+	if err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return plumbing.ZeroHash, nil
+		}
+
+		return plumbing.ZeroHash, err
+	}
+
+	return ref.Hash(), nil
+}
+
+// History returns the commits that changed the file at path, the most recent first.
+// When path is empty all commits are returned.
+func (repo *Repository) History(path string) ([]CommitInfo, error) {
+	head, err := repo.Head()
+	if err != nil || head.IsZero() {
+		return []CommitInfo{}, err
+	}
+
+	r, err := repo.gitRepo()
+	if err != nil {
+		return nil, err
+	}
+
+	options := &gitgo.LogOptions{From: head, Order: gitgo.LogOrderCommitterTime}
+	if path != "" {
+		options.FileName = &path
+	}
+
+	iter, err := r.Log(options)
+	if err != nil {
+		return nil, err
+	}
+
+	commits := []CommitInfo{}
+
+	err = iter.ForEach(func(c *object.Commit) error {
+		commits = append(commits, CommitInfo{
+			SHA:     c.Hash.String(),
+			Message: c.Message,
+			Author:  c.Author.Name,
+			When:    c.Author.When,
+		})
+
+		return nil
+	})
+
+	return commits, err
+}
+
+func (repo *Repository) gitRepo() (*gitgo.Repository, error) {
+	if repo.r == nil {
+		r, err := gitgo.PlainOpen(repo.path)
+		if err != nil {
+			return nil, err
+		}
+
+		repo.r = r
+	}
+
+	return repo.r, nil
 }
 
 func (repo *Repository) workTree() (*gitgo.Worktree, error) {
 	if repo.w == nil {
-		r, err := gitgo.PlainOpen(repo.path)
+		r, err := repo.gitRepo()
 		if err != nil {
 			return nil, err
 		}
@@ -16,27 +16,35 @@ package revision
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"code.gitea.io/gitea/modules/git"
+	"github.com/delving/hub3/ikuzo/domain"
+	"github.com/delving/hub3/ikuzo/service/x/dataset"
 	gitgo "github.com/go-git/go-git/v5"
 	"github.com/sosedoff/gitkit"
 )
 
 var (
 	ErrRepositoryNotExists = errors.New("repository does not exist")
+	ErrInvalidRepository   = errors.New("invalid repository identifier")
 )
 
 type Service struct {
 	base     string
 	server   *gitkit.Server
 	BareRepo bool
+	// locks are the commit locks per repository path
+	locks   map[string]*sync.Mutex
+	locksMu sync.Mutex
 }
 
 func NewService(path string) (*Service, error) {
-	s := &Service{base: path}
+	s := &Service{base: path, locks: map[string]*sync.Mutex{}}
 	if strings.HasSuffix(s.base, "/") {
 		s.base = strings.TrimSuffix(s.base, "/")
 	}
@@ -48,25 +56,37 @@ func NewService(path string) (*Service, error) {
 
 // InitRepository initializes a Repository and returns it.
 //
-// An error is only returned if there are underlying FS errors.
-func (s *Service) InitRepository(organization, dataset string) (*Repository, error) {
-	if err := git.InitRepository(s.repoPath(organization, dataset), false); err != nil {
+// An ErrInvalidRepository is returned when the organization or dataset is not a
+// valid identifier. Other errors are only returned if there are underlying FS errors.
+func (s *Service) InitRepository(orgID, datasetID string) (*Repository, error) {
+	path, err := s.repoPath(orgID, datasetID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := git.InitRepository(path, false); err != nil {
 		return nil, err
 	}
 
-	return s.OpenRepository(organization, dataset)
+	return s.OpenRepository(orgID, datasetID)
 }
 
 // OpenRepository returns an *Repository. When the Repository is not initialized
 // or does not exist a ErrRepositoryNotExists is returned.
 //
 // To create a repository you need to call InitRepository.
-func (s *Service) OpenRepository(organization, dataset string) (*Repository, error) {
+func (s *Service) OpenRepository(orgID, datasetID string) (*Repository, error) {
+	path, err := s.repoPath(orgID, datasetID)
+	if err != nil {
+		return nil, err
+	}
+
 	repo := &Repository{
-		path:         s.repoPath(organization, dataset),
-		organization: organization,
-		dataset:      dataset,
+		path:         path,
+		organization: orgID,
+		dataset:      datasetID,
 	}
+	repo.mu = s.lock(repo.path)
 
 	gr, err := git.OpenRepository(repo.path)
 	if err != nil {
@@ -82,8 +102,46 @@ func (s *Service) OpenRepository(organization, dataset string) (*Repository, err
 	return repo, nil
 }
 
-func (s *Service) repoPath(organization, dataset string) string {
-	return filepath.Join(s.base, organization, dataset)
+// GetRepository opens the Repository and initializes it when it does not exist.
+func (s *Service) GetRepository(orgID, datasetID string) (*Repository, error) {
+	repo, err := s.OpenRepository(orgID, datasetID)
+	if errors.Is(err, ErrRepositoryNotExists) {
+		return s.InitRepository(orgID, datasetID)
+	}
+
+	return repo, err
+}
+
+// lock returns the commit lock of the repository at path.
+func (s *Service) lock(path string) *sync.Mutex {
+	s.locksMu.Lock()
+	defer s.locksMu.Unlock()
+
+	if s.locks == nil {
+		s.locks = map[string]*sync.Mutex{}
+	}
+
+	mu, ok := s.locks[path]
+	if !ok {
+		mu = &sync.Mutex{}
+		s.locks[path] = mu
+	}
+
+	return mu
+}
+
+// repoPath returns the path of the repository in the base directory. The
+// identifiers are validated, so the path cannot escape the base directory.
+func (s *Service) repoPath(orgID, datasetID string) (string, error) {
+	if err := domain.OrganizationID(orgID).Valid(); err != nil {
+		return "", fmt.Errorf("%w: organization %q; %s", ErrInvalidRepository, orgID, err)
+	}
+
+	if err := dataset.ValidID(datasetID); err != nil {
+		return "", fmt.Errorf("%w: dataset %q; %s", ErrInvalidRepository, datasetID, err)
+	}
+
+	return filepath.Join(s.base, orgID, datasetID), nil
 }
 
 func (s *Service) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -82,7 +82,7 @@ func TestService(t *testing.T) {
 	is.NoErr(err)
 	is.True(s != nil)
 
-	var org, ds = "demo-org", "demo-spec"
+	var org, ds = "demo", "demo-spec"
 
 	t.Run("NewRepo", testNewRepo(s, org, ds))
 
@@ -158,3 +158,47 @@ func TestService(t *testing.T) {
 
 	is.True(len(files) == 2)
 }
+
+// nolint:gocritic
+func TestService_invalidRepository(t *testing.T) {
+	is := is.New(t)
+
+	dir, err := ioutil.TempDir("", "revision")
+	is.NoErr(err)
+
+	defer os.RemoveAll(dir)
+
+	s, err := NewService(filepath.Join(dir, "base"))
+	is.NoErr(err)
+
+	tests := []struct {
+		name    string
+		org     string
+		dataset string
+	}{
+		{"parent organization", "..", "spec"},
+		{"parent dataset", "demo", ".."},
+		{"nested dataset", "demo", "../../escape"},
+		{"empty organization", "", "spec"},
+		{"invalid organization", "demo/..", "spec"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			_, err := s.GetRepository(tt.org, tt.dataset)
+			is.True(errors.Is(err, ErrInvalidRepository))
+
+			_, err = s.OpenRepository(tt.org, tt.dataset)
+			is.True(errors.Is(err, ErrInvalidRepository))
+		})
+	}
+
+	// no repository is initialized outside the base directory
+	entries, err := ioutil.ReadDir(dir)
+	is.NoErr(err)
+	is.Equal(len(entries), 1)
+}
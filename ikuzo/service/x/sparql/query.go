@@ -0,0 +1,368 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sparql
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidQuery      = errors.New("invalid sparql query")
+	ErrUpdateNotAllowed  = errors.New("sparql update operations are not allowed")
+	ErrOffsetNotAllowed  = errors.New("sparql offset exceeds the maximum offset")
+	errUnterminatedToken = errors.New("unterminated string or IRI")
+)
+
+// QueryForm is the form of a SPARQL query.
+type QueryForm string
+
+const (
+	Select    QueryForm = "SELECT"
+	Construct QueryForm = "CONSTRUCT"
+	Describe  QueryForm = "DESCRIBE"
+	Ask       QueryForm = "ASK"
+)
+
+// updateKeywords start a SPARQL 1.1 Update operation.
+var updateKeywords = map[string]bool{
+	"INSERT": true, "DELETE": true, "LOAD": true, "CLEAR": true, "CREATE": true,
+	"DROP": true, "COPY": true, "MOVE": true, "ADD": true, "WITH": true,
+}
+
+type tokenKind int
+
+const (
+	tokWord tokenKind = iota
+	tokVar
+	tokIRI
+	tokString
+	tokNumber
+	tokPunct
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	depth int // the group depth, i.e. the number of enclosing '{'
+}
+
+// keyword returns the uppercased text of top-level words.
+func (t token) keyword() string {
+	if t.kind != tokWord || t.depth != 0 {
+		return ""
+	}
+
+	return strings.ToUpper(t.text)
+}
+
+// Query is a parsed SPARQL query.
+type Query struct {
+	Form   QueryForm
+	Limit  int // -1 when the query has no LIMIT
+	Offset int // -1 when the query has no OFFSET
+
+	tokens []token
+	// limitAt and offsetAt are the token indexes of the top-level LIMIT and OFFSET keywords.
+	limitAt  int
+	offsetAt int
+	// valuesAt is the token index of a trailing VALUES clause.
+	valuesAt int
+}
+
+// ParseQuery parses the query. Only the top-level structure of the query is parsed:
+// the query form and the solution modifiers. Subqueries are left as is.
+//
+// An ErrUpdateNotAllowed is returned for SPARQL Update requests.
+func ParseQuery(query string) (*Query, error) {
+	tokens, err := tokenize(query)
+	if err != nil {
+		return nil, fmt.Errorf("%w; %s", ErrInvalidQuery, err)
+	}
+
+	q := &Query{
+		tokens:   tokens,
+		Limit:    -1,
+		Offset:   -1,
+		limitAt:  -1,
+		offsetAt: -1,
+		valuesAt: -1,
+	}
+
+	for i, t := range tokens {
+		kw := t.keyword()
+
+		switch {
+		case updateKeywords[kw]:
+			return nil, ErrUpdateNotAllowed
+		case q.Form == "" && (kw == string(Select) || kw == string(Construct) || kw == string(Describe) || kw == string(Ask)):
+			q.Form = QueryForm(kw)
+		case kw == "LIMIT" || kw == "OFFSET":
+			if i+1 >= len(tokens) || tokens[i+1].kind != tokNumber {
+				return nil, fmt.Errorf("%w; %s must be followed by an integer", ErrInvalidQuery, kw)
+			}
+
+			n, err := strconv.Atoi(tokens[i+1].text)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("%w; %s must be followed by an integer", ErrInvalidQuery, kw)
+			}
+
+			if kw == "LIMIT" {
+				q.Limit, q.limitAt = n, i
+			} else {
+				q.Offset, q.offsetAt = n, i
+			}
+		case kw == "VALUES" && q.Form != "":
+			q.valuesAt = i
+		}
+	}
+
+	if q.Form == "" {
+		return nil, fmt.Errorf("%w; no SELECT, CONSTRUCT, DESCRIBE or ASK query form", ErrInvalidQuery)
+	}
+
+	return q, nil
+}
+
+// String returns the normalized query. Comments are removed, keywords are
+// uppercased and tokens are separated by a single space.
+func (q *Query) String() string {
+	texts := make([]string, 0, len(q.tokens))
+	for _, t := range q.tokens {
+		texts = append(texts, t.text)
+	}
+
+	return strings.Join(texts, " ")
+}
+
+// Rewrite returns the normalized query with the LIMIT enforced. Queries without
+// a LIMIT get the defaultLimit and a LIMIT above maxLimit is lowered to maxLimit.
+// A zero value disables the default or the maximum.
+//
+// An ErrOffsetNotAllowed is returned when the OFFSET exceeds the maxOffset.
+func (q *Query) Rewrite(defaultLimit, maxLimit, maxOffset int) (string, error) {
+	if maxOffset > 0 && q.Offset > maxOffset {
+		return "", fmt.Errorf("%w; %d > %d", ErrOffsetNotAllowed, q.Offset, maxOffset)
+	}
+
+	if q.Form == Ask {
+		return q.String(), nil
+	}
+
+	limit := q.Limit
+	if limit == -1 && defaultLimit > 0 {
+		limit = defaultLimit
+	}
+
+	if maxLimit > 0 && (limit == -1 || limit > maxLimit) {
+		limit = maxLimit
+	}
+
+	modifiers := []string{}
+	if limit >= 0 {
+		modifiers = append(modifiers, "LIMIT", strconv.Itoa(limit))
+	}
+
+	if q.Offset > 0 {
+		modifiers = append(modifiers, "OFFSET", strconv.Itoa(q.Offset))
+	}
+
+	texts := make([]string, 0, len(q.tokens)+len(modifiers))
+
+	for i := 0; i < len(q.tokens); i++ {
+		switch i {
+		case q.limitAt, q.offsetAt:
+			// skip the keyword and its value
+			i++
+			continue
+		case q.valuesAt:
+			texts = append(texts, modifiers...)
+			modifiers = nil
+		}
+
+		texts = append(texts, q.tokens[i].text)
+	}
+
+	texts = append(texts, modifiers...)
+
+	return strings.Join(texts, " "), nil
+}
+
+func isWordStart(c byte) bool {
+	return c == '_' || c == ':' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+func isWordChar(c byte) bool {
+	return isWordStart(c) || c == '-' || c == '.' || c == '%' || c == '\\' || (c >= '0' && c <= '9')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// iriEnd returns the index after the closing '>' of the IRI that starts at i,
+// or -1 when the '<' is an operator.
+func iriEnd(s string, i int) int {
+	for j := i + 1; j < len(s); j++ {
+		switch c := s[j]; {
+		case c == '>':
+			return j + 1
+		case c <= ' ' || strings.IndexByte("<\"{}|^`\\", c) != -1:
+			return -1
+		}
+	}
+
+	return -1
+}
+
+// stringEnd returns the index after the closing quote of the string that starts at i.
+func stringEnd(s string, i int) (int, error) {
+	quote := s[i : i+1]
+	if strings.HasPrefix(s[i:], strings.Repeat(quote, 3)) {
+		quote = strings.Repeat(quote, 3)
+	}
+
+	for j := i + len(quote); j < len(s); j++ {
+		switch {
+		case s[j] == '\\':
+			j++
+		case strings.HasPrefix(s[j:], quote):
+			return j + len(quote), nil
+		case len(quote) == 1 && (s[j] == '\n' || s[j] == '\r'):
+			return 0, errUnterminatedToken
+		}
+	}
+
+	return 0, errUnterminatedToken
+}
+
+// normalizeWord uppercases keywords and function names, which are case-insensitive.
+// Prefixed names, language tags and the case-sensitive 'a', 'true' and 'false'
+// are left as is.
+func normalizeWord(text string) string {
+	switch {
+	case strings.ContainsAny(text, ":@"), text == "a", text == "true", text == "false":
+		return text
+	}
+
+	return strings.ToUpper(text)
+}
+
+// tokenize splits the query into tokens. Whitespace and comments are dropped.
+func tokenize(s string) ([]token, error) {
+	tokens := []token{}
+	depth := 0
+
+	for i := 0; i < len(s); {
+		c := s[i]
+		start := i
+
+		var kind tokenKind
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+			continue
+		case c == '#':
+			for i < len(s) && s[i] != '\n' {
+				i++
+			}
+
+			continue
+		case c == '"' || c == '\'':
+			end, err := stringEnd(s, i)
+			if err != nil {
+				return nil, err
+			}
+
+			kind, i = tokString, end
+		case c == '<' && iriEnd(s, i) != -1:
+			kind, i = tokIRI, iriEnd(s, i)
+		case (c == '?' || c == '$') && i+1 < len(s) && isWordChar(s[i+1]):
+			i++
+			for i < len(s) && isWordChar(s[i]) && s[i] != '.' && s[i] != ':' {
+				i++
+			}
+
+			kind = tokVar
+		case c == '@':
+			i++
+			for i < len(s) && (isWordStart(s[i]) || isDigit(s[i]) || s[i] == '-') {
+				i++
+			}
+
+			kind = tokWord
+		case isDigit(c) || (c == '.' && i+1 < len(s) && isDigit(s[i+1])):
+			for i < len(s) && (isDigit(s[i]) || s[i] == '.' || s[i] == 'e' || s[i] == 'E' ||
+				((s[i] == '+' || s[i] == '-') && (s[i-1] == 'e' || s[i-1] == 'E'))) {
+				i++
+			}
+
+			// a trailing '.' terminates the triple
+			for s[i-1] == '.' {
+				i--
+			}
+
+			kind = tokNumber
+		case isWordStart(c):
+			for i < len(s) && isWordChar(s[i]) {
+				i++
+			}
+
+			// a local name can't end with a '.'
+			for s[i-1] == '.' {
+				i--
+			}
+
+			kind = tokWord
+		default:
+			kind = tokPunct
+			i++
+
+			if i < len(s) {
+				switch s[start:i] + s[i:i+1] {
+				case "<=", ">=", "!=", "&&", "||", "^^":
+					i++
+				}
+			}
+		}
+
+		text := s[start:i]
+		if kind == tokWord {
+			text = normalizeWord(text)
+		}
+
+		if text == "}" {
+			depth--
+			if depth < 0 {
+				return nil, errors.New("unbalanced '}'")
+			}
+		}
+
+		tokens = append(tokens, token{kind: kind, text: text, depth: depth})
+
+		if text == "{" {
+			depth++
+		}
+	}
+
+	if depth != 0 {
+		return nil, errors.New("unbalanced '{'")
+	}
+
+	return tokens, nil
+}
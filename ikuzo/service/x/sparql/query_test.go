@@ -0,0 +1,215 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sparql
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		form   QueryForm
+		limit  int
+		offset int
+		err    error
+	}{
+		{
+			"select with modifiers",
+			"SELECT * WHERE { ?s ?p ?o } limit 10 OFFSET 20",
+			Select, 10, 20, nil,
+		},
+		{
+			"prefixes and comments",
+			"PREFIX dc: <http://purl.org/dc/elements/1.1/>\n# LIMIT 5\nSELECT ?s WHERE { ?s dc:title ?o }",
+			Select, -1, -1, nil,
+		},
+		{
+			"subquery limit is ignored",
+			"SELECT ?s WHERE { { SELECT ?s WHERE { ?s ?p ?o } LIMIT 5000 } }",
+			Select, -1, -1, nil,
+		},
+		{
+			"keywords in strings and IRIs",
+			`SELECT ?s WHERE { ?s <http://example.org/limit> "LIMIT 1000 } DELETE" ; ?p """multi
+line""" }`,
+			Select, -1, -1, nil,
+		},
+		{
+			"less than operator",
+			"SELECT ?s WHERE { ?s ?p ?o FILTER(?o <10 && ?o>=2) } LIMIT 3",
+			Select, 3, -1, nil,
+		},
+		{
+			"construct",
+			"CONSTRUCT { ?s ?p ?o } WHERE { ?s ?p ?o }",
+			Construct, -1, -1, nil,
+		},
+		{
+			"describe",
+			"DESCRIBE <http://example.org/1>",
+			Describe, -1, -1, nil,
+		},
+		{
+			"ask",
+			"ASK { ?s ?p ?o }",
+			Ask, -1, -1, nil,
+		},
+		{
+			"insert data",
+			"INSERT DATA { <http://example.org/1> <http://example.org/p> 1 }",
+			"", 0, 0, ErrUpdateNotAllowed,
+		},
+		{
+			"update after a query",
+			"SELECT * WHERE { ?s ?p ?o } ; DROP ALL",
+			"", 0, 0, ErrUpdateNotAllowed,
+		},
+		{
+			"delete where with prefix",
+			"PREFIX dc: <http://purl.org/dc/elements/1.1/> DELETE WHERE { ?s dc:title ?o }",
+			"", 0, 0, ErrUpdateNotAllowed,
+		},
+		{
+			"no query form",
+			"PREFIX dc: <http://purl.org/dc/elements/1.1/>",
+			"", 0, 0, ErrInvalidQuery,
+		},
+		{
+			"unterminated string",
+			`SELECT * WHERE { ?s ?p "open }`,
+			"", 0, 0, ErrInvalidQuery,
+		},
+		{
+			"unbalanced braces",
+			"SELECT * WHERE { ?s ?p ?o",
+			"", 0, 0, ErrInvalidQuery,
+		},
+		{
+			"limit without a value",
+			"SELECT * WHERE { ?s ?p ?o } LIMIT ?x",
+			"", 0, 0, ErrInvalidQuery,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			q, err := ParseQuery(tt.query)
+			if tt.err != nil {
+				is.True(errors.Is(err, tt.err))
+				return
+			}
+
+			is.NoErr(err)
+			is.Equal(q.Form, tt.form)
+			is.Equal(q.Limit, tt.limit)
+			is.Equal(q.Offset, tt.offset)
+		})
+	}
+}
+
+func TestQuery_Rewrite(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+		err   error
+	}{
+		{
+			"default limit",
+			"SELECT * WHERE {\n  ?s ?p ?o .\n}",
+			"SELECT * WHERE { ?s ?p ?o . } LIMIT 25",
+			nil,
+		},
+		{
+			"limit within bounds",
+			"SELECT * WHERE { ?s ?p ?o } LIMIT 50",
+			"SELECT * WHERE { ?s ?p ?o } LIMIT 50",
+			nil,
+		},
+		{
+			"limit above the maximum",
+			"select * where { ?s ?p ?o } offset 10 limit 5000",
+			"SELECT * WHERE { ?s ?p ?o } LIMIT 100 OFFSET 10",
+			nil,
+		},
+		{
+			"subquery limit is kept",
+			"SELECT ?s WHERE { { SELECT ?s WHERE { ?s ?p ?o } LIMIT 5000 } }",
+			"SELECT ?s WHERE { { SELECT ?s WHERE { ?s ?p ?o } LIMIT 5000 } } LIMIT 25",
+			nil,
+		},
+		{
+			"trailing values",
+			"SELECT ?s WHERE { ?s ?p ?o } LIMIT 500 VALUES ?s { <http://example.org/1> }",
+			"SELECT ?s WHERE { ?s ?p ?o } LIMIT 100 VALUES ?s { <http://example.org/1> }",
+			nil,
+		},
+		{
+			"ask is unchanged",
+			"ASK { ?s ?p ?o }",
+			"ASK { ?s ?p ?o }",
+			nil,
+		},
+		{
+			"comments and strings",
+			"SELECT ?s # LIMIT 1\nWHERE { ?s ?p \"a # b\"@en-GB }",
+			"SELECT ?s WHERE { ?s ?p \"a # b\" @en-GB } LIMIT 25",
+			nil,
+		},
+		{
+			"offset above the maximum",
+			"SELECT * WHERE { ?s ?p ?o } OFFSET 2000",
+			"",
+			ErrOffsetNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			q, err := ParseQuery(tt.query)
+			is.NoErr(err)
+
+			got, err := q.Rewrite(25, 100, 1000)
+			if tt.err != nil {
+				is.True(errors.Is(err, tt.err))
+				return
+			}
+
+			is.NoErr(err)
+			is.Equal(got, tt.want)
+
+			// the rewritten query is stable
+			q, err = ParseQuery(got)
+			is.NoErr(err)
+
+			again, err := q.Rewrite(25, 100, 1000)
+			is.NoErr(err)
+			is.Equal(again, got)
+		})
+	}
+}
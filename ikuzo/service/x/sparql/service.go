@@ -0,0 +1,462 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sparql
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/OneOfOne/xxhash"
+	"github.com/go-chi/chi"
+	"github.com/mailgun/groupcache"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	ErrNoEndpoint           = errors.New("sparql endpoint is required")
+	ErrMissingQuery         = errors.New("a value for the query parameter is required")
+	ErrUnsupportedMediaType = errors.New("unsupported content type for a sparql query")
+)
+
+const (
+	defaultTimeout      = 10 * time.Second
+	defaultLimit        = 25
+	defaultMaxLimit     = 1000
+	defaultCacheTTL     = time.Minute
+	defaultCacheSize    = 50 // MB
+	maxQuerySize        = 1 << 20
+	defaultSelectFormat = "application/sparql-results+json"
+	defaultGraphFormat  = "text/turtle"
+)
+
+// resultFormats are the media types that are passed through to the triple
+// store for SELECT and ASK queries.
+var resultFormats = []string{
+	"application/sparql-results+json",
+	"application/sparql-results+xml",
+	"text/csv",
+	"text/tab-separated-values",
+}
+
+// graphFormats are the media types that are passed through to the triple
+// store for CONSTRUCT and DESCRIBE queries.
+var graphFormats = []string{
+	"text/turtle",
+	"application/n-triples",
+	"application/ld+json",
+	"application/rdf+xml",
+}
+
+// groups counts the groupcache groups, because their names must be unique.
+var groups int32
+
+type Option func(*Service) error
+
+// Service is a read-only SPARQL 1.1 Protocol proxy for the triple store.
+type Service struct {
+	endpoint     string // The SPARQL query endpoint of the triple store
+	client       *Client
+	timeout      time.Duration // The maximum duration of a query. default: 10s
+	defaultLimit int           // The LIMIT of queries without one. default: 25
+	maxLimit     int           // The maximum LIMIT of a query. default: 1000
+	maxOffset    int           // The maximum OFFSET of a query. 0 is unbounded
+	cacheTTL     time.Duration // How long results are cached. 0 disables the cache. default: 1m
+	cacheSize    int64         // The size of the result cache in MB. default: 50
+	group        *groupcache.Group
+}
+
+// UpstreamError is returned when the triple store does not respond with results.
+type UpstreamError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *UpstreamError) Error() string {
+	return fmt.Sprintf("sparql endpoint responded with status %d: %s", e.StatusCode, e.Message)
+}
+
+// SetEndpoint sets the SPARQL query endpoint of the triple store.
+func SetEndpoint(endpoint string) Option {
+	return func(s *Service) error {
+		s.endpoint = endpoint
+		return nil
+	}
+}
+
+// SetClient sets the http.Client that is used to query the triple store.
+func SetClient(client *http.Client) Option {
+	return func(s *Service) error {
+		s.client.HTTPClient = client
+		return nil
+	}
+}
+
+// SetTimeout sets the maximum duration of a query. Clients can request a
+// shorter timeout with the 'timeout' parameter in seconds.
+func SetTimeout(timeout time.Duration) Option {
+	return func(s *Service) error {
+		s.timeout = timeout
+		return nil
+	}
+}
+
+// SetLimits sets the LIMIT for queries without one, the maximum LIMIT and the
+// maximum OFFSET of queries. A zero value disables the limit.
+func SetLimits(defaultLimit, maxLimit, maxOffset int) Option {
+	return func(s *Service) error {
+		s.defaultLimit = defaultLimit
+		s.maxLimit = maxLimit
+		s.maxOffset = maxOffset
+
+		return nil
+	}
+}
+
+// SetCache sets how long query results are cached and the size of the cache in MB.
+// A zero ttl disables the cache.
+func SetCache(ttl time.Duration, sizeMB int64) Option {
+	return func(s *Service) error {
+		s.cacheTTL = ttl
+
+		if sizeMB > 0 {
+			s.cacheSize = sizeMB
+		}
+
+		return nil
+	}
+}
+
+func NewService(options ...Option) (*Service, error) {
+	s := &Service{
+		timeout:      defaultTimeout,
+		defaultLimit: defaultLimit,
+		maxLimit:     defaultMaxLimit,
+		cacheTTL:     defaultCacheTTL,
+		cacheSize:    defaultCacheSize,
+		client:       &Client{},
+	}
+
+	// apply options
+	for _, option := range options {
+		if err := option(s); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.endpoint == "" {
+		return nil, ErrNoEndpoint
+	}
+
+	if s.cacheTTL > 0 {
+		name := "sparqlRemote"
+		if n := atomic.AddInt32(&groups, 1); n > 1 {
+			name = fmt.Sprintf("%s-%d", name, n)
+		}
+
+		s.group = groupcache.NewGroup(name, s.cacheSize*1024*1024, groupcache.GetterFunc(s.retrieveFromEndpoint))
+	}
+
+	return s, nil
+}
+
+// RegisterRoutes registers the SPARQL endpoint on the router.
+func (s *Service) RegisterRoutes(r chi.Router) {
+	r.Get("/sparql", s.Query)
+	r.Post("/sparql", s.Query)
+}
+
+// queryRequest is a query that is sent to the triple store.
+type queryRequest struct {
+	query  string
+	accept string
+	graphs url.Values // default-graph-uri and named-graph-uri
+}
+
+// key returns the cache key of the request.
+func (qr *queryRequest) key() string {
+	hash := xxhash.New64()
+	_, _ = hash.WriteString(qr.accept + "\n" + qr.graphs.Encode() + "\n" + qr.query)
+
+	return fmt.Sprintf("%016x", hash.Sum64())
+}
+
+// result is the response of the triple store.
+type result struct {
+	contentType string
+	body        []byte
+}
+
+func (res *result) marshal() []byte {
+	return append([]byte(res.contentType+"\n"), res.body...)
+}
+
+func unmarshalResult(b []byte) *result {
+	i := bytes.IndexByte(b, '\n')
+	if i == -1 {
+		return &result{body: b}
+	}
+
+	return &result{contentType: string(b[:i]), body: b[i+1:]}
+}
+
+// parseRequest returns the query and the graph parameters of a SPARQL Protocol request.
+func parseRequest(r *http.Request) (string, url.Values, error) {
+	params := r.URL.Query()
+
+	if r.Method == http.MethodPost {
+		contentType := strings.TrimSpace(strings.Split(r.Header.Get("Content-Type"), ";")[0])
+
+		switch contentType {
+		case "application/x-www-form-urlencoded":
+			r.Body = http.MaxBytesReader(nil, r.Body, maxQuerySize)
+			if err := r.ParseForm(); err != nil {
+				return "", nil, fmt.Errorf("%w; %s", ErrInvalidQuery, err)
+			}
+
+			params = r.Form
+		case "application/sparql-query":
+			body, err := ioutil.ReadAll(http.MaxBytesReader(nil, r.Body, maxQuerySize))
+			if err != nil {
+				return "", nil, fmt.Errorf("%w; %s", ErrInvalidQuery, err)
+			}
+
+			params.Set("query", string(body))
+		case "application/sparql-update":
+			return "", nil, ErrUpdateNotAllowed
+		default:
+			return "", nil, fmt.Errorf("%w: %s", ErrUnsupportedMediaType, contentType)
+		}
+	}
+
+	if params.Get("update") != "" {
+		return "", nil, ErrUpdateNotAllowed
+	}
+
+	query := params.Get("query")
+	if strings.TrimSpace(query) == "" {
+		return "", nil, ErrMissingQuery
+	}
+
+	graphs := url.Values{}
+
+	for _, key := range []string{"default-graph-uri", "named-graph-uri"} {
+		if values, ok := params[key]; ok {
+			graphs[key] = values
+		}
+	}
+
+	return query, graphs, nil
+}
+
+// negotiate returns the media type from the Accept header that the triple store
+// supports for the query form, or the default for the query form.
+func negotiate(accept string, form QueryForm) string {
+	supported, fallback := resultFormats, defaultSelectFormat
+	if form == Construct || form == Describe {
+		supported, fallback = graphFormats, defaultGraphFormat
+	}
+
+	best, bestQ := "", 0.0
+
+	for _, part := range strings.Split(accept, ",") {
+		params := strings.Split(part, ";")
+		mediaType := strings.ToLower(strings.TrimSpace(params[0]))
+
+		if mediaType == "application/json" {
+			mediaType = defaultSelectFormat
+		}
+
+		q := 1.0
+
+		for _, param := range params[1:] {
+			kv := strings.SplitN(strings.TrimSpace(param), "=", 2)
+			if len(kv) == 2 && kv[0] == "q" {
+				fmt.Sscanf(kv[1], "%g", &q) // nolint:errcheck // invalid values keep the default quality
+			}
+		}
+
+		for _, mt := range supported {
+			if mt == mediaType && q > bestQ {
+				best, bestQ = mt, q
+			}
+		}
+	}
+
+	if best == "" {
+		return fallback
+	}
+
+	return best
+}
+
+// timeoutFor returns the timeout of the request.
+func (s *Service) timeoutFor(r *http.Request) time.Duration {
+	timeout := s.timeout
+
+	if seconds, err := time.ParseDuration(r.URL.Query().Get("timeout") + "s"); err == nil && seconds > 0 {
+		if timeout <= 0 || seconds < timeout {
+			timeout = seconds
+		}
+	}
+
+	return timeout
+}
+
+// Query handles SPARQL 1.1 Protocol query requests.
+func (s *Service) Query(w http.ResponseWriter, r *http.Request) {
+	query, graphs, err := parseRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), errorStatus(err))
+		return
+	}
+
+	q, err := ParseQuery(query)
+	if err != nil {
+		http.Error(w, err.Error(), errorStatus(err))
+		return
+	}
+
+	rewritten, err := q.Rewrite(s.defaultLimit, s.maxLimit, s.maxOffset)
+	if err != nil {
+		http.Error(w, err.Error(), errorStatus(err))
+		return
+	}
+
+	qr := &queryRequest{
+		query:  rewritten,
+		accept: negotiate(r.Header.Get("Accept"), q.Form),
+		graphs: graphs,
+	}
+
+	ctx := r.Context()
+
+	if timeout := s.timeoutFor(r); timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	res, err := s.do(ctx, qr)
+	if err != nil {
+		log.Warn().Err(err).Str("cmp", "sparql").Str("query", qr.query).Msg("unable to run sparql query")
+		http.Error(w, err.Error(), errorStatus(err))
+
+		return
+	}
+
+	if res.contentType != "" {
+		w.Header().Set("Content-Type", res.contentType)
+	}
+
+	w.Header().Set("Vary", "Accept")
+	_, _ = w.Write(res.body)
+}
+
+type queryCtxKey int
+
+var queryKey queryCtxKey
+
+// do returns the result of the query from the cache or the triple store.
+func (s *Service) do(ctx context.Context, qr *queryRequest) (*result, error) {
+	if s.group == nil {
+		return s.fetch(ctx, qr)
+	}
+
+	var data []byte
+
+	ctx = context.WithValue(ctx, queryKey, qr)
+
+	if err := s.group.Get(ctx, qr.key(), groupcache.AllocatingByteSliceSink(&data)); err != nil {
+		return nil, err
+	}
+
+	return unmarshalResult(data), nil
+}
+
+func (s *Service) retrieveFromEndpoint(gctx groupcache.Context, key string, dest groupcache.Sink) error {
+	ctx := gctx.(context.Context)
+	qr := ctx.Value(queryKey).(*queryRequest)
+
+	res, err := s.fetch(ctx, qr)
+	if err != nil {
+		return err
+	}
+
+	return dest.SetBytes(res.marshal(), time.Now().Add(s.cacheTTL))
+}
+
+// fetch sends the query to the triple store.
+func (s *Service) fetch(ctx context.Context, qr *queryRequest) (*result, error) {
+	form := url.Values{}
+	for key, values := range qr.graphs {
+		form[key] = values
+	}
+
+	form.Set("query", qr.query)
+
+	queryStart := time.Now()
+
+	resp, err := s.client.PostForm(ctx, s.endpoint, form, qr.accept)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug().
+		Str("cmp", "sparql").
+		Int("size", len(resp.Body)).
+		Dur("duration", time.Since(queryStart)).
+		Msg("sparql query")
+
+	return &result{contentType: resp.ContentType, body: resp.Body}, nil
+}
+
+// errorStatus returns the HTTP status that is returned to the client for the error.
+func errorStatus(err error) int {
+	var (
+		upstream *UpstreamError
+		netErr   net.Error
+	)
+
+	switch {
+	case errors.Is(err, ErrUpdateNotAllowed):
+		return http.StatusForbidden
+	case errors.Is(err, ErrInvalidQuery), errors.Is(err, ErrMissingQuery), errors.Is(err, ErrOffsetNotAllowed):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrUnsupportedMediaType):
+		return http.StatusUnsupportedMediaType
+	case errors.As(err, &upstream):
+		if upstream.StatusCode >= http.StatusBadRequest && upstream.StatusCode < http.StatusInternalServerError {
+			return upstream.StatusCode
+		}
+
+		return http.StatusBadGateway
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return http.StatusGatewayTimeout
+	case errors.As(err, &netErr):
+		return http.StatusBadGateway
+	}
+
+	return http.StatusInternalServerError
+}
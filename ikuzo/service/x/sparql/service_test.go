@@ -0,0 +1,304 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sparql
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/matryer/is"
+)
+
+// upstream is a fake triple store that echoes the received query.
+type upstream struct {
+	sync.Mutex
+	hits    int
+	queries []url.Values
+	accept  []string
+	delay   time.Duration
+	status  int
+}
+
+func (u *upstream) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+
+	u.Lock()
+	u.hits++
+	u.queries = append(u.queries, r.PostForm)
+	u.accept = append(u.accept, r.Header.Get("Accept"))
+	status, delay := u.status, u.delay
+	u.Unlock()
+
+	if delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	if status != 0 {
+		http.Error(w, "upstream error", status)
+		return
+	}
+
+	w.Header().Set("Content-Type", r.Header.Get("Accept"))
+	_, _ = w.Write([]byte(r.PostForm.Get("query")))
+}
+
+func (u *upstream) count() int {
+	u.Lock()
+	defer u.Unlock()
+
+	return u.hits
+}
+
+func newTestService(t *testing.T, u *upstream, options ...Option) *httptest.Server {
+	t.Helper()
+
+	ts := httptest.NewServer(u)
+	t.Cleanup(ts.Close)
+
+	svc, err := NewService(append([]Option{SetEndpoint(ts.URL), SetLimits(25, 100, 1000)}, options...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := chi.NewRouter()
+	svc.RegisterRoutes(r)
+
+	proxy := httptest.NewServer(r)
+	t.Cleanup(proxy.Close)
+
+	return proxy
+}
+
+func TestService_Query(t *testing.T) {
+	const selectQuery = "SELECT * WHERE { ?s ?p ?o } LIMIT 5000"
+
+	tests := []struct {
+		name        string
+		method      string
+		contentType string
+		body        string
+		params      url.Values
+		accept      string
+		status      int
+		wantAccept  string
+		wantQuery   string
+	}{
+		{
+			name:       "get with the default format",
+			method:     http.MethodGet,
+			params:     url.Values{"query": {selectQuery}},
+			status:     http.StatusOK,
+			wantAccept: "application/sparql-results+json",
+			wantQuery:  "SELECT * WHERE { ?s ?p ?o } LIMIT 100",
+		},
+		{
+			name:       "get with csv results",
+			method:     http.MethodGet,
+			params:     url.Values{"query": {selectQuery}},
+			accept:     "text/html;q=0.9, text/csv;q=0.8, text/tab-separated-values;q=0.5",
+			status:     http.StatusOK,
+			wantAccept: "text/csv",
+			wantQuery:  "SELECT * WHERE { ?s ?p ?o } LIMIT 100",
+		},
+		{
+			name:        "post form with xml results",
+			method:      http.MethodPost,
+			contentType: "application/x-www-form-urlencoded",
+			body:        url.Values{"query": {"SELECT * WHERE { ?s ?p ?o }"}}.Encode(),
+			accept:      "application/sparql-results+xml",
+			status:      http.StatusOK,
+			wantAccept:  "application/sparql-results+xml",
+			wantQuery:   "SELECT * WHERE { ?s ?p ?o } LIMIT 25",
+		},
+		{
+			name:        "post query with turtle results",
+			method:      http.MethodPost,
+			contentType: "application/sparql-query",
+			body:        "CONSTRUCT { ?s ?p ?o } WHERE { ?s ?p ?o }",
+			accept:      "text/turtle",
+			status:      http.StatusOK,
+			wantAccept:  "text/turtle",
+			wantQuery:   "CONSTRUCT { ?s ?p ?o } WHERE { ?s ?p ?o } LIMIT 25",
+		},
+		{
+			name:       "construct with an unsupported format",
+			method:     http.MethodGet,
+			params:     url.Values{"query": {"DESCRIBE <http://example.org/1>"}},
+			accept:     "application/sparql-results+json",
+			status:     http.StatusOK,
+			wantAccept: "text/turtle",
+			wantQuery:  "DESCRIBE <http://example.org/1> LIMIT 25",
+		},
+		{
+			name:   "update query",
+			method: http.MethodGet,
+			params: url.Values{"query": {"DROP ALL"}},
+			status: http.StatusForbidden,
+		},
+		{
+			name:        "update operation",
+			method:      http.MethodPost,
+			contentType: "application/x-www-form-urlencoded",
+			body:        url.Values{"update": {"DROP ALL"}}.Encode(),
+			status:      http.StatusForbidden,
+		},
+		{
+			name:        "update body",
+			method:      http.MethodPost,
+			contentType: "application/sparql-update",
+			body:        "DROP ALL",
+			status:      http.StatusForbidden,
+		},
+		{
+			name:        "unsupported content type",
+			method:      http.MethodPost,
+			contentType: "application/json",
+			body:        "{}",
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			name:   "missing query",
+			method: http.MethodGet,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "invalid query",
+			method: http.MethodGet,
+			params: url.Values{"query": {"SELECT * WHERE { ?s ?p ?o"}},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "offset above the maximum",
+			method: http.MethodGet,
+			params: url.Values{"query": {"SELECT * WHERE { ?s ?p ?o } OFFSET 5000"}},
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			u := &upstream{}
+			proxy := newTestService(t, u, SetCache(0, 0))
+
+			req, err := http.NewRequest(tt.method, proxy.URL+"/sparql?"+tt.params.Encode(), strings.NewReader(tt.body))
+			is.NoErr(err)
+
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			is.NoErr(err)
+			resp.Body.Close()
+
+			is.Equal(resp.StatusCode, tt.status)
+
+			if tt.status != http.StatusOK {
+				is.Equal(u.count(), 0) // rejected requests are not sent to the triple store
+				return
+			}
+
+			is.Equal(u.count(), 1)
+			is.Equal(u.accept[0], tt.wantAccept)
+			is.Equal(u.queries[0].Get("query"), tt.wantQuery)
+			is.Equal(resp.Header.Get("Content-Type"), tt.wantAccept)
+		})
+	}
+}
+
+func TestService_Query_cache(t *testing.T) {
+	is := is.New(t)
+
+	u := &upstream{}
+	proxy := newTestService(t, u)
+
+	get := func(query, accept string) string {
+		req, err := http.NewRequest(http.MethodGet, proxy.URL+"/sparql?"+url.Values{"query": {query}}.Encode(), nil)
+		is.NoErr(err)
+		req.Header.Set("Accept", accept)
+
+		resp, err := http.DefaultClient.Do(req)
+		is.NoErr(err)
+		defer resp.Body.Close()
+
+		is.Equal(resp.StatusCode, http.StatusOK)
+
+		return resp.Header.Get("Content-Type")
+	}
+
+	get("SELECT * WHERE { ?s ?p ?o }", "application/sparql-results+json")
+	// the normalized query is the same
+	get("select *\nwhere {\n  ?s ?p ?o\n} # comment", "application/sparql-results+json")
+	is.Equal(u.count(), 1)
+
+	// results are cached per format
+	is.Equal(get("SELECT * WHERE { ?s ?p ?o }", "text/csv"), "text/csv")
+	is.Equal(u.count(), 2)
+	is.Equal(get("SELECT * WHERE { ?s ?p ?o }", "application/sparql-results+json"), "application/sparql-results+json")
+	is.Equal(u.count(), 2)
+}
+
+func TestService_Query_upstreamErrors(t *testing.T) {
+	is := is.New(t)
+
+	u := &upstream{delay: time.Second}
+	proxy := newTestService(t, u, SetTimeout(50*time.Millisecond))
+
+	query := url.Values{"query": {"SELECT * WHERE { ?s ?p ?o }"}}.Encode()
+
+	resp, err := http.Get(proxy.URL + "/sparql?" + query)
+	is.NoErr(err)
+	resp.Body.Close()
+	is.Equal(resp.StatusCode, http.StatusGatewayTimeout)
+
+	u.Lock()
+	u.delay, u.status = 0, http.StatusInternalServerError
+	u.Unlock()
+
+	resp, err = http.Get(proxy.URL + "/sparql?" + query)
+	is.NoErr(err)
+	resp.Body.Close()
+	is.Equal(resp.StatusCode, http.StatusBadGateway)
+
+	u.Lock()
+	u.status = http.StatusBadRequest
+	u.Unlock()
+
+	resp, err = http.Get(proxy.URL + "/sparql?" + query)
+	is.NoErr(err)
+	resp.Body.Close()
+	is.Equal(resp.StatusCode, http.StatusBadRequest)
+
+	// errors are not cached
+	is.Equal(u.count(), 3)
+}
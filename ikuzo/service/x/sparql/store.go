@@ -0,0 +1,193 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sparql
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/delving/hub3/hub3/fragments"
+)
+
+const (
+	// SpecPredicate links a named graph to the spec of its dataset.
+	SpecPredicate = "http://schemas.delving.eu/nave/terms/datasetSpec"
+	// RevisionPredicate links a named graph to the revision of its dataset.
+	RevisionPredicate = "http://schemas.delving.eu/nave/terms/specRevision"
+)
+
+// Store is the storage interface for a SPARQL 1.1 triple store.
+//
+// Each triple store has its own implementation that deals with the
+// endpoint layout and quirks of the vendor.
+type Store interface {
+	// Query runs a SPARQL query and returns the results in the accept media type.
+	Query(ctx context.Context, query, accept string) (*Response, error)
+	// Update runs a SPARQL Update request.
+	Update(ctx context.Context, update string) error
+	// PutGraph replaces the named graph with the RDF in body.
+	PutGraph(ctx context.Context, graphURI, contentType string, body io.Reader) error
+	// DeleteGraph removes the named graph. Deleting a graph that does not exist is not an error.
+	DeleteGraph(ctx context.Context, graphURI string) error
+	// DropDataset removes all named graphs of the dataset.
+	DropDataset(ctx context.Context, spec string) error
+	// DropOrphans removes all named graphs of the dataset that are not of the revision.
+	DropOrphans(ctx context.Context, spec string, revision int) error
+}
+
+// Response is the response of a triple store.
+type Response struct {
+	ContentType string
+	Body        []byte
+}
+
+// Client sends SPARQL 1.1 Protocol requests to a triple store.
+// It is shared by the Store implementations.
+type Client struct {
+	HTTPClient *http.Client
+	Username   string
+	Password   string
+}
+
+// Do sends the request and returns an UpstreamError for non 2xx responses.
+func (c *Client) Do(req *http.Request) (*Response, error) {
+	if c.Username != "" {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, &UpstreamError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(body))}
+	}
+
+	return &Response{ContentType: resp.Header.Get("Content-Type"), Body: body}, nil
+}
+
+// PostForm sends the form as an application/x-www-form-urlencoded POST request.
+func (c *Client) PostForm(ctx context.Context, endpoint string, form url.Values, accept string) (*Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	return c.Do(req)
+}
+
+// Query sends the query to the SPARQL query endpoint.
+func (c *Client) Query(ctx context.Context, endpoint, query, accept string) (*Response, error) {
+	return c.PostForm(ctx, endpoint, url.Values{"query": {query}}, accept)
+}
+
+// Update sends the update to the SPARQL Update endpoint.
+func (c *Client) Update(ctx context.Context, endpoint, update string) error {
+	_, err := c.PostForm(ctx, endpoint, url.Values{"update": {update}}, "")
+	return err
+}
+
+// DropDatasetUpdate returns the SPARQL Update that removes all named graphs of the dataset.
+func DropDatasetUpdate(spec string) string {
+	return fmt.Sprintf(`DELETE { GRAPH ?g { ?s ?p ?o . } }
+WHERE {
+	GRAPH ?g { ?subject <%s> %q . }
+	GRAPH ?g { ?s ?p ?o . }
+}`, SpecPredicate, spec)
+}
+
+// DropOrphansUpdate returns the SPARQL Update that removes all named graphs of the
+// dataset that are not of the revision.
+func DropOrphansUpdate(spec string, revision int) string {
+	return fmt.Sprintf(`DELETE { GRAPH ?g { ?s ?p ?o . } }
+WHERE {
+	GRAPH ?g {
+		?subject <%s> %q ;
+			<%s> ?revision .
+		FILTER (?revision != %d)
+	}
+	GRAPH ?g { ?s ?p ?o . }
+}`, SpecPredicate, spec, RevisionPredicate, revision)
+}
+
+// DatasetGraphsQuery returns the SELECT query for the named graphs of the dataset.
+// When revision is not -1 only the graphs that are not of the revision are returned.
+func DatasetGraphsQuery(spec string, revision int) string {
+	filter := ""
+	if revision != -1 {
+		filter = fmt.Sprintf("; <%s> ?revision . FILTER (?revision != %d)", RevisionPredicate, revision)
+	}
+
+	return fmt.Sprintf("SELECT DISTINCT ?g WHERE { GRAPH ?g { ?subject <%s> %q %s } }", SpecPredicate, spec, filter)
+}
+
+// InsertGraphs replaces the named graphs of the updates in a single SPARQL
+// Update request and returns the number of triples that are stored.
+func InsertGraphs(ctx context.Context, store Store, updates ...fragments.SparqlUpdate) (int, error) {
+	if len(updates) == 0 {
+		return 0, nil
+	}
+
+	var (
+		drops   strings.Builder
+		inserts strings.Builder
+	)
+
+	triples := 0
+
+	for _, su := range updates {
+		count, err := su.TripleCount()
+		if err != nil {
+			return 0, fmt.Errorf("unable to count triples for %s; %w", su.NamedGraphURI, err)
+		}
+
+		triples += count
+
+		fmt.Fprintf(&drops, "DROP SILENT GRAPH <%s> ;\n", su.NamedGraphURI)
+		inserts.WriteString(su.String())
+		inserts.WriteString("\n")
+	}
+
+	update := fmt.Sprintf("%sINSERT DATA {\n%s}", drops.String(), inserts.String())
+
+	if err := store.Update(ctx, update); err != nil {
+		return 0, err
+	}
+
+	return triples, nil
+}
@@ -0,0 +1,154 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sparql
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/delving/hub3/hub3/fragments"
+	"github.com/matryer/is"
+)
+
+// updateStore records the updates.
+type updateStore struct {
+	updates []string
+}
+
+func (us *updateStore) Query(ctx context.Context, query, accept string) (*Response, error) {
+	return &Response{}, nil
+}
+
+func (us *updateStore) Update(ctx context.Context, update string) error {
+	us.updates = append(us.updates, update)
+	return nil
+}
+
+func (us *updateStore) PutGraph(ctx context.Context, graphURI, contentType string, body io.Reader) error {
+	return nil
+}
+
+func (us *updateStore) DeleteGraph(ctx context.Context, graphURI string) error {
+	return nil
+}
+
+func (us *updateStore) DropDataset(ctx context.Context, spec string) error {
+	return us.Update(ctx, DropDatasetUpdate(spec))
+}
+
+func (us *updateStore) DropOrphans(ctx context.Context, spec string, revision int) error {
+	return us.Update(ctx, DropOrphansUpdate(spec, revision))
+}
+
+func TestInsertGraphs(t *testing.T) {
+	is := is.New(t)
+
+	store := &updateStore{}
+	ctx := context.Background()
+
+	count, err := InsertGraphs(ctx, store)
+	is.NoErr(err)
+	is.Equal(count, 0)
+	is.Equal(len(store.updates), 0)
+
+	count, err = InsertGraphs(ctx, store,
+		fragments.SparqlUpdate{
+			Triples:       "<urn:1> <urn:p> \"a\" .\n<urn:1> <urn:p> \"b\" .",
+			NamedGraphURI: "urn:1/graph",
+			Spec:          "spec-1",
+			SpecRevision:  2,
+		},
+		fragments.SparqlUpdate{
+			Triples:       "<urn:2> <urn:p> \"c\" .",
+			NamedGraphURI: "urn:2/graph",
+			Spec:          "spec-1",
+			SpecRevision:  2,
+		},
+	)
+	is.NoErr(err)
+	is.Equal(count, 3)
+	is.Equal(len(store.updates), 1)
+
+	update := store.updates[0]
+	is.True(strings.HasPrefix(update, "DROP SILENT GRAPH <urn:1/graph> ;\nDROP SILENT GRAPH <urn:2/graph> ;\nINSERT DATA {"))
+	is.True(strings.Contains(update, "GRAPH <urn:2/graph> {"))
+	is.True(strings.Contains(update, `"2"^^<http://www.w3.org/2001/XMLSchema#integer>`))
+}
+
+func TestDatasetQueries(t *testing.T) {
+	is := is.New(t)
+
+	// the updates must not be accepted by the public endpoint
+	_, err := ParseQuery(DropDatasetUpdate("spec-1"))
+	is.True(errors.Is(err, ErrUpdateNotAllowed))
+
+	_, err = ParseQuery(DropOrphansUpdate("spec-1", 2))
+	is.True(errors.Is(err, ErrUpdateNotAllowed))
+
+	for _, revision := range []int{-1, 2} {
+		q, err := ParseQuery(DatasetGraphsQuery("spec-1", revision))
+		is.NoErr(err)
+		is.Equal(q.Form, Select)
+	}
+
+	is.True(strings.Contains(DatasetGraphsQuery("spec-1", 2), "FILTER (?revision != 2)"))
+	is.True(!strings.Contains(DatasetGraphsQuery("spec-1", -1), "FILTER"))
+}
+
+func TestClient(t *testing.T) {
+	is := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, _, ok := r.BasicAuth(); !ok || user != "hub3" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		_ = r.ParseForm()
+
+		if r.PostForm.Get("update") != "" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		w.Header().Set("Content-Type", r.Header.Get("Accept"))
+		_, _ = w.Write([]byte("s\nurn:1\n"))
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	c := &Client{Username: "hub3", Password: "secret"}
+
+	resp, err := c.Query(ctx, ts.URL, "SELECT ?s WHERE { ?s ?p ?o }", "text/csv")
+	is.NoErr(err)
+	is.Equal(resp.ContentType, "text/csv")
+	is.Equal(string(resp.Body), "s\nurn:1\n")
+
+	is.NoErr(c.Update(ctx, ts.URL, "CLEAR ALL"))
+
+	c.Username = ""
+
+	var upstream *UpstreamError
+
+	err = c.Update(ctx, ts.URL, "CLEAR ALL")
+	is.True(errors.As(err, &upstream))
+	is.Equal(upstream.StatusCode, http.StatusUnauthorized)
+	is.Equal(upstream.Message, "unauthorized")
+}
@@ -0,0 +1,156 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/delving/hub3/ikuzo/domain"
+	"github.com/delving/hub3/ikuzo/service/x/dataset"
+)
+
+// compile time check to see if full interface is implemented
+var _ dataset.Store = (*DataSetStore)(nil)
+
+type dataSetKey struct {
+	orgID domain.OrganizationID
+	id    string
+}
+
+type DataSetStore struct {
+	shutdownCalled bool
+	rw             sync.RWMutex
+	datasets       map[dataSetKey]dataset.DataSet
+}
+
+func NewDataSetStore() *DataSetStore {
+	return &DataSetStore{
+		datasets: map[dataSetKey]dataset.DataSet{},
+	}
+}
+
+func (ms *DataSetStore) Delete(ctx context.Context, orgID domain.OrganizationID, id string) error {
+	ms.rw.Lock()
+	defer ms.rw.Unlock()
+	delete(ms.datasets, dataSetKey{orgID: orgID, id: id})
+
+	return nil
+}
+
+func (ms *DataSetStore) Get(ctx context.Context, orgID domain.OrganizationID, id string) (dataset.DataSet, error) {
+	ms.rw.RLock()
+	defer ms.rw.RUnlock()
+
+	ds, ok := ms.datasets[dataSetKey{orgID: orgID, id: id}]
+	if !ok {
+		return dataset.DataSet{}, dataset.ErrDataSetNotFound
+	}
+
+	return ds, nil
+}
+
+func (ms *DataSetStore) Filter(ctx context.Context, orgID domain.OrganizationID, filter ...dataset.Filter) ([]dataset.DataSet, error) {
+	ms.rw.RLock()
+	defer ms.rw.RUnlock()
+
+	datasets := []dataset.DataSet{}
+
+	for key, ds := range ms.datasets {
+		if key.orgID == orgID {
+			datasets = append(datasets, ds)
+		}
+	}
+
+	sort.Slice(datasets, func(i, j int) bool {
+		return datasets[i].ID < datasets[j].ID
+	})
+
+	if len(filter) != 0 {
+		f := filter[0]
+
+		if f.OffSet >= len(datasets) {
+			return []dataset.DataSet{}, nil
+		}
+
+		datasets = datasets[f.OffSet:]
+
+		if f.Limit > 0 && f.Limit < len(datasets) {
+			datasets = datasets[:f.Limit]
+		}
+	}
+
+	return datasets, nil
+}
+
+func (ms *DataSetStore) Put(ctx context.Context, ds dataset.DataSet) error {
+	ms.rw.Lock()
+	defer ms.rw.Unlock()
+	ms.datasets[dataSetKey{orgID: ds.OrgID, id: ds.ID}] = ds
+
+	return nil
+}
+
+func (ms *DataSetStore) IncrementRevision(ctx context.Context, orgID domain.OrganizationID, id string) (int, error) {
+	ms.rw.Lock()
+	defer ms.rw.Unlock()
+
+	key := dataSetKey{orgID: orgID, id: id}
+
+	ds, ok := ms.datasets[key]
+	if !ok {
+		return 0, dataset.ErrDataSetNotFound
+	}
+
+	ds.Revision++
+	ms.datasets[key] = ds
+
+	return ds.Revision, nil
+}
+
+func (ms *DataSetStore) Update(ctx context.Context, orgID domain.OrganizationID, id string, update dataset.Update) error {
+	ms.rw.Lock()
+	defer ms.rw.Unlock()
+
+	key := dataSetKey{orgID: orgID, id: id}
+
+	ds, ok := ms.datasets[key]
+	if !ok {
+		return dataset.ErrDataSetNotFound
+	}
+
+	if update.Access != nil {
+		ds.Access = *update.Access
+	}
+
+	if update.Metadata != nil {
+		ds.Metadata = *update.Metadata
+	}
+
+	if update.Stats != nil {
+		ds.Stats = *update.Stats
+	}
+
+	ds.Modified = update.Modified
+	ms.datasets[key] = ds
+
+	return nil
+}
+
+func (ms *DataSetStore) Shutdown(ctx context.Context) error {
+	ms.shutdownCalled = true
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// nolint:gocritic
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/delving/hub3/ikuzo/service/x/dataset"
+	"github.com/matryer/is"
+)
+
+func TestDataSetStore(t *testing.T) {
+	is := is.New(t)
+	ctx := context.TODO()
+
+	store := NewDataSetStore()
+	datasets, err := store.Filter(ctx, "demo")
+	is.NoErr(err)
+	is.Equal(len(datasets), 0)
+
+	// the same id can be used by different organizations
+	for _, ds := range []dataset.DataSet{
+		{OrgID: "demo", ID: "b"},
+		{OrgID: "demo", ID: "a"},
+		{OrgID: "demo", ID: "c"},
+		{OrgID: "other", ID: "a"},
+	} {
+		is.NoErr(store.Put(ctx, ds))
+	}
+
+	datasets, err = store.Filter(ctx, "demo")
+	is.NoErr(err)
+	is.Equal(len(datasets), 3)
+	is.Equal(datasets[0].ID, "a")
+	is.Equal(datasets[2].ID, "c")
+
+	// offset and limit
+	datasets, err = store.Filter(ctx, "demo", dataset.Filter{OffSet: 1, Limit: 1})
+	is.NoErr(err)
+	is.Equal(len(datasets), 1)
+	is.Equal(datasets[0].ID, "b")
+
+	datasets, err = store.Filter(ctx, "demo", dataset.Filter{OffSet: 5})
+	is.NoErr(err)
+	is.Equal(len(datasets), 0)
+
+	// increment revision
+	revision, err := store.IncrementRevision(ctx, "demo", "a")
+	is.NoErr(err)
+	is.Equal(revision, 1)
+
+	ds, err := store.Get(ctx, "other", "a")
+	is.NoErr(err)
+	is.Equal(ds.Revision, 0)
+
+	_, err = store.IncrementRevision(ctx, "demo", "unknown")
+	is.True(errors.Is(err, dataset.ErrDataSetNotFound))
+
+	// delete a dataset
+	is.NoErr(store.Delete(ctx, "demo", "a"))
+
+	_, err = store.Get(ctx, "demo", "a")
+	is.True(errors.Is(err, dataset.ErrDataSetNotFound))
+
+	_, err = store.Get(ctx, "other", "a")
+	is.NoErr(err)
+
+	is.NoErr(store.Shutdown(ctx))
+	is.True(store.shutdownCalled)
+}
@@ -43,6 +43,9 @@ func NewNameSpaceStore() *NameSpaceStore {
 // Len returns the number of stored namespaces.
 // Alternatives Base or Prefixes don't count towards the total.
 func (ms *NameSpaceStore) Len() int {
+	ms.RLock()
+	defer ms.RUnlock()
+
 	return len(ms.namespaces)
 }
 
@@ -132,6 +135,9 @@ func (ms *NameSpaceStore) GetWithBase(base string) (*domain.NameSpace, error) {
 // List returns a list of all the stored NameSpace objects.
 // An error is only returned when the underlying datastructure is unavailable.
 func (ms *NameSpaceStore) List() ([]*domain.NameSpace, error) {
+	ms.RLock()
+	defer ms.RUnlock()
+
 	namespaces := []*domain.NameSpace{}
 	for _, ns := range ms.namespaces {
 		if ns != nil {
@@ -16,6 +16,7 @@ package memory
 
 import (
 	"context"
+	"sort"
 	"sync"
 
 	"github.com/delving/hub3/ikuzo/domain"
@@ -38,11 +39,17 @@ func NewOrganizationStore() *OrganizationStore {
 }
 
 func (ms *OrganizationStore) Delete(ctx context.Context, id domain.OrganizationID) error {
+	ms.rw.Lock()
+	defer ms.rw.Unlock()
 	delete(ms.organizations, id)
+
 	return nil
 }
 
 func (ms *OrganizationStore) Get(ctx context.Context, id domain.OrganizationID) (domain.Organization, error) {
+	ms.rw.RLock()
+	defer ms.rw.RUnlock()
+
 	org, ok := ms.organizations[id]
 	if !ok {
 		return domain.Organization{}, domain.ErrOrgNotFound
@@ -52,11 +59,32 @@ func (ms *OrganizationStore) Get(ctx context.Context, id domain.OrganizationID)
 }
 
 func (ms *OrganizationStore) Filter(ctx context.Context, filter ...domain.OrganizationFilter) ([]domain.Organization, error) {
+	ms.rw.RLock()
+	defer ms.rw.RUnlock()
+
 	organizations := []domain.Organization{}
 	for _, org := range ms.organizations {
 		organizations = append(organizations, org)
 	}
 
+	sort.Slice(organizations, func(i, j int) bool {
+		return organizations[i].ID < organizations[j].ID
+	})
+
+	if len(filter) != 0 {
+		f := filter[0]
+
+		if f.OffSet >= len(organizations) {
+			return []domain.Organization{}, nil
+		}
+
+		organizations = organizations[f.OffSet:]
+
+		if f.Limit > 0 && f.Limit < len(organizations) {
+			organizations = organizations[:f.Limit]
+		}
+	}
+
 	return organizations, nil
 }
 
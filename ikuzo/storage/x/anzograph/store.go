@@ -0,0 +1,193 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anzograph
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/delving/hub3/ikuzo/service/x/sparql"
+)
+
+// compile time check to see if full interface is implemented
+var _ sparql.Store = (*Store)(nil)
+
+var (
+	ErrNoEndpoint             = errors.New("anzograph endpoint is required")
+	ErrUnsupportedContentType = errors.New("anzograph graphs can only be stored from N-Triples or Turtle")
+)
+
+type Option func(*Store) error
+
+// Store is the sparql.Store for AnzoGraph.
+//
+// AnzoGraph serves queries and updates from a single SPARQL endpoint. Graphs
+// are written with SPARQL Update and dropped as a whole, which is much cheaper
+// in AnzoGraph than deleting their triples.
+type Store struct {
+	client   sparql.Client
+	endpoint string // The SPARQL endpoint, e.g. http://localhost:7070/sparql
+}
+
+// SetEndpoint sets the SPARQL endpoint.
+func SetEndpoint(endpoint string) Option {
+	return func(s *Store) error {
+		s.endpoint = endpoint
+		return nil
+	}
+}
+
+// SetClient sets the http.Client that is used to connect to AnzoGraph.
+func SetClient(client *http.Client) Option {
+	return func(s *Store) error {
+		s.client.HTTPClient = client
+		return nil
+	}
+}
+
+// SetBasicAuth sets the credentials for the AnzoGraph endpoint.
+func SetBasicAuth(username, password string) Option {
+	return func(s *Store) error {
+		s.client.Username = username
+		s.client.Password = password
+
+		return nil
+	}
+}
+
+func NewStore(options ...Option) (*Store, error) {
+	s := &Store{}
+
+	// apply options
+	for _, option := range options {
+		if err := option(s); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.endpoint == "" {
+		return nil, ErrNoEndpoint
+	}
+
+	return s, nil
+}
+
+func (s *Store) Query(ctx context.Context, query, accept string) (*sparql.Response, error) {
+	return s.client.Query(ctx, s.endpoint, query, accept)
+}
+
+// Update posts the update directly as an application/sparql-update body.
+func (s *Store) Update(ctx context.Context, update string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, strings.NewReader(update))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/sparql-update")
+
+	_, err = s.client.Do(req)
+
+	return err
+}
+
+// PutGraph replaces the named graph with a SPARQL Update. The prefixes of Turtle
+// data are moved to the prologue of the update.
+func (s *Store) PutGraph(ctx context.Context, graphURI, contentType string, body io.Reader) error {
+	switch strings.TrimSpace(strings.Split(contentType, ";")[0]) {
+	case "application/n-triples", "text/plain", "text/turtle", "application/x-turtle":
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedContentType, contentType)
+	}
+
+	var prologue, data strings.Builder
+
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 5*1024*1024)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case strings.HasPrefix(line, "@prefix"), strings.HasPrefix(line, "@base"):
+			// '@prefix dc: <http://purl.org/dc/elements/1.1/> .' becomes 'PREFIX dc: <http://purl.org/dc/elements/1.1/>'
+			fields := strings.Fields(strings.TrimSuffix(line, "."))
+			prologue.WriteString(strings.ToUpper(fields[0][1:]) + " " + strings.Join(fields[1:], " ") + "\n")
+		default:
+			data.WriteString(scanner.Text())
+			data.WriteString("\n")
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	update := fmt.Sprintf("%sDROP SILENT GRAPH <%s> ;\nINSERT DATA { GRAPH <%s> {\n%s} }",
+		prologue.String(), graphURI, graphURI, data.String())
+
+	return s.Update(ctx, update)
+}
+
+func (s *Store) DeleteGraph(ctx context.Context, graphURI string) error {
+	return s.Update(ctx, fmt.Sprintf("DROP SILENT GRAPH <%s>", graphURI))
+}
+
+func (s *Store) DropDataset(ctx context.Context, spec string) error {
+	return s.dropGraphs(ctx, sparql.DatasetGraphsQuery(spec, -1))
+}
+
+func (s *Store) DropOrphans(ctx context.Context, spec string, revision int) error {
+	return s.dropGraphs(ctx, sparql.DatasetGraphsQuery(spec, revision))
+}
+
+// dropGraphs drops the named graphs that are returned by the query.
+func (s *Store) dropGraphs(ctx context.Context, query string) error {
+	resp, err := s.Query(ctx, query, "application/sparql-results+json")
+	if err != nil {
+		return err
+	}
+
+	var results struct {
+		Results struct {
+			Bindings []map[string]struct {
+				Value string `json:"value"`
+			} `json:"bindings"`
+		} `json:"results"`
+	}
+
+	if err := json.Unmarshal(resp.Body, &results); err != nil {
+		return fmt.Errorf("unable to decode anzograph results; %w", err)
+	}
+
+	drops := []string{}
+
+	for _, binding := range results.Results.Bindings {
+		if g, ok := binding["g"]; ok {
+			drops = append(drops, fmt.Sprintf("DROP SILENT GRAPH <%s>", g.Value))
+		}
+	}
+
+	if len(drops) == 0 {
+		return nil
+	}
+
+	return s.Update(ctx, strings.Join(drops, " ;\n"))
+}
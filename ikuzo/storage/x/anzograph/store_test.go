@@ -0,0 +1,106 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anzograph
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+// anzograph is a stand-in for the AnzoGraph SPARQL endpoint that records the updates.
+func anzograph(t *testing.T, updates *[]string) *httptest.Server {
+	t.Helper()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "admin" || pass != "secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		if r.Header.Get("Content-Type") == "application/sparql-update" {
+			body, _ := ioutil.ReadAll(r.Body)
+			*updates = append(*updates, string(body))
+
+			return
+		}
+
+		_ = r.ParseForm()
+
+		w.Header().Set("Content-Type", "application/sparql-results+json")
+
+		if strings.Contains(r.PostForm.Get("query"), "spec-2") {
+			_, _ = w.Write([]byte(`{"head": {"vars": ["g"]}, "results": {"bindings": []}}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"head": {"vars": ["g"]}, "results": {"bindings": [
+			{"g": {"type": "uri", "value": "http://example.org/1/graph"}},
+			{"g": {"type": "uri", "value": "http://example.org/2/graph"}}
+		]}}`))
+	}))
+
+	t.Cleanup(ts.Close)
+
+	return ts
+}
+
+func TestStore(t *testing.T) {
+	is := is.New(t)
+
+	updates := []string{}
+	ts := anzograph(t, &updates)
+
+	store, err := NewStore(SetEndpoint(ts.URL+"/sparql"), SetBasicAuth("admin", "secret"))
+	is.NoErr(err)
+
+	ctx := context.Background()
+
+	turtle := `@prefix dc: <http://purl.org/dc/elements/1.1/> .
+<http://example.org/1> dc:title "title" .
+`
+
+	is.NoErr(store.PutGraph(ctx, "http://example.org/1/graph", "text/turtle; charset=utf-8", strings.NewReader(turtle)))
+	is.Equal(updates[0], `PREFIX dc: <http://purl.org/dc/elements/1.1/>
+DROP SILENT GRAPH <http://example.org/1/graph> ;
+INSERT DATA { GRAPH <http://example.org/1/graph> {
+<http://example.org/1> dc:title "title" .
+} }`)
+
+	err = store.PutGraph(ctx, "http://example.org/1/graph", "application/rdf+xml", strings.NewReader(""))
+	is.True(errors.Is(err, ErrUnsupportedContentType))
+
+	is.NoErr(store.DeleteGraph(ctx, "http://example.org/1/graph"))
+	is.Equal(updates[1], "DROP SILENT GRAPH <http://example.org/1/graph>")
+
+	// the graphs of the dataset are selected and dropped
+	is.NoErr(store.DropOrphans(ctx, "spec-1", 3))
+	is.Equal(updates[2], "DROP SILENT GRAPH <http://example.org/1/graph> ;\nDROP SILENT GRAPH <http://example.org/2/graph>")
+
+	// no update without graphs
+	is.NoErr(store.DropDataset(ctx, "spec-2"))
+	is.Equal(len(updates), 3)
+
+	// credentials are required
+	store, err = NewStore(SetEndpoint(ts.URL + "/sparql"))
+	is.NoErr(err)
+	is.True(store.DeleteGraph(ctx, "http://example.org/1/graph") != nil)
+}
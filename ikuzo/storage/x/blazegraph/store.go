@@ -0,0 +1,149 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blazegraph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/delving/hub3/ikuzo/service/x/sparql"
+)
+
+// compile time check to see if full interface is implemented
+var _ sparql.Store = (*Store)(nil)
+
+var ErrNoEndpoint = errors.New("blazegraph namespace URL or endpoint is required")
+
+type Option func(*Store) error
+
+// Store is the sparql.Store for Blazegraph.
+//
+// Blazegraph serves queries, updates and graph operations from the single
+// SPARQL endpoint of a namespace. The namespace must be created in quads mode
+// to support named graphs.
+type Store struct {
+	client   sparql.Client
+	endpoint string // The SPARQL endpoint of the namespace
+}
+
+// SetNamespaceURL sets the endpoint from the URL of the Blazegraph namespace,
+// e.g. 'http://localhost:9999/blazegraph/namespace/hub3'.
+func SetNamespaceURL(namespaceURL string) Option {
+	return func(s *Store) error {
+		s.endpoint = strings.TrimSuffix(namespaceURL, "/") + "/sparql"
+		return nil
+	}
+}
+
+// SetEndpoint sets the SPARQL endpoint of the namespace.
+func SetEndpoint(endpoint string) Option {
+	return func(s *Store) error {
+		s.endpoint = endpoint
+		return nil
+	}
+}
+
+// SetClient sets the http.Client that is used to connect to Blazegraph.
+func SetClient(client *http.Client) Option {
+	return func(s *Store) error {
+		s.client.HTTPClient = client
+		return nil
+	}
+}
+
+// SetBasicAuth sets the credentials for the Blazegraph endpoint.
+func SetBasicAuth(username, password string) Option {
+	return func(s *Store) error {
+		s.client.Username = username
+		s.client.Password = password
+
+		return nil
+	}
+}
+
+func NewStore(options ...Option) (*Store, error) {
+	s := &Store{}
+
+	// apply options
+	for _, option := range options {
+		if err := option(s); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.endpoint == "" {
+		return nil, ErrNoEndpoint
+	}
+
+	return s, nil
+}
+
+func (s *Store) Query(ctx context.Context, query, accept string) (*sparql.Response, error) {
+	return s.client.Query(ctx, s.endpoint, query, accept)
+}
+
+func (s *Store) Update(ctx context.Context, update string) error {
+	return s.client.Update(ctx, s.endpoint, update)
+}
+
+// PutGraph replaces the named graph. Blazegraph does not implement the Graph
+// Store Protocol, so the graph is deleted and the data is posted to the
+// REST API with the graph as 'context-uri'.
+func (s *Store) PutGraph(ctx context.Context, graphURI, contentType string, body io.Reader) error {
+	if err := s.DeleteGraph(ctx, graphURI); err != nil {
+		return err
+	}
+
+	u := fmt.Sprintf("%s?%s", s.endpoint, url.Values{"context-uri": {graphURI}}.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", contentType)
+
+	_, err = s.client.Do(req)
+
+	return err
+}
+
+// DeleteGraph removes the named graph with a REST API DELETE. The graph is
+// given as an N-Triples IRI in the 'c' parameter.
+func (s *Store) DeleteGraph(ctx context.Context, graphURI string) error {
+	u := fmt.Sprintf("%s?%s", s.endpoint, url.Values{"c": {"<" + graphURI + ">"}}.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, u, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(req)
+
+	return err
+}
+
+func (s *Store) DropDataset(ctx context.Context, spec string) error {
+	return s.Update(ctx, sparql.DropDatasetUpdate(spec))
+}
+
+func (s *Store) DropOrphans(ctx context.Context, spec string, revision int) error {
+	return s.Update(ctx, sparql.DropOrphansUpdate(spec, revision))
+}
@@ -0,0 +1,109 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blazegraph
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+type request struct {
+	method      string
+	params      url.Values
+	contentType string
+	body        string
+}
+
+// blazegraph is a stand-in for the SPARQL endpoint of a Blazegraph namespace.
+func blazegraph(t *testing.T, requests *[]request) *httptest.Server {
+	t.Helper()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/blazegraph/namespace/hub3/sparql" {
+			http.NotFound(w, r)
+			return
+		}
+
+		body, _ := ioutil.ReadAll(r.Body)
+		req := request{
+			method:      r.Method,
+			params:      r.URL.Query(),
+			contentType: r.Header.Get("Content-Type"),
+			body:        string(body),
+		}
+
+		if req.contentType == "application/x-www-form-urlencoded" {
+			req.params, _ = url.ParseQuery(req.body)
+		}
+
+		*requests = append(*requests, req)
+
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write([]byte(`<?xml version="1.0"?><data modified="0" milliseconds="1"/>`))
+	}))
+
+	t.Cleanup(ts.Close)
+
+	return ts
+}
+
+func TestStore(t *testing.T) {
+	is := is.New(t)
+
+	requests := []request{}
+	ts := blazegraph(t, &requests)
+
+	store, err := NewStore(SetNamespaceURL(ts.URL + "/blazegraph/namespace/hub3"))
+	is.NoErr(err)
+
+	ctx := context.Background()
+
+	_, err = store.Query(ctx, "SELECT * WHERE { ?s ?p ?o }", "text/csv")
+	is.NoErr(err)
+	is.Equal(requests[0].params.Get("query"), "SELECT * WHERE { ?s ?p ?o }")
+
+	is.NoErr(store.Update(ctx, "CLEAR ALL"))
+	is.Equal(requests[1].params.Get("update"), "CLEAR ALL")
+
+	graph := "http://example.org/resource/1/graph"
+	turtle := "<http://example.org/resource/1> <http://purl.org/dc/elements/1.1/title> \"title\" ."
+
+	// put is a delete of the context followed by a post with the context-uri
+	is.NoErr(store.PutGraph(ctx, graph, "text/turtle", strings.NewReader(turtle)))
+	is.Equal(requests[2].method, http.MethodDelete)
+	is.Equal(requests[2].params.Get("c"), "<"+graph+">")
+	is.Equal(requests[3].method, http.MethodPost)
+	is.Equal(requests[3].params.Get("context-uri"), graph)
+	is.Equal(requests[3].contentType, "text/turtle")
+	is.Equal(requests[3].body, turtle)
+
+	is.NoErr(store.DropOrphans(ctx, "spec-1", 3))
+	is.True(strings.Contains(requests[4].params.Get("update"), "FILTER (?revision != 3)"))
+
+	is.NoErr(store.DropDataset(ctx, "spec-1"))
+	is.True(strings.Contains(requests[5].params.Get("update"), `"spec-1"`))
+
+	// errors of the endpoint are returned
+	store, err = NewStore(SetEndpoint(ts.URL + "/blazegraph/namespace/unknown/sparql"))
+	is.NoErr(err)
+	is.True(store.Update(ctx, "CLEAR ALL") != nil)
+}
@@ -0,0 +1,171 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fuseki
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/delving/hub3/ikuzo/service/x/sparql"
+)
+
+// compile time check to see if full interface is implemented
+var _ sparql.Store = (*Store)(nil)
+
+var ErrNoEndpoint = errors.New("fuseki dataset URL or query and update endpoints are required")
+
+type Option func(*Store) error
+
+// Store is the sparql.Store for Apache Jena Fuseki.
+//
+// Fuseki exposes the query, update and Graph Store Protocol services as
+// separate endpoints of a dataset, e.g. http://localhost:3030/hub3/query.
+type Store struct {
+	client     sparql.Client
+	query      string // The SPARQL query endpoint
+	update     string // The SPARQL Update endpoint
+	graphStore string // The Graph Store Protocol endpoint
+}
+
+// SetDatasetURL sets the endpoints from the URL of the Fuseki dataset,
+// e.g. 'http://localhost:3030/hub3'.
+func SetDatasetURL(datasetURL string) Option {
+	return func(s *Store) error {
+		datasetURL = strings.TrimSuffix(datasetURL, "/")
+
+		s.query = datasetURL + "/query"
+		s.update = datasetURL + "/update"
+		s.graphStore = datasetURL + "/data"
+
+		return nil
+	}
+}
+
+// SetEndpoints sets the query, update and Graph Store Protocol endpoints.
+// Empty values are ignored.
+func SetEndpoints(query, update, graphStore string) Option {
+	return func(s *Store) error {
+		if query != "" {
+			s.query = query
+		}
+
+		if update != "" {
+			s.update = update
+		}
+
+		if graphStore != "" {
+			s.graphStore = graphStore
+		}
+
+		return nil
+	}
+}
+
+// SetClient sets the http.Client that is used to connect to Fuseki.
+func SetClient(client *http.Client) Option {
+	return func(s *Store) error {
+		s.client.HTTPClient = client
+		return nil
+	}
+}
+
+// SetBasicAuth sets the credentials for the Fuseki endpoints.
+func SetBasicAuth(username, password string) Option {
+	return func(s *Store) error {
+		s.client.Username = username
+		s.client.Password = password
+
+		return nil
+	}
+}
+
+func NewStore(options ...Option) (*Store, error) {
+	s := &Store{}
+
+	// apply options
+	for _, option := range options {
+		if err := option(s); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.query == "" || s.update == "" {
+		return nil, ErrNoEndpoint
+	}
+
+	// every Fuseki dataset has a Graph Store Protocol service next to the query service
+	if s.graphStore == "" {
+		s.graphStore = s.query[:strings.LastIndex(s.query, "/")] + "/data"
+	}
+
+	return s, nil
+}
+
+func (s *Store) Query(ctx context.Context, query, accept string) (*sparql.Response, error) {
+	return s.client.Query(ctx, s.query, query, accept)
+}
+
+func (s *Store) Update(ctx context.Context, update string) error {
+	return s.client.Update(ctx, s.update, update)
+}
+
+func (s *Store) graphURL(graphURI string) string {
+	return fmt.Sprintf("%s?%s", s.graphStore, url.Values{"graph": {graphURI}}.Encode())
+}
+
+// PutGraph replaces the named graph with a Graph Store Protocol PUT.
+func (s *Store) PutGraph(ctx context.Context, graphURI, contentType string, body io.Reader) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.graphURL(graphURI), body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", contentType)
+
+	_, err = s.client.Do(req)
+
+	return err
+}
+
+// DeleteGraph removes the named graph with a Graph Store Protocol DELETE.
+func (s *Store) DeleteGraph(ctx context.Context, graphURI string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.graphURL(graphURI), nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(req)
+
+	// Fuseki responds with 404 Not Found for unknown graphs
+	var upstream *sparql.UpstreamError
+	if errors.As(err, &upstream) && upstream.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	return err
+}
+
+func (s *Store) DropDataset(ctx context.Context, spec string) error {
+	return s.Update(ctx, sparql.DropDatasetUpdate(spec))
+}
+
+func (s *Store) DropOrphans(ctx context.Context, spec string, revision int) error {
+	return s.Update(ctx, sparql.DropOrphansUpdate(spec, revision))
+}
@@ -0,0 +1,139 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fuseki
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/delving/hub3/ikuzo/service/x/sparql"
+	"github.com/matryer/is"
+)
+
+type request struct {
+	method      string
+	path        string
+	query       string
+	contentType string
+	body        string
+	form        string
+}
+
+// fuseki is a stand-in for a Fuseki dataset that records the requests.
+func fuseki(t *testing.T, requests *[]request) *httptest.Server {
+	t.Helper()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		req := request{
+			method:      r.Method,
+			path:        r.URL.Path,
+			query:       r.URL.Query().Get("graph"),
+			contentType: r.Header.Get("Content-Type"),
+			body:        string(body),
+		}
+
+		if req.contentType == "application/x-www-form-urlencoded" {
+			r.Body = ioutil.NopCloser(strings.NewReader(req.body))
+			_ = r.ParseForm()
+			req.form = r.PostForm.Get("query") + r.PostForm.Get("update")
+		}
+
+		*requests = append(*requests, req)
+
+		switch {
+		case r.Method == http.MethodDelete && req.query == "http://example.org/unknown/graph":
+			http.Error(w, "No such graph", http.StatusNotFound)
+		case r.Method == http.MethodPut && req.contentType == "application/rdf+json":
+			http.Error(w, "unsupported", http.StatusUnsupportedMediaType)
+		case r.URL.Path == "/hub3/query":
+			w.Header().Set("Content-Type", r.Header.Get("Accept"))
+			_, _ = w.Write([]byte(`{"boolean": true}`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+
+	t.Cleanup(ts.Close)
+
+	return ts
+}
+
+func TestStore(t *testing.T) {
+	is := is.New(t)
+
+	requests := []request{}
+	ts := fuseki(t, &requests)
+
+	store, err := NewStore(SetDatasetURL(ts.URL + "/hub3/"))
+	is.NoErr(err)
+
+	ctx := context.Background()
+
+	resp, err := store.Query(ctx, "ASK { ?s ?p ?o }", "application/sparql-results+json")
+	is.NoErr(err)
+	is.Equal(resp.ContentType, "application/sparql-results+json")
+	is.Equal(string(resp.Body), `{"boolean": true}`)
+	is.Equal(requests[0].path, "/hub3/query")
+	is.Equal(requests[0].form, "ASK { ?s ?p ?o }")
+
+	is.NoErr(store.Update(ctx, "CLEAR ALL"))
+	is.Equal(requests[1].path, "/hub3/update")
+	is.Equal(requests[1].form, "CLEAR ALL")
+
+	graph := "http://example.org/resource/1/graph"
+	turtle := "<http://example.org/resource/1> <http://purl.org/dc/elements/1.1/title> \"title\" ."
+
+	is.NoErr(store.PutGraph(ctx, graph, "text/turtle", strings.NewReader(turtle)))
+	is.Equal(requests[2], request{method: http.MethodPut, path: "/hub3/data", query: graph, contentType: "text/turtle", body: turtle})
+
+	is.NoErr(store.DeleteGraph(ctx, graph))
+	is.Equal(requests[3].method, http.MethodDelete)
+	is.Equal(requests[3].query, graph)
+
+	// unknown graphs are not an error
+	is.NoErr(store.DeleteGraph(ctx, "http://example.org/unknown/graph"))
+
+	err = store.PutGraph(ctx, graph, "application/rdf+json", strings.NewReader("{}"))
+	is.True(err != nil)
+
+	is.NoErr(store.DropOrphans(ctx, "spec-1", 3))
+	last := requests[len(requests)-1]
+	is.Equal(last.path, "/hub3/update")
+	is.True(strings.Contains(last.form, `"spec-1"`))
+	is.True(strings.Contains(last.form, "FILTER (?revision != 3)"))
+
+	is.NoErr(store.DropDataset(ctx, "spec-1"))
+	last = requests[len(requests)-1]
+	is.True(strings.Contains(last.form, `<`+sparql.SpecPredicate+`> "spec-1"`))
+	is.True(!strings.Contains(last.form, "FILTER"))
+}
+
+func TestNewStore(t *testing.T) {
+	is := is.New(t)
+
+	_, err := NewStore()
+	is.Equal(err, ErrNoEndpoint)
+
+	// the graph store endpoint is derived from the query endpoint
+	store, err := NewStore(SetEndpoints("http://localhost:3030/hub3/sparql", "http://localhost:3030/hub3/update", ""))
+	is.NoErr(err)
+	is.Equal(store.graphStore, "http://localhost:3030/hub3/data")
+	is.Equal(store.graphURL("http://example.org/g"), "http://localhost:3030/hub3/data?graph=http%3A%2F%2Fexample.org%2Fg")
+}
@@ -0,0 +1,156 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gorm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/delving/hub3/ikuzo/domain"
+	"github.com/delving/hub3/ikuzo/service/x/dataset"
+	"github.com/jinzhu/gorm"
+)
+
+// compile time check to see if full interface is implemented
+var _ dataset.Store = (*DataSetStore)(nil)
+
+type DataSetStore struct {
+	db *gorm.DB
+}
+
+func NewDataSetStore(db *gorm.DB) (*DataSetStore, error) {
+	if db == nil {
+		return nil, fmt.Errorf("*gorm.DB cannot be nil")
+	}
+
+	db.AutoMigrate(dataset.DataSet{})
+
+	return &DataSetStore{db: db}, nil
+}
+
+func (d *DataSetStore) Delete(ctx context.Context, orgID domain.OrganizationID, id string) error {
+	return d.db.Delete(dataset.DataSet{}, "org_id = ? AND id = ?", orgID, id).Error
+}
+
+func (d *DataSetStore) Get(ctx context.Context, orgID domain.OrganizationID, id string) (dataset.DataSet, error) {
+	var ds dataset.DataSet
+
+	if err := d.db.Where("org_id = ? AND id = ?", orgID, id).First(&ds).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return ds, dataset.ErrDataSetNotFound
+		}
+
+		return ds, err
+	}
+
+	return ds, nil
+}
+
+func (d *DataSetStore) Filter(ctx context.Context, orgID domain.OrganizationID, filter ...dataset.Filter) ([]dataset.DataSet, error) {
+	datasets := []dataset.DataSet{}
+
+	q := d.db.Where("org_id = ?", orgID).Order("id")
+
+	if len(filter) != 0 {
+		f := filter[0]
+		if f.OffSet != 0 {
+			q = q.Offset(f.OffSet)
+		}
+
+		if f.Limit > 0 {
+			q = q.Limit(f.Limit)
+		}
+	}
+
+	if err := q.Find(&datasets).Error; err != nil {
+		return nil, err
+	}
+
+	return datasets, nil
+}
+
+func (d *DataSetStore) Put(ctx context.Context, ds dataset.DataSet) error {
+	return d.db.Save(&ds).Error
+}
+
+func (d *DataSetStore) IncrementRevision(ctx context.Context, orgID domain.OrganizationID, id string) (int, error) {
+	var revision int
+
+	err := d.db.Transaction(func(tx *gorm.DB) error {
+		q := tx.Model(&dataset.DataSet{}).
+			Where("org_id = ? AND id = ?", orgID, id).
+			UpdateColumn("revision", gorm.Expr("revision + ?", 1))
+		if q.Error != nil {
+			return q.Error
+		}
+
+		if q.RowsAffected == 0 {
+			return dataset.ErrDataSetNotFound
+		}
+
+		var ds dataset.DataSet
+		if err := tx.Where("org_id = ? AND id = ?", orgID, id).First(&ds).Error; err != nil {
+			return err
+		}
+
+		revision = ds.Revision
+
+		return nil
+	})
+
+	return revision, err
+}
+
+func (d *DataSetStore) Update(ctx context.Context, orgID domain.OrganizationID, id string, update dataset.Update) error {
+	// the keys are the struct field names, which gorm resolves to the columns
+	columns := map[string]interface{}{
+		"Modified": update.Modified,
+	}
+
+	if update.Access != nil {
+		columns["OAIPMH"] = update.Access.OAIPMH
+		columns["Search"] = update.Access.Search
+		columns["LOD"] = update.Access.LOD
+	}
+
+	if update.Metadata != nil {
+		columns["Metadata"] = *update.Metadata
+	}
+
+	if update.Stats != nil {
+		columns["Stats"] = *update.Stats
+	}
+
+	q := d.db.Model(&dataset.DataSet{}).
+		Where("org_id = ? AND id = ?", orgID, id).
+		UpdateColumns(columns)
+	if q.Error != nil {
+		return q.Error
+	}
+
+	if q.RowsAffected == 0 {
+		return dataset.ErrDataSetNotFound
+	}
+
+	return nil
+}
+
+func (d *DataSetStore) Shutdown(ctx context.Context) error {
+	if d.db != nil {
+		d.db.Close()
+	}
+
+	return nil
+}
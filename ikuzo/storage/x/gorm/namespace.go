@@ -0,0 +1,244 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gorm
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/delving/hub3/ikuzo/domain"
+	"github.com/delving/hub3/ikuzo/service/x/namespace"
+	"github.com/jinzhu/gorm"
+)
+
+// compile time check to see if full interface is implemented
+var _ namespace.Store = (*NameSpaceStore)(nil)
+
+const (
+	keyPrefix = "prefix"
+	keyBase   = "base"
+)
+
+// nameSpaceRecord is the database representation of a domain.NameSpace.
+type nameSpaceRecord struct {
+	UUID      string `gorm:"primary_key"`
+	Prefix    string
+	Base      string `gorm:"type:text"`
+	PrefixAlt string `gorm:"type:text"`
+	BaseAlt   string `gorm:"type:text"`
+	Schema    string `gorm:"type:text"`
+	Temporary bool
+	Usage     int
+}
+
+func (nameSpaceRecord) TableName() string {
+	return "namespaces"
+}
+
+// nameSpaceKey links each prefix and base-URI of a NameSpace, including the
+// alternatives, to the UUID of the NameSpace.
+type nameSpaceKey struct {
+	Kind  string `gorm:"primary_key"`
+	Value string `gorm:"primary_key;type:text"`
+	UUID  string `gorm:"index"`
+}
+
+func (nameSpaceKey) TableName() string {
+	return "namespace_keys"
+}
+
+// NameSpaceStore is a persistent namespace.Store.
+type NameSpaceStore struct {
+	db *gorm.DB
+}
+
+func NewNameSpaceStore(db *gorm.DB) (*NameSpaceStore, error) {
+	if db == nil {
+		return nil, fmt.Errorf("*gorm.DB cannot be nil")
+	}
+
+	db.AutoMigrate(nameSpaceRecord{}, nameSpaceKey{})
+
+	return &NameSpaceStore{db: db}, nil
+}
+
+func newNameSpaceRecord(ns *domain.NameSpace) (nameSpaceRecord, error) {
+	prefixAlt, err := json.Marshal(ns.PrefixAlt)
+	if err != nil {
+		return nameSpaceRecord{}, err
+	}
+
+	baseAlt, err := json.Marshal(ns.BaseAlt)
+	if err != nil {
+		return nameSpaceRecord{}, err
+	}
+
+	return nameSpaceRecord{
+		UUID:      ns.GetID(),
+		Prefix:    ns.Prefix,
+		Base:      ns.Base,
+		PrefixAlt: string(prefixAlt),
+		BaseAlt:   string(baseAlt),
+		Schema:    ns.Schema,
+		Temporary: ns.Temporary,
+		Usage:     ns.Usage,
+	}, nil
+}
+
+func (rec *nameSpaceRecord) nameSpace() (*domain.NameSpace, error) {
+	ns := &domain.NameSpace{
+		UUID:      rec.UUID,
+		Prefix:    rec.Prefix,
+		Base:      rec.Base,
+		Schema:    rec.Schema,
+		Temporary: rec.Temporary,
+		Usage:     rec.Usage,
+	}
+
+	if rec.PrefixAlt != "" {
+		if err := json.Unmarshal([]byte(rec.PrefixAlt), &ns.PrefixAlt); err != nil {
+			return nil, err
+		}
+	}
+
+	if rec.BaseAlt != "" {
+		if err := json.Unmarshal([]byte(rec.BaseAlt), &ns.BaseAlt); err != nil {
+			return nil, err
+		}
+	}
+
+	return ns, nil
+}
+
+// Set stores the NameSpace and links all its prefixes and base-URIs to it.
+// Like the memory store, a prefix or base-URI of another NameSpace is taken over.
+func (n *NameSpaceStore) Set(ns *domain.NameSpace) error {
+	if ns == nil {
+		return fmt.Errorf("cannot store empty namespace")
+	}
+
+	rec, err := newNameSpaceRecord(ns)
+	if err != nil {
+		return err
+	}
+
+	return n.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(nameSpaceKey{}, "uuid = ?", rec.UUID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Save(&rec).Error; err != nil {
+			return err
+		}
+
+		for kind, values := range map[string][]string{keyPrefix: ns.Prefixes(), keyBase: ns.BaseURIs()} {
+			for _, value := range values {
+				key := nameSpaceKey{Kind: kind, Value: value, UUID: rec.UUID}
+				if err := tx.Save(&key).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+// Delete removes the NameSpace. When the UUID is empty the NameSpace is matched by its Prefix.
+func (n *NameSpaceStore) Delete(ns *domain.NameSpace) error {
+	id := ns.UUID
+
+	if id == "" {
+		stored, err := n.GetWithPrefix(ns.Prefix)
+		if err != nil {
+			if err == domain.ErrNameSpaceNotFound {
+				return nil
+			}
+
+			return err
+		}
+
+		id = stored.UUID
+	}
+
+	return n.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(nameSpaceKey{}, "uuid = ?", id).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(nameSpaceRecord{}, "uuid = ?", id).Error
+	})
+}
+
+// Len returns the number of stored namespaces.
+func (n *NameSpaceStore) Len() int {
+	var count int
+
+	if err := n.db.Model(&nameSpaceRecord{}).Count(&count).Error; err != nil {
+		return 0
+	}
+
+	return count
+}
+
+func (n *NameSpaceStore) get(kind, value string) (*domain.NameSpace, error) {
+	var rec nameSpaceRecord
+
+	err := n.db.
+		Joins("JOIN namespace_keys ON namespace_keys.uuid = namespaces.uuid").
+		Where("namespace_keys.kind = ? AND namespace_keys.value = ?", kind, value).
+		First(&rec).Error
+	if err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return nil, domain.ErrNameSpaceNotFound
+		}
+
+		return nil, err
+	}
+
+	return rec.nameSpace()
+}
+
+// GetWithPrefix returns the NameSpace for the default or an alternative prefix.
+func (n *NameSpaceStore) GetWithPrefix(prefix string) (*domain.NameSpace, error) {
+	return n.get(keyPrefix, prefix)
+}
+
+// GetWithBase returns the NameSpace for the default or an alternative base-URI.
+func (n *NameSpaceStore) GetWithBase(base string) (*domain.NameSpace, error) {
+	return n.get(keyBase, base)
+}
+
+// List returns all stored namespaces.
+func (n *NameSpaceStore) List() ([]*domain.NameSpace, error) {
+	var records []nameSpaceRecord
+
+	if err := n.db.Order("prefix").Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	namespaces := make([]*domain.NameSpace, 0, len(records))
+
+	for i := range records {
+		ns, err := records[i].nameSpace()
+		if err != nil {
+			return nil, err
+		}
+
+		namespaces = append(namespaces, ns)
+	}
+
+	return namespaces, nil
+}
@@ -72,20 +72,18 @@ func (o *OrganizationStore) getFilter(filter ...domain.OrganizationFilter) *gorm
 			q = q.Limit(f.Limit)
 		}
 
-		if (f.Org != domain.Organization{}) {
-			q = q.Where(&f.Org)
+		if f.Org.ID != "" || f.Org.Description != "" {
+			q = q.Where(&domain.Organization{ID: f.Org.ID, Description: f.Org.Description})
 		}
-
-		o.db.Where(&filter[0])
 	}
 
 	return q
 }
 
 func (o *OrganizationStore) Filter(ctx context.Context, filter ...domain.OrganizationFilter) ([]domain.Organization, error) {
-	var orgs []domain.Organization
+	orgs := []domain.Organization{}
 
-	q := o.getFilter(filter...)
+	q := o.getFilter(filter...).Order("id")
 
 	if err := q.Find(&orgs).Error; err != nil {
 		return nil, err
@@ -0,0 +1,275 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sparqltest
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrResultsDiffer is returned when the actual results are not equivalent to the expected results.
+var ErrResultsDiffer = errors.New("results differ")
+
+// row is a solution or a triple as a list of terms.
+type row []Term
+
+// key returns the row as a string. When bnodes is false, all blank nodes are
+// replaced by the same label, so that rows can be matched before the blank
+// nodes are mapped.
+func (r row) key(bnodes bool) string {
+	parts := make([]string, len(r))
+
+	for i, t := range r {
+		if t.Kind == BlankNode && !bnodes {
+			parts[i] = "_:"
+			continue
+		}
+
+		parts[i] = t.String()
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func (r row) hasBlankNodes() bool {
+	for _, t := range r {
+		if t.Kind == BlankNode {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Compare returns an error when the actual results are not equivalent to the
+// expected results. Solutions and triples are compared without order and
+// blank nodes are compared by isomorphism, i.e. the blank nodes must map
+// one-to-one on each other.
+func Compare(expected, actual *Results) error {
+	if expected.Boolean != nil || actual.Boolean != nil {
+		if expected.Boolean == nil || actual.Boolean == nil {
+			return fmt.Errorf("%w: expected boolean %v, got %v", ErrResultsDiffer, expected.Boolean != nil, actual.Boolean != nil)
+		}
+
+		if *expected.Boolean != *actual.Boolean {
+			return fmt.Errorf("%w: expected %t, got %t", ErrResultsDiffer, *expected.Boolean, *actual.Boolean)
+		}
+
+		return nil
+	}
+
+	if expected.IsGraph != actual.IsGraph {
+		return fmt.Errorf("%w: expected graph %t, got graph %t", ErrResultsDiffer, expected.IsGraph, actual.IsGraph)
+	}
+
+	var want, got []row
+
+	if expected.IsGraph {
+		want, got = graphRows(expected.Graph), graphRows(actual.Graph)
+	} else {
+		variables := solutionVariables(expected, actual)
+		want, got = solutionRows(expected.Solutions, variables), solutionRows(actual.Solutions, variables)
+	}
+
+	if len(want) != len(got) {
+		return fmt.Errorf("%w: expected %d rows, got %d", ErrResultsDiffer, len(want), len(got))
+	}
+
+	return isomorphic(want, got)
+}
+
+// graphRows returns the distinct triples of the graph.
+func graphRows(graph []Triple) []row {
+	seen := map[Triple]bool{}
+	rows := []row{}
+
+	for _, t := range graph {
+		if seen[t] {
+			continue
+		}
+
+		seen[t] = true
+
+		rows = append(rows, row{t.Subject, t.Predicate, t.Object})
+	}
+
+	return rows
+}
+
+// solutionVariables returns the sorted names of all variables of both results.
+func solutionVariables(results ...*Results) []string {
+	seen := map[string]bool{}
+
+	for _, r := range results {
+		for _, v := range r.Variables {
+			seen[v] = true
+		}
+
+		for _, s := range r.Solutions {
+			for v := range s {
+				seen[v] = true
+			}
+		}
+	}
+
+	variables := make([]string, 0, len(seen))
+	for v := range seen {
+		variables = append(variables, v)
+	}
+
+	sort.Strings(variables)
+
+	return variables
+}
+
+func solutionRows(solutions []Solution, variables []string) []row {
+	rows := make([]row, 0, len(solutions))
+
+	for _, s := range solutions {
+		r := make(row, len(variables))
+		for i, v := range variables {
+			r[i] = s[v]
+		}
+
+		rows = append(rows, r)
+	}
+
+	return rows
+}
+
+// isomorphic compares rows of the same length. Rows without blank nodes are
+// compared as a multiset. The rows with blank nodes are matched by
+// backtracking over a one-to-one mapping of the blank nodes.
+func isomorphic(want, got []row) error {
+	ground := map[string]int{}
+
+	var wantBNodes, gotBNodes []row
+
+	for _, r := range want {
+		if r.hasBlankNodes() {
+			wantBNodes = append(wantBNodes, r)
+			continue
+		}
+
+		ground[r.key(true)]++
+	}
+
+	for _, r := range got {
+		if r.hasBlankNodes() {
+			gotBNodes = append(gotBNodes, r)
+			continue
+		}
+
+		k := r.key(true)
+		if ground[k] == 0 {
+			return fmt.Errorf("%w: unexpected %s", ErrResultsDiffer, k)
+		}
+
+		ground[k]--
+	}
+
+	for k, count := range ground {
+		if count > 0 {
+			return fmt.Errorf("%w: missing %s", ErrResultsDiffer, k)
+		}
+	}
+
+	if len(wantBNodes) != len(gotBNodes) {
+		return fmt.Errorf("%w: expected %d rows with blank nodes, got %d", ErrResultsDiffer, len(wantBNodes), len(gotBNodes))
+	}
+
+	m := &bnodeMap{to: map[string]string{}, from: map[string]string{}}
+	if !m.match(wantBNodes, gotBNodes, make([]bool, len(gotBNodes)), 0) {
+		return fmt.Errorf("%w: rows with blank nodes are not isomorphic", ErrResultsDiffer)
+	}
+
+	return nil
+}
+
+// bnodeMap is a one-to-one mapping between expected and actual blank nodes.
+type bnodeMap struct {
+	to   map[string]string
+	from map[string]string
+}
+
+// match maps want[i:] onto the unused rows of got.
+func (m *bnodeMap) match(want, got []row, used []bool, i int) bool {
+	if i == len(want) {
+		return true
+	}
+
+	signature := want[i].key(false)
+
+	for j, candidate := range got {
+		if used[j] || candidate.key(false) != signature {
+			continue
+		}
+
+		added, ok := m.bind(want[i], candidate)
+		if ok {
+			used[j] = true
+
+			if m.match(want, got, used, i+1) {
+				return true
+			}
+
+			used[j] = false
+		}
+
+		m.unbind(added)
+	}
+
+	return false
+}
+
+// bind maps the blank nodes of the rows and returns the blank nodes it added.
+// The rows must have the same signature.
+func (m *bnodeMap) bind(want, got row) ([]string, bool) {
+	added := []string{}
+
+	for i, t := range want {
+		if t.Kind != BlankNode {
+			continue
+		}
+
+		to, ok := m.to[t.Value]
+		if !ok {
+			if _, taken := m.from[got[i].Value]; taken {
+				return added, false
+			}
+
+			m.to[t.Value] = got[i].Value
+			m.from[got[i].Value] = t.Value
+			added = append(added, t.Value)
+
+			continue
+		}
+
+		if to != got[i].Value {
+			return added, false
+		}
+	}
+
+	return added, true
+}
+
+func (m *bnodeMap) unbind(added []string) {
+	for _, label := range added {
+		delete(m.from, m.to[label])
+		delete(m.to, label)
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sparqltest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestCompare(t *testing.T) {
+	yes, no := true, false
+
+	a, b, c := NewBlankNode("a"), NewBlankNode("b"), NewBlankNode("c")
+	x, y := NewBlankNode("x"), NewBlankNode("y")
+	p := NewIRI("http://example.org/p")
+	one := NewLiteral("1", "", "http://www.w3.org/2001/XMLSchema#integer")
+	two := NewLiteral("2", "", "http://www.w3.org/2001/XMLSchema#integer")
+
+	tests := []struct {
+		name     string
+		expected *Results
+		actual   *Results
+		wantErr  bool
+	}{
+		{
+			"same boolean",
+			&Results{Boolean: &yes},
+			&Results{Boolean: &yes},
+			false,
+		},
+		{
+			"different boolean",
+			&Results{Boolean: &yes},
+			&Results{Boolean: &no},
+			true,
+		},
+		{
+			"boolean and solutions",
+			&Results{Boolean: &yes},
+			&Results{Variables: []string{"x"}},
+			true,
+		},
+		{
+			"solutions in any order",
+			&Results{Solutions: []Solution{{"x": one}, {"x": two}}},
+			&Results{Solutions: []Solution{{"x": two}, {"x": one}}},
+			false,
+		},
+		{
+			"missing solution",
+			&Results{Solutions: []Solution{{"x": one}, {"x": two}}},
+			&Results{Solutions: []Solution{{"x": one}}},
+			true,
+		},
+		{
+			"duplicate solutions are counted",
+			&Results{Solutions: []Solution{{"x": one}, {"x": one}}},
+			&Results{Solutions: []Solution{{"x": one}, {"x": two}}},
+			true,
+		},
+		{
+			"unbound variable",
+			&Results{Variables: []string{"x", "y"}, Solutions: []Solution{{"x": one}}},
+			&Results{Variables: []string{"x", "y"}, Solutions: []Solution{{"x": one, "y": two}}},
+			true,
+		},
+		{
+			"xsd:string is a simple literal",
+			&Results{Solutions: []Solution{{"x": NewLiteral("a", "", "")}}},
+			&Results{Solutions: []Solution{{"x": NewLiteral("a", "", xsdString)}}},
+			false,
+		},
+		{
+			"blank nodes with other labels",
+			&Results{Solutions: []Solution{{"x": a, "y": one}, {"x": b, "y": two}}},
+			&Results{Solutions: []Solution{{"x": y, "y": one}, {"x": x, "y": two}}},
+			false,
+		},
+		{
+			"blank nodes are not one-to-one",
+			&Results{Solutions: []Solution{{"x": a}, {"x": b}}},
+			&Results{Solutions: []Solution{{"x": x}, {"x": x}}},
+			true,
+		},
+		{
+			"isomorphic graphs",
+			&Results{IsGraph: true, Graph: []Triple{{a, p, b}, {b, p, c}, {c, p, one}}},
+			&Results{IsGraph: true, Graph: []Triple{{y, p, a}, {x, p, y}, {a, p, one}}},
+			false,
+		},
+		{
+			"graphs that are not isomorphic",
+			&Results{IsGraph: true, Graph: []Triple{{a, p, b}, {b, p, c}, {c, p, one}}},
+			&Results{IsGraph: true, Graph: []Triple{{y, p, a}, {x, p, y}, {x, p, one}}},
+			true,
+		},
+		{
+			"graph and solutions",
+			&Results{IsGraph: true},
+			&Results{},
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			err := Compare(tt.expected, tt.actual)
+			if !tt.wantErr {
+				is.NoErr(err)
+				return
+			}
+
+			is.True(errors.Is(err, ErrResultsDiffer))
+		})
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sparqltest_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/delving/hub3/ikuzo/service/x/sparql"
+	"github.com/delving/hub3/ikuzo/storage/x/anzograph"
+	"github.com/delving/hub3/ikuzo/storage/x/blazegraph"
+	"github.com/delving/hub3/ikuzo/storage/x/fuseki"
+	"github.com/delving/hub3/ikuzo/storage/x/sparqltest"
+)
+
+// TestConformance runs the W3C SPARQL 1.1 test-suite against a live triple store.
+// All data in the store is removed. It is configured with environment variables:
+//
+//	SPARQLTEST_STORE     fuseki, blazegraph or anzograph
+//	SPARQLTEST_URL       the Fuseki dataset, Blazegraph namespace or AnzoGraph endpoint URL
+//	SPARQLTEST_MANIFEST  the manifest to run (default testdata/data-sparql11/manifest-all.ttl)
+//	SPARQLTEST_EARL      the path of the EARL report (optional)
+func TestConformance(t *testing.T) {
+	storeName, storeURL := os.Getenv("SPARQLTEST_STORE"), os.Getenv("SPARQLTEST_URL")
+	if storeName == "" || storeURL == "" {
+		t.Skip("set SPARQLTEST_STORE and SPARQLTEST_URL to run the conformance tests")
+	}
+
+	var (
+		store sparql.Store
+		err   error
+	)
+
+	switch storeName {
+	case "fuseki":
+		store, err = fuseki.NewStore(fuseki.SetDatasetURL(storeURL))
+	case "blazegraph":
+		store, err = blazegraph.NewStore(blazegraph.SetNamespaceURL(storeURL))
+	case "anzograph":
+		store, err = anzograph.NewStore(anzograph.SetEndpoint(storeURL))
+	default:
+		t.Fatalf("unknown store %q", storeName)
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	manifestPath := os.Getenv("SPARQLTEST_MANIFEST")
+	if manifestPath == "" {
+		manifestPath = "testdata/data-sparql11/manifest-all.ttl"
+	}
+
+	m, err := sparqltest.LoadManifest(manifestPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := sparqltest.NewRunner(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertions := r.RunManifest(context.Background(), m)
+
+	outcomes := map[sparqltest.Outcome]int{}
+
+	for _, a := range assertions {
+		outcomes[a.Outcome]++
+
+		if a.Outcome == sparqltest.Failed {
+			t.Logf("%s (%s): %s", a.Test.Name, a.Test.IRI, a.Message)
+		}
+	}
+
+	t.Logf("%s: %v", storeName, outcomes)
+
+	if path := os.Getenv("SPARQLTEST_EARL"); path != "" {
+		f, err := os.Create(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+
+		project := sparqltest.Project{IRI: storeURL, Name: storeName}
+		assertor := sparqltest.Assertor{IRI: "https://github.com/delving/hub3", Name: "hub3 sparqltest"}
+
+		if err := sparqltest.WriteEARL(f, project, assertor, assertions); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
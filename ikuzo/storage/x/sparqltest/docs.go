@@ -1,5 +1,9 @@
 // package sparqltest contains a test-suite based on the W3c SPARQL workinggroup.
 //
 // This test-suite should be run against any supported SPARQL 1.1 triple store
-// that implements the sparql.Store interface.
+// that implements the sparql.Store interface. LoadManifest reads the W3C test
+// manifests in testdata, a Runner executes the tests against a store and
+// WriteEARL writes the outcomes as an EARL report.
+//
+// See TestConformance for running the test-suite against a live triple store.
 package sparqltest
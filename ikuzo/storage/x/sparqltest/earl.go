@@ -0,0 +1,96 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sparqltest
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"time"
+)
+
+const earlPrefixes = `@prefix earl: <http://www.w3.org/ns/earl#> .
+@prefix dc: <http://purl.org/dc/terms/> .
+@prefix doap: <http://usefulinc.com/ns/doap#> .
+@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+
+`
+
+// Project is the software that is tested, i.e. the triple store.
+type Project struct {
+	IRI         string
+	Name        string
+	Homepage    string
+	Version     string
+	Description string
+}
+
+// Assertor is the person or organisation that runs the tests.
+type Assertor struct {
+	IRI  string
+	Name string
+}
+
+// WriteEARL writes the assertions as an EARL report in Turtle. The report can
+// be submitted to the W3C implementation reports.
+func WriteEARL(w io.Writer, project Project, assertor Assertor, assertions []Assertion) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprint(bw, earlPrefixes)
+
+	fmt.Fprintf(bw, "<%s> a doap:Project, earl:TestSubject, earl:Software ;\n", project.IRI)
+	fmt.Fprintf(bw, "\tdoap:name %s", quote(project.Name))
+
+	if project.Homepage != "" {
+		fmt.Fprintf(bw, " ;\n\tdoap:homepage <%s>", project.Homepage)
+	}
+
+	if project.Description != "" {
+		fmt.Fprintf(bw, " ;\n\tdoap:description %s", quote(project.Description))
+	}
+
+	if project.Version != "" {
+		fmt.Fprintf(bw, " ;\n\tdoap:release [ doap:revision %s ]", quote(project.Version))
+	}
+
+	fmt.Fprint(bw, " .\n\n")
+
+	fmt.Fprintf(bw, "<%s> a foaf:Agent, earl:Assertor ;\n\tfoaf:name %s .\n", assertor.IRI, quote(assertor.Name))
+
+	for _, a := range assertions {
+		fmt.Fprintf(bw, "\n[] a earl:Assertion ;\n")
+		fmt.Fprintf(bw, "\tearl:assertedBy <%s> ;\n", assertor.IRI)
+		fmt.Fprintf(bw, "\tearl:subject <%s> ;\n", project.IRI)
+		fmt.Fprintf(bw, "\tearl:test <%s> ;\n", a.Test.IRI)
+		fmt.Fprintf(bw, "\tearl:mode earl:automatic ;\n")
+		fmt.Fprintf(bw, "\tearl:result [\n")
+		fmt.Fprintf(bw, "\t\ta earl:TestResult ;\n")
+		fmt.Fprintf(bw, "\t\tearl:outcome earl:%s ;\n", a.Outcome)
+
+		if a.Message != "" {
+			fmt.Fprintf(bw, "\t\tearl:info %s ;\n", quote(a.Message))
+		}
+
+		fmt.Fprintf(bw, "\t\tdc:date %s^^xsd:dateTime\n", quote(a.Date.UTC().Format(time.RFC3339)))
+		fmt.Fprintf(bw, "\t] .\n")
+	}
+
+	return bw.Flush()
+}
+
+func quote(s string) string {
+	return `"` + escapeLiteral(s) + `"`
+}
@@ -0,0 +1,308 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sparqltest
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	mfNS    = "http://www.w3.org/2001/sw/DataAccess/tests/test-manifest#"
+	qtNS    = "http://www.w3.org/2001/sw/DataAccess/tests/test-query#"
+	utNS    = "http://www.w3.org/2009/sparql/tests/test-update#"
+	dawgtNS = "http://www.w3.org/2001/sw/DataAccess/tests/test-dawg#"
+	sdNS    = "http://www.w3.org/ns/sparql-service-description#"
+)
+
+// TestType is the type of a test in the manifest, e.g. QueryEvaluationTest.
+type TestType string
+
+const (
+	QueryEvaluationTest        TestType = "QueryEvaluationTest"
+	UpdateEvaluationTest       TestType = "UpdateEvaluationTest"
+	PositiveSyntaxTest         TestType = "PositiveSyntaxTest"
+	NegativeSyntaxTest         TestType = "NegativeSyntaxTest"
+	PositiveSyntaxTest11       TestType = "PositiveSyntaxTest11"
+	NegativeSyntaxTest11       TestType = "NegativeSyntaxTest11"
+	PositiveUpdateSyntaxTest11 TestType = "PositiveUpdateSyntaxTest11"
+	NegativeUpdateSyntaxTest11 TestType = "NegativeUpdateSyntaxTest11"
+)
+
+// w3cBases are the IRIs the W3C test suites are published at. The tests are
+// resolved against these IRIs, so that graph names match the expected results.
+var w3cBases = map[string]string{
+	"data-r2":       "http://www.w3.org/2001/sw/DataAccess/tests/data-r2/",
+	"data-sparql11": "http://www.w3.org/2009/sparql/docs/tests/data-sparql11/",
+}
+
+// NamedGraph is a file that is loaded as a named graph.
+type NamedGraph struct {
+	Name string // the graph IRI
+	Path string
+}
+
+// Test is a test from a W3C test manifest. Files are given as local paths.
+type Test struct {
+	IRI      string
+	Name     string
+	Type     TestType
+	Approval string // e.g. Approved, NotClassified or Withdrawn
+	Manifest string // the path of the manifest
+	// Entailment is the entailment regime the test requires. Empty is simple entailment.
+	Entailment string
+
+	// Query is the query file of query evaluation and syntax tests.
+	Query string
+	// Data and GraphData are the default and named graphs of query evaluation tests.
+	Data      []string
+	GraphData []NamedGraph
+	// Result is the expected results file of query evaluation tests.
+	Result string
+
+	// Request is the update file of update evaluation and syntax tests.
+	Request string
+	// UpdateData and UpdateGraphData are the graph store before the update.
+	UpdateData      []string
+	UpdateGraphData []NamedGraph
+	// ResultData and ResultGraphData are the expected graph store after the update.
+	ResultData      []string
+	ResultGraphData []NamedGraph
+}
+
+// Manifest is a W3C test manifest with the tests of all included manifests.
+type Manifest struct {
+	Path  string
+	Label string
+	Tests []*Test
+}
+
+// fileIRI returns the IRI of a local file. Files of the W3C test suites get
+// the IRI they are published at.
+func fileIRI(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		abs = path
+	}
+
+	abs = filepath.ToSlash(abs)
+
+	for dir, base := range w3cBases {
+		if i := strings.LastIndex(abs, "/"+dir+"/"); i != -1 {
+			return base + abs[i+len(dir)+2:]
+		}
+	}
+
+	return "file://" + abs
+}
+
+// filePath returns the local path of an IRI that is resolved against the manifest IRI.
+func filePath(manifestPath, manifestIRI, iri string) (string, error) {
+	dirIRI := manifestIRI[:strings.LastIndex(manifestIRI, "/")+1]
+	if !strings.HasPrefix(iri, dirIRI) {
+		return "", fmt.Errorf("%s is not relative to manifest %s", iri, manifestPath)
+	}
+
+	return filepath.Join(filepath.Dir(manifestPath), filepath.FromSlash(strings.TrimPrefix(iri, dirIRI))), nil
+}
+
+// LoadManifest loads the tests of the manifest and the manifests it includes.
+func LoadManifest(path string) (*Manifest, error) {
+	graph, err := readGraphFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := newIndex(graph)
+	iri := fileIRI(path)
+	m := &Manifest{Path: path}
+
+	// relative IRIs in manifests are resolved against the directory, so '<>' is the directory
+	self := NewIRI(iri[:strings.LastIndex(iri, "/")+1])
+
+	if label, ok := idx.one(self, rdfsLabel); ok {
+		m.Label = label.Value
+	}
+
+	local := func(term Term) (string, error) {
+		return filePath(path, iri, term.Value)
+	}
+
+	for _, head := range idx[self][mfNS+"include"] {
+		for _, include := range idx.list(head) {
+			includePath, err := local(include)
+			if err != nil {
+				return nil, err
+			}
+
+			included, err := LoadManifest(includePath)
+			if err != nil {
+				return nil, err
+			}
+
+			m.Tests = append(m.Tests, included.Tests...)
+		}
+	}
+
+	for _, head := range idx[self][mfNS+"entries"] {
+		for _, entry := range idx.list(head) {
+			test, err := newTest(idx, entry, local)
+			if err != nil {
+				return nil, fmt.Errorf("invalid test %s; %w", entry.Value, err)
+			}
+
+			test.Manifest = path
+			m.Tests = append(m.Tests, test)
+		}
+	}
+
+	return m, nil
+}
+
+func newTest(idx index, entry Term, local func(Term) (string, error)) (*Test, error) {
+	test := &Test{IRI: entry.Value}
+
+	if name, ok := idx.one(entry, mfNS+"name"); ok {
+		test.Name = name.Value
+	}
+
+	if approval, ok := idx.one(entry, dawgtNS+"approval"); ok {
+		test.Approval = strings.TrimPrefix(approval.Value, dawgtNS)
+	}
+
+	if t, ok := idx.one(entry, rdfType); ok {
+		test.Type = TestType(strings.TrimPrefix(t.Value, mfNS))
+	}
+
+	files := func(subject Term, predicate string) ([]string, error) {
+		paths := []string{}
+
+		for _, o := range idx[subject][predicate] {
+			p, err := local(o)
+			if err != nil {
+				return nil, err
+			}
+
+			paths = append(paths, p)
+		}
+
+		return paths, nil
+	}
+
+	graphs := func(subject Term, predicate string) ([]NamedGraph, error) {
+		named := []NamedGraph{}
+
+		for _, o := range idx[subject][predicate] {
+			g := NamedGraph{Name: o.Value}
+
+			// update tests describe the graph with a label
+			if file, ok := idx.one(o, utNS+"graph"); ok {
+				label, _ := idx.one(o, rdfsLabel)
+				g.Name = label.Value
+				o = file
+			}
+
+			p, err := local(o)
+			if err != nil {
+				return nil, err
+			}
+
+			g.Path = p
+			named = append(named, g)
+		}
+
+		return named, nil
+	}
+
+	var err error
+
+	action, ok := idx.one(entry, mfNS+"action")
+	if !ok {
+		return test, nil
+	}
+
+	// syntax tests have the query file as action
+	if action.Kind == IRI {
+		p, err := local(action)
+		if err != nil {
+			return nil, err
+		}
+
+		if strings.HasSuffix(p, ".ru") {
+			test.Request = p
+		} else {
+			test.Query = p
+		}
+
+		return test, nil
+	}
+
+	if regime, ok := idx.one(action, sdNS+"entailmentRegime"); ok {
+		test.Entailment = regime.Value
+	}
+
+	if query, ok := idx.one(action, qtNS+"query"); ok {
+		if test.Query, err = local(query); err != nil {
+			return nil, err
+		}
+
+		if test.Type == "" {
+			test.Type = QueryEvaluationTest
+		}
+	}
+
+	if request, ok := idx.one(action, utNS+"request"); ok {
+		if test.Request, err = local(request); err != nil {
+			return nil, err
+		}
+	}
+
+	if test.Data, err = files(action, qtNS+"data"); err != nil {
+		return nil, err
+	}
+
+	if test.GraphData, err = graphs(action, qtNS+"graphData"); err != nil {
+		return nil, err
+	}
+
+	if test.UpdateData, err = files(action, utNS+"data"); err != nil {
+		return nil, err
+	}
+
+	if test.UpdateGraphData, err = graphs(action, utNS+"graphData"); err != nil {
+		return nil, err
+	}
+
+	result, ok := idx.one(entry, mfNS+"result")
+	if !ok {
+		return test, nil
+	}
+
+	if result.Kind == IRI {
+		test.Result, err = local(result)
+		return test, err
+	}
+
+	if test.ResultData, err = files(result, utNS+"data"); err != nil {
+		return nil, err
+	}
+
+	if test.ResultGraphData, err = graphs(result, utNS+"graphData"); err != nil {
+		return nil, err
+	}
+
+	return test, nil
+}
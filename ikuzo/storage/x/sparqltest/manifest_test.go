@@ -0,0 +1,76 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sparqltest
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestLoadManifest(t *testing.T) {
+	is := is.New(t)
+
+	m, err := LoadManifest("testdata/data-sparql11/manifest-sparql11-query.ttl")
+	is.NoErr(err)
+	is.True(len(m.Tests) > 200)
+
+	tests := map[string]*Test{}
+	for _, test := range m.Tests {
+		tests[test.IRI] = test
+	}
+
+	bind01 := tests["http://www.w3.org/2009/sparql/docs/tests/data-sparql11/bind/manifest#bind01"]
+	is.True(bind01 != nil)
+	is.Equal(bind01.Name, "bind01 - BIND")
+	is.Equal(bind01.Type, QueryEvaluationTest)
+	is.Equal(bind01.Approval, "Approved")
+	is.Equal(bind01.Query, "testdata/data-sparql11/bind/bind01.rq")
+	is.Equal(bind01.Data, []string{"testdata/data-sparql11/bind/data.ttl"})
+	is.Equal(bind01.Result, "testdata/data-sparql11/bind/bind01.srx")
+
+	m, err = LoadManifest("testdata/data-sparql11/manifest-sparql11-update.ttl")
+	is.NoErr(err)
+
+	tests = map[string]*Test{}
+	for _, test := range m.Tests {
+		tests[test.IRI] = test
+	}
+
+	add01 := tests["http://www.w3.org/2009/sparql/docs/tests/data-sparql11/add/manifest#add01"]
+	is.True(add01 != nil)
+	is.Equal(add01.Type, UpdateEvaluationTest)
+	is.Equal(add01.Request, "testdata/data-sparql11/add/add-01.ru")
+	is.Equal(add01.UpdateData, []string{"testdata/data-sparql11/add/add-default.ttl"})
+	is.Equal(add01.UpdateGraphData, []NamedGraph{{Name: "http://example.org/g1", Path: "testdata/data-sparql11/add/add-01-pre.ttl"}})
+	is.Equal(add01.ResultGraphData, []NamedGraph{{Name: "http://example.org/g1", Path: "testdata/data-sparql11/add/add-01-post.ttl"}})
+}
+
+func TestLoadManifest_syntax(t *testing.T) {
+	is := is.New(t)
+
+	m, err := LoadManifest("testdata/data-sparql11/syntax-update-1/manifest.ttl")
+	is.NoErr(err)
+
+	types := map[TestType]int{}
+	for _, test := range m.Tests {
+		types[test.Type]++
+
+		is.True(test.Request != "" || test.Query != "") // action is the request file
+	}
+
+	is.True(types[PositiveUpdateSyntaxTest11] > 0)
+	is.True(types[NegativeUpdateSyntaxTest11] > 0)
+}
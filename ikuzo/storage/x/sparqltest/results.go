@@ -0,0 +1,488 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sparqltest
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/knakk/rdf"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported result format")
+
+const (
+	rsNS      = "http://www.w3.org/2001/sw/DataAccess/tests/result-set#"
+	rdfType   = "http://www.w3.org/1999/02/22-rdf-syntax-ns#type"
+	rdfFirst  = "http://www.w3.org/1999/02/22-rdf-syntax-ns#first"
+	rdfRest   = "http://www.w3.org/1999/02/22-rdf-syntax-ns#rest"
+	rdfNil    = "http://www.w3.org/1999/02/22-rdf-syntax-ns#nil"
+	rdfsLabel = "http://www.w3.org/2000/01/rdf-schema#label"
+)
+
+// ReadResultsFile reads the expected results of a test. The format is taken
+// from the extension: .srx, .srj or an RDF graph in .ttl, .nt or .rdf. RDF
+// graphs that describe a result set with the result-set vocabulary are
+// returned as a result set.
+func ReadResultsFile(path string) (*Results, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	switch filepath.Ext(path) {
+	case ".srx":
+		return ParseXMLResults(f)
+	case ".srj":
+		return ParseJSONResults(f)
+	case ".ttl", ".nt", ".rdf":
+		graph, err := readGraph(f, path)
+		if err != nil {
+			return nil, err
+		}
+
+		if results, ok := resultSetFromGraph(graph); ok {
+			return results, nil
+		}
+
+		return &Results{Graph: graph, IsGraph: true}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedFormat, path)
+}
+
+// ParseResults parses the response of a triple store by its content type.
+func ParseResults(r io.Reader, contentType string) (*Results, error) {
+	mediaType := strings.TrimSpace(strings.Split(contentType, ";")[0])
+
+	switch mediaType {
+	case "application/sparql-results+json", "application/json":
+		return ParseJSONResults(r)
+	case "application/sparql-results+xml", "application/xml", "text/xml":
+		return ParseXMLResults(r)
+	}
+
+	var format rdf.Format
+
+	switch mediaType {
+	case "application/n-triples", "text/plain":
+		format = rdf.NTriples
+	case "text/turtle", "application/x-turtle":
+		format = rdf.Turtle
+	case "application/rdf+xml":
+		format = rdf.RDFXML
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFormat, contentType)
+	}
+
+	triples, err := rdf.NewTripleDecoder(r, format).DecodeAll()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Results{Graph: fromRDFTriples(triples), IsGraph: true}, nil
+}
+
+// ParseJSONResults parses SPARQL 1.1 Query Results JSON.
+func ParseJSONResults(r io.Reader) (*Results, error) {
+	var doc struct {
+		Head struct {
+			Vars []string `json:"vars"`
+		} `json:"head"`
+		Boolean *bool `json:"boolean"`
+		Results struct {
+			Bindings []map[string]struct {
+				Type     string `json:"type"`
+				Value    string `json:"value"`
+				Lang     string `json:"xml:lang"`
+				Datatype string `json:"datatype"`
+			} `json:"bindings"`
+		} `json:"results"`
+	}
+
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("unable to decode json results; %w", err)
+	}
+
+	results := &Results{Boolean: doc.Boolean, Variables: doc.Head.Vars}
+
+	for _, binding := range doc.Results.Bindings {
+		solution := Solution{}
+
+		for name, value := range binding {
+			switch value.Type {
+			case "uri":
+				solution[name] = NewIRI(value.Value)
+			case "bnode":
+				solution[name] = NewBlankNode(value.Value)
+			case "literal", "typed-literal":
+				solution[name] = NewLiteral(value.Value, value.Lang, value.Datatype)
+			default:
+				return nil, fmt.Errorf("unknown json result type %q", value.Type)
+			}
+		}
+
+		results.Solutions = append(results.Solutions, solution)
+	}
+
+	return results, nil
+}
+
+// ParseXMLResults parses SPARQL Query Results XML.
+func ParseXMLResults(r io.Reader) (*Results, error) {
+	type literal struct {
+		Value    string `xml:",chardata"`
+		Lang     string `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+		Datatype string `xml:"datatype,attr"`
+	}
+
+	var doc struct {
+		Head struct {
+			Variables []struct {
+				Name string `xml:"name,attr"`
+			} `xml:"variable"`
+		} `xml:"head"`
+		Boolean *string `xml:"boolean"`
+		Results []struct {
+			Bindings []struct {
+				Name    string   `xml:"name,attr"`
+				URI     *string  `xml:"uri"`
+				BNode   *string  `xml:"bnode"`
+				Literal *literal `xml:"literal"`
+			} `xml:"binding"`
+		} `xml:"results>result"`
+	}
+
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("unable to decode xml results; %w", err)
+	}
+
+	results := &Results{}
+
+	for _, v := range doc.Head.Variables {
+		results.Variables = append(results.Variables, v.Name)
+	}
+
+	if doc.Boolean != nil {
+		b := strings.TrimSpace(*doc.Boolean) == "true"
+		results.Boolean = &b
+	}
+
+	for _, result := range doc.Results {
+		solution := Solution{}
+
+		for _, binding := range result.Bindings {
+			switch {
+			case binding.URI != nil:
+				solution[binding.Name] = NewIRI(strings.TrimSpace(*binding.URI))
+			case binding.BNode != nil:
+				solution[binding.Name] = NewBlankNode(strings.TrimSpace(*binding.BNode))
+			case binding.Literal != nil:
+				solution[binding.Name] = NewLiteral(binding.Literal.Value, binding.Literal.Lang, binding.Literal.Datatype)
+			}
+		}
+
+		results.Solutions = append(results.Solutions, solution)
+	}
+
+	return results, nil
+}
+
+// readGraph reads an RDF file. Relative IRIs are resolved against the IRI of the file.
+func readGraph(r io.Reader, path string) ([]Triple, error) {
+	var format rdf.Format
+
+	switch filepath.Ext(path) {
+	case ".nt":
+		format = rdf.NTriples
+	case ".rdf":
+		format = rdf.RDFXML
+	default:
+		format = rdf.Turtle
+	}
+
+	if format == rdf.Turtle {
+		b, err := ioutil.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+
+		r = bytes.NewReader(normalizeTurtle(b))
+	}
+
+	dec := rdf.NewTripleDecoder(r, format)
+
+	if format != rdf.NTriples {
+		// the decoder appends relative IRIs to the base, so the base is the directory of the file
+		iri := fileIRI(path)
+
+		base, err := rdf.NewIRI(iri[:strings.LastIndex(iri, "/")+1])
+		if err != nil {
+			return nil, err
+		}
+
+		if err := dec.SetOption(rdf.Base, base); err != nil {
+			return nil, err
+		}
+	}
+
+	triples, err := dec.DecodeAll()
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %s; %w", path, err)
+	}
+
+	return fromRDFTriples(triples), nil
+}
+
+// normalizeTurtle rewrites the Turtle constructs the decoder does not support:
+// long strings become short strings, trailing semicolons in predicate object
+// lists are removed and numbers are separated from line endings.
+func normalizeTurtle(b []byte) []byte {
+	var out bytes.Buffer
+
+	for i := 0; i < len(b); i++ {
+		c := b[i]
+
+		switch c {
+		case '#':
+			end := bytes.IndexByte(b[i:], '\n')
+			if end == -1 {
+				end = len(b) - i
+			}
+
+			out.Write(b[i : i+end])
+			i += end - 1
+		case '<':
+			end := bytes.IndexByte(b[i:], '>')
+			if end == -1 {
+				end = len(b) - i - 1
+			}
+
+			out.Write(b[i : i+end+1])
+			i += end
+		case '"', '\'':
+			quote := string([]byte{c, c, c})
+			if bytes.HasPrefix(b[i:], []byte(quote)) {
+				i = writeLongString(&out, b, i+3, quote) - 1
+				continue
+			}
+
+			j := i + 1
+			for ; j < len(b) && b[j] != c; j++ {
+				if b[j] == '\\' {
+					j++
+				}
+			}
+
+			if j >= len(b) {
+				j = len(b) - 1
+			}
+
+			out.Write(b[i : j+1])
+			i = j
+		case ';':
+			if !endsPredicateList(b[i+1:]) {
+				out.WriteByte(c)
+			}
+		case '\n':
+			// the decoder does not accept a number at the end of a line
+			out.WriteString(" \n")
+		default:
+			out.WriteByte(c)
+		}
+	}
+
+	return out.Bytes()
+}
+
+// writeLongString writes the long string that starts at i as a short string
+// and returns the position after the closing quotes.
+func writeLongString(out *bytes.Buffer, b []byte, i int, quote string) int {
+	out.WriteByte('"')
+
+	for ; i < len(b); i++ {
+		if bytes.HasPrefix(b[i:], []byte(quote)) {
+			// quotes directly before the closing quotes belong to the string
+			for i+3 < len(b) && b[i+3] == quote[0] {
+				out.WriteString(`\"`)
+				i++
+			}
+
+			out.WriteByte('"')
+
+			return i + 3
+		}
+
+		switch c := b[i]; c {
+		case '\\':
+			out.WriteByte(c)
+
+			if i+1 < len(b) {
+				i++
+				out.WriteByte(b[i])
+			}
+		case '"':
+			out.WriteString(`\"`)
+		case '\n':
+			out.WriteString(`\n`)
+		case '\r':
+			out.WriteString(`\r`)
+		case '\t':
+			out.WriteString(`\t`)
+		default:
+			out.WriteByte(c)
+		}
+	}
+
+	return i
+}
+
+// endsPredicateList reports if only whitespace, semicolons and comments are
+// left before the end of the predicate object list.
+func endsPredicateList(b []byte) bool {
+	for i := 0; i < len(b); i++ {
+		switch b[i] {
+		case ' ', '\t', '\r', '\n', ';':
+		case '#':
+			end := bytes.IndexByte(b[i:], '\n')
+			if end == -1 {
+				return false
+			}
+
+			i += end
+		case ']', '.':
+			return true
+		default:
+			return false
+		}
+	}
+
+	return false
+}
+
+// readGraphFile reads the RDF file at path.
+func readGraphFile(path string) ([]Triple, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return readGraph(f, path)
+}
+
+// index is a graph indexed by subject and predicate.
+type index map[Term]map[string][]Term
+
+func newIndex(graph []Triple) index {
+	idx := index{}
+
+	for _, t := range graph {
+		if idx[t.Subject] == nil {
+			idx[t.Subject] = map[string][]Term{}
+		}
+
+		idx[t.Subject][t.Predicate.Value] = append(idx[t.Subject][t.Predicate.Value], t.Object)
+	}
+
+	return idx
+}
+
+// one returns the first object of the subject and predicate.
+func (idx index) one(subject Term, predicate string) (Term, bool) {
+	objects := idx[subject][predicate]
+	if len(objects) == 0 {
+		return Term{}, false
+	}
+
+	return objects[0], true
+}
+
+// list returns the members of the RDF collection that starts at head.
+func (idx index) list(head Term) []Term {
+	members := []Term{}
+
+	for head.Value != rdfNil {
+		first, ok := idx.one(head, rdfFirst)
+		if !ok {
+			break
+		}
+
+		members = append(members, first)
+
+		if head, ok = idx.one(head, rdfRest); !ok {
+			break
+		}
+	}
+
+	return members
+}
+
+// resultSetFromGraph converts a graph that uses the result-set vocabulary.
+func resultSetFromGraph(graph []Triple) (*Results, bool) {
+	idx := newIndex(graph)
+
+	var resultSet *Term
+
+	for subject, predicates := range idx {
+		for _, o := range predicates[rdfType] {
+			if o.Value == rsNS+"ResultSet" {
+				s := subject
+				resultSet = &s
+			}
+		}
+	}
+
+	if resultSet == nil {
+		return nil, false
+	}
+
+	results := &Results{}
+
+	if b, ok := idx.one(*resultSet, rsNS+"boolean"); ok {
+		value := b.Value == "true"
+		results.Boolean = &value
+
+		return results, true
+	}
+
+	for _, v := range idx[*resultSet][rsNS+"resultVariable"] {
+		results.Variables = append(results.Variables, v.Value)
+	}
+
+	for _, s := range idx[*resultSet][rsNS+"solution"] {
+		solution := Solution{}
+
+		for _, binding := range idx[s][rsNS+"binding"] {
+			variable, _ := idx.one(binding, rsNS+"variable")
+			if value, ok := idx.one(binding, rsNS+"value"); ok {
+				solution[variable.Value] = value
+			}
+		}
+
+		results.Solutions = append(results.Solutions, solution)
+	}
+
+	return results, true
+}
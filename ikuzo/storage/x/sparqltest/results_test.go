@@ -0,0 +1,168 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sparqltest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+const (
+	srxResults = `<?xml version="1.0"?>
+<sparql xmlns="http://www.w3.org/2005/sparql-results#">
+  <head>
+    <variable name="s"/>
+    <variable name="o"/>
+  </head>
+  <results>
+    <result>
+      <binding name="s"><bnode>r1</bnode></binding>
+      <binding name="o"><literal xml:lang="en">hello</literal></binding>
+    </result>
+    <result>
+      <binding name="s"><uri>http://example.org/a</uri></binding>
+      <binding name="o"><literal datatype="http://www.w3.org/2001/XMLSchema#integer">1</literal></binding>
+    </result>
+    <result>
+      <binding name="s"><bnode>r2</bnode></binding>
+    </result>
+  </results>
+</sparql>`
+
+	srjResults = `{
+  "head": {"vars": ["s", "o"]},
+  "results": {"bindings": [
+    {"s": {"type": "uri", "value": "http://example.org/a"},
+     "o": {"type": "literal", "value": "1", "datatype": "http://www.w3.org/2001/XMLSchema#integer"}},
+    {"s": {"type": "bnode", "value": "b0"}},
+    {"s": {"type": "bnode", "value": "b1"},
+     "o": {"type": "literal", "value": "hello", "xml:lang": "EN"}}
+  ]}
+}`
+
+	ttlResults = `@prefix rs: <http://www.w3.org/2001/sw/DataAccess/tests/result-set#> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+
+[] a rs:ResultSet ;
+	rs:resultVariable "s", "o" ;
+	rs:solution [
+		rs:binding [ rs:variable "s" ; rs:value _:x ] ;
+		rs:binding [ rs:variable "o" ; rs:value "hello"@en ] ;
+	] ;
+	rs:solution [
+		rs:binding [ rs:variable "s" ; rs:value <http://example.org/a> ] ;
+		rs:binding [ rs:variable "o" ; rs:value 1 ]
+	] ;
+	rs:solution [
+		rs:binding [ rs:variable "s" ; rs:value _:y ]
+	] .
+`
+)
+
+func TestParseResults_formats(t *testing.T) {
+	is := is.New(t)
+
+	expected, err := ParseResults(strings.NewReader(srxResults), "application/sparql-results+xml")
+	is.NoErr(err)
+	is.Equal(expected.Variables, []string{"s", "o"})
+	is.Equal(len(expected.Solutions), 3)
+
+	actual, err := ParseResults(strings.NewReader(srjResults), "application/sparql-results+json; charset=utf-8")
+	is.NoErr(err)
+	is.NoErr(Compare(expected, actual))
+
+	graph, err := readGraph(strings.NewReader(ttlResults), "results.ttl")
+	is.NoErr(err)
+
+	actual, ok := resultSetFromGraph(graph)
+	is.True(ok)
+	is.NoErr(Compare(expected, actual))
+
+	_, err = ParseResults(strings.NewReader(""), "text/csv")
+	is.True(err != nil) // csv is not supported
+}
+
+func TestParseResults_graph(t *testing.T) {
+	is := is.New(t)
+
+	results, err := ParseResults(strings.NewReader(`<http://example.org/a> <http://example.org/p> "a\"b" .
+_:b1 <http://example.org/p> "x"@nl .
+`), "application/n-triples")
+	is.NoErr(err)
+	is.True(results.IsGraph)
+	is.Equal(nTriples(results.Graph), `<http://example.org/a> <http://example.org/p> "a\"b" .
+_:b1 <http://example.org/p> "x"@nl .
+`)
+}
+
+func TestNormalizeTurtle(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			"trailing semicolon in blank node",
+			"<a> <b> [ <c> 1 ; ] .",
+			"<a> <b> [ <c> 1  ] .",
+		},
+		{
+			"trailing semicolon before comment",
+			"<a> <b> 1 ; # done\n .",
+			"<a> <b> 1  # done \n .",
+		},
+		{
+			"semicolon in IRI and string",
+			`<a;> <b> "x ; ]" .`,
+			`<a;> <b> "x ; ]" .`,
+		},
+		{
+			"long string",
+			"<a> <b> \"\"\"x\n\"y\" \"\"\"\" .",
+			`<a> <b> "x\n\"y\" \"" .`,
+		},
+		{
+			"number at end of line",
+			"<a> <b> 1\n.",
+			"<a> <b> 1 \n.",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(string(normalizeTurtle([]byte(tt.input))), tt.want)
+		})
+	}
+}
+
+func TestReadResultsFile(t *testing.T) {
+	is := is.New(t)
+
+	results, err := ReadResultsFile("testdata/data-sparql11/bind/bind01.srx")
+	is.NoErr(err)
+	is.Equal(len(results.Solutions), 4)
+
+	results, err = ReadResultsFile("testdata/data-sparql11/construct/constructwhere01result.ttl")
+	is.NoErr(err)
+	is.True(results.IsGraph)
+	is.True(len(results.Graph) > 0)
+
+	_, err = ReadResultsFile("testdata/data-sparql11/csv-tsv-res/csvtsv01.csv")
+	is.True(err != nil)
+}
@@ -0,0 +1,434 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sparqltest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/delving/hub3/ikuzo/service/x/sparql"
+)
+
+// Outcome is the outcome of a test as defined by the EARL 1.0 schema.
+type Outcome string
+
+const (
+	Passed       Outcome = "passed"
+	Failed       Outcome = "failed"
+	CantTell     Outcome = "cantTell"
+	Inapplicable Outcome = "inapplicable"
+	Untested     Outcome = "untested"
+)
+
+const (
+	graphAccept   = "application/n-triples"
+	resultsAccept = "application/sparql-results+json"
+)
+
+// Assertion is the outcome of running a test against a store.
+type Assertion struct {
+	Test    *Test
+	Outcome Outcome
+	Message string
+	Date    time.Time
+}
+
+// Option is a closure to configure the Runner.
+// It is used in NewRunner.
+type Option func(*Runner) error
+
+// Runner runs the tests of W3C test manifests against a sparql.Store.
+//
+// The store is emptied with 'DROP ALL' before each test, so never run it
+// against a store that contains data you want to keep.
+type Runner struct {
+	store   sparql.Store
+	skip    map[string]string
+	timeout time.Duration
+}
+
+// SetSkip marks the tests as untested with the reason, e.g. for known
+// limitations of the store.
+func SetSkip(reason string, testIRIs ...string) Option {
+	return func(r *Runner) error {
+		for _, iri := range testIRIs {
+			r.skip[iri] = reason
+		}
+
+		return nil
+	}
+}
+
+// SetTimeout sets the maximum duration of a single test. The default is 30 seconds.
+func SetTimeout(timeout time.Duration) Option {
+	return func(r *Runner) error {
+		r.timeout = timeout
+		return nil
+	}
+}
+
+// NewRunner returns a Runner for the store.
+func NewRunner(store sparql.Store, options ...Option) (*Runner, error) {
+	r := &Runner{
+		store:   store,
+		skip:    map[string]string{},
+		timeout: 30 * time.Second,
+	}
+
+	for _, option := range options {
+		if err := option(r); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// RunManifest runs all tests of the manifest.
+func (r *Runner) RunManifest(ctx context.Context, m *Manifest) []Assertion {
+	assertions := make([]Assertion, 0, len(m.Tests))
+
+	for _, test := range m.Tests {
+		assertions = append(assertions, r.Run(ctx, test))
+	}
+
+	return assertions
+}
+
+// Run runs the test and returns the outcome.
+func (r *Runner) Run(ctx context.Context, test *Test) Assertion {
+	assertion := Assertion{Test: test, Date: time.Now().UTC()}
+
+	if reason, ok := r.skip[test.IRI]; ok {
+		assertion.Outcome = Untested
+		assertion.Message = reason
+
+		return assertion
+	}
+
+	switch {
+	case test.Approval == "Withdrawn":
+		assertion.Outcome = Untested
+		assertion.Message = "the test is withdrawn"
+
+		return assertion
+	case test.Entailment != "":
+		assertion.Outcome = Inapplicable
+		assertion.Message = fmt.Sprintf("entailment regime %s is not supported", test.Entailment)
+
+		return assertion
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	var err error
+
+	switch test.Type {
+	case QueryEvaluationTest:
+		assertion.Outcome, err = r.runQuery(ctx, test)
+	case UpdateEvaluationTest:
+		assertion.Outcome, err = r.runUpdate(ctx, test)
+	case PositiveSyntaxTest, PositiveSyntaxTest11, PositiveUpdateSyntaxTest11:
+		assertion.Outcome, err = r.runSyntax(ctx, test, true)
+	case NegativeSyntaxTest, NegativeSyntaxTest11, NegativeUpdateSyntaxTest11:
+		assertion.Outcome, err = r.runSyntax(ctx, test, false)
+	default:
+		assertion.Outcome = Inapplicable
+		err = fmt.Errorf("%s tests are not supported", test.Type)
+	}
+
+	if err != nil {
+		assertion.Message = err.Error()
+	}
+
+	return assertion
+}
+
+// fromClause matches the graphs of the dataset of a query.
+var fromClause = regexp.MustCompile(`(?i)FROM\s+(NAMED\s+)?<([^>]*)>`)
+
+func (r *Runner) runQuery(ctx context.Context, test *Test) (Outcome, error) {
+	switch filepath.Ext(test.Result) {
+	case ".srx", ".srj", ".ttl", ".nt", ".rdf":
+	default:
+		return Untested, fmt.Errorf("%w: %s", ErrUnsupportedFormat, test.Result)
+	}
+
+	expected, err := ReadResultsFile(test.Result)
+	if err != nil {
+		return CantTell, err
+	}
+
+	query, err := ioutil.ReadFile(test.Query)
+	if err != nil {
+		return CantTell, err
+	}
+
+	queryIRI := fileIRI(test.Query)
+
+	graphs := append([]NamedGraph{}, test.GraphData...)
+
+	// graphs in FROM and FROM NAMED clauses are loaded as named graphs
+	for _, match := range fromClause.FindAllStringSubmatch(string(query), -1) {
+		iri := match[2]
+		if !strings.Contains(iri, ":") {
+			iri = queryIRI[:strings.LastIndex(queryIRI, "/")+1] + iri
+		}
+
+		path, err := filePath(test.Query, queryIRI, iri)
+		if err != nil {
+			continue
+		}
+
+		if _, err := os.Stat(path); err == nil {
+			graphs = append(graphs, NamedGraph{Name: iri, Path: path})
+		}
+	}
+
+	if err := r.load(ctx, test.Data, graphs); err != nil {
+		return CantTell, err
+	}
+
+	accept := resultsAccept
+	if expected.IsGraph {
+		accept = graphAccept
+	}
+
+	actual, err := r.query(ctx, fmt.Sprintf("BASE <%s>\n%s", queryIRI, query), accept)
+	if err != nil {
+		return Failed, err
+	}
+
+	if err := Compare(expected, actual); err != nil {
+		return Failed, err
+	}
+
+	return Passed, nil
+}
+
+func (r *Runner) runUpdate(ctx context.Context, test *Test) (Outcome, error) {
+	request, err := ioutil.ReadFile(test.Request)
+	if err != nil {
+		return CantTell, err
+	}
+
+	if err := r.load(ctx, test.UpdateData, test.UpdateGraphData); err != nil {
+		return CantTell, err
+	}
+
+	if err := r.store.Update(ctx, fmt.Sprintf("BASE <%s>\n%s", fileIRI(test.Request), request)); err != nil {
+		return Failed, err
+	}
+
+	expected, err := readGraphs(test.ResultData)
+	if err != nil {
+		return CantTell, err
+	}
+
+	actual, err := r.query(ctx, "CONSTRUCT { ?s ?p ?o } WHERE { ?s ?p ?o }", graphAccept)
+	if err != nil {
+		return Failed, err
+	}
+
+	if err := Compare(&Results{Graph: expected, IsGraph: true}, actual); err != nil {
+		return Failed, fmt.Errorf("default graph: %w", err)
+	}
+
+	names := []string{}
+
+	for _, g := range test.ResultGraphData {
+		expected, err := readGraphFile(g.Path)
+		if err != nil {
+			return CantTell, err
+		}
+
+		// empty graphs can not be observed in a store
+		if len(expected) > 0 {
+			names = append(names, g.Name)
+		}
+
+		actual, err := r.query(ctx, fmt.Sprintf("CONSTRUCT { ?s ?p ?o } WHERE { GRAPH <%s> { ?s ?p ?o } }", g.Name), graphAccept)
+		if err != nil {
+			return Failed, err
+		}
+
+		if err := Compare(&Results{Graph: expected, IsGraph: true}, actual); err != nil {
+			return Failed, fmt.Errorf("graph %s: %w", g.Name, err)
+		}
+	}
+
+	// there must not be any other named graphs
+	graphs, err := r.query(ctx, "SELECT DISTINCT ?g WHERE { GRAPH ?g { ?s ?p ?o } }", resultsAccept)
+	if err != nil {
+		return Failed, err
+	}
+
+	actualNames := []string{}
+	for _, s := range graphs.Solutions {
+		actualNames = append(actualNames, s["g"].Value)
+	}
+
+	sort.Strings(names)
+	sort.Strings(actualNames)
+
+	if strings.Join(names, " ") != strings.Join(actualNames, " ") {
+		return Failed, fmt.Errorf("%w: expected named graphs %v, got %v", ErrResultsDiffer, names, actualNames)
+	}
+
+	return Passed, nil
+}
+
+func (r *Runner) runSyntax(ctx context.Context, test *Test, positive bool) (Outcome, error) {
+	if err := r.reset(ctx); err != nil {
+		return CantTell, err
+	}
+
+	var err error
+
+	switch {
+	case test.Request != "":
+		var request []byte
+
+		if request, err = ioutil.ReadFile(test.Request); err != nil {
+			return CantTell, err
+		}
+
+		err = r.store.Update(ctx, fmt.Sprintf("BASE <%s>\n%s", fileIRI(test.Request), request))
+	default:
+		var query []byte
+
+		if query, err = ioutil.ReadFile(test.Query); err != nil {
+			return CantTell, err
+		}
+
+		_, err = r.store.Query(ctx, fmt.Sprintf("BASE <%s>\n%s", fileIRI(test.Query), query), "")
+	}
+
+	if positive {
+		if err != nil {
+			return Failed, err
+		}
+
+		return Passed, nil
+	}
+
+	var upstream *sparql.UpstreamError
+
+	switch {
+	case err == nil:
+		return Failed, errors.New("the store accepted the invalid request")
+	case errors.As(err, &upstream) && upstream.StatusCode >= http.StatusBadRequest && upstream.StatusCode < http.StatusInternalServerError:
+		return Passed, nil
+	}
+
+	return Failed, err
+}
+
+// reset removes all graphs from the store.
+func (r *Runner) reset(ctx context.Context) error {
+	if err := r.store.Update(ctx, "DROP ALL"); err != nil {
+		return fmt.Errorf("unable to empty store; %w", err)
+	}
+
+	return nil
+}
+
+// load empties the store and loads the default graph and the named graphs.
+func (r *Runner) load(ctx context.Context, data []string, graphs []NamedGraph) error {
+	if err := r.reset(ctx); err != nil {
+		return err
+	}
+
+	defaultGraph, err := readGraphs(data)
+	if err != nil {
+		return err
+	}
+
+	if len(defaultGraph) > 0 {
+		if err := r.store.Update(ctx, fmt.Sprintf("INSERT DATA {\n%s}", nTriples(defaultGraph))); err != nil {
+			return fmt.Errorf("unable to load default graph; %w", err)
+		}
+	}
+
+	for i, g := range graphs {
+		graph, err := readGraphFile(g.Path)
+		if err != nil {
+			return err
+		}
+
+		graph = relabel(graph, fmt.Sprintf("g%d", i))
+
+		if err := r.store.PutGraph(ctx, g.Name, graphAccept, strings.NewReader(nTriples(graph))); err != nil {
+			return fmt.Errorf("unable to load graph %s; %w", g.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Runner) query(ctx context.Context, query, accept string) (*Results, error) {
+	resp, err := r.store.Query(ctx, query, accept)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseResults(bytes.NewReader(resp.Body), resp.ContentType)
+}
+
+// readGraphs merges the graphs of the files. Blank nodes are not shared between files.
+func readGraphs(paths []string) ([]Triple, error) {
+	merged := []Triple{}
+
+	for i, path := range paths {
+		graph, err := readGraphFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		merged = append(merged, relabel(graph, fmt.Sprintf("d%d", i))...)
+	}
+
+	return merged, nil
+}
+
+// relabel prefixes the blank node labels of the graph.
+func relabel(graph []Triple, prefix string) []Triple {
+	term := func(t Term) Term {
+		if t.Kind == BlankNode {
+			t.Value = prefix + t.Value
+		}
+
+		return t
+	}
+
+	relabeled := make([]Triple, 0, len(graph))
+
+	for _, t := range graph {
+		relabeled = append(relabeled, Triple{Subject: term(t.Subject), Predicate: t.Predicate, Object: term(t.Object)})
+	}
+
+	return relabeled
+}
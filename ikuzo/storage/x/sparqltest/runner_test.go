@@ -0,0 +1,300 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sparqltest
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/delving/hub3/ikuzo/service/x/sparql"
+	"github.com/matryer/is"
+)
+
+// fakeStore records the updates and graphs and answers queries with respond.
+type fakeStore struct {
+	updates []string
+	graphs  map[string]string
+	update  func(update string) error
+	respond func(query, accept string) (*sparql.Response, error)
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{
+		graphs: map[string]string{},
+		update: func(string) error { return nil },
+	}
+}
+
+func (s *fakeStore) Query(ctx context.Context, query, accept string) (*sparql.Response, error) {
+	return s.respond(query, accept)
+}
+
+func (s *fakeStore) Update(ctx context.Context, update string) error {
+	s.updates = append(s.updates, update)
+	return s.update(update)
+}
+
+func (s *fakeStore) PutGraph(ctx context.Context, graphURI, contentType string, body io.Reader) error {
+	b, err := ioutil.ReadAll(body)
+	s.graphs[graphURI] = string(b)
+
+	return err
+}
+
+func (s *fakeStore) DeleteGraph(ctx context.Context, graphURI string) error {
+	delete(s.graphs, graphURI)
+	return nil
+}
+
+func (s *fakeStore) DropDataset(ctx context.Context, spec string) error { return nil }
+
+func (s *fakeStore) DropOrphans(ctx context.Context, spec string, revision int) error { return nil }
+
+var _ sparql.Store = (*fakeStore)(nil)
+
+func fileResponse(t *testing.T, path, contentType string) *sparql.Response {
+	t.Helper()
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &sparql.Response{ContentType: contentType, Body: b}
+}
+
+func graphResponse(t *testing.T, path string) *sparql.Response {
+	t.Helper()
+
+	graph, err := readGraphFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &sparql.Response{ContentType: "application/n-triples", Body: []byte(nTriples(graph))}
+}
+
+func loadTests(t *testing.T, path string) map[string]*Test {
+	t.Helper()
+
+	m, err := LoadManifest(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]*Test{}
+	for _, test := range m.Tests {
+		tests[test.Name] = test
+	}
+
+	return tests
+}
+
+func TestRunner_query(t *testing.T) {
+	is := is.New(t)
+
+	test := loadTests(t, "testdata/data-sparql11/bind/manifest.ttl")["bind01 - BIND"]
+	store := newFakeStore()
+
+	var query string
+
+	store.respond = func(q, accept string) (*sparql.Response, error) {
+		query = q
+		is.Equal(accept, "application/sparql-results+json")
+
+		return fileResponse(t, test.Result, "application/sparql-results+xml"), nil
+	}
+
+	r, err := NewRunner(store)
+	is.NoErr(err)
+
+	a := r.Run(context.Background(), test)
+	is.Equal(a.Outcome, Passed)
+	is.Equal(a.Message, "")
+	is.True(strings.HasPrefix(query, "BASE <http://www.w3.org/2009/sparql/docs/tests/data-sparql11/bind/bind01.rq>\n"))
+
+	is.Equal(len(store.updates), 2)
+	is.Equal(store.updates[0], "DROP ALL")
+	is.True(strings.HasPrefix(store.updates[1], "INSERT DATA {\n<http://example.org/s1> "))
+
+	store.respond = func(q, accept string) (*sparql.Response, error) {
+		return &sparql.Response{
+			ContentType: "application/sparql-results+json",
+			Body:        []byte(`{"head": {"vars": ["z"]}, "results": {"bindings": []}}`),
+		}, nil
+	}
+
+	a = r.Run(context.Background(), test)
+	is.Equal(a.Outcome, Failed)
+	is.True(strings.Contains(a.Message, ErrResultsDiffer.Error()))
+}
+
+func TestRunner_update(t *testing.T) {
+	is := is.New(t)
+
+	test := loadTests(t, "testdata/data-sparql11/add/manifest.ttl")["ADD 1"]
+	store := newFakeStore()
+
+	store.respond = func(q, accept string) (*sparql.Response, error) {
+		switch {
+		case strings.Contains(q, "GRAPH <http://example.org/g1>"):
+			return graphResponse(t, test.ResultGraphData[0].Path), nil
+		case strings.HasPrefix(q, "CONSTRUCT"):
+			return graphResponse(t, test.ResultData[0]), nil
+		}
+
+		return &sparql.Response{
+			ContentType: "application/sparql-results+json",
+			Body:        []byte(`{"head": {"vars": ["g"]}, "results": {"bindings": [{"g": {"type": "uri", "value": "http://example.org/g1"}}]}}`),
+		}, nil
+	}
+
+	r, err := NewRunner(store)
+	is.NoErr(err)
+
+	a := r.Run(context.Background(), test)
+	is.Equal(a.Message, "")
+	is.Equal(a.Outcome, Passed)
+
+	_, ok := store.graphs["http://example.org/g1"]
+	is.True(ok) // named graph is loaded
+	is.True(strings.Contains(store.updates[len(store.updates)-1], "ADD DEFAULT TO :g1"))
+
+	// an extra named graph fails the test
+	store.respond = func(q, accept string) (*sparql.Response, error) {
+		switch {
+		case strings.Contains(q, "GRAPH <http://example.org/g1>"):
+			return graphResponse(t, test.ResultGraphData[0].Path), nil
+		case strings.HasPrefix(q, "CONSTRUCT"):
+			return graphResponse(t, test.ResultData[0]), nil
+		}
+
+		return &sparql.Response{
+			ContentType: "application/sparql-results+json",
+			Body: []byte(`{"head": {"vars": ["g"]}, "results": {"bindings": [
+				{"g": {"type": "uri", "value": "http://example.org/g1"}},
+				{"g": {"type": "uri", "value": "http://example.org/g2"}}
+			]}}`),
+		}, nil
+	}
+
+	a = r.Run(context.Background(), test)
+	is.Equal(a.Outcome, Failed)
+}
+
+func TestRunner_syntax(t *testing.T) {
+	tests := []struct {
+		name    string
+		typ     TestType
+		err     error
+		outcome Outcome
+	}{
+		{"positive accepted", PositiveUpdateSyntaxTest11, nil, Passed},
+		{"positive rejected", PositiveUpdateSyntaxTest11, &sparql.UpstreamError{StatusCode: http.StatusBadRequest}, Failed},
+		{"negative rejected", NegativeUpdateSyntaxTest11, &sparql.UpstreamError{StatusCode: http.StatusBadRequest}, Passed},
+		{"negative accepted", NegativeUpdateSyntaxTest11, nil, Failed},
+		{"negative server error", NegativeUpdateSyntaxTest11, &sparql.UpstreamError{StatusCode: http.StatusInternalServerError}, Failed},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			store := newFakeStore()
+			store.update = func(update string) error {
+				if update == "DROP ALL" {
+					return nil
+				}
+
+				return tt.err
+			}
+
+			r, err := NewRunner(store)
+			is.NoErr(err)
+
+			a := r.Run(context.Background(), &Test{
+				IRI:     "http://example.org/test",
+				Type:    tt.typ,
+				Request: "testdata/data-sparql11/syntax-update-1/syntax-update-01.ru",
+			})
+			is.Equal(a.Outcome, tt.outcome)
+		})
+	}
+}
+
+func TestRunner_notRun(t *testing.T) {
+	is := is.New(t)
+
+	r, err := NewRunner(newFakeStore(), SetSkip("not supported by the store", "http://example.org/skipped"))
+	is.NoErr(err)
+
+	tests := []struct {
+		test    *Test
+		outcome Outcome
+	}{
+		{&Test{IRI: "http://example.org/skipped", Type: QueryEvaluationTest}, Untested},
+		{&Test{IRI: "http://example.org/withdrawn", Type: QueryEvaluationTest, Approval: "Withdrawn"}, Untested},
+		{&Test{IRI: "http://example.org/entailment", Type: QueryEvaluationTest, Entailment: "http://www.w3.org/ns/entailment/RDFS"}, Inapplicable},
+		{&Test{IRI: "http://example.org/protocol", Type: "ProtocolTest"}, Inapplicable},
+		{&Test{IRI: "http://example.org/csv", Type: QueryEvaluationTest, Result: "testdata/data-sparql11/csv-tsv-res/csvtsv01.csv"}, Untested},
+	}
+
+	for _, tt := range tests {
+		a := r.Run(context.Background(), tt.test)
+		is.Equal(a.Outcome, tt.outcome) // outcome of tt.test.IRI
+		is.True(a.Message != "")
+	}
+}
+
+func TestWriteEARL(t *testing.T) {
+	is := is.New(t)
+
+	test := &Test{IRI: "http://example.org/manifest#test1"}
+	assertions := []Assertion{
+		{Test: test, Outcome: Passed},
+		{Test: test, Outcome: Failed, Message: "results differ: missing \"a\"\nline"},
+	}
+
+	var buf bytes.Buffer
+
+	err := WriteEARL(
+		&buf,
+		Project{IRI: "http://example.org/store", Name: "Store", Homepage: "http://example.org/", Version: "1.0"},
+		Assertor{IRI: "http://example.org/me", Name: "Me"},
+		assertions,
+	)
+	is.NoErr(err)
+
+	graph, err := readGraph(&buf, "earl.ttl")
+	is.NoErr(err)
+
+	outcomes := map[string]int{}
+	for _, triple := range graph {
+		if triple.Predicate.Value == "http://www.w3.org/ns/earl#outcome" {
+			outcomes[triple.Object.Value]++
+		}
+	}
+
+	is.Equal(outcomes, map[string]int{
+		"http://www.w3.org/ns/earl#passed": 1,
+		"http://www.w3.org/ns/earl#failed": 1,
+	})
+}
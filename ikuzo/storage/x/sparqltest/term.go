@@ -0,0 +1,176 @@
+// Copyright 2020 Delving B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sparqltest
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/knakk/rdf"
+)
+
+const (
+	xsdString     = "http://www.w3.org/2001/XMLSchema#string"
+	rdfLangString = "http://www.w3.org/1999/02/22-rdf-syntax-ns#langString"
+)
+
+// TermKind is the kind of an RDF term.
+type TermKind int
+
+const (
+	// Unbound is the zero Term of a variable without a binding.
+	Unbound TermKind = iota
+	IRI
+	Literal
+	BlankNode
+)
+
+// Term is an RDF term in a result set or graph.
+type Term struct {
+	Kind     TermKind
+	Value    string // the IRI, the lexical form or the blank node label
+	Language string
+	Datatype string
+}
+
+// NewIRI returns an IRI Term.
+func NewIRI(iri string) Term {
+	return Term{Kind: IRI, Value: iri}
+}
+
+// NewLiteral returns a literal Term. Simple literals and xsd:string literals
+// are the same in RDF 1.1, so the xsd:string datatype is dropped.
+func NewLiteral(value, language, datatype string) Term {
+	if datatype == xsdString || datatype == rdfLangString {
+		datatype = ""
+	}
+
+	return Term{Kind: Literal, Value: value, Language: strings.ToLower(language), Datatype: datatype}
+}
+
+// NewBlankNode returns a blank node Term.
+func NewBlankNode(label string) Term {
+	return Term{Kind: BlankNode, Value: label}
+}
+
+// String returns the term in N-Triples syntax.
+func (t Term) String() string {
+	switch t.Kind {
+	case IRI:
+		return "<" + t.Value + ">"
+	case BlankNode:
+		return "_:" + t.Value
+	case Literal:
+		s := `"` + escapeLiteral(t.Value) + `"`
+
+		switch {
+		case t.Language != "":
+			s += "@" + t.Language
+		case t.Datatype != "":
+			s += "^^<" + t.Datatype + ">"
+		}
+
+		return s
+	}
+
+	return ""
+}
+
+var literalEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+
+func escapeLiteral(s string) string {
+	return literalEscaper.Replace(s)
+}
+
+// Triple is an RDF triple.
+type Triple struct {
+	Subject, Predicate, Object Term
+}
+
+// String returns the triple in N-Triples syntax.
+func (t Triple) String() string {
+	return fmt.Sprintf("%s %s %s .", t.Subject, t.Predicate, t.Object)
+}
+
+// Solution is a single result of a SELECT query by variable name.
+type Solution map[string]Term
+
+// Results are the results of a query or the contents of a graph.
+type Results struct {
+	// Boolean is the result of an ASK query.
+	Boolean *bool
+	// Variables and Solutions are the results of a SELECT query.
+	Variables []string
+	Solutions []Solution
+	// Graph is set for CONSTRUCT and DESCRIBE queries and the graphs of update tests.
+	Graph   []Triple
+	IsGraph bool
+}
+
+// resolveIRI resolves dot segments in IRIs that are made absolute by the Turtle decoder.
+func resolveIRI(iri string) string {
+	if !strings.Contains(iri, "/./") && !strings.Contains(iri, "/../") {
+		return iri
+	}
+
+	u, err := url.Parse(iri)
+	if err != nil {
+		return iri
+	}
+
+	return u.ResolveReference(&url.URL{}).String()
+}
+
+// fromRDF converts a term of the RDF decoder.
+func fromRDF(term rdf.Term) Term {
+	switch t := term.(type) {
+	case rdf.IRI:
+		return NewIRI(resolveIRI(t.String()))
+	case rdf.Blank:
+		return NewBlankNode(t.String())
+	case rdf.Literal:
+		return NewLiteral(t.String(), t.Lang(), t.DataType.String())
+	}
+
+	return Term{}
+}
+
+// fromRDFTriples converts the triples of the RDF decoder.
+func fromRDFTriples(triples []rdf.Triple) []Triple {
+	graph := make([]Triple, 0, len(triples))
+
+	for _, t := range triples {
+		graph = append(graph, Triple{
+			Subject:   fromRDF(t.Subj),
+			Predicate: fromRDF(t.Pred),
+			Object:    fromRDF(t.Obj),
+		})
+	}
+
+	return graph
+}
+
+// nTriples returns the graph in N-Triples syntax.
+func nTriples(graph []Triple) string {
+	var sb strings.Builder
+
+	for _, t := range graph {
+		sb.WriteString(t.String())
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}